@@ -22,7 +22,9 @@ export default function AsyncAwait() {
           <li><code>proyash</code> (প্রয়াস) - Marks a function as asynchronous</li>
           <li><code>opekha</code> (অপেক্ষা) - Waits for a promise to resolve</li>
           <li><strong>Promise</strong> - A value that will be available in the future</li>
-          <li><strong>Concurrent Execution</strong> - Multiple async operations run at the same time</li>
+          <li><strong>Concurrent Execution</strong> - Multiple async operations are in flight at the same time</li>
+          <li><strong>Event Loop</strong> - Runs all callbacks one at a time, so code never runs in parallel</li>
+          <li><strong>notun Promise</strong> - Wraps callback-style code; chain with <code>.then</code>, <code>.catch</code>, <code>.finally</code></li>
         </ul>
       </div>
 
@@ -203,6 +205,52 @@ proyash kaj fetchAPI() {
 fetchAPI();`}
       />
 
+      <h2>Creating and Chaining Promises</h2>
+
+      <p>
+        <code>notun Promise((resolve, reject) =&gt; ...)</code> turns callback-style code into a promise. The first call to <code>resolve</code> or <code>reject</code> settles it; an executor that throws rejects it.
+      </p>
+
+      <CodeBlock
+        code={`kaj porePorbe(ms) {
+    ferao notun Promise((resolve, reject) => {
+        setTimeout(() => resolve("waited " + lipi(ms)), ms);
+    });
+}
+
+porePorbe(500)
+    .then((msg) => boroHater(msg))
+    .catch((e) => "failed: " + e)
+    .finally(() => dekho("cleanup"));`}
+      />
+
+      <h3>Combinators</h3>
+
+      <ul>
+        <li><code>Promise.all</code> / <code>sob_proyash</code> - every value, or the first rejection</li>
+        <li><code>Promise.race</code> / <code>prothom_proyash</code> - whichever promise settles first</li>
+        <li><code>Promise.allSettled</code> / <code>sob_nishpotti</code> - a <code>{"{status, value}"}</code> or <code>{"{status, reason}"}</code> map per promise</li>
+        <li><code>Promise.any</code> / <code>jekono_proyash</code> - the first value, or an <code>AggregateError</code> holding every reason</li>
+        <li><code>Promise.timeout</code> / <code>somoy_shima(promise, ms)</code> - rejects with a <code>TimeoutError</code> if the promise takes longer than <code>ms</code></li>
+      </ul>
+
+      <h3>Cancelling with AbortController</h3>
+
+      <p>
+        Pass <code>controller.signal</code> as the last argument of <code>setTimeout</code>, <code>setInterval</code>, <code>ghumaao</code>, <code>anun_async</code> or an async database call. Calling <code>abort(reason)</code> cancels the work, and its promise rejects with the reason (an <code>AbortError</code> by default):
+      </p>
+
+      <CodeBlock
+        code={`dhoro controller = notun AbortController();
+setTimeout(() => controller.abort(), 1000);
+
+chesta {
+    dhoro rows = opekha db_query_async_postgres(conn, "SELECT * FROM big_table", controller.signal);
+} dhoro_bhul(e) {
+    dekho(e.name);  // AbortError
+}`}
+      />
+
       <h2>Practical Examples</h2>
 
       <h3>Sequential vs Concurrent Execution</h3>
@@ -312,27 +360,62 @@ proyash kaj loadUserData() {
 loadUserData();`}
       />
 
-      <h2>Timeout Handling</h2>
+      <h2>The Event Loop</h2>
+
+      <p>
+        All BanglaCode runs on a single thread. Timer callbacks, promise continuations, HTTP, TCP, UDP and WebSocket handlers and worker messages are queued on an event loop and run one at a time, so they never interleave with each other and shared variables need no locks. An async function runs straight away until its first <code>opekha</code>; the rest of it runs later, once the promise has settled.
+      </p>
 
       <p>
-        All <code>opekha</code> operations have a built-in 30-second timeout to prevent infinite blocking:
+        After each task the loop runs every pending promise continuation (a <em>microtask</em>) before it starts the next timer or handler, so the order of output is always the same:
       </p>
 
       <CodeBlock
-        code={`proyash kaj neverResolves() {
-    // This promise never resolves or rejects
-    // After 30 seconds, await will timeout
+        code={`setTimeout(kaj() { dekho("4: timer"); }, 0);
+
+proyash kaj kaj_ek() {
+    dekho("1: before opekha");
+    opekha ghumaao(0);
+    dekho("5: after opekha");
 }
 
-proyash kaj testTimeout() {
-    chesta {
-        opekha neverResolves();
-    } dhoro_bhul (error) {
-        dekho(error);  // await timeout: promise did not resolve within 30 seconds
-    }
+proyash kaj ferot() { ferao 1; }
+proyash kaj kaj_dui() {
+    opekha ferot();
+    dekho("3: microtask");
 }
 
-testTimeout();`}
+kaj_ek();
+kaj_dui();
+dekho("2: sync code finished");`}
+      />
+
+      <p>
+        A program keeps running after its last line until no timers, pending I/O or listening servers are left. A blocking call such as <code>ghum(ms)</code> holds the loop, so nothing else runs meanwhile; use <code>opekha ghumaao(ms)</code> to wait without blocking. An <code>opekha</code> on a promise that nothing can ever settle is reported as an error instead of hanging:
+      </p>
+
+      <CodeBlock
+        code={`dhoro s = stream_readable_srishti();
+
+proyash kaj read() {
+    ghuriye opekha (chunk of s) { dekho(chunk); }  // nobody ever writes to s
+}
+
+opekha read();
+// Error: opekha (await) on a promise that can never settle: nothing is left to run`}
+      />
+
+      <p>
+        A promise that is still rejected without a handler (<code>.catch</code>, a second <code>.then</code> callback or an <code>opekha</code>) once the pending microtasks have run is an <em>unhandled rejection</em>. As in Node, the program prints it to stderr and exits with status 1:
+      </p>
+
+      <CodeBlock
+        code={`proyash kaj load() { felo "network down"; }
+
+load();           // nobody awaits or catches the promise
+// Uncaught (in promise) network down   (exit status 1)
+
+load().catch((e) => dekho("failed:", e));  // handled, exits normally`}
       />
 
       <h2>Best Practices</h2>
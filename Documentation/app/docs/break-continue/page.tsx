@@ -160,7 +160,7 @@ dekho("Average:", sum / count);              // 18.75`}
       <h2>Nested Loops</h2>
 
       <p>
-        <code>thamo</code> and <code>chharo</code> only affect the innermost loop:
+        Without a label, <code>thamo</code> and <code>chharo</code> only affect the innermost loop:
       </p>
 
       <CodeBlock
@@ -212,6 +212,35 @@ ghuriye (dhoro i = 1; i <= 5; i = i + 1) {
 dekho("Exited both loops");`}
       />
 
+      <h3>Labeled Loops</h3>
+
+      <p>
+        Put a label before a loop to leave or continue it from a nested loop. The label must
+        belong to a statement around the <code>thamo</code>/<code>chharo</code>, otherwise the
+        parser reports it:
+      </p>
+
+      <CodeBlock
+        code={`outer: ghuriye (dhoro i = 1; i <= 5; i = i + 1) {
+    ghuriye (dhoro j = 1; j <= 5; j = j + 1) {
+        jodi (j > i) {
+            chharo outer;  // Next i
+        }
+        jodi (i == 2 ebong j == 2) {
+            thamo outer;   // Leave both loops
+        }
+        dekho("i =", i, "j =", j);
+    }
+}
+
+dekho("Exited both loops");
+
+// Output:
+// i = 1 j = 1
+// i = 2 j = 1
+// Exited both loops`}
+      />
+
       <h2>Practical Examples</h2>
 
       <h3>Finding First Match</h3>
@@ -17,19 +17,20 @@ export default function Database() {
       <h1>Database Connectivity</h1>
 
       <p className="lead text-xl text-muted-foreground mt-4">
-        BanglaCode provides production-grade database connectors for PostgreSQL, MySQL, MongoDB, and Redis
+        BanglaCode provides production-grade database connectors for PostgreSQL, MySQL, SQLite, MongoDB, and Redis
         with built-in connection pooling, async/await support, and SQL injection protection.
       </p>
 
       <h2>Supported Databases</h2>
 
       <p>
-        BanglaCode supports four popular databases with both synchronous and asynchronous APIs:
+        BanglaCode supports five popular databases with both synchronous and asynchronous APIs:
       </p>
 
       <ul>
         <li><strong>PostgreSQL</strong> - Advanced relational database with ACID compliance</li>
         <li><strong>MySQL</strong> - Popular relational database for web applications</li>
+        <li><strong>SQLite</strong> - Embedded relational database, no server needed</li>
         <li><strong>MongoDB</strong> - NoSQL document database for flexible schemas</li>
         <li><strong>Redis</strong> - In-memory data store for caching and real-time apps</li>
       </ul>
@@ -138,7 +139,8 @@ dekho("Last insert ID:", insertResult["last_insert_id"]);`}
 
       <p>
         Transactions ensure that multiple database operations either all succeed or all fail together,
-        maintaining data integrity.
+        maintaining data integrity. Pass the transaction ID instead of the connection to run a
+        statement inside the transaction.
       </p>
 
       <CodeBlock
@@ -148,8 +150,8 @@ dhoro tx = db_transaction_shuru_postgres(conn);
 
 chesta {
     // Transfer money between accounts (atomic operation)
-    db_exec_postgres(conn, "UPDATE accounts SET balance = balance - 100 WHERE id = 1");
-    db_exec_postgres(conn, "UPDATE accounts SET balance = balance + 100 WHERE id = 2");
+    db_exec_postgres(tx, "UPDATE accounts SET balance = balance - 100 WHERE id = 1");
+    db_exec_postgres(tx, "UPDATE accounts SET balance = balance + 100 WHERE id = 2");
 
     // Commit if all operations succeed
     db_commit_postgres(tx);
@@ -194,6 +196,11 @@ chesta {
               <td><code>conn, sql, params</code></td>
               <td>Prepared statement (SQL injection safe)</td>
             </tr>
+            <tr>
+              <td><code>db_cursor_postgres</code></td>
+              <td><code>conn, sql, params?</code></td>
+              <td>Read a large result lazily, row by row</td>
+            </tr>
             <tr>
               <td><code>db_transaction_shuru_postgres</code></td>
               <td><code>conn</code></td>
@@ -251,6 +258,42 @@ db_proshno(conn, "DELETE FROM users WHERE name = ?", ["Karim"]);
 db_bandho(conn);`}
       />
 
+      <h2>SQLite</h2>
+
+      <p>
+        SQLite runs inside the BanglaCode process, so scripts, CLI tools and tests need no database
+        server. Pass a file path as <code>database</code>, or leave it out for a private in-memory
+        database. Connections opened with the same <code>shared</code> name see one in-memory database.
+        Queries use <code>?</code> placeholders.
+      </p>
+
+      <CodeBlock
+        filename="sqlite_example.bang"
+        code={`// File database (created if missing); {} gives a fresh in-memory one
+dhoro conn = db_jukto("sqlite", {"database": "app.db"});
+
+db_exec_sqlite(conn, "CREATE TABLE IF NOT EXISTS notes (id INTEGER PRIMARY KEY, text TEXT)");
+db_bulk_insert_sqlite(conn, "notes", ["text"], [["kaj shesh"], ["bazar"]]);
+
+dhoro notes = db_proshno_sqlite(conn, "SELECT * FROM notes WHERE id > ?", [0]);
+dekho(notes["rows"]);
+
+// Pass the transaction ID instead of the connection to run inside it
+dhoro tx = db_transaction_shuru_sqlite(conn);
+db_exec_sqlite(tx, "DELETE FROM notes");
+db_rollback_sqlite(tx); // notes are still there
+
+db_bandho(conn);`}
+      />
+
+      <p>
+        The SQLite functions mirror the MySQL ones: <code>db_jukto_sqlite</code>,{' '}
+        <code>db_query_sqlite</code>, <code>db_exec_sqlite</code>, <code>db_proshno_sqlite</code>,{' '}
+        their <code>_async</code> variants, <code>db_transaction_shuru_sqlite</code>,{' '}
+        <code>db_commit_sqlite</code>, <code>db_rollback_sqlite</code> and{' '}
+        <code>db_bulk_insert_sqlite</code>.
+      </p>
+
       <h2>MongoDB</h2>
 
       <p>
@@ -342,6 +385,11 @@ dekho("Deleted", deleteResult["rows_affected"], "documents");`}
               <td><code>conn, collection, filter</code></td>
               <td>Find documents</td>
             </tr>
+            <tr>
+              <td><code>db_cursor_mongodb</code></td>
+              <td><code>conn, collection, filter, options?</code></td>
+              <td>Read matching documents lazily</td>
+            </tr>
             <tr>
               <td><code>db_dhokao_mongodb</code></td>
               <td><code>conn, collection, doc</code></td>
@@ -508,10 +556,137 @@ dekho("Full profile:", profile);`}
         </table>
       </div>
 
+      <h2>Streaming Large Results</h2>
+
+      <p>
+        <code>db_query_*</code> and <code>db_khojo_mongodb</code> load the whole result into
+        memory. For reports over millions of rows, open a cursor instead:{' '}
+        <code>db_cursor</code>, <code>db_cursor_postgres</code>, <code>db_cursor_mysql</code>,{' '}
+        <code>db_cursor_sqlite</code> or <code>db_cursor_mongodb</code>. Rows are fetched as the
+        loop reads them, and the cursor closes itself when the rows run out, when a read fails,
+        and when the loop stops early with <code>thamo</code>, <code>ferao</code> or an error.
+      </p>
+
+      <CodeBlock
+        filename="report.bang"
+        code={`dhoro total = 0;
+ghuriye (row of db_cursor_postgres(conn, "SELECT amount FROM orders WHERE year = $1", [2026])) {
+    total = total + row.amount;
+}
+
+ghuriye (entry of db_cursor_mongodb(mongoConn, "logs", {}, {"sort": {"at": 1}, "batch_size": 500})) {
+    jodi (entry.level == "error") {
+        dekho(entry);
+        thamo;  // closes the MongoDB cursor
+    }
+}
+
+// Rows as JSON lines into a stream
+dhoro out = stream_readable_srishti();
+db_cursor(db, "SELECT * FROM users").pipe(out);`}
+      />
+
+      <p>
+        A cursor also has <code>next()</code>, <code>close()</code> and{' '}
+        <code>pipe(stream)</code>, which writes the remaining rows as JSON lines and ends a readable
+        stream. An open cursor holds one pooled connection until it is closed.
+      </p>
+
+      <h2>Query Builder</h2>
+
+      <p>
+        <code>db_table(conn, table)</code> builds SQL for PostgreSQL, MySQL and SQLite without
+        string concatenation. Values are always sent as parameters, and table and column names are
+        checked and quoted for the database. Each call returns a new query, so a base query can be
+        reused. Pass a database name such as <code>&quot;postgres&quot;</code> instead of a
+        connection to only build SQL with <code>toSql()</code>.
+      </p>
+
+      <CodeBlock
+        filename="query_builder.bang"
+        code={`dhoro users = db_table(db, "users");
+
+users.insert([{"name": "Rahim", "age": 30}, {"name": "Karim", "age": 25}]).run();
+
+dhoro adults = users
+    .select("id", "name")
+    .where("age", ">=", 18)
+    .orderBy("name")
+    .limit(10)
+    .get();
+
+dekho(users.where({"name": "Karim"}).first());   // one row or khali
+dekho(users.where("age", "<", 30).count());
+
+users.where("name", "Karim").update({"age": 26}).run();
+users.where("age", "IN", [25, 26]).delete().run();
+
+// Posts with their author
+db_table(db, "posts")
+    .select("posts.title", "users.name AS author")
+    .join("users", "users.id", "posts.user_id")
+    .get();
+
+dekho(db_table("postgres", "users").where("id", 7).toSql());
+// {sql: SELECT * FROM "users" WHERE "id" = $1, params: [7]}`}
+      />
+
+      <p>
+        Building: <code>select</code>, <code>where</code> (<code>col, value</code>,{' '}
+        <code>col, op, value</code> or a map), <code>orWhere</code>, <code>join</code>,{' '}
+        <code>leftJoin</code>, <code>orderBy</code>, <code>limit</code>, <code>offset</code>,{' '}
+        <code>insert</code>, <code>update</code>, <code>delete</code> and <code>returning</code>{' '}
+        (PostgreSQL and SQLite). Running: <code>get</code>, <code>first</code>, <code>count</code>,{' '}
+        <code>run</code> and <code>toSql</code>.
+      </p>
+
+      <h2>Models</h2>
+
+      <p>
+        <code>db_model(Sreni, conn)</code> maps a class onto a table. The class declares{' '}
+        <code>sthir table</code>, and optionally <code>sthir primaryKey</code> (default{' '}
+        <code>&quot;id&quot;</code>) and <code>sthir columns</code>. Without <code>columns</code>,
+        every number, string, boolean and <code>khali</code> property is saved.
+      </p>
+
+      <CodeBlock
+        filename="model.bang"
+        code={`sreni User {
+    sthir table = "users";
+
+    shuru(name, age) {
+        ei.name = name;
+        ei.age = age;
+    }
+
+    kaj porichoy() {
+        ferao ei.name + " (" + lipi(ei.age) + ")";
+    }
+}
+db_model(User, db);
+
+dhoro u = notun User("Jamil", 41);
+u.save();                 // INSERT; u.id is filled in
+u.age = 42;
+u.save();                 // UPDATE
+
+dekho(User.khojo(u.id).porichoy());         // Jamil (42)
+dekho(User.khojo({"age": 42}));             // array of User
+dekho(User.query().where("age", ">", 40).first());
+
+u.delete();               // sotti
+dekho(User.khojo(u.id));  // khali`}
+      />
+
+      <p>
+        Rows loaded through <code>khojo</code> and <code>query</code> become instances without
+        running <code>shuru</code>.
+      </p>
+
       <h2>Universal Database Functions</h2>
 
       <p>
-        These functions work with all supported databases (PostgreSQL, MySQL, MongoDB, Redis).
+        These functions work with all supported databases (PostgreSQL, MySQL, SQLite, MongoDB, Redis).
         The <code>db_jukto</code> function automatically routes to the correct database driver.
       </p>
 
@@ -575,6 +750,11 @@ dekho("Full profile:", profile);`}
               <td><code>conn, sql, params</code></td>
               <td>Prepared statement async</td>
             </tr>
+            <tr>
+              <td><code>db_cursor</code></td>
+              <td><code>conn, sql, params?</code></td>
+              <td>Read a large result lazily (SQL databases only)</td>
+            </tr>
           </tbody>
         </table>
       </div>
@@ -593,7 +773,7 @@ dekho("Full profile:", profile);`}
           <tbody>
             <tr>
               <td><code>db_pool_banao</code></td>
-              <td><code>type, config, maxConns</code></td>
+              <td><code>type, config, maxConns?, options?</code></td>
               <td>Create connection pool</td>
             </tr>
             <tr>
@@ -612,14 +792,56 @@ dekho("Full profile:", profile);`}
               <td>Close connection pool</td>
             </tr>
             <tr>
-              <td><code>db_pool_tothyo</code></td>
+              <td><code>db_pool_tothyo</code> / <code>db_pool_stats</code></td>
               <td><code>pool</code></td>
               <td>Get pool statistics</td>
             </tr>
+            <tr>
+              <td><code>db_pool_porikkha</code></td>
+              <td><code>pool</code></td>
+              <td>Run a health check now and return the statistics</td>
+            </tr>
           </tbody>
         </table>
       </div>
 
+      <h3>Pool Options and Health Checks</h3>
+
+      <p>
+        The optional fourth argument of <code>db_pool_banao</code> tunes the pool. Times are in
+        milliseconds.
+      </p>
+
+      <ul>
+        <li><code>min_conns</code> - connections kept open while idle (default 2)</li>
+        <li><code>max_idle_time</code> - close connections above <code>min_conns</code> idle this long (default 300000)</li>
+        <li><code>max_lifetime</code> - replace connections older than this (default 0, never)</li>
+        <li><code>health_check_interval</code> - ping idle connections this often, close dead ones and reconnect up to <code>min_conns</code> (default 30000; 0 turns pings off)</li>
+        <li><code>wait_timeout</code> - how long <code>db_pool_nao</code> waits when every connection is in use (default 30000; 0 waits forever)</li>
+        <li><code>ping_timeout</code> - timeout of one health-check ping (default 10000)</li>
+      </ul>
+
+      <p>
+        A connection that has not been returned or pinged within <code>health_check_interval</code> is
+        also pinged when it is borrowed, so a dead connection is replaced before a query runs on it.
+        Recently used connections are lent without a ping.
+      </p>
+
+      <CodeBlock
+        filename="pool_metrics.bang"
+        code={`dhoro pool = db_pool_banao("postgres", config, 20, {
+    "wait_timeout": 5000,
+    "max_lifetime": 1800000,
+    "health_check_interval": 15000
+});
+
+// e.g. from a /metrics route
+dhoro s = db_pool_stats(pool);
+dekho(s.active_conns, s.idle_conns, s.wait_count, s.wait_duration_ms);
+// Also: total_conns, max_conns, min_conns, wait_timeouts, created_conns,
+// closed_conns, expired_conns (replaced for age), unhealthy_conns (failed a ping)`}
+      />
+
       <h2>Async Database Queries</h2>
 
       <p>
@@ -701,6 +923,7 @@ proyash kaj fetchMultiple() {
         <li>Choose the right database for your needs:
           <ul>
             <li><strong>PostgreSQL/MySQL</strong> - Structured data, complex queries, ACID compliance</li>
+            <li><strong>SQLite</strong> - Self-contained tools, local app data, tests</li>
             <li><strong>MongoDB</strong> - Flexible schemas, hierarchical data, rapid iteration</li>
             <li><strong>Redis</strong> - Caching, sessions, real-time data, message queues</li>
           </ul>
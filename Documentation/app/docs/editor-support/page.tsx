@@ -0,0 +1,81 @@
+import CodeBlock from "@/components/CodeBlock";
+import DocNavigation from "@/components/DocNavigation";
+
+export default function EditorSupport() {
+  return (
+    <div>
+      <div className="flex items-center gap-2 text-sm text-muted-foreground mb-4">
+        <span className="px-2 py-1 bg-primary/10 text-primary rounded-full text-xs font-medium">
+          Tooling
+        </span>
+      </div>
+
+      <h1>Editor Support</h1>
+
+      <p className="lead text-xl text-muted-foreground mt-4">
+        The <code>banglacode</code> binary has a built-in language server. Any editor that speaks the
+        Language Server Protocol (VS Code, Neovim, Helix, Sublime Text, Emacs) can use it for live
+        errors, completion, hover docs and go-to-definition.
+      </p>
+
+      <h2>Starting the Server</h2>
+
+      <p>
+        The server talks LSP over stdin/stdout. Editors start it themselves; you only need
+        <code>banglacode</code> on your <code>PATH</code>:
+      </p>
+
+      <CodeBlock
+        language="bash"
+        showLineNumbers={false}
+        code={`banglacode lsp`}
+      />
+
+      <h2>Features</h2>
+
+      <ul>
+        <li><strong>Diagnostics</strong> - every syntax error in the file, updated as you type</li>
+        <li><strong>Completion</strong> - keywords, all built-in functions, and the names visible at the cursor</li>
+        <li><strong>Hover</strong> - signature and description of built-ins, declaration of your own functions and variables</li>
+        <li><strong>Document symbols</strong> - outline of <code>kaj</code> functions and <code>sreni</code> classes with their methods</li>
+        <li><strong>Go to definition</strong> - jumps to local declarations and, through <code>ano</code>, into the exported functions of other modules</li>
+      </ul>
+
+      <CodeBlock
+        code={`ano "utils.bang";
+ano "ganit.bang" hisabe ganit;
+
+// Ctrl+Click on jog opens utils.bang at "pathao kaj jog"
+dhoro mot = jog(1, 2);
+
+// Ctrl+Click on PI opens ganit.bang at "pathao sthir PI"
+dekho(ganit.PI);`}
+      />
+
+      <h2>Neovim</h2>
+
+      <CodeBlock
+        language="lua"
+        showLineNumbers={false}
+        code={`vim.filetype.add({ extension = { bang = "banglacode", bangla = "banglacode", bong = "banglacode" } })
+
+vim.api.nvim_create_autocmd("FileType", {
+  pattern = "banglacode",
+  callback = function()
+    vim.lsp.start({ name = "banglacode", cmd = { "banglacode", "lsp" } })
+  end,
+})`}
+      />
+
+      <h2>VS Code</h2>
+
+      <p>
+        The BanglaCode extension underlines syntax errors using <code>banglacode check --json -</code>
+        when the binary is installed. Any generic LSP client extension can also be pointed at
+        <code>banglacode lsp</code> for the full feature set.
+      </p>
+
+      <DocNavigation currentPath="/docs/editor-support" />
+    </div>
+  );
+}
@@ -343,6 +343,54 @@ chesta {
 // Caught error: Error from level 3`}
       />
 
+      <h2>Runtime Error Reports</h2>
+
+      <p>
+        When a runtime error stops a program, <code>banglacode</code> prints the file, line and column
+        of the error, the offending line with the failing token underlined, and the chain of function
+        calls that led there. Errors raised inside an imported module point at the module&apos;s own file:
+      </p>
+
+      <CodeBlock
+        language="output"
+        showLineNumbers={false}
+        code={`Error [utils.bang:2:17]: division by zero
+   2 |     dhoro r = a / b;
+     |                 ^
+Stack trace:
+  at bhag (utils.bang:2:17)
+  at hisab (main.bang:4:12)
+  at <anonymous> (main.bang:7:12)`}
+      />
+
+      <h2>Syntax Errors</h2>
+
+      <p>
+        The parser does not stop at the first mistake. It skips to the next statement and keeps going,
+        so one run lists every syntax error in the file, each with an error code and, where it can
+        guess one, a suggested fix:
+      </p>
+
+      <CodeBlock
+        language="output"
+        showLineNumbers={false}
+        code={`main.bang:1:7: error[E001]: expected next token to be IDENT, got =
+   1 | dhoro = 5;
+     |       ^
+main.bang:4:6: error[E001]: expected next token to be (, got IDENT
+   4 | jodi x > 1 { dekho(1); }
+     |      ^
+     = help: insert '('
+2 syntax error(s)`}
+      />
+
+      <p>
+        <code>banglacode check main.bang</code> reports syntax errors without running the program.
+        <code>banglacode check --json -</code> reads source from stdin and prints the diagnostics as
+        JSON (severity, code, message, start/end position and fix); the VS Code extension uses this
+        to underline errors as you type.
+      </p>
+
       <h2>Re-throwing Errors</h2>
 
       <CodeBlock
@@ -0,0 +1,91 @@
+import CodeBlock from "@/components/CodeBlock";
+import DocNavigation from "@/components/DocNavigation";
+
+export default function Formatting() {
+  return (
+    <div>
+      <div className="flex items-center gap-2 text-sm text-muted-foreground mb-4">
+        <span className="px-2 py-1 bg-primary/10 text-primary rounded-full text-xs font-medium">
+          Tooling
+        </span>
+      </div>
+
+      <h1>Formatting</h1>
+
+      <p className="lead text-xl text-muted-foreground mt-4">
+        <code>banglacode fmt</code> rewrites your code in one canonical style, so every BanglaCode
+        project looks the same and nobody has to argue about spaces.
+      </p>
+
+      <h2>What It Changes</h2>
+
+      <ul>
+        <li>Four spaces of indentation, one statement per line</li>
+        <li>One space around operators and after commas, keywords and <code>:</code> in maps</li>
+        <li>Every statement ends with <code>;</code></li>
+        <li>Strings use double quotes, unless the text itself contains <code>&quot;</code></li>
+        <li>Parentheses that the operator precedence makes unnecessary are removed</li>
+      </ul>
+
+      <p>
+        Comments are kept where they were, and so is a single blank line between statements.
+        A map or array written over several lines stays one entry per line.
+      </p>
+
+      <CodeBlock
+        code={`// before
+kaj jog(a,b){ferao (a+b);}
+dhoro user={naam:'Rahim',boyosh:25}   // user
+
+// after banglacode fmt
+kaj jog(a, b) {
+    ferao a + b;
+}
+dhoro user = {naam: "Rahim", boyosh: 25}; // user`}
+      />
+
+      <h2>Usage</h2>
+
+      <CodeBlock
+        language="bash"
+        showLineNumbers={false}
+        code={`banglacode fmt app.bang            # print the formatted file
+banglacode fmt -w app.bang lib.bang # rewrite the files in place
+banglacode fmt --check *.bang       # list files that are not formatted, exit 1 if any
+cat app.bang | banglacode fmt -     # format stdin`}
+      />
+
+      <p>
+        Files with syntax errors are reported and left untouched. Formatting is idempotent:
+        running <code>banglacode fmt</code> on formatted code changes nothing, which makes
+        <code>--check</code> suitable for CI or a git pre-commit hook:
+      </p>
+
+      <CodeBlock
+        language="bash"
+        showLineNumbers={false}
+        code={`#!/bin/sh
+# .git/hooks/pre-commit
+files=$(git diff --cached --name-only --diff-filter=ACM | grep -E '\\.(bang|bangla|bong)$')
+[ -z "$files" ] || banglacode fmt --check $files`}
+      />
+
+      <h2>Format on Save</h2>
+
+      <p>
+        The VS Code extension formats BanglaCode files through <code>banglacode fmt</code>. Turn on
+        format-on-save in your settings:
+      </p>
+
+      <CodeBlock
+        language="json"
+        showLineNumbers={false}
+        code={`"[banglacode]": {
+    "editor.formatOnSave": true
+}`}
+      />
+
+      <DocNavigation currentPath="/docs/formatting" />
+    </div>
+  );
+}
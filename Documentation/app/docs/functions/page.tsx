@@ -297,23 +297,63 @@ kaj sumArray(arr, index) {
 dekho(sumArray([1, 2, 3, 4, 5], 0));  // 15`}
       />
 
-      <h2>Default-Like Behavior</h2>
+      <h2>Default Parameters</h2>
 
       <p>
-        BanglaCode doesn&apos;t have default parameters, but you can simulate them:
+        A parameter can have a default value, used when the argument is left out or is <code>khali</code>.
+        Defaults are evaluated on every call, inside the function, so they can use earlier parameters
+        and <code>ei</code>:
       </p>
 
       <CodeBlock
-        code={`kaj greet(naam, greeting) {
-    // Check if parameter is provided
-    jodi (greeting == khali) {
-        greeting = "Namaskar";
-    }
+        code={`kaj greet(naam, greeting = "Namaskar") {
     dekho(greeting + ",", naam);
 }
 
 greet("Rahim", "Hello");     // Hello, Rahim
-greet("Karim", khali);       // Namaskar, Karim`}
+greet("Karim");              // Namaskar, Karim
+greet("Jamal", khali);       // Namaskar, Jamal
+
+kaj area(width, height = width) {
+    ferao width * height;
+}
+dekho(area(4));              // 16
+
+dhoro scale = (x, factor = 2) => x * factor;
+dekho(scale(5));             // 10`}
+      />
+
+      <p>
+        A call must pass every parameter before the first default: <code>greet()</code> is an error
+        (&quot;expects 1 to 2 argument(s)&quot;).
+      </p>
+
+      <h2>Destructuring Parameters</h2>
+
+      <p>
+        Parameters can unpack an array or a map argument with the same patterns as <code>dhoro [a, b]</code>{' '}
+        and <code>dhoro {'{'}x, y{'}'}</code>; declarations and parameters share one pattern grammar. Missing
+        elements and keys are <code>khali</code>. Any element can be renamed (<code>boyosh: age</code>), given a
+        default (<code>boyosh = 18</code>), hold a nested pattern, or be a final <code>...rest</code> that collects
+        the remaining elements or keys:
+      </p>
+
+      <CodeBlock
+        code={`kaj describe({naam, boyosh: age}) {
+    ferao naam + " (" + age + ")";
+}
+dekho(describe({naam: "Rahim", boyosh: 30}));   // Rahim (30)
+
+kaj distance([x1, y1], [x2, y2] = [0, 0]) {
+    ferao borgomul((x2 - x1) ** 2 + (y2 - y1) ** 2);
+}
+dekho(distance([3, 4]));                        // 5
+
+dhoro names = manchitro(users, ({naam}) => naam);
+
+kaj summary({naam, thikana: {shohor = "Dhaka"} = {}, ...baki}, [first, ...others]) {
+    ferao naam + " " + shohor + " " + dorghyo(others);
+}`}
       />
 
       <h2>Rest Parameters</h2>
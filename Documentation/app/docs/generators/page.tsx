@@ -40,12 +40,122 @@ dekho(g.next()); // {"value": khali, "done": sotti}`}
       <section className="mb-10">
         <h2 className="text-2xl font-bold mb-4">Generator Methods</h2>
         <ul className="list-disc list-inside space-y-2 text-gray-700 dark:text-gray-300">
-          <li><code>next()</code>: Resume execution until next <code>utpadan</code> or completion.</li>
-          <li><code>return(value)</code>: Stop generator early and mark done.</li>
-          <li><code>throw(err)</code>: Throw an error into the generator and end it.</li>
+          <li><code>next(value)</code>: Resume execution until the next <code>utpadan</code> or completion; <code>value</code> becomes the result of the paused <code>utpadan</code>.</li>
+          <li><code>return(value)</code>: Finish the generator where it is paused, running its <code>shesh</code> blocks.</li>
+          <li><code>throw(err)</code>: Throw <code>err</code> where the generator is paused; a <code>chesta</code> inside the generator can catch it.</li>
         </ul>
       </section>
 
+      <section className="mb-10">
+        <h2 className="text-2xl font-bold mb-4">Sending Values In</h2>
+        <div className="bg-gray-100 dark:bg-gray-900 rounded-lg p-5">
+          <pre className="text-sm overflow-x-auto">
+            <code className="language-banglacode">
+{`kaj* jog() {
+  dhoro mot = 0;
+  jotokkhon (sotti) {
+    dhoro x = utpadan mot;
+    mot = mot + x;
+  }
+}
+
+dhoro g = jog();
+g.next();                      // start, paused at the first utpadan
+dekho(g.next(5)["value"]);     // 5
+dekho(g.next(7)["value"]);     // 12`}
+            </code>
+          </pre>
+        </div>
+      </section>
+
+      <section className="mb-10">
+        <h2 className="text-2xl font-bold mb-4">Delegation with utpadan*</h2>
+        <p className="text-gray-700 dark:text-gray-300 mb-4">
+          <code>utpadan*</code> yields every value of another generator or iterable. For a
+          generator, the expression evaluates to that generator&apos;s return value.
+        </p>
+        <div className="bg-gray-100 dark:bg-gray-900 rounded-lg p-5">
+          <pre className="text-sm overflow-x-auto">
+            <code className="language-banglacode">
+{`kaj* bhitor() {
+  utpadan 1;
+  ferao "shesh";
+}
+
+kaj* bahir() {
+  dhoro r = utpadan* bhitor();
+  utpadan r;
+  utpadan* [2, 3];
+}
+
+dekho([...bahir()]); // [1, shesh, 2, 3]`}
+            </code>
+          </pre>
+        </div>
+      </section>
+
+      <section className="mb-10">
+        <h2 className="text-2xl font-bold mb-4">Iterator Protocol</h2>
+        <p className="text-gray-700 dark:text-gray-300 mb-4">
+          Generators, and classes or maps with an <code>iterator()</code> method, work with{" "}
+          <code>ghuriye ... of</code>, spread and array destructuring. <code>iterator()</code>{" "}
+          returns a generator or an object whose <code>next()</code> returns{" "}
+          <code>{"{value, done}"}</code>. Leaving a loop early closes the iterator.
+        </p>
+        <div className="bg-gray-100 dark:bg-gray-900 rounded-lg p-5">
+          <pre className="text-sm overflow-x-auto">
+            <code className="language-banglacode">
+{`sreni Porishor {
+  shuru(lo, hi) { ei.lo = lo; ei.hi = hi; }
+  kaj* iterator() {
+    ghuriye (dhoro i = ei.lo; i < ei.hi; i = i + 1) {
+      utpadan i;
+    }
+  }
+}
+
+ghuriye (n of notun Porishor(1, 4)) {
+  dekho(n);                       // 1, 2, 3
+}
+dhoro [a, b] = notun Porishor(5, 9); // a = 5, b = 6`}
+            </code>
+          </pre>
+        </div>
+      </section>
+
+      <section className="mb-10">
+        <h2 className="text-2xl font-bold mb-4">Async Generators and ghuriye opekha</h2>
+        <p className="text-gray-700 dark:text-gray-300 mb-4">
+          <code>proyash kaj*</code> declares an async generator. It may <code>opekha</code> between
+          values, a promise it yields is awaited first, and <code>next()</code>,{" "}
+          <code>return()</code> and <code>throw()</code> return promises. Read it with{" "}
+          <code>ghuriye opekha (x of src)</code>, which also reads readable streams, TCP
+          connections, <code>db_khojo_async_mongodb</code> results and plain iterables, awaiting
+          each value that is a promise.
+        </p>
+        <div className="bg-gray-100 dark:bg-gray-900 rounded-lg p-5">
+          <pre className="text-sm overflow-x-auto">
+            <code className="language-banglacode">
+{`proyash kaj* pata(url) {
+  ghuriye (dhoro page = 1; page <= 3; page = page + 1) {
+    utpadan anun_async(url + "?page=" + page);
+  }
+}
+
+proyash kaj main() {
+  ghuriye opekha (res of pata("https://api.example.com/items")) {
+    dekho(res["status"]);
+  }
+
+  ghuriye opekha (doc of db_khojo_async_mongodb(conn, "users", {})) {
+    dekho(doc["naam"]);
+  }
+}`}
+            </code>
+          </pre>
+        </div>
+      </section>
+
       <section>
         <h2 className="text-2xl font-bold mb-4">Early Return Example</h2>
         <div className="bg-gray-100 dark:bg-gray-900 rounded-lg p-5">
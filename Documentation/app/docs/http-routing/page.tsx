@@ -24,6 +24,8 @@ export default function HTTPRoutingPage() {
         <li>✅ <strong>Modular Routing</strong> - Organize routes in separate files</li>
         <li>✅ <strong>All HTTP Methods</strong> - GET, POST, PUT, DELETE, PATCH, HEAD, OPTIONS support</li>
         <li>✅ <strong>Router Mounting</strong> - Mount sub-routers on paths</li>
+        <li>✅ <strong>Route Parameters</strong> - Named, optional and wildcard path segments</li>
+        <li>✅ <strong>Middleware</strong> - Global, per-path and per-route middleware with <code>next()</code></li>
         <li>✅ <strong>Method Chaining</strong> - Define multiple routes fluently</li>
         <li>✅ <strong>Pure Banglish</strong> - Bengali keywords throughout</li>
       </ul>
@@ -202,6 +204,75 @@ server_chalu(3000, app);`}</code></pre>
 
       <hr />
 
+      <h2>Route Parameters</h2>
+      <p>Route paths can capture parts of the URL. Captured values are available in <code>req.params</code>:</p>
+
+      <pre><code className="language-banglacode">{`// Named segment: /users/42 -> req.params.id == "42"
+app.ana("/users/:id", kaj(req, res) {
+    json_uttor(res, {"id": req.params.id});
+});
+
+// Optional segment: matches /archive/2024 and /archive/2024/05
+app.ana("/archive/:year/:month?", kaj(req, res) {
+    json_uttor(res, req.params);
+});
+
+// Trailing wildcard: /static/css/site.css -> req.params["*"] == "css/site.css"
+app.ana("/static/*", kaj(req, res) {
+    uttor(res, req.params["*"]);
+});
+
+// Named wildcard: /files/a/b.txt -> req.params.path == "a/b.txt"
+app.ana("/files/*path", kaj(req, res) {
+    uttor(res, req.params.path);
+});`}</code></pre>
+
+      <p>Static segments take priority over named segments, which take priority over wildcards, so <code>/users/me</code> can be defined alongside <code>/users/:id</code>.</p>
+
+      <hr />
+
+      <h2>Middleware</h2>
+      <p>Middleware functions receive <code>req</code>, <code>res</code> and <code>next</code>. Call <code>next()</code> to continue to the next middleware or the route handler; skip it to end the request early. Changes made to <code>req</code> are visible to everything later in the chain.</p>
+      <p>Global and path middleware run before the route is looked up, so they also see requests that no route matches, such as a CORS preflight or a mistyped URL. If the middleware calls <code>next()</code> and no route matches, the response is a plain-text <code>404 page not found</code>.</p>
+
+      <pre><code className="language-banglacode">{`dhoro app = router_banao();
+
+// Global middleware: runs for every request
+app.bebohar(kaj(req, res, next) {
+    res.headers["Access-Control-Allow-Origin"] = "*";
+    next();
+});
+
+// Path middleware: runs for /admin and everything below it
+app.bebohar("/admin", kaj(req, res, next) {
+    jodi (req.headers["Authorization"] == khali) {
+        uttor(res, "Unauthorized", 401);
+        ferao;
+    }
+    req.user = "admin";
+    next();
+});
+
+// Per-route middleware: listed before the handler
+dhoro logger = kaj(req, res, next) {
+    dekho(req.method, req.path);
+    next();
+};
+app.ana("/admin/stats", logger, kaj(req, res) {
+    json_uttor(res, {"user": req.user});
+});
+
+// Middleware on a sub-router applies to its mount path
+dhoro api = router_banao();
+api.bebohar(kaj(req, res, next) { next(); });
+app.bebohar("/api", api);
+
+// The function form of server_chalu also accepts middleware; every
+// server_chalu call gets its own server, so several can run at once
+server_chalu(3000, logger, kaj(req, res) { uttor(res, "ok"); });`}</code></pre>
+
+      <hr />
+
       <h2>Method Chaining</h2>
       <p>Define multiple routes fluently with method chaining:</p>
 
@@ -231,6 +302,7 @@ app
       <pre><code className="language-banglacode">{`app.ana("/inspect", kaj(req, res) {
     dhoro method = req["method"];      // "GET"
     dhoro path = req["path"];          // "/inspect"
+    dhoro params = req["params"];      // Route parameters
     dhoro query = req["query"];        // Query string
     dhoro headers = req["headers"];    // Request headers
     dhoro body = req["body"];          // Request body
@@ -314,6 +314,10 @@ dhoro data = json_poro(response.body);
 dekho(data);`}
       />
 
+      <p>
+        <code>anun</code> waits for the response the way <code>opekha</code> waits for a promise: the event loop keeps running meanwhile, so a script can call its own server, e.g. to test it. Timers and other callbacks that are due can run during the wait too. <code>anun_async</code> returns the promise instead of waiting.
+      </p>
+
       <h2>Best Practices</h2>
 
       <ul>
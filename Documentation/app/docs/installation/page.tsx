@@ -320,7 +320,7 @@ git clone https://github.com/nexoral/BanglaCode.git
 cd BanglaCode
 
 # Build
-go build -o banglacode main.go
+go build -o banglacode .
 
 # Verify
 ./banglacode --version
@@ -337,16 +337,16 @@ sudo mv banglacode /usr/local/bin/`}
         language="bash"
         showLineNumbers={false}
         code={`# Windows (64-bit)
-GOOS=windows GOARCH=amd64 go build -o banglacode.exe main.go
+GOOS=windows GOARCH=amd64 go build -o banglacode.exe .
 
 # macOS (Apple Silicon)
-GOOS=darwin GOARCH=arm64 go build -o banglacode main.go
+GOOS=darwin GOARCH=arm64 go build -o banglacode .
 
 # Linux (ARM64 - Raspberry Pi 4)
-GOOS=linux GOARCH=arm64 go build -o banglacode main.go
+GOOS=linux GOARCH=arm64 go build -o banglacode .
 
 # FreeBSD
-GOOS=freebsd GOARCH=amd64 go build -o banglacode main.go`}
+GOOS=freebsd GOARCH=amd64 go build -o banglacode .`}
       />
 
       <h2>✅ Verify Installation</h2>
@@ -389,6 +389,17 @@ dekho("Hello,", naam);`}
 Hello, World`}
       />
 
+      <p>
+        Programs can also run on the bytecode virtual machine. It compiles variables, loops, functions,
+        closures and member access to bytecode and produces the same results as the default interpreter:
+      </p>
+
+      <CodeBlock
+        language="bash"
+        showLineNumbers={false}
+        code={`banglacode --vm hello.bang`}
+      />
+
       <h2>💻 Interactive REPL</h2>
 
       <p>Start the interactive mode by running without arguments:</p>
@@ -483,7 +494,7 @@ source ~/.bashrc`}
         language="bash"
         showLineNumbers={false}
         code={`go mod tidy
-go build -o banglacode main.go`}
+go build -o banglacode .`}
       />
 
       <DocNavigation currentPath="/docs/installation" />
@@ -325,6 +325,77 @@ proyash kaj loadAll() {
         For detailed async/await documentation and examples, see the <a href="/docs/async-await" className="text-primary hover:underline">Async/Await guide</a>.
       </p>
 
+      <h2>Bengali Script</h2>
+
+      <p>
+        Every Banglish keyword also has a Bengali-script spelling, and variable names and numbers can
+        be written in Bengali script too. The two spellings mean the same thing and can be mixed in
+        one file. Bengali digits (০-৯) work everywhere 0-9 do.
+      </p>
+
+      <CodeBlock
+        code={`ধরো মোট = ০;
+ঘুরিয়ে (ধরো i = ১; i <= ১০; i += ১) {
+    যদি (i % ২ == ০) {
+        মোট += i;
+    }
+}
+dekho(মোট); // 30`}
+      />
+
+      <p>
+        The English keywords (<code>do</code>, <code>in</code>, <code>of</code>, <code>instanceof</code>,
+        <code>delete</code>, <code>set</code>) and builtin functions such as <code>dekho</code> have one
+        spelling. <code>banglacode fmt</code> keeps a file in the script its keywords are written in.
+      </p>
+
+      <div className="overflow-x-auto my-6">
+        <table className="min-w-full">
+          <thead>
+            <tr>
+              <th className="text-left">Banglish</th>
+              <th className="text-left">Bengali Script</th>
+            </tr>
+          </thead>
+          <tbody>
+            <tr><td><code>dhoro</code></td><td><code className="text-primary">ধরো</code></td></tr>
+            <tr><td><code>sthir</code></td><td><code className="text-primary">স্থির</code></td></tr>
+            <tr><td><code>bishwo</code></td><td><code className="text-primary">বিশ্ব</code></td></tr>
+            <tr><td><code>jodi</code></td><td><code className="text-primary">যদি</code></td></tr>
+            <tr><td><code>nahole</code></td><td><code className="text-primary">নাহলে</code></td></tr>
+            <tr><td><code>jotokkhon</code></td><td><code className="text-primary">যতক্ষণ</code></td></tr>
+            <tr><td><code>ghuriye</code></td><td><code className="text-primary">ঘুরিয়ে</code></td></tr>
+            <tr><td><code>kaj</code></td><td><code className="text-primary">কাজ</code></td></tr>
+            <tr><td><code>ferao</code></td><td><code className="text-primary">ফেরাও</code></td></tr>
+            <tr><td><code>sreni</code></td><td><code className="text-primary">শ্রেণী</code></td></tr>
+            <tr><td><code>shuru</code></td><td><code className="text-primary">শুরু</code></td></tr>
+            <tr><td><code>notun</code></td><td><code className="text-primary">নতুন</code></td></tr>
+            <tr><td><code>sotti</code></td><td><code className="text-primary">সত্যি</code></td></tr>
+            <tr><td><code>mittha</code></td><td><code className="text-primary">মিথ্যা</code></td></tr>
+            <tr><td><code>khali</code></td><td><code className="text-primary">খালি</code></td></tr>
+            <tr><td><code>ebong</code></td><td><code className="text-primary">এবং</code></td></tr>
+            <tr><td><code>ba</code></td><td><code className="text-primary">বা</code></td></tr>
+            <tr><td><code>na</code></td><td><code className="text-primary">না</code></td></tr>
+            <tr><td><code>thamo</code></td><td><code className="text-primary">থামো</code></td></tr>
+            <tr><td><code>chharo</code></td><td><code className="text-primary">ছাড়ো</code></td></tr>
+            <tr><td><code>ano</code></td><td><code className="text-primary">আনো</code></td></tr>
+            <tr><td><code>pathao</code></td><td><code className="text-primary">পাঠাও</code></td></tr>
+            <tr><td><code>hisabe</code></td><td><code className="text-primary">হিসাবে</code></td></tr>
+            <tr><td><code>chesta</code></td><td><code className="text-primary">চেষ্টা</code></td></tr>
+            <tr><td><code>dhoro_bhul</code></td><td><code className="text-primary">ধরো_ভুল</code></td></tr>
+            <tr><td><code>shesh</code></td><td><code className="text-primary">শেষ</code></td></tr>
+            <tr><td><code>felo</code></td><td><code className="text-primary">ফেলো</code></td></tr>
+            <tr><td><code>proyash</code></td><td><code className="text-primary">প্রয়াস</code></td></tr>
+            <tr><td><code>opekha</code></td><td><code className="text-primary">অপেক্ষা</code></td></tr>
+            <tr><td><code>bikolpo</code></td><td><code className="text-primary">বিকল্প</code></td></tr>
+            <tr><td><code>khetre</code></td><td><code className="text-primary">ক্ষেত্রে</code></td></tr>
+            <tr><td><code>manchito</code></td><td><code className="text-primary">মানচিত্র</code></td></tr>
+            <tr><td><code>pao</code></td><td><code className="text-primary">পাও</code></td></tr>
+            <tr><td><code>utpadan</code></td><td><code className="text-primary">উৎপাদন</code></td></tr>
+          </tbody>
+        </table>
+      </div>
+
       <h2>Reserved Words</h2>
 
       <p>
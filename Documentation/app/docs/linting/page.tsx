@@ -0,0 +1,99 @@
+import CodeBlock from "@/components/CodeBlock";
+import DocNavigation from "@/components/DocNavigation";
+
+export default function Linting() {
+  return (
+    <div>
+      <div className="flex items-center gap-2 text-sm text-muted-foreground mb-4">
+        <span className="px-2 py-1 bg-primary/10 text-primary rounded-full text-xs font-medium">
+          Tooling
+        </span>
+      </div>
+
+      <h1>Linting</h1>
+
+      <p className="lead text-xl text-muted-foreground mt-4">
+        <code>banglacode lint</code> reads your code without running it and points out mistakes that
+        would otherwise only show up at run time - or never.
+      </p>
+
+      <h2>Rules</h2>
+
+      <ul>
+        <li><code>unused-variable</code> (warning) - a <code>dhoro</code>/<code>sthir</code> variable that is never read</li>
+        <li><code>const-reassign</code> (error) - assigning to a <code>sthir</code></li>
+        <li><code>unreachable-code</code> (warning) - statements after <code>ferao</code>, <code>felo</code>, <code>thamo</code> or <code>chharo</code></li>
+        <li><code>unknown-function</code> (error) - calling a name that is neither declared nor a builtin, with a suggestion for typos</li>
+        <li><code>builtin-arity</code> (error) - calling a builtin with the wrong number of arguments</li>
+        <li><code>shadow</code> (warning) - a declaration that hides an outer variable or a builtin</li>
+        <li><code>await-outside-async</code> (warning) - <code>opekha</code> inside a function that is not <code>proyash kaj</code></li>
+      </ul>
+
+      <p>
+        Variables whose name starts with <code>_</code> and variables exported with <code>pathao</code>
+        are never reported as unused.
+      </p>
+
+      <CodeBlock
+        language="text"
+        showLineNumbers={false}
+        code={`app.bang:3:7: warning[unused-variable]: 'fol' is declared but never used
+app.bang:8:1: error[unknown-function]: unknown function 'dekhoo': it is not declared and is not a builtin
+     = help: did you mean 'dekho'?
+app.bang:9:1: error[builtin-arity]: dorghyo expects 1 argument(s), got 2
+3 problem(s): 2 error(s), 1 warning(s)`}
+      />
+
+      <h2>Usage</h2>
+
+      <CodeBlock
+        language="bash"
+        showLineNumbers={false}
+        code={`banglacode lint                  # every .bang/.bangla/.bong file below the current directory
+banglacode lint src/ app.bang    # files and directories
+banglacode lint --json app.bang  # problems as a JSON array, for editors
+banglacode lint --rules          # list the rules
+cat app.bang | banglacode lint -  # lint stdin`}
+      />
+
+      <p>
+        The command exits with status 1 when any problem has error severity, so it can run in CI.
+        Files with syntax errors report those instead.
+      </p>
+
+      <h2>Configuration</h2>
+
+      <p>
+        Put a <code>.banglalint.json</code> file in your project; the linter uses the nearest one
+        above each file (or the one given with <code>--config</code>). Each rule can be set to
+        <code>&quot;off&quot;</code>, <code>&quot;warning&quot;</code> or <code>&quot;error&quot;</code>:
+      </p>
+
+      <CodeBlock
+        language="json"
+        showLineNumbers={false}
+        code={`{
+  "rules": {
+    "shadow": "off",
+    "unused-variable": "error"
+  }
+}`}
+      />
+
+      <h2>Suppressing Problems</h2>
+
+      <p>A comment can turn rules off for one line or the whole file. Without rule names, every rule is off.</p>
+
+      <CodeBlock
+        code={`// lint-disable shadow
+
+// lint-disable-next-line unused-variable
+dhoro porerJonno = 1;
+
+dhoro lekho = "..."; // lint-disable-line`}
+      />
+
+      <DocNavigation currentPath="/docs/linting" />
+    </div>
+  );
+}
@@ -254,6 +254,25 @@ ghuriye (dhoro i = 0; i < dorghyo(keys); i = i + 1) {
 dekho("Top student:", topStudent, "with", highest);`}
       />
 
+      <h3>Key Order</h3>
+
+      <p>
+        Keys come back in the order they were added, so <code>chabi</code>,{' '}
+        <code>ghuriye (k in map)</code>, <code>dekho</code> and <code>json_banao</code>{' '}
+        always agree from run to run. As in JavaScript, integer-like keys are listed
+        first in ascending numeric order, and a deleted key that is added again moves
+        to the end.
+      </p>
+
+      <CodeBlock
+        code={`dhoro m = {z: 1, a: 2, "10": 3, "2": 4};
+dekho(chabi(m));      // [2, 10, z, a]
+
+delete m.z;
+m.z = 5;
+dekho(json_banao(m)); // {"2":4,"10":3,"a":2,"z":5}`}
+      />
+
       <h2>Practical Examples</h2>
 
       <h3>Configuration Object</h3>
@@ -86,6 +86,24 @@ jotokkhon (sotti) {
 tcpClient();`}
       />
 
+      <p>
+        A connection is also an async iterable: <code>ghuriye opekha</code> reads it chunk by chunk
+        and ends when the other side closes the connection.
+      </p>
+
+      <CodeBlock
+        filename="tcp_loop.bang"
+        code={`proyash kaj shuno() {
+    dhoro conn = opekha tcp_jukto("localhost", 8080);
+    ghuriye opekha (chunk of conn) {
+        dekho("Received:", chunk);
+    }
+    dekho("Server closed the connection");
+}
+
+shuno();`}
+      />
+
       <h3>TCP Functions</h3>
 
       <div className="overflow-x-auto my-4">
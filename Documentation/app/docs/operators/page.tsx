@@ -56,6 +56,53 @@ dhoro greeting = "Hello" + " " + "World";
 dekho(greeting);  // "Hello World"`}
       />
 
+      <h2>Bitwise Operators</h2>
+
+      <p>
+        Bitwise operators work on 32-bit integers, like JavaScript: both sides are truncated and
+        wrapped to 32 bits first, and shift counts are taken modulo 32.
+      </p>
+
+      <div className="overflow-x-auto my-6">
+        <table>
+          <thead>
+            <tr>
+              <th>Operator</th>
+              <th>Name</th>
+              <th>Example</th>
+              <th>Result</th>
+            </tr>
+          </thead>
+          <tbody>
+            <tr><td><code>&amp;</code></td><td>AND</td><td><code>5 &amp; 3</code></td><td>1</td></tr>
+            <tr><td><code>|</code></td><td>OR</td><td><code>5 | 3</code></td><td>7</td></tr>
+            <tr><td><code>^</code></td><td>XOR</td><td><code>5 ^ 3</code></td><td>6</td></tr>
+            <tr><td><code>~</code></td><td>NOT</td><td><code>~5</code></td><td>-6</td></tr>
+            <tr><td><code>&lt;&lt;</code></td><td>Left shift</td><td><code>1 &lt;&lt; 4</code></td><td>16</td></tr>
+            <tr><td><code>&gt;&gt;</code></td><td>Right shift</td><td><code>-16 &gt;&gt; 2</code></td><td>-4</td></tr>
+            <tr><td><code>&gt;&gt;&gt;</code></td><td>Unsigned right shift</td><td><code>-1 &gt;&gt;&gt; 0</code></td><td>4294967295</td></tr>
+          </tbody>
+        </table>
+      </div>
+
+      <CodeBlock
+        code={`// Pack flags into one number
+sthir READ = 1 << 0;
+sthir WRITE = 1 << 1;
+dhoro mode = READ | WRITE;
+dekho((mode & WRITE) != 0);   // sotti
+
+// A simple 32-bit string hash
+kaj hash(s) {
+    dhoro h = 2166136261;
+    ghuriye (dhoro i = 0; i < dorghyo(s); i = i + 1) {
+        h = h ^ okkhor_code(s, i);
+        h = (h * 16777619) >>> 0;
+    }
+    ferao h;
+}`}
+      />
+
       <h2>Comparison Operators</h2>
 
       <div className="overflow-x-auto my-6">
@@ -113,8 +160,8 @@ dekho("abc" < "abd");       // sotti (lexicographic)`}
             </tr>
           </thead>
           <tbody>
-            <tr><td><code>ebong</code></td><td>এবং</td><td>AND</td><td><code>sotti ebong mittha</code></td><td><code>mittha</code></td></tr>
-            <tr><td><code>ba</code></td><td>বা</td><td>OR</td><td><code>sotti ba mittha</code></td><td><code>sotti</code></td></tr>
+            <tr><td><code>ebong</code> or <code>&amp;&amp;</code></td><td>এবং</td><td>AND</td><td><code>sotti ebong mittha</code></td><td><code>mittha</code></td></tr>
+            <tr><td><code>ba</code> or <code>||</code></td><td>বা</td><td>OR</td><td><code>sotti ba mittha</code></td><td><code>sotti</code></td></tr>
             <tr><td><code>na</code> or <code>!</code></td><td>না</td><td>NOT</td><td><code>na sotti</code></td><td><code>mittha</code></td></tr>
           </tbody>
         </table>
@@ -166,6 +213,13 @@ jodi (age < 13 ba age > 65) {
             <tr><td><code>-=</code></td><td><code>x -= 3</code></td><td><code>x = x - 3</code></td></tr>
             <tr><td><code>*=</code></td><td><code>x *= 3</code></td><td><code>x = x * 3</code></td></tr>
             <tr><td><code>/=</code></td><td><code>x /= 3</code></td><td><code>x = x / 3</code></td></tr>
+            <tr><td><code>%=</code></td><td><code>x %= 3</code></td><td><code>x = x % 3</code></td></tr>
+            <tr><td><code>**=</code></td><td><code>x **= 3</code></td><td><code>x = x ** 3</code></td></tr>
+            <tr><td><code>&amp;=</code>, <code>|=</code>, <code>^=</code></td><td><code>x |= 4</code></td><td><code>x = x | 4</code></td></tr>
+            <tr><td><code>&lt;&lt;=</code>, <code>&gt;&gt;=</code>, <code>&gt;&gt;&gt;=</code></td><td><code>x &lt;&lt;= 1</code></td><td><code>x = x &lt;&lt; 1</code></td></tr>
+            <tr><td><code>??=</code></td><td><code>x ??= 3</code></td><td>Assign only if x is <code>khali</code></td></tr>
+            <tr><td><code>||=</code></td><td><code>x ||= 3</code></td><td>Assign only if x is <code>khali</code> or <code>mittha</code></td></tr>
+            <tr><td><code>&amp;&amp;=</code></td><td><code>x &amp;&amp;= 3</code></td><td>Assign only if x is neither</td></tr>
           </tbody>
         </table>
       </div>
@@ -183,7 +237,44 @@ x *= 2;   // x is now 24
 dekho(x);
 
 x /= 4;   // x is now 6
-dekho(x);`}
+dekho(x);
+
+// The right side of ??=, ||= and &&= only runs when it is assigned
+dhoro config = {};
+config.port ??= 8080;   // 8080
+config.port ??= 3000;   // still 8080`}
+      />
+
+      <h2>Conditional and Nullish Operators</h2>
+
+      <div className="overflow-x-auto my-6">
+        <table>
+          <thead>
+            <tr>
+              <th>Operator</th>
+              <th>Name</th>
+              <th>Example</th>
+              <th>Result</th>
+            </tr>
+          </thead>
+          <tbody>
+            <tr><td><code>? :</code></td><td>Conditional (ternary)</td><td><code>x &gt; 0 ? &quot;pos&quot; : &quot;neg&quot;</code></td><td>One of the two values</td></tr>
+            <tr><td><code>??</code></td><td>Nullish coalescing</td><td><code>x ?? 0</code></td><td><code>0</code> only if x is <code>khali</code></td></tr>
+          </tbody>
+        </table>
+      </div>
+
+      <CodeBlock
+        code={`dhoro boyosh = 20;
+dekho(boyosh >= 18 ? "boro" : "choto");   // "boro"
+
+// Ternaries nest to the right
+dhoro n = 0;
+dekho(n > 0 ? "positive" : n < 0 ? "negative" : "shunno");   // "shunno"
+
+// ?? keeps 0, "" and mittha; only khali is replaced
+dekho(khali ?? "default");   // "default"
+dekho(0 ?? 10);              // 0`}
       />
 
       <h2>Unary Operators</h2>
@@ -231,6 +322,7 @@ dekho(!!flag);   // sotti (double negation)`}
           <tbody>
             <tr><td><code>.</code></td><td>Dot notation</td><td><code>obj.prop</code></td><td>Access known property</td></tr>
             <tr><td><code>[]</code></td><td>Bracket notation</td><td><code>obj[&quot;prop&quot;]</code></td><td>Dynamic property access</td></tr>
+            <tr><td><code>?.</code></td><td>Optional chaining</td><td><code>obj?.prop</code>, <code>obj?.[key]</code>, <code>fn?.()</code></td><td>Value that may be <code>khali</code></td></tr>
           </tbody>
         </table>
       </div>
@@ -263,7 +355,13 @@ dhoro data = {
         {naam: "B"}
     ]
 };
-dekho(data.users[0].naam);  // "A"`}
+dekho(data.users[0].naam);  // "A"
+
+// Optional chaining: khali instead of an error
+dhoro user = khali;
+dekho(user?.thikana.shohor);   // khali (the rest of the chain is skipped)
+dekho(data.users?.[5]?.naam);  // khali
+user?.save();                  // not called`}
       />
 
       <h2>Operator Precedence</h2>
@@ -282,17 +380,24 @@ dekho(data.users[0].naam);  // "A"`}
             </tr>
           </thead>
           <tbody>
-            <tr><td>1 (highest)</td><td><code>[]</code>, <code>.</code></td><td>Member access</td></tr>
+            <tr><td>1 (highest)</td><td><code>[]</code>, <code>.</code>, <code>?.</code></td><td>Member access</td></tr>
             <tr><td>2</td><td><code>()</code></td><td>Function call</td></tr>
-            <tr><td>3</td><td><code>-</code>, <code>!</code>, <code>na</code></td><td>Unary operators</td></tr>
+            <tr><td>3</td><td><code>-</code>, <code>!</code>, <code>na</code>, <code>~</code></td><td>Unary operators</td></tr>
             <tr><td>4</td><td><code>**</code></td><td>Exponentiation</td></tr>
             <tr><td>5</td><td><code>*</code>, <code>/</code>, <code>%</code></td><td>Multiplication, division</td></tr>
             <tr><td>6</td><td><code>+</code>, <code>-</code></td><td>Addition, subtraction</td></tr>
-            <tr><td>7</td><td><code>&lt;</code>, <code>&gt;</code>, <code>&lt;=</code>, <code>&gt;=</code></td><td>Comparison</td></tr>
-            <tr><td>8</td><td><code>==</code>, <code>!=</code></td><td>Equality</td></tr>
-            <tr><td>9</td><td><code>ebong</code></td><td>Logical AND</td></tr>
-            <tr><td>10</td><td><code>ba</code></td><td>Logical OR</td></tr>
-            <tr><td>11 (lowest)</td><td><code>=</code>, <code>+=</code>, etc.</td><td>Assignment</td></tr>
+            <tr><td>7</td><td><code>&lt;&lt;</code>, <code>&gt;&gt;</code>, <code>&gt;&gt;&gt;</code></td><td>Shifts</td></tr>
+            <tr><td>8</td><td><code>&lt;</code>, <code>&gt;</code>, <code>&lt;=</code>, <code>&gt;=</code></td><td>Comparison</td></tr>
+            <tr><td>9</td><td><code>==</code>, <code>!=</code></td><td>Equality</td></tr>
+            <tr><td>10</td><td><code>in</code>, <code>instanceof</code></td><td>Membership</td></tr>
+            <tr><td>11</td><td><code>&amp;</code></td><td>Bitwise AND</td></tr>
+            <tr><td>12</td><td><code>^</code></td><td>Bitwise XOR</td></tr>
+            <tr><td>13</td><td><code>|</code></td><td>Bitwise OR</td></tr>
+            <tr><td>14</td><td><code>ebong</code>, <code>&amp;&amp;</code></td><td>Logical AND</td></tr>
+            <tr><td>15</td><td><code>ba</code>, <code>||</code></td><td>Logical OR</td></tr>
+            <tr><td>16</td><td><code>??</code></td><td>Nullish coalescing</td></tr>
+            <tr><td>17</td><td><code>? :</code></td><td>Conditional</td></tr>
+            <tr><td>18 (lowest)</td><td><code>=</code>, <code>+=</code>, <code>??=</code>, etc.</td><td>Assignment</td></tr>
           </tbody>
         </table>
       </div>
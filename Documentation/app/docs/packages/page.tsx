@@ -0,0 +1,134 @@
+import CodeBlock from "@/components/CodeBlock";
+import DocNavigation from "@/components/DocNavigation";
+
+export default function Packages() {
+  return (
+    <div>
+      <div className="flex items-center gap-2 text-sm text-muted-foreground mb-4">
+        <span className="px-2 py-1 bg-primary/10 text-primary rounded-full text-xs font-medium">
+          Tooling
+        </span>
+      </div>
+
+      <h1>Packages</h1>
+
+      <p className="lead text-xl text-muted-foreground mt-4">
+        A package is a folder of BanglaCode files with a <code>banglacode.json</code>. The package
+        manager installs packages into <code>bangla_modules/</code>, and <code>ano &quot;name&quot;</code>
+        loads them.
+      </p>
+
+      <h2>banglacode.json</h2>
+
+      <CodeBlock
+        language="json"
+        showLineNumbers={false}
+        code={`{
+  "name": "amar-app",
+  "version": "1.0.0",
+  "main": "main.bang",
+  "registry": "https://packages.example.com",
+  "dependencies": {
+    "strutil": "^1.2.0"
+  }
+}`}
+      />
+
+      <p>Version requirements:</p>
+
+      <ul>
+        <li><code>1.2.3</code> - exactly 1.2.3</li>
+        <li><code>^1.2.3</code> - 1.2.3 up to, but not including, 2.0.0 (<code>^0.2.3</code> stops before 0.3.0)</li>
+        <li><code>~1.2.3</code> - 1.2.3 up to, but not including, 1.3.0</li>
+        <li><code>&gt;=1.2.3</code> - 1.2.3 or later</li>
+        <li><code>*</code> - any version</li>
+      </ul>
+
+      <h2>Commands</h2>
+
+      <CodeBlock
+        language="bash"
+        showLineNumbers={false}
+        code={`banglacode add strutil            # newest version, saved as ^x.y.z
+banglacode add strutil@~1.2.0     # with a version requirement
+banglacode remove strutil         # also uninstalls what only strutil needed
+banglacode install                # install everything in banglacode.json
+banglacode publish ../registry    # publish this package to a registry folder`}
+      />
+
+      <p>
+        <code>banglacode add</code> creates <code>banglacode.json</code> if the folder has none.
+        The registry comes from <code>--registry</code>, then the <code>BANGLACODE_REGISTRY</code>
+        environment variable, then the <code>registry</code> field of the manifest.
+      </p>
+
+      <h2>Using a Package</h2>
+
+      <CodeBlock
+        code={`ano "strutil";                       // the package's main file
+ano "strutil/extra.bang";            // another file in the package
+ano "strutil" hisabe s;              // as a namespace
+
+dekho(doubleLen("abc"));`}
+      />
+
+      <p>
+        A path that is a local file, or starts with <code>./</code> or <code>../</code>, is imported as
+        before. Otherwise BanglaCode looks in <code>bangla_modules/</code> next to the importing file
+        and in every folder above it. The language server and <code>banglacode lint</code> resolve
+        imports the same way.
+      </p>
+
+      <h2>The Lockfile</h2>
+
+      <p>
+        <code>banglacode.lock</code> records the exact version of every installed package, direct or
+        not, with the SHA-256 of its archive. Commit it: <code>banglacode install</code> keeps the
+        locked versions as long as they still match <code>banglacode.json</code>, and refuses to
+        install an archive whose hash is different.
+      </p>
+
+      <CodeBlock
+        language="json"
+        showLineNumbers={false}
+        code={`{
+  "lockfileVersion": 1,
+  "packages": {
+    "strutil": {
+      "version": "1.2.0",
+      "resolved": "strutil-1.2.0.tgz",
+      "integrity": "sha256-9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+    }
+  }
+}`}
+      />
+
+      <h2>Hosting a Registry</h2>
+
+      <p>
+        A registry is just files, so a shared folder or any static HTTP server can host one.
+        <code>banglacode publish</code> writes them:
+      </p>
+
+      <CodeBlock
+        language="text"
+        showLineNumbers={false}
+        code={`registry/
+└── strutil/
+    ├── index.json          # versions, archive names, hashes, dependencies
+    ├── strutil-1.1.0.tgz
+    └── strutil-1.2.0.tgz`}
+      />
+
+      <CodeBlock
+        language="bash"
+        showLineNumbers={false}
+        code={`cd strutil && banglacode publish /srv/registry
+cd /srv/registry && python3 -m http.server 8080
+BANGLACODE_REGISTRY=http://localhost:8080 banglacode add strutil`}
+      />
+
+      <DocNavigation currentPath="/docs/packages" />
+    </div>
+  );
+}
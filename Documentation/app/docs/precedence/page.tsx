@@ -19,7 +19,7 @@ export default function Precedence() {
 
       <h2>Precedence Table</h2>
 
-      <p>Operators are listed from highest precedence (1) to lowest (11):</p>
+      <p>Operators are listed from highest precedence (1) to lowest (18):</p>
 
       <div className="overflow-x-auto my-6">
         <table>
@@ -34,7 +34,7 @@ export default function Precedence() {
           <tbody>
             <tr>
               <td><strong>1</strong></td>
-              <td><code>[]</code> <code>.</code></td>
+              <td><code>[]</code> <code>.</code> <code>?.</code></td>
               <td>Member access, index</td>
               <td>Left to right</td>
             </tr>
@@ -46,8 +46,8 @@ export default function Precedence() {
             </tr>
             <tr>
               <td><strong>3</strong></td>
-              <td><code>-</code> <code>!</code> <code>na</code></td>
-              <td>Unary minus, logical NOT</td>
+              <td><code>-</code> <code>!</code> <code>na</code> <code>~</code></td>
+              <td>Unary minus, logical NOT, bitwise NOT</td>
               <td>Right to left</td>
             </tr>
             <tr>
@@ -70,31 +70,73 @@ export default function Precedence() {
             </tr>
             <tr>
               <td><strong>7</strong></td>
+              <td><code>&lt;&lt;</code> <code>&gt;&gt;</code> <code>&gt;&gt;&gt;</code></td>
+              <td>Bit shifts</td>
+              <td>Left to right</td>
+            </tr>
+            <tr>
+              <td><strong>8</strong></td>
               <td><code>&lt;</code> <code>&gt;</code> <code>&lt;=</code> <code>&gt;=</code></td>
               <td>Comparison</td>
               <td>Left to right</td>
             </tr>
             <tr>
-              <td><strong>8</strong></td>
+              <td><strong>9</strong></td>
               <td><code>==</code> <code>!=</code></td>
               <td>Equality</td>
               <td>Left to right</td>
             </tr>
             <tr>
-              <td><strong>9</strong></td>
-              <td><code>ebong</code></td>
+              <td><strong>10</strong></td>
+              <td><code>in</code> <code>instanceof</code></td>
+              <td>Membership</td>
+              <td>Left to right</td>
+            </tr>
+            <tr>
+              <td><strong>11</strong></td>
+              <td><code>&amp;</code></td>
+              <td>Bitwise AND</td>
+              <td>Left to right</td>
+            </tr>
+            <tr>
+              <td><strong>12</strong></td>
+              <td><code>^</code></td>
+              <td>Bitwise XOR</td>
+              <td>Left to right</td>
+            </tr>
+            <tr>
+              <td><strong>13</strong></td>
+              <td><code>|</code></td>
+              <td>Bitwise OR</td>
+              <td>Left to right</td>
+            </tr>
+            <tr>
+              <td><strong>14</strong></td>
+              <td><code>ebong</code> <code>&amp;&amp;</code></td>
               <td>Logical AND</td>
               <td>Left to right</td>
             </tr>
             <tr>
-              <td><strong>10</strong></td>
-              <td><code>ba</code></td>
+              <td><strong>15</strong></td>
+              <td><code>ba</code> <code>||</code></td>
               <td>Logical OR</td>
               <td>Left to right</td>
             </tr>
             <tr>
-              <td><strong>11</strong></td>
-              <td><code>=</code> <code>+=</code> <code>-=</code> <code>*=</code> <code>/=</code></td>
+              <td><strong>16</strong></td>
+              <td><code>??</code></td>
+              <td>Nullish coalescing</td>
+              <td>Left to right</td>
+            </tr>
+            <tr>
+              <td><strong>17</strong></td>
+              <td><code>? :</code></td>
+              <td>Conditional</td>
+              <td>Right to left</td>
+            </tr>
+            <tr>
+              <td><strong>18</strong></td>
+              <td><code>=</code> <code>+=</code> <code>-=</code> <code>*=</code> <code>/=</code> <code>%=</code> <code>**=</code> <code>&amp;=</code> <code>|=</code> <code>^=</code> <code>&lt;&lt;=</code> <code>&gt;&gt;=</code> <code>&gt;&gt;&gt;=</code> <code>??=</code> <code>||=</code> <code>&amp;&amp;=</code></td>
               <td>Assignment</td>
               <td>Right to left</td>
             </tr>
@@ -119,7 +161,12 @@ dekho(20 / 4 * 2);      // 10
 
 // Exponentiation is right-associative
 dekho(2 ** 3 ** 2);     // 512
-// Evaluated as: 2 ** (3 ** 2) = 2 ** 9`}
+// Evaluated as: 2 ** (3 ** 2) = 2 ** 9
+
+// A unary operator before ** needs parentheses, as in JavaScript
+// dekho(-2 ** 2);      // error[E010]: unary '-' cannot appear directly before '**'
+dekho((-2) ** 2);       // 4
+dekho(-(2 ** 2));       // -4`}
       />
 
       <h2>Comparison vs Arithmetic</h2>
@@ -136,7 +136,7 @@ dhoro customStream = stream_writable_srishti(32768); // 32KB buffer`}
               stream_lekho(stream, data)
             </h3>
             <p className="text-gray-700 dark:text-gray-300 mb-4">
-              Writes data to a writable stream. Triggers "data" event handlers if registered.
+              Writes data to a writable stream, or feeds a readable stream that has not ended. Triggers "data" event handlers if registered.
             </p>
             <div className="bg-gray-100 dark:bg-gray-900 rounded p-4 mb-4">
               <p className="text-sm font-semibold mb-2">Parameters:</p>
@@ -266,6 +266,37 @@ stream_pipe(source, destination);`}
             </div>
           </div>
 
+          {/* ghuriye opekha */}
+          <div className="border rounded-lg p-6 bg-white dark:bg-gray-800">
+            <h3 className="text-2xl font-semibold mb-3 text-teal-600 dark:text-teal-400">
+              ghuriye opekha (chunk of stream)
+            </h3>
+            <p className="text-gray-700 dark:text-gray-300 mb-4">
+              Reads a readable stream chunk by chunk. Each step waits until data is written with{" "}
+              <code>stream_lekho()</code>, and the loop ends after <code>stream_shesh()</code> once the
+              buffer is drained.
+            </p>
+            <div className="bg-gray-50 dark:bg-gray-900 rounded p-4">
+              <pre className="text-sm overflow-x-auto">
+                <code className="language-banglacode">
+{`dhoro stream = stream_readable_srishti();
+
+proyash kaj utpadak() {
+  stream_lekho(stream, "ek ");
+  opekha ghumaao(10);
+  stream_lekho(stream, "dui");
+  stream_shesh(stream);
+}
+utpadak();
+
+ghuriye opekha (chunk of stream) {
+  dekho(chunk); // "ek ", then "dui"
+}`}
+                </code>
+              </pre>
+            </div>
+          </div>
+
           {/* stream_on */}
           <div className="border rounded-lg p-6 bg-white dark:bg-gray-800">
             <h3 className="text-2xl font-semibold mb-3 text-pink-600 dark:text-pink-400">
@@ -0,0 +1,116 @@
+import CodeBlock from "@/components/CodeBlock";
+import DocNavigation from "@/components/DocNavigation";
+
+export default function Testing() {
+  return (
+    <div>
+      <div className="flex items-center gap-2 text-sm text-muted-foreground mb-4">
+        <span className="px-2 py-1 bg-primary/10 text-primary rounded-full text-xs font-medium">
+          Tooling
+        </span>
+      </div>
+
+      <h1>Testing</h1>
+
+      <p className="lead text-xl text-muted-foreground mt-4">
+        <code>banglacode test</code> finds every <code>*_test.bang</code> file in your project, runs the
+        tests declared with <code>porikkha</code> and reports which passed and which failed.
+      </p>
+
+      <h2>Writing Tests</h2>
+
+      <p>
+        Put tests next to the code they check, in a file ending in <code>_test.bang</code>
+        (<code>_test.bangla</code> and <code>_test.bong</code> work too). Each <code>porikkha</code>
+        (পরীক্ষা - test) takes a name and a function; <code>describe</code> groups related tests:
+      </p>
+
+      <CodeBlock
+        code={`// ganit_test.bang
+ano "ganit.bang";
+
+describe("jog", kaj() {
+    porikkha("duiti sonkha jog kore", kaj() {
+        nishchit_soman(jog(2, 3), 5);
+    });
+
+    porikkha("khali array er jogfol 0", kaj() {
+        nishchit_soman(jogfol([]), 0);
+    });
+});
+
+porikkha("shunno diye bhag kora jay na", kaj() {
+    nishchit_felbe(kaj() { bhag(1, 0); }, "shunno");
+});`}
+      />
+
+      <h2>Assertions</h2>
+
+      <p>
+        A failed assertion throws an <code>AssertionError</code>, which stops the test. Every assertion
+        takes an optional message as its last argument.
+      </p>
+
+      <ul>
+        <li><code>nishchit(value)</code> - value must be truthy</li>
+        <li><code>nishchit_soman(actual, expected)</code> - deep equality for numbers, strings, arrays, maps, Sets, Maps and class instances</li>
+        <li><code>nishchit_osoman(actual, expected)</code> - values must differ</li>
+        <li><code>nishchit_felbe(fn, "text")</code> - calling <code>fn</code> (or awaiting a promise) must throw, optionally with <code>text</code> in the message; returns the thrown value</li>
+      </ul>
+
+      <p>When values differ, the failure shows both values and every path where they differ:</p>
+
+      <CodeBlock
+        language="text"
+        showLineNumbers={false}
+        code={`  ✗ user > naam bodlay
+      AssertionError: values are not equal
+        expected: {naam: "Rahim", tags: ["admin"]}
+        actual:   {naam: "Karim", tags: []}
+        differences:
+          .naam: expected "Rahim", got "Karim"
+          .tags[0]: missing "admin"`}
+      />
+
+      <h2>Async Tests</h2>
+
+      <p>
+        A test written as <code>proyash kaj</code> is awaited before the next test starts, so you can use
+        <code>opekha</code> inside it. A test that does not settle within the timeout (5 seconds by
+        default) fails.
+      </p>
+
+      <CodeBlock
+        code={`porikkha("data ane", proyash kaj() {
+    dhoro data = opekha fetch_user(1);
+    nishchit_soman(data.id, 1);
+});
+
+porikkha("bhul id te reject kore", kaj() {
+    nishchit_felbe(fetch_user(-1), "not found");
+});`}
+      />
+
+      <h2>Running Tests</h2>
+
+      <CodeBlock
+        language="bash"
+        showLineNumbers={false}
+        code={`banglacode test                     # every *_test.bang below the current directory
+banglacode test src/ganit_test.bang  # one file
+banglacode test --run "jog >"        # only tests whose full name matches the pattern
+banglacode test --junit report.xml   # also write a JUnit XML report for CI
+banglacode test --watch              # rerun whenever a .bang file changes
+banglacode test --timeout 10s        # allow slower async tests`}
+      />
+
+      <p>
+        The full name of a test is its <code>describe</code> groups and its own name joined with
+        <code>&quot; &gt; &quot;</code>, e.g. <code>jog &gt; duiti sonkha jog kore</code>; <code>--run</code> takes a
+        regular expression. The command exits with status 1 if any test fails, so it can gate CI builds.
+      </p>
+
+      <DocNavigation currentPath="/docs/testing" />
+    </div>
+  );
+}
@@ -5,7 +5,8 @@ import {
   FunctionSquare,
   GraduationCap,
   List,
-  FileText
+  FileText,
+  Wrench
 } from "lucide-react";
 
 export interface DocItem {
@@ -89,6 +90,18 @@ export const DOCS_CONFIG: DocSection[] = [
       { name: "Database", href: "/docs/database", description: "PostgreSQL, MySQL, MongoDB, Redis" },
     ],
   },
+  {
+    section: "Tooling",
+    icon: Wrench,
+    items: [
+      { name: "Editor Support", href: "/docs/editor-support", description: "Language server for VS Code, Neovim and more" },
+      { name: "Testing", href: "/docs/testing", description: "Write and run tests with banglacode test" },
+      { name: "Formatting", href: "/docs/formatting", description: "Canonical code style with banglacode fmt" },
+      { name: "Linting", href: "/docs/linting", description: "Find likely bugs with banglacode lint" },
+      { name: "Packages", href: "/docs/packages", description: "Share code with banglacode add and install" },
+      { name: "Migrations", href: "/docs/migrations", description: "Database schema changes with banglacode migrate" },
+    ],
+  },
   {
     section: "Reference",
     icon: FileText,
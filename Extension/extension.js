@@ -1,6 +1,7 @@
 const vscode = require('vscode');
 const path = require('path');
 const fs = require('fs');
+const { execFile } = require('child_process');
 
 /**
  * BanglaCode Language Extension
@@ -150,6 +151,10 @@ const builtinFunctions = [
     { label: 'poro_async', kind: vscode.CompletionItemKind.Function, detail: 'পড়ো অ্যাসিঙ্ক - ফাইল পড়ো অ্যাসিঙ্ক', insertText: 'poro_async(${1:"filename"})', documentation: 'অ্যাসিঙ্ক্রোনাস ফাইল পড়ে (প্রমিস রিটার্ন করে)' },
     { label: 'lekho_async', kind: vscode.CompletionItemKind.Function, detail: 'লেখো অ্যাসিঙ্ক - ফাইল লেখো অ্যাসিঙ্ক', insertText: 'lekho_async(${1:"filename"}, ${2:content})', documentation: 'অ্যাসিঙ্ক্রোনাস ফাইলে লেখে (প্রমিস রিটার্ন করে)' },
     { label: 'sob_proyash', kind: vscode.CompletionItemKind.Function, detail: 'সব প্রয়াস - Promise.all', insertText: 'sob_proyash(${1:promisesArray})', documentation: 'সব প্রমিসের জন্য অপেক্ষা করে (Promise.all এর মতো)' },
+    { label: 'prothom_proyash', kind: vscode.CompletionItemKind.Function, detail: 'প্রথম প্রয়াস - Promise.race', insertText: 'prothom_proyash(${1:promisesArray})', documentation: 'যে প্রমিস প্রথমে শেষ হয় তার ফলাফল দেয়' },
+    { label: 'sob_nishpotti', kind: vscode.CompletionItemKind.Function, detail: 'সব নিষ্পত্তি - Promise.allSettled', insertText: 'sob_nishpotti(${1:promisesArray})', documentation: 'সব প্রমিস শেষ হলে প্রতিটির {status, value/reason} দেয়' },
+    { label: 'jekono_proyash', kind: vscode.CompletionItemKind.Function, detail: 'যেকোনো প্রয়াস - Promise.any', insertText: 'jekono_proyash(${1:promisesArray})', documentation: 'প্রথম সফল প্রমিসের মান দেয়; সব ব্যর্থ হলে AggregateError' },
+    { label: 'somoy_shima', kind: vscode.CompletionItemKind.Function, detail: 'সময় সীমা - Promise timeout', insertText: 'somoy_shima(${1:promise}, ${2:milliseconds})', documentation: 'নির্দিষ্ট সময়ে শেষ না হলে TimeoutError দিয়ে রিজেক্ট করে' },
 
     // TCP নেটওয়ার্ক ফাংশন
     { label: 'tcp_server_chalu', kind: vscode.CompletionItemKind.Function, detail: 'টিসিপি সার্ভার চালু - TCP Server', insertText: 'tcp_server_chalu(${1:port}, ${2:handler})', documentation: 'নির্দিষ্ট পোর্টে TCP সার্ভার চালু করে। হ্যান্ডলার ফাংশন প্রতিটি সংযোগের জন্য কল হয়।' },
@@ -1375,7 +1380,10 @@ function activate(context) {
                 const variadicBuiltins = new Set(['dekho', 'choto', 'boro']);
 
                 // Special case: functions that take array arguments (argument counting should ignore commas inside arrays)
-                const arrayArgFunctions = new Set(['sob_proyash']);
+                const arrayArgFunctions = new Set(['sob_proyash', 'prothom_proyash', 'sob_nishpotti', 'jekono_proyash']);
+
+                // Functions that also take an optional trailing abort signal
+                const signalArgFunctions = new Set(['ghumaao', 'anun_async']);
 
                 // Skip validation for variadic functions and array argument functions
                 if (!variadicBuiltins.has(funcName) && !arrayArgFunctions.has(funcName)) {
@@ -1384,7 +1392,8 @@ function activate(context) {
                     if (paramMatch) {
                         const expectedCount = paramMatch.length;
 
-                        if (argCount !== expectedCount) {
+                        const acceptsSignal = signalArgFunctions.has(funcName) && argCount === expectedCount + 1;
+                        if (argCount !== expectedCount && !acceptsSignal) {
                             const pos = document.positionAt(match.index);
                             const range = new vscode.Range(pos, pos.translate(0, funcName.length));
                             diagnostics.push(new vscode.Diagnostic(
@@ -1567,19 +1576,83 @@ function activate(context) {
         diagnosticCollection.set(document.uri, diagnostics);
     };
     
+    // Syntax errors reported by the interpreter itself (`banglacode check --json -`)
+    const syntaxCollection = vscode.languages.createDiagnosticCollection('banglacode-syntax');
+    const syntaxTimers = new Map();
+
+    const updateSyntaxDiagnostics = (document) => {
+        if (document.languageId !== 'banglacode') return;
+
+        const key = document.uri.toString();
+        clearTimeout(syntaxTimers.get(key));
+        syntaxTimers.set(key, setTimeout(() => {
+            syntaxTimers.delete(key);
+            const child = execFile('banglacode', ['check', '--json', '-'], (err, stdout) => {
+                // banglacode not installed: keep the regex-based checks only
+                if (err && err.code === 'ENOENT') return;
+
+                let reported;
+                try {
+                    reported = JSON.parse(stdout);
+                } catch (parseErr) {
+                    return;
+                }
+
+                const diagnostics = reported.map(d => {
+                    const range = new vscode.Range(
+                        d.start.line - 1, d.start.column - 1,
+                        d.end.line - 1, d.end.column - 1
+                    );
+                    const message = d.fix ? `${d.message} (${d.fix})` : d.message;
+                    const diagnostic = new vscode.Diagnostic(range, message,
+                        d.severity === 'warning' ? vscode.DiagnosticSeverity.Warning : vscode.DiagnosticSeverity.Error);
+                    diagnostic.code = d.code;
+                    diagnostic.source = 'banglacode';
+                    return diagnostic;
+                });
+                syntaxCollection.set(document.uri, diagnostics);
+            });
+            child.on('error', () => {});
+            child.stdin.end(document.getText());
+        }, 300));
+    };
+
     // Update diagnostics on document change
     vscode.workspace.onDidChangeTextDocument(event => {
         updateDiagnostics(event.document);
+        updateSyntaxDiagnostics(event.document);
     });
     
     // Update diagnostics on document open
     vscode.workspace.onDidOpenTextDocument(document => {
         updateDiagnostics(document);
+        updateSyntaxDiagnostics(document);
     });
     
     // Initial diagnostics for open documents
     vscode.workspace.textDocuments.forEach(document => {
         updateDiagnostics(document);
+        updateSyntaxDiagnostics(document);
+    });
+
+    // Format document (and format-on-save) through `banglacode fmt -`
+    const formattingProvider = vscode.languages.registerDocumentFormattingEditProvider('banglacode', {
+        provideDocumentFormattingEdits(document) {
+            return new Promise(resolve => {
+                const child = execFile('banglacode', ['fmt', '-'], (err, stdout) => {
+                    // Not installed or a syntax error: leave the document as it is
+                    if (err) return resolve([]);
+
+                    const fullRange = new vscode.Range(
+                        document.positionAt(0),
+                        document.positionAt(document.getText().length)
+                    );
+                    resolve([vscode.TextEdit.replace(fullRange, stdout)]);
+                });
+                child.on('error', () => resolve([]));
+                child.stdin.end(document.getText());
+            });
+        }
     });
 
     // Register signature help provider
@@ -1609,7 +1682,7 @@ function activate(context) {
         '(', ','
     );
 
-    context.subscriptions.push(completionProvider, importPathProvider, hoverProvider, signatureProvider, diagnosticCollection);
+    context.subscriptions.push(completionProvider, importPathProvider, hoverProvider, signatureProvider, formattingProvider, diagnosticCollection, syntaxCollection);
 }
 
 function deactivate() {}
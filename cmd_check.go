@@ -0,0 +1,58 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/parser"
+)
+
+// checkFiles parses files without running them and reports every syntax
+// error. With --json the diagnostics are printed as one JSON array (used by
+// the VS Code extension); "-" reads the source from stdin.
+// It returns the process exit code.
+func checkFiles(args []string) int {
+	asJSON := false
+	if len(args) > 0 && args[0] == "--json" {
+		asJSON = true
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: banglacode check [--json] <file>...")
+		return 2
+	}
+
+	all := []parser.Diagnostic{}
+	for _, filename := range args {
+		var content []byte
+		var err error
+		if filename == "-" {
+			content, err = io.ReadAll(os.Stdin)
+		} else {
+			content, err = os.ReadFile(filename)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+			return 2
+		}
+
+		p := parser.New(lexer.NewWithFile(string(content), filename))
+		p.ParseProgram()
+		if !asJSON && len(p.Diagnostics()) != 0 {
+			printDiagnostics(p.Diagnostics(), string(content))
+		}
+		all = append(all, p.Diagnostics()...)
+	}
+
+	if asJSON {
+		out, _ := json.Marshal(all)
+		fmt.Println(string(out))
+	}
+	if len(all) != 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"BanglaCode/src/format"
+)
+
+// formatFiles implements banglacode fmt [-w] [--check] files...: it prints
+// each file in the canonical layout, rewrites it with -w, or with --check
+// only lists the files that are not formatted (exit code 1 if any).
+// "-" formats stdin to stdout, which editors use for format-on-save.
+func formatFiles(args []string) int {
+	flags := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	write := flags.Bool("w", false, "write the result back to the source file")
+	check := flags.Bool("check", false, "list files whose formatting differs and exit with status 1")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: banglacode fmt [-w] [--check] <file>...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	status := 0
+	for _, filename := range flags.Args() {
+		var content []byte
+		var err error
+		if filename == "-" {
+			content, err = io.ReadAll(os.Stdin)
+		} else {
+			content, err = os.ReadFile(filename)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+			return 2
+		}
+
+		formatted, err := format.Source(content, filename)
+		if err != nil {
+			if syntaxErr, ok := err.(*format.SyntaxError); ok {
+				printDiagnostics(syntaxErr.Diagnostics, string(content))
+			} else {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", filename, err)
+			}
+			status = 2
+			continue
+		}
+
+		switch {
+		case *check:
+			if string(formatted) != string(content) {
+				fmt.Println(filename)
+				if status == 0 {
+					status = 1
+				}
+			}
+		case *write && filename != "-":
+			if string(formatted) == string(content) {
+				continue
+			}
+			info, err := os.Stat(filename)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 2
+			}
+			if err := os.WriteFile(filename, formatted, info.Mode().Perm()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing file: %v\n", err)
+				return 2
+			}
+		default:
+			os.Stdout.Write(formatted)
+		}
+	}
+	return status
+}
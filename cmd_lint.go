@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"BanglaCode/src/lint"
+	"BanglaCode/src/parser"
+)
+
+// lintFiles implements banglacode lint [--json] [--config file] [paths...]:
+// it runs the lint rules over every file below the given paths and returns
+// 1 if any problem has error severity. "-" lints stdin.
+func lintFiles(args []string) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print the problems as one JSON array")
+	configPath := flags.String("config", "", "config file (default: nearest "+lint.ConfigFile+")")
+	listRules := flags.Bool("rules", false, "list the available rules")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: banglacode lint [--json] [--config file] [--rules] [path...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *listRules {
+		for _, rule := range lint.Rules() {
+			fmt.Printf("%-20s %-8s %s\n", rule.Name, rule.Severity, rule.Description)
+		}
+		return 0
+	}
+
+	var cfg *lint.Config
+	if *configPath != "" {
+		var err error
+		if cfg, err = lint.LoadConfig(*configPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+	}
+
+	var files []string
+	if flags.NArg() == 1 && flags.Arg(0) == "-" {
+		files = []string{"-"}
+	} else {
+		var err error
+		if files, err = lint.Discover(flags.Args()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+	}
+
+	all := []parser.Diagnostic{}
+	for _, filename := range files {
+		var content []byte
+		var err error
+		dir := filepath.Dir(filename)
+		if filename == "-" {
+			content, err = io.ReadAll(os.Stdin)
+			dir = "."
+		} else {
+			content, err = os.ReadFile(filename)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+			return 2
+		}
+
+		fileCfg := cfg
+		if fileCfg == nil {
+			if fileCfg, err = lint.FindConfig(dir); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return 2
+			}
+		}
+
+		diagnostics := lint.Source(content, filename, fileCfg)
+		if !*asJSON {
+			printDiagnosticList(diagnostics, string(content))
+		}
+		all = append(all, diagnostics...)
+	}
+
+	errors := 0
+	for _, d := range all {
+		if d.Severity == parser.SeverityError {
+			errors++
+		}
+	}
+	if *asJSON {
+		out, _ := json.Marshal(all)
+		fmt.Println(string(out))
+	} else if len(all) != 0 {
+		fmt.Fprintf(os.Stderr, "%d problem(s): %d error(s), %d warning(s)\n", len(all), errors, len(all)-errors)
+	}
+	if errors != 0 {
+		return 1
+	}
+	return 0
+}
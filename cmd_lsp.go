@@ -0,0 +1,18 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"BanglaCode/src/lsp"
+)
+
+// lspCommand implements banglacode lsp: it serves the language server
+// protocol on stdin and stdout until the client exits
+func lspCommand() int {
+	if err := lsp.NewServer(os.Stdin, os.Stdout).Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "lsp: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"BanglaCode/src/migrate"
+)
+
+// migrateCommand implements banglacode migrate up|down|status|create|unlock.
+// The database comes from --db or DATABASE_URL.
+func migrateCommand(args []string) int {
+	usage := func() {
+		fmt.Fprintln(os.Stderr, "Usage: banglacode migrate <command> [flags]")
+		fmt.Fprintln(os.Stderr, "")
+		fmt.Fprintln(os.Stderr, "Commands:")
+		fmt.Fprintln(os.Stderr, "  up [N]          Apply pending migrations (at most N)")
+		fmt.Fprintln(os.Stderr, "  down [N]        Roll back the last N migrations (default 1)")
+		fmt.Fprintln(os.Stderr, "  status          List applied and pending migrations")
+		fmt.Fprintln(os.Stderr, "  create <name>   Write new .up.sql/.down.sql files (--bang for a script)")
+		fmt.Fprintln(os.Stderr, "  unlock          Release a lock left by a crashed run")
+	}
+	if len(args) == 0 {
+		usage()
+		return 2
+	}
+
+	command := args[0]
+	flags := flag.NewFlagSet("migrate "+command, flag.ContinueOnError)
+	dir := flags.String("dir", migrate.DefaultDir, "migrations directory")
+	dbURL := flags.String("db", os.Getenv("DATABASE_URL"), "database URL: postgres://..., mysql://... or sqlite:file.db (default $DATABASE_URL)")
+	dryRun := flags.Bool("dry-run", false, "print the migrations that would run without running them")
+	script := flags.Bool("bang", false, "create: write a BanglaCode migration instead of SQL files")
+	flags.Usage = func() {
+		usage()
+		fmt.Fprintln(os.Stderr, "")
+		flags.PrintDefaults()
+	}
+	// Flags may come before or after the positional argument
+	var positional []string
+	for rest := args[1:]; ; {
+		if err := flags.Parse(rest); err != nil {
+			return 2
+		}
+		if flags.NArg() == 0 {
+			break
+		}
+		positional = append(positional, flags.Arg(0))
+		rest = flags.Args()[1:]
+	}
+
+	if command == "create" {
+		if len(positional) != 1 {
+			flags.Usage()
+			return 2
+		}
+		files, err := migrate.Create(*dir, positional[0], *script, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		for _, file := range files {
+			fmt.Printf("Created %s\n", file)
+		}
+		return 0
+	}
+
+	n := 0
+	switch command {
+	case "up", "down":
+		if len(positional) > 1 {
+			flags.Usage()
+			return 2
+		}
+		if len(positional) == 1 {
+			var err error
+			if n, err = strconv.Atoi(positional[0]); err != nil || n < 1 {
+				fmt.Fprintf(os.Stderr, "Error: %s expects a positive count, got %q\n", command, positional[0])
+				return 2
+			}
+		}
+	case "status", "unlock":
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown migrate command %q\n\n", command)
+		usage()
+		return 2
+	}
+
+	if *dbURL == "" {
+		fmt.Fprintln(os.Stderr, "Error: no database given (use --db or set DATABASE_URL)")
+		return 2
+	}
+	conn, err := migrate.Connect(*dbURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	migrator, err := migrate.New(conn, *dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	migrator.DryRun = *dryRun
+	migrator.Out = os.Stdout
+
+	switch command {
+	case "up", "down":
+		var done int
+		if command == "up" {
+			done, err = migrator.Up(n)
+		} else {
+			done, err = migrator.Down(n)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if done > 0 && !*dryRun {
+			fmt.Printf("Done: %d migration(s)\n", done)
+		}
+	case "status":
+		entries, err := migrator.Status()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		if len(entries) == 0 {
+			fmt.Printf("No migrations in %s\n", *dir)
+		}
+		for _, e := range entries {
+			switch {
+			case e.Missing:
+				fmt.Printf("  \033[33mmissing\033[0m  %s  (applied %s, files not found)\n", e.ID(), e.AppliedAt)
+			case e.Applied:
+				fmt.Printf("  \033[32mapplied\033[0m  %s  (%s)\n", e.ID(), e.AppliedAt)
+			default:
+				fmt.Printf("  pending  %s\n", e.ID())
+			}
+		}
+	case "unlock":
+		if err := migrator.Unlock(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Println("Migration lock released")
+	}
+	return 0
+}
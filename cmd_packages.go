@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"BanglaCode/src/packages"
+)
+
+// packageCommand implements banglacode install, add, remove and publish.
+// The registry comes from --registry, then BANGLACODE_REGISTRY, then the
+// "registry" field of banglacode.json.
+func packageCommand(command string, args []string) int {
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	registry := flags.String("registry", "", "registry directory or http(s) URL")
+	flags.Usage = func() {
+		switch command {
+		case "install":
+			fmt.Fprintln(os.Stderr, "Usage: banglacode install [--registry location]")
+		case "publish":
+			fmt.Fprintln(os.Stderr, "Usage: banglacode publish <registry-dir>")
+		default:
+			fmt.Fprintf(os.Stderr, "Usage: banglacode %s [--registry location] <package>...\n", command)
+		}
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if command == "publish" {
+		if flags.NArg() != 1 {
+			flags.Usage()
+			return 2
+		}
+		entry, err := packages.Publish(".", flags.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Published %s (sha256 %s)\n", entry.File, entry.SHA256)
+		return 0
+	}
+	if command != "install" && flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	var project *packages.Project
+	var err error
+	if command == "add" {
+		project, err = packages.InitProject(".")
+	} else {
+		project, err = packages.OpenProject(".")
+	}
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = fmt.Errorf("no %s in this directory (banglacode add <package> creates one)", packages.ManifestFile)
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if *registry != "" {
+		project.RegistryLocation = *registry
+	}
+	project.Out = os.Stdout
+
+	switch command {
+	case "install":
+		err = project.Install()
+	case "add":
+		err = project.Add(flags.Args())
+	case "remove":
+		err = project.Remove(flags.Args())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
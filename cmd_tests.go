@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"BanglaCode/src/testrunner"
+)
+
+// runTests implements banglacode test [flags] [paths...]: it runs every
+// *_test.bang file below the given paths (default: the current directory)
+// and returns 1 if any test failed
+func runTests(args []string) int {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	filter := flags.String("run", "", "only run tests whose name matches this regular expression")
+	junit := flags.String("junit", "", "also write a JUnit XML report to this file")
+	watch := flags.Bool("watch", false, "rerun the tests whenever a source file changes")
+	timeout := flags.Duration("timeout", 5*time.Second, "time limit for each async test")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: banglacode test [--run pattern] [--junit file] [--watch] [--timeout 5s] [path...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	opts := testrunner.Options{Timeout: *timeout, Out: os.Stdout}
+	if info, err := os.Stdout.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		opts.Color = true
+	}
+	if *filter != "" {
+		re, err := regexp.Compile(*filter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid --run pattern: %v\n", err)
+			return 2
+		}
+		opts.Filter = re
+	}
+
+	run := func() int {
+		files, err := testrunner.Discover(flags.Args())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+		if len(files) == 0 {
+			fmt.Fprintln(os.Stderr, "no *_test.bang files found")
+			return 1
+		}
+		report := testrunner.Run(files, opts)
+		if *junit != "" {
+			f, err := os.Create(*junit)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing JUnit report: %v\n", err)
+				return 2
+			}
+			defer f.Close()
+			if err := testrunner.WriteJUnit(f, report); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing JUnit report: %v\n", err)
+				return 2
+			}
+		}
+		if !report.OK() {
+			return 1
+		}
+		return 0
+	}
+
+	if *watch {
+		testrunner.Watch(flags.Args(), 500*time.Millisecond, nil, func() {
+			fmt.Print("\033[H\033[2J")
+			run()
+			fmt.Println("\033[2mwatching for changes...\033[0m")
+		})
+		return 0
+	}
+	return run()
+}
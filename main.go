@@ -5,14 +5,17 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 
 	"BanglaCode/src/Update"
 	"BanglaCode/src/evaluator"
 	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/eventloop"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
 	"BanglaCode/src/parser"
 	"BanglaCode/src/repl"
+	"BanglaCode/src/vm"
 )
 
 func main() {
@@ -34,19 +37,44 @@ func main() {
 		return
 	}
 
-	if os.Args[1] == "--help" || os.Args[1] == "-h" {
+	// Subcommands return the process exit code
+	switch os.Args[1] {
+	case "--help", "-h":
 		printHelp()
 		return
-	}
-
-	if os.Args[1] == "--version" || os.Args[1] == "-v" {
+	case "--version", "-v":
 		printVersion()
 		return
+	case "lsp":
+		os.Exit(lspCommand())
+	case "check":
+		os.Exit(checkFiles(os.Args[2:]))
+	case "test":
+		os.Exit(runTests(os.Args[2:]))
+	case "fmt":
+		os.Exit(formatFiles(os.Args[2:]))
+	case "lint":
+		os.Exit(lintFiles(os.Args[2:]))
+	case "install", "add", "remove", "publish":
+		os.Exit(packageCommand(os.Args[1], os.Args[2:]))
+	case "migrate":
+		os.Exit(migrateCommand(os.Args[2:]))
+	}
+
+	// Execute file, optionally on the bytecode VM
+	args := os.Args[1:]
+	useVM := false
+	if args[0] == "--vm" {
+		useVM = true
+		args = args[1:]
+		if len(args) == 0 {
+			fmt.Fprintln(os.Stderr, "Usage: banglacode --vm <file>")
+			os.Exit(1)
+		}
 	}
 
-	// Execute file
-	filename := os.Args[1]
-	runFile(filename)
+	filename := args[0]
+	runFile(filename, useVM)
 }
 
 func printHelp() {
@@ -61,6 +89,17 @@ func printHelp() {
 	fmt.Println("\033[1;33m▸ Usage:\033[0m")
 	fmt.Println("  \033[1;32mbanglacode\033[0m                  Start interactive REPL")
 	fmt.Println("  \033[1;32mbanglacode <file>\033[0m           Execute a BanglaCode file")
+	fmt.Println("  \033[1;32mbanglacode --vm <file>\033[0m      Execute a file on the bytecode VM")
+	fmt.Println("  \033[1;32mbanglacode check <file>\033[0m     Report all syntax errors without running")
+	fmt.Println("  \033[1;32mbanglacode test [path]\033[0m      Run *_test.bang files")
+	fmt.Println("  \033[1;32mbanglacode fmt [-w] <file>\033[0m  Format files in the canonical style")
+	fmt.Println("  \033[1;32mbanglacode lint [path]\033[0m      Find likely bugs without running")
+	fmt.Println("  \033[1;32mbanglacode install\033[0m          Install the dependencies in banglacode.json")
+	fmt.Println("  \033[1;32mbanglacode add <pkg>\033[0m        Add a dependency (pkg or pkg@^1.2.0)")
+	fmt.Println("  \033[1;32mbanglacode remove <pkg>\033[0m     Remove a dependency")
+	fmt.Println("  \033[1;32mbanglacode publish <dir>\033[0m    Publish this package to a registry directory")
+	fmt.Println("  \033[1;32mbanglacode migrate <cmd>\033[0m    Database migrations: up, down, status, create")
+	fmt.Println("  \033[1;32mbanglacode lsp\033[0m              Start the language server (stdio)")
 	fmt.Println("  \033[1;32mbanglacode update\033[0m           Update to the latest version")
 	fmt.Println("  \033[1;32mbanglacode --help, -h\033[0m       Show this help message")
 	fmt.Println("  \033[1;32mbanglacode --version, -v\033[0m    Show version information")
@@ -73,6 +112,11 @@ func printHelp() {
 	fmt.Println("  \033[0;34m$\033[0m banglacode hello.bang       \033[2m# Run hello.bang file\033[0m")
 	fmt.Println("  \033[0;34m$\033[0m banglacode app.bangla       \033[2m# Run app.bangla file\033[0m")
 	fmt.Println("  \033[0;34m$\033[0m banglacode server.bong      \033[2m# Run server.bong file\033[0m")
+	fmt.Println("  \033[0;34m$\033[0m banglacode --vm hello.bang  \033[2m# Run hello.bang on the VM\033[0m")
+	fmt.Println("  \033[0;34m$\033[0m banglacode test --watch     \033[2m# Rerun tests on every change\033[0m")
+	fmt.Println("  \033[0;34m$\033[0m banglacode fmt -w app.bang  \033[2m# Format app.bang in place\033[0m")
+	fmt.Println("  \033[0;34m$\033[0m banglacode add strutil     \033[2m# Install a package\033[0m")
+	fmt.Println("  \033[0;34m$\033[0m banglacode migrate up       \033[2m# Apply pending migrations\033[0m")
 	fmt.Println("  \033[0;34m$\033[0m banglacode update           \033[2m# Update to latest version\033[0m")
 	fmt.Println("")
 	fmt.Println("\033[1;36m╚══════════════════════════════════════════════════════════════════╝\033[0m")
@@ -93,7 +137,7 @@ func printVersion() {
 	fmt.Println("\033[1;36m╚════════════════════════════════════════════════════════╝\033[0m")
 }
 
-func runFile(filename string) {
+func runFile(filename string, useVM bool) {
 	// Validate file extension (warning only, not enforced)
 	ext := filepath.Ext(filename)
 	if ext != ".bang" && ext != ".bangla" && ext != ".bong" {
@@ -115,26 +159,99 @@ func runFile(filename string) {
 	env := object.NewEnvironment()
 	builtins.InitializeEnvironmentWithConstants(env)
 
-	// Lex
-	l := lexer.New(string(content))
+	// Lex (tokens remember the file name for error messages)
+	l := lexer.NewWithFile(string(content), filename)
 
 	// Parse
 	p := parser.New(l)
 	program := p.ParseProgram()
 
-	if len(p.Errors()) != 0 {
-		fmt.Fprintln(os.Stderr, "\033[31mParser errors:\033[0m")
-		for _, msg := range p.Errors() {
-			fmt.Fprintf(os.Stderr, "\t%s\n", msg)
-		}
+	if len(p.Diagnostics()) != 0 {
+		printDiagnostics(p.Diagnostics(), string(content))
 		os.Exit(1)
 	}
 
-	// Evaluate
-	result := evaluator.Eval(program, env)
+	// As in Node, a promise still rejected without a handler once the
+	// microtask queue drains ends the program
+	object.SetUnhandledRejectionHandler(func(rejected []*object.Promise) {
+		for _, promise := range rejected {
+			reason, _ := promise.Result()
+			if errObj, ok := reason.(*object.Error); ok {
+				fmt.Fprintln(os.Stderr, "\033[31mUncaught (in promise)\033[0m")
+				printRuntimeError(errObj, filename, string(content))
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "\033[31mUncaught (in promise) %s\033[0m\n", object.RejectionReason(reason).Inspect())
+		}
+		os.Exit(1)
+	})
+
+	// Evaluate with the tree-walking interpreter or the bytecode VM
+	eventloop.Lock()
+	defer eventloop.Unlock()
+	var result object.Object
+	if useVM {
+		result = vm.Run(program, env)
+	} else {
+		result = evaluator.Eval(program, env)
+	}
 
 	if result != nil && result.Type() == object.ERROR_OBJ {
-		fmt.Fprintf(os.Stderr, "\033[31m%s\033[0m\n", result.Inspect())
+		printRuntimeError(result.(*object.Error), filename, string(content))
 		os.Exit(1)
 	}
+
+	// Keep going until no timers, callbacks or pending I/O are left
+	eventloop.Run()
+}
+
+// printDiagnostics prints every syntax error with an underlined excerpt
+// and the suggested fix, followed by a count
+func printDiagnostics(diagnostics []parser.Diagnostic, source string) {
+	printDiagnosticList(diagnostics, source)
+	fmt.Fprintf(os.Stderr, "\033[31m%d syntax error(s)\033[0m\n", len(diagnostics))
+}
+
+// printDiagnosticList prints each diagnostic with its source excerpt;
+// warnings are yellow, errors red
+func printDiagnosticList(diagnostics []parser.Diagnostic, source string) {
+	for _, d := range diagnostics {
+		color := "31"
+		if d.Severity == parser.SeverityWarning {
+			color = "33"
+		}
+		fmt.Fprintf(os.Stderr, "\033[%sm%s\033[0m\n", color, d)
+		width := d.End.Column - d.Start.Column
+		if d.End.Line != d.Start.Line {
+			width = 1
+		}
+		if excerpt := object.SourceExcerpt(source, d.Start.Line, d.Start.Column, width); excerpt != "" {
+			fmt.Fprintln(os.Stderr, excerpt)
+		}
+		if d.Fix != "" {
+			fmt.Fprintf(os.Stderr, "     = \033[36mhelp:\033[0m %s\n", d.Fix)
+		}
+	}
+}
+
+// printRuntimeError prints an error with an underlined excerpt of the
+// offending line, followed by the stack trace if there is one
+func printRuntimeError(errObj *object.Error, filename, source string) {
+	fmt.Fprintf(os.Stderr, "\033[31m%s\033[0m\n", errObj.Inspect())
+
+	// Errors raised inside imported modules point at the module's source
+	if errObj.File != "" && errObj.File != filename {
+		data, err := os.ReadFile(errObj.File)
+		if err != nil {
+			data = nil
+		}
+		source = string(data)
+	}
+	if excerpt := errObj.Excerpt(source); excerpt != "" {
+		fmt.Fprintln(os.Stderr, excerpt)
+	}
+
+	if len(errObj.Stack) > 0 {
+		fmt.Fprintln(os.Stderr, strings.TrimPrefix(errObj.GetStack(), errObj.Inspect()+"\n"))
+	}
 }
@@ -6,79 +6,186 @@ import (
 	"strings"
 )
 
-// ArrayDestructuringDeclaration represents: dhoro [a, b] = expr;
-type ArrayDestructuringDeclaration struct {
+// DestructuringDeclaration represents: dhoro [a, b] = expr; or
+// dhoro {x, y: z} = expr;
+type DestructuringDeclaration struct {
 	Token      lexer.Token // DHORO/STHIR/BISHWO token
-	Names      []*Identifier
+	Pattern    Expression  // *ArrayPattern or *ObjectPattern
 	Source     Expression
 	IsConstant bool
 	IsGlobal   bool
 }
 
-func (ad *ArrayDestructuringDeclaration) statementNode()       {}
-func (ad *ArrayDestructuringDeclaration) TokenLiteral() string { return ad.Token.Literal }
-func (ad *ArrayDestructuringDeclaration) String() string {
+func (dd *DestructuringDeclaration) statementNode()       {}
+func (dd *DestructuringDeclaration) TokenLiteral() string { return dd.Token.Literal }
+func (dd *DestructuringDeclaration) String() string {
 	var out bytes.Buffer
-	if ad.IsConstant {
+	if dd.IsConstant {
 		out.WriteString("sthir ")
-	} else if ad.IsGlobal {
+	} else if dd.IsGlobal {
 		out.WriteString("bishwo ")
 	} else {
 		out.WriteString("dhoro ")
 	}
-	names := make([]string, 0, len(ad.Names))
-	for _, n := range ad.Names {
-		names = append(names, n.Value)
-	}
-	out.WriteString("[")
-	out.WriteString(strings.Join(names, ", "))
-	out.WriteString("] = ")
-	out.WriteString(ad.Source.String())
+	out.WriteString(dd.Pattern.String())
+	out.WriteString(" = ")
+	out.WriteString(dd.Source.String())
 	out.WriteString(";")
 	return out.String()
 }
 
-// ObjectDestructuringDeclaration represents: dhoro {x, y} = expr;
-type ObjectDestructuringDeclaration struct {
-	Token      lexer.Token // DHORO/STHIR/BISHWO token
-	Keys       []string
-	Names      []*Identifier
-	Source     Expression
-	IsConstant bool
-	IsGlobal   bool
-}
-
-func (od *ObjectDestructuringDeclaration) statementNode()       {}
-func (od *ObjectDestructuringDeclaration) TokenLiteral() string { return od.Token.Literal }
-func (od *ObjectDestructuringDeclaration) String() string {
-	var out bytes.Buffer
-	if od.IsConstant {
-		out.WriteString("sthir ")
-	} else if od.IsGlobal {
-		out.WriteString("bishwo ")
-	} else {
-		out.WriteString("dhoro ")
-	}
-	out.WriteString("{")
-	out.WriteString(strings.Join(od.Keys, ", "))
-	out.WriteString("} = ")
-	out.WriteString(od.Source.String())
-	out.WriteString(";")
-	return out.String()
+// Names returns the identifiers the declaration binds
+func (dd *DestructuringDeclaration) Names() []*Identifier {
+	return PatternNames(dd.Pattern)
 }
 
 // ArrowParamList is an internal expression node used to parse (a, b) => ...
 type ArrowParamList struct {
 	Token  lexer.Token // LPAREN token
-	Params []*Identifier
+	Params []*Parameter
+	Rest   *Identifier // optional rest parameter (...args)
 }
 
 func (ap *ArrowParamList) expressionNode()      {}
 func (ap *ArrowParamList) TokenLiteral() string { return ap.Token.Literal }
 func (ap *ArrowParamList) String() string {
-	names := make([]string, 0, len(ap.Params))
-	for _, p := range ap.Params {
-		names = append(names, p.Value)
+	return "(" + FormatParameters(ap.Params, ap.Rest) + ")"
+}
+
+// ArrayPattern is an array destructuring target, in a declaration, a
+// parameter list or an assignment: [a, b = 1, [c, d], ...rest]
+type ArrayPattern struct {
+	Token    lexer.Token // LBRACKET token
+	Elements []*BindingElement
+	Rest     *Identifier // optional, collects the remaining elements
+}
+
+func (ap *ArrayPattern) expressionNode()      {}
+func (ap *ArrayPattern) TokenLiteral() string { return ap.Token.Literal }
+func (ap *ArrayPattern) String() string {
+	parts := make([]string, 0, len(ap.Elements)+1)
+	for _, el := range ap.Elements {
+		parts = append(parts, el.String())
+	}
+	if ap.Rest != nil {
+		parts = append(parts, "..."+ap.Rest.Value)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// ObjectPattern is an object destructuring target, in a declaration or a
+// parameter list: {naam, boyosh: age = 0, thikana: {shohor}, ...rest}
+type ObjectPattern struct {
+	Token  lexer.Token // LBRACE token
+	Keys   []string
+	Values []*BindingElement // what each key is bound to
+	Rest   *Identifier       // optional, collects the remaining keys
+}
+
+func (op *ObjectPattern) expressionNode()      {}
+func (op *ObjectPattern) TokenLiteral() string { return op.Token.Literal }
+func (op *ObjectPattern) String() string {
+	parts := make([]string, 0, len(op.Keys)+1)
+	for i, key := range op.Keys {
+		if el := op.Values[i]; el.Name != nil && el.Name.Value == key {
+			parts = append(parts, el.String())
+		} else {
+			parts = append(parts, key+": "+el.String())
+		}
+	}
+	if op.Rest != nil {
+		parts = append(parts, "..."+op.Rest.Value)
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// BindingElement is one target of a binding: a name or a nested pattern,
+// with an optional default used when the value is missing or khali. The
+// elements of destructuring patterns and function parameters share it.
+type BindingElement struct {
+	Name    *Identifier       // plain name, nil when Pattern or Member is set
+	Pattern Expression        // *ArrayPattern or *ObjectPattern
+	Member  *MemberExpression // obj.key or obj[i], only in assignments
+	Default Expression
+}
+
+// Parameter is one function parameter: kaj f(a, b = 10, {naam}) { ... }
+type Parameter = BindingElement
+
+func (b *BindingElement) TokenLiteral() string { return b.Target().TokenLiteral() }
+func (b *BindingElement) String() string {
+	out := b.Target().String()
+	if b.Default != nil {
+		out += " = " + b.Default.String()
+	}
+	return out
+}
+
+// Target returns the element's name, pattern or member
+func (b *BindingElement) Target() Expression {
+	if b.Pattern != nil {
+		return b.Pattern
+	}
+	if b.Member != nil {
+		return b.Member
+	}
+	return b.Name
+}
+
+// Names returns the identifiers the element binds
+func (b *BindingElement) Names() []*Identifier {
+	if b.Pattern != nil {
+		return PatternNames(b.Pattern)
+	}
+	if b.Member != nil {
+		return nil
+	}
+	return []*Identifier{b.Name}
+}
+
+// PatternNames returns the identifiers a destructuring pattern binds, in
+// the order they are bound
+func PatternNames(pattern Expression) []*Identifier {
+	var names []*Identifier
+	switch pat := pattern.(type) {
+	case *ArrayPattern:
+		for _, el := range pat.Elements {
+			names = append(names, el.Names()...)
+		}
+		if pat.Rest != nil {
+			names = append(names, pat.Rest)
+		}
+	case *ObjectPattern:
+		for _, el := range pat.Values {
+			names = append(names, el.Names()...)
+		}
+		if pat.Rest != nil {
+			names = append(names, pat.Rest)
+		}
+	}
+	return names
+}
+
+// RequiredParameters counts the parameters before the first one with a
+// default value; a call must pass at least that many arguments
+func RequiredParameters(params []*Parameter) int {
+	for i, p := range params {
+		if p.Default != nil {
+			return i
+		}
+	}
+	return len(params)
+}
+
+// FormatParameters renders a parameter list with its rest parameter,
+// without the parentheses
+func FormatParameters(params []*Parameter, rest *Identifier) string {
+	parts := make([]string, 0, len(params)+1)
+	for _, p := range params {
+		parts = append(parts, p.String())
+	}
+	if rest != nil {
+		parts = append(parts, "..."+rest.String())
 	}
-	return "(" + strings.Join(names, ", ") + ")"
+	return strings.Join(parts, ", ")
 }
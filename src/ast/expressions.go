@@ -76,9 +76,10 @@ func (ae *AssignmentExpression) String() string {
 
 // CallExpression represents function calls: add(1, 2)
 type CallExpression struct {
-	Token     lexer.Token // the '(' token
+	Token     lexer.Token // the '(' token, or '?.' for f?.()
 	Function  Expression  // Identifier or FunctionLiteral
 	Arguments []Expression
+	Optional  bool // f?.(): khali instead of an error when f is khali
 }
 
 func (ce *CallExpression) expressionNode()      {}
@@ -90,6 +91,9 @@ func (ce *CallExpression) String() string {
 		args = append(args, a.String())
 	}
 	out.WriteString(ce.Function.String())
+	if ce.Optional {
+		out.WriteString("?.")
+	}
 	out.WriteString("(")
 	out.WriteString(strings.Join(args, ", "))
 	out.WriteString(")")
@@ -98,10 +102,11 @@ func (ce *CallExpression) String() string {
 
 // MemberExpression represents property access: obj.prop, arr[0]
 type MemberExpression struct {
-	Token    lexer.Token // the '.' or '[' token
+	Token    lexer.Token // the '.', '[' or '?.' token
 	Object   Expression
 	Property Expression
 	Computed bool // true for arr[0], false for obj.prop
+	Optional bool // obj?.prop, arr?.[0]: khali instead of an error when obj is khali
 }
 
 func (me *MemberExpression) expressionNode()      {}
@@ -109,17 +114,36 @@ func (me *MemberExpression) TokenLiteral() string { return me.Token.Literal }
 func (me *MemberExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString(me.Object.String())
+	if me.Optional {
+		out.WriteString("?.")
+	}
 	if me.Computed {
 		out.WriteString("[")
 		out.WriteString(me.Property.String())
 		out.WriteString("]")
 	} else {
-		out.WriteString(".")
+		if !me.Optional {
+			out.WriteString(".")
+		}
 		out.WriteString(me.Property.String())
 	}
 	return out.String()
 }
 
+// ConditionalExpression represents cond ? a : b
+type ConditionalExpression struct {
+	Token       lexer.Token // the '?' token
+	Condition   Expression
+	Consequence Expression
+	Alternative Expression
+}
+
+func (ce *ConditionalExpression) expressionNode()      {}
+func (ce *ConditionalExpression) TokenLiteral() string { return ce.Token.Literal }
+func (ce *ConditionalExpression) String() string {
+	return "(" + ce.Condition.String() + " ? " + ce.Consequence.String() + " : " + ce.Alternative.String() + ")"
+}
+
 // NewExpression represents: notun Manush(args)
 type NewExpression struct {
 	Token     lexer.Token // the NOTUN token
@@ -173,17 +197,23 @@ func (ae *AwaitExpression) String() string {
 	return out.String()
 }
 
-// YieldExpression represents yield expression in generators
+// YieldExpression represents yield expression in generators:
+// utpadan value, or utpadan* iterable to yield each of its values
 type YieldExpression struct {
 	Token      lexer.Token // the UTPADAN token
 	Expression Expression  // value to yield (optional)
+	Delegate   bool        // utpadan*
 }
 
 func (ye *YieldExpression) expressionNode()      {}
 func (ye *YieldExpression) TokenLiteral() string { return ye.Token.Literal }
 func (ye *YieldExpression) String() string {
 	var out bytes.Buffer
-	out.WriteString("utpadan ")
+	out.WriteString("utpadan")
+	if ye.Delegate {
+		out.WriteString("*")
+	}
+	out.WriteString(" ")
 	if ye.Expression != nil {
 		out.WriteString(ye.Expression.String())
 	}
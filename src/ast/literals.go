@@ -81,6 +81,7 @@ func (al *ArrayLiteral) String() string {
 type MapLiteral struct {
 	Token lexer.Token // the '{' token
 	Pairs map[Expression]Expression
+	Keys  []Expression // the keys of Pairs in source order
 }
 
 func (ml *MapLiteral) expressionNode()      {}
@@ -88,8 +89,8 @@ func (ml *MapLiteral) TokenLiteral() string { return ml.Token.Literal }
 func (ml *MapLiteral) String() string {
 	var out bytes.Buffer
 	pairs := []string{}
-	for key, value := range ml.Pairs {
-		pairs = append(pairs, key.String()+":"+value.String())
+	for _, key := range ml.Keys {
+		pairs = append(pairs, key.String()+":"+ml.Pairs[key].String())
 	}
 	out.WriteString("{")
 	out.WriteString(strings.Join(pairs, ", "))
@@ -101,7 +102,7 @@ func (ml *MapLiteral) String() string {
 type FunctionLiteral struct {
 	Token         lexer.Token // the KAJ token
 	Name          *Identifier // optional function name
-	Parameters    []*Identifier
+	Parameters    []*Parameter
 	RestParameter *Identifier // optional rest parameter (...args)
 	Body          *BlockStatement
 	IsGenerator   bool // true if generator function (kaj* or has yield)
@@ -111,13 +112,6 @@ func (fl *FunctionLiteral) expressionNode()      {}
 func (fl *FunctionLiteral) TokenLiteral() string { return fl.Token.Literal }
 func (fl *FunctionLiteral) String() string {
 	var out bytes.Buffer
-	params := []string{}
-	for _, p := range fl.Parameters {
-		params = append(params, p.String())
-	}
-	if fl.RestParameter != nil {
-		params = append(params, "..."+fl.RestParameter.String())
-	}
 	// Constructor shuru() is output without kaj prefix
 	if fl.Name != nil && fl.Name.Value == "shuru" {
 		out.WriteString("shuru(")
@@ -132,38 +126,36 @@ func (fl *FunctionLiteral) String() string {
 		}
 		out.WriteString("(")
 	}
-	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(FormatParameters(fl.Parameters, fl.RestParameter))
 	out.WriteString(") ")
 	out.WriteString(fl.Body.String())
 	return out.String()
 }
 
-// AsyncFunctionLiteral represents: proyash kaj(a, b) { ... }
+// AsyncFunctionLiteral represents: proyash kaj(a, b) { ... } and the async
+// generator proyash kaj*(a, b) { ... }
 type AsyncFunctionLiteral struct {
 	Token         lexer.Token // the PROYASH token
 	Name          *Identifier // optional function name
-	Parameters    []*Identifier
+	Parameters    []*Parameter
 	RestParameter *Identifier // optional rest parameter (...args)
 	Body          *BlockStatement
+	IsGenerator   bool // true for proyash kaj*
 }
 
 func (afl *AsyncFunctionLiteral) expressionNode()      {}
 func (afl *AsyncFunctionLiteral) TokenLiteral() string { return afl.Token.Literal }
 func (afl *AsyncFunctionLiteral) String() string {
 	var out bytes.Buffer
-	params := []string{}
-	for _, p := range afl.Parameters {
-		params = append(params, p.String())
-	}
-	if afl.RestParameter != nil {
-		params = append(params, "..."+afl.RestParameter.String())
-	}
 	out.WriteString("proyash kaj")
+	if afl.IsGenerator {
+		out.WriteString("*")
+	}
 	if afl.Name != nil {
 		out.WriteString(" " + afl.Name.String())
 	}
 	out.WriteString("(")
-	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(FormatParameters(afl.Parameters, afl.RestParameter))
 	out.WriteString(") ")
 	out.WriteString(afl.Body.String())
 	return out.String()
@@ -5,19 +5,25 @@ import (
 	"bytes"
 )
 
-// ForOfStatement represents: ghuriye (item of iterable) { ... }
+// ForOfStatement represents: ghuriye (item of iterable) { ... } and,
+// with Await, ghuriye opekha (item of iterable) { ... }
 type ForOfStatement struct {
 	Token    lexer.Token // GHURIYE token
 	VarName  *Identifier
 	Iterable Expression
 	Body     *BlockStatement
+	Await    bool // ghuriye opekha: await each value
 }
 
 func (fs *ForOfStatement) statementNode()       {}
 func (fs *ForOfStatement) TokenLiteral() string { return fs.Token.Literal }
 func (fs *ForOfStatement) String() string {
 	var out bytes.Buffer
-	out.WriteString("ghuriye (")
+	out.WriteString("ghuriye ")
+	if fs.Await {
+		out.WriteString("opekha ")
+	}
+	out.WriteString("(")
 	out.WriteString(fs.VarName.String())
 	out.WriteString(" of ")
 	out.WriteString(fs.Iterable.String())
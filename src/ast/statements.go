@@ -3,6 +3,7 @@ package ast
 import (
 	"BanglaCode/src/lexer"
 	"bytes"
+	"strings"
 )
 
 // ==================== Statement Nodes ====================
@@ -36,6 +37,27 @@ func (vd *VariableDeclaration) String() string {
 	return out.String()
 }
 
+// DeclarationList represents several declarations sharing one keyword:
+// dhoro a = 1, b = 2;
+type DeclarationList struct {
+	Token        lexer.Token // the DHORO/STHIR/BISHWO token
+	Declarations []*VariableDeclaration
+}
+
+func (dl *DeclarationList) statementNode()       {}
+func (dl *DeclarationList) TokenLiteral() string { return dl.Token.Literal }
+func (dl *DeclarationList) String() string {
+	parts := make([]string, 0, len(dl.Declarations))
+	for _, d := range dl.Declarations {
+		part := d.Name.String() + " = "
+		if d.Value != nil {
+			part += d.Value.String()
+		}
+		parts = append(parts, part)
+	}
+	return dl.Token.Literal + " " + strings.Join(parts, ", ") + ";"
+}
+
 // ExpressionStatement wraps an expression as a statement
 type ExpressionStatement struct {
 	Token      lexer.Token
@@ -55,6 +77,7 @@ func (es *ExpressionStatement) String() string {
 type BlockStatement struct {
 	Token      lexer.Token // the '{' token
 	Statements []Statement
+	Rbrace     lexer.Token // the closing '}' (zero for blocks the parser synthesizes)
 }
 
 func (bs *BlockStatement) statementNode()       {}
@@ -164,6 +187,7 @@ type ClassDeclaration struct {
 	Getters          map[string]*FunctionLiteral // getters: pao prop() { }
 	Setters          map[string]*FunctionLiteral // setters: set prop(val) { }
 	StaticProperties map[string]Expression       // static properties: sthir prop = value
+	Rbrace           lexer.Token                 // the closing '}'
 }
 
 func (cd *ClassDeclaration) statementNode()       {}
@@ -180,23 +204,48 @@ func (cd *ClassDeclaration) String() string {
 	return out.String()
 }
 
-// BreakStatement represents: thamo;
+// BreakStatement represents: thamo; or thamo outer;
 type BreakStatement struct {
 	Token lexer.Token // the THAMO token
+	Label *Identifier // optional: the labeled statement to leave
 }
 
 func (bs *BreakStatement) statementNode()       {}
 func (bs *BreakStatement) TokenLiteral() string { return bs.Token.Literal }
-func (bs *BreakStatement) String() string       { return "thamo;" }
+func (bs *BreakStatement) String() string {
+	if bs.Label != nil {
+		return "thamo " + bs.Label.Value + ";"
+	}
+	return "thamo;"
+}
 
-// ContinueStatement represents: chharo;
+// ContinueStatement represents: chharo; or chharo outer;
 type ContinueStatement struct {
 	Token lexer.Token // the CHHARO token
+	Label *Identifier // optional: the labeled loop to continue
 }
 
 func (cs *ContinueStatement) statementNode()       {}
 func (cs *ContinueStatement) TokenLiteral() string { return cs.Token.Literal }
-func (cs *ContinueStatement) String() string       { return "chharo;" }
+func (cs *ContinueStatement) String() string {
+	if cs.Label != nil {
+		return "chharo " + cs.Label.Value + ";"
+	}
+	return "chharo;"
+}
+
+// LabeledStatement represents: outer: ghuriye (...) { ... }
+type LabeledStatement struct {
+	Token lexer.Token // the label's IDENT token
+	Label *Identifier
+	Body  Statement
+}
+
+func (ls *LabeledStatement) statementNode()       {}
+func (ls *LabeledStatement) TokenLiteral() string { return ls.Token.Literal }
+func (ls *LabeledStatement) String() string {
+	return ls.Label.Value + ": " + ls.Body.String()
+}
 
 // ImportStatement represents: ano "module.bang" hisabe alias;
 type ImportStatement struct {
@@ -287,6 +336,7 @@ type SwitchStatement struct {
 	Expr    Expression      // the expression to match against
 	Cases   []*CaseClause   // list of case clauses
 	Default *BlockStatement // default case (optional)
+	Rbrace  lexer.Token     // the closing '}'
 }
 
 func (ss *SwitchStatement) statementNode()       {}
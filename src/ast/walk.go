@@ -0,0 +1,222 @@
+package ast
+
+import (
+	"BanglaCode/src/lexer"
+	"reflect"
+	"sort"
+)
+
+// Inspect traverses the tree rooted at node in source order, calling f for
+// every node. If f returns false, the children of that node are skipped.
+// Tools such as the language server use it to find declarations and
+// references without evaluating the program.
+func Inspect(node Node, f func(Node) bool) {
+	if isNilNode(node) || !f(node) {
+		return
+	}
+
+	switch n := node.(type) {
+	case *Program:
+		for _, s := range n.Statements {
+			Inspect(s, f)
+		}
+	case *BlockStatement:
+		for _, s := range n.Statements {
+			Inspect(s, f)
+		}
+	case *ExpressionStatement:
+		Inspect(n.Expression, f)
+	case *VariableDeclaration:
+		Inspect(n.Name, f)
+		Inspect(n.Value, f)
+	case *DeclarationList:
+		for _, d := range n.Declarations {
+			Inspect(d, f)
+		}
+	case *DestructuringDeclaration:
+		Inspect(n.Pattern, f)
+		Inspect(n.Source, f)
+	case *ReturnStatement:
+		Inspect(n.ReturnValue, f)
+	case *BreakStatement:
+		Inspect(n.Label, f)
+	case *ContinueStatement:
+		Inspect(n.Label, f)
+	case *LabeledStatement:
+		Inspect(n.Label, f)
+		Inspect(n.Body, f)
+	case *ThrowStatement:
+		Inspect(n.Value, f)
+	case *IfStatement:
+		Inspect(n.Condition, f)
+		Inspect(n.Consequence, f)
+		Inspect(n.Alternative, f)
+	case *WhileStatement:
+		Inspect(n.Condition, f)
+		Inspect(n.Body, f)
+	case *DoWhileStatement:
+		Inspect(n.Body, f)
+		Inspect(n.Condition, f)
+	case *ForStatement:
+		Inspect(n.Init, f)
+		Inspect(n.Condition, f)
+		Inspect(n.Update, f)
+		Inspect(n.Body, f)
+	case *ForOfStatement:
+		Inspect(n.VarName, f)
+		Inspect(n.Iterable, f)
+		Inspect(n.Body, f)
+	case *ForInStatement:
+		Inspect(n.VarName, f)
+		Inspect(n.Object, f)
+		Inspect(n.Body, f)
+	case *ClassDeclaration:
+		Inspect(n.Name, f)
+		for _, key := range sortedKeys(n.StaticProperties) {
+			Inspect(n.StaticProperties[key], f)
+		}
+		for _, m := range n.Methods {
+			Inspect(m, f)
+		}
+		for _, key := range sortedKeys(n.Getters) {
+			Inspect(n.Getters[key], f)
+		}
+		for _, key := range sortedKeys(n.Setters) {
+			Inspect(n.Setters[key], f)
+		}
+	case *ImportStatement:
+		Inspect(n.Path, f)
+		Inspect(n.Alias, f)
+	case *ExportStatement:
+		Inspect(n.Statement, f)
+	case *TryCatchStatement:
+		Inspect(n.TryBlock, f)
+		Inspect(n.CatchParam, f)
+		Inspect(n.CatchBlock, f)
+		Inspect(n.FinallyBlock, f)
+	case *SwitchStatement:
+		Inspect(n.Expr, f)
+		for _, c := range n.Cases {
+			Inspect(c, f)
+		}
+		Inspect(n.Default, f)
+	case *CaseClause:
+		Inspect(n.Value, f)
+		Inspect(n.Body, f)
+
+	case *FunctionLiteral:
+		Inspect(n.Name, f)
+		for _, p := range n.Parameters {
+			Inspect(p, f)
+		}
+		Inspect(n.RestParameter, f)
+		Inspect(n.Body, f)
+	case *AsyncFunctionLiteral:
+		Inspect(n.Name, f)
+		for _, p := range n.Parameters {
+			Inspect(p, f)
+		}
+		Inspect(n.RestParameter, f)
+		Inspect(n.Body, f)
+	case *ArrowParamList:
+		for _, p := range n.Params {
+			Inspect(p, f)
+		}
+		Inspect(n.Rest, f)
+	case *BindingElement:
+		Inspect(n.Target(), f)
+		Inspect(n.Default, f)
+	case *ArrayPattern:
+		for _, el := range n.Elements {
+			Inspect(el, f)
+		}
+		Inspect(n.Rest, f)
+	case *ObjectPattern:
+		for _, el := range n.Values {
+			Inspect(el, f)
+		}
+		Inspect(n.Rest, f)
+	case *BinaryExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *UnaryExpression:
+		Inspect(n.Right, f)
+	case *ConditionalExpression:
+		Inspect(n.Condition, f)
+		Inspect(n.Consequence, f)
+		Inspect(n.Alternative, f)
+	case *AssignmentExpression:
+		Inspect(n.Name, f)
+		Inspect(n.Value, f)
+	case *CallExpression:
+		Inspect(n.Function, f)
+		for _, a := range n.Arguments {
+			Inspect(a, f)
+		}
+	case *MemberExpression:
+		Inspect(n.Object, f)
+		Inspect(n.Property, f)
+	case *NewExpression:
+		Inspect(n.Class, f)
+		for _, a := range n.Arguments {
+			Inspect(a, f)
+		}
+	case *SpreadElement:
+		Inspect(n.Argument, f)
+	case *AwaitExpression:
+		Inspect(n.Expression, f)
+	case *YieldExpression:
+		Inspect(n.Expression, f)
+	case *DeleteExpression:
+		Inspect(n.Target, f)
+	case *ArrayLiteral:
+		for _, e := range n.Elements {
+			Inspect(e, f)
+		}
+	case *MapLiteral:
+		for _, k := range n.Keys {
+			Inspect(k, f)
+			Inspect(n.Pairs[k], f)
+		}
+	}
+}
+
+// TokenOf returns the token a node starts at (its Token field), or the
+// zero token for nodes without one such as *Program
+func TokenOf(node Node) lexer.Token {
+	if isNilNode(node) {
+		return lexer.Token{}
+	}
+	v := reflect.ValueOf(node)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return lexer.Token{}
+	}
+	if field := v.FieldByName("Token"); field.IsValid() {
+		if tok, ok := field.Interface().(lexer.Token); ok {
+			return tok
+		}
+	}
+	return lexer.Token{}
+}
+
+// isNilNode reports whether node is nil or a typed nil pointer, which the
+// parser leaves behind for optional parts such as a missing else block
+func isNilNode(node Node) bool {
+	if node == nil {
+		return true
+	}
+	v := reflect.ValueOf(node)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,226 @@
+// Package compiler translates a BanglaCode AST into compact bytecode for the
+// stack VM in src/vm. Variables of function bodies and for loops are
+// resolved to slot indices at compile time, and function literals, calls,
+// member access and the core statements get dedicated opcodes. Nodes without
+// one are kept as-is and handed back to the tree-walking evaluator at run
+// time (OpEval); Bytecode.Fallbacks lists them, so both backends support
+// the same language and the VM's coverage can be checked.
+package compiler
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// Instructions is a flat sequence of encoded opcodes and operands
+type Instructions []byte
+
+// Opcode identifies a single VM instruction
+type Opcode byte
+
+const (
+	// OpConstant pushes Constants[operand]
+	OpConstant Opcode = iota
+	// OpNull pushes khali
+	OpNull
+	// OpNil pushes the empty completion value of an empty block
+	OpNil
+	// OpPop discards the top of the stack
+	OpPop
+	// OpEndStmt pops a statement's value and unwinds the frame on
+	// ferao/felo/thamo/chharo results
+	OpEndStmt
+	// OpLoopSignal is OpEndStmt inside a loop: thamo jumps to the first
+	// operand, chharo to the second
+	OpLoopSignal
+	// OpResult records the value of a top-level statement
+	OpResult
+	// OpJump jumps to an absolute offset
+	OpJump
+	// OpJumpIfFalse pops a condition and jumps if it is not truthy
+	OpJumpIfFalse
+	// OpJumpIfNotNull jumps, keeping the top of the stack, unless it is
+	// khali; khali is popped (a ?? b)
+	OpJumpIfNotNull
+	// OpGetName pushes the variable named by Nodes[operand]
+	OpGetName
+	// OpDefine binds the value on top of the stack as declared by
+	// Nodes[operand], a variable declaration or a function name
+	OpDefine
+	// OpAssign pops a value and assigns it as described by Nodes[operand]
+	OpAssign
+	// OpGetLocal pushes the variable in slot operand of the current scope;
+	// Nodes[second operand] is the identifier
+	OpGetLocal
+	// OpSetLocal is OpAssign for the variable in slot operand of the
+	// current scope
+	OpSetLocal
+	// OpDefineLocal binds the value on top of the stack to slot operand of
+	// the current scope
+	OpDefineLocal
+	// OpGetFree is OpGetLocal for a scope the first operand levels out,
+	// such as a variable a closure captured
+	OpGetFree
+	// OpSetFree is OpSetLocal for a scope the first operand levels out
+	OpSetFree
+	// OpClosure pushes a function for Functions[operand] that captures the
+	// current scope
+	OpClosure
+	// OpGetMember replaces the object on top of the stack with the member
+	// named by Nodes[operand]
+	OpGetMember
+	// OpIndex pops a key and an object and pushes object[key]
+	OpIndex
+	// OpSetMember pops a value and an object and assigns the member, as
+	// described by the assignment Nodes[operand]
+	OpSetMember
+	// OpSetIndex pops a key, a value and an object and assigns object[key]
+	OpSetIndex
+	// OpMap collects the values of the map literal Nodes[operand]
+	OpMap
+	// OpUnary applies the operator of Nodes[operand] to the top of the stack
+	OpUnary
+	// OpBinary applies the operator of Nodes[operand] to the top two values
+	OpBinary
+	// OpArray collects the top operand values into an array
+	OpArray
+	// OpCall calls a function with the second operand's number of arguments;
+	// Nodes[first operand] is the call expression
+	OpCall
+	// OpReturn pops a value and returns it from the current function
+	OpReturn
+	// OpEnterScope opens a nested scope with the slots of Scopes[operand]
+	// (for loop variables)
+	OpEnterScope
+	// OpLeaveScope closes the scope opened by OpEnterScope
+	OpLeaveScope
+	// OpEval evaluates Nodes[operand] with the tree-walking evaluator
+	OpEval
+)
+
+// Definition describes an opcode for encoding and disassembly
+type Definition struct {
+	Name          string
+	OperandWidths []int
+}
+
+var definitions = map[Opcode]*Definition{
+	OpConstant:      {"OpConstant", []int{2}},
+	OpNull:          {"OpNull", []int{}},
+	OpNil:           {"OpNil", []int{}},
+	OpPop:           {"OpPop", []int{}},
+	OpEndStmt:       {"OpEndStmt", []int{}},
+	OpLoopSignal:    {"OpLoopSignal", []int{2, 2}},
+	OpResult:        {"OpResult", []int{}},
+	OpJump:          {"OpJump", []int{2}},
+	OpJumpIfFalse:   {"OpJumpIfFalse", []int{2}},
+	OpJumpIfNotNull: {"OpJumpIfNotNull", []int{2}},
+	OpGetName:       {"OpGetName", []int{2}},
+	OpDefine:        {"OpDefine", []int{2}},
+	OpAssign:        {"OpAssign", []int{2}},
+	OpGetLocal:      {"OpGetLocal", []int{2, 2}},
+	OpSetLocal:      {"OpSetLocal", []int{2, 2}},
+	OpDefineLocal:   {"OpDefineLocal", []int{2}},
+	OpGetFree:       {"OpGetFree", []int{2, 2, 2}},
+	OpSetFree:       {"OpSetFree", []int{2, 2, 2}},
+	OpClosure:       {"OpClosure", []int{2}},
+	OpGetMember:     {"OpGetMember", []int{2}},
+	OpIndex:         {"OpIndex", []int{2}},
+	OpSetMember:     {"OpSetMember", []int{2}},
+	OpSetIndex:      {"OpSetIndex", []int{2}},
+	OpMap:           {"OpMap", []int{2}},
+	OpUnary:         {"OpUnary", []int{2}},
+	OpBinary:        {"OpBinary", []int{2}},
+	OpArray:         {"OpArray", []int{2}},
+	OpCall:          {"OpCall", []int{2, 2}},
+	OpReturn:        {"OpReturn", []int{}},
+	OpEnterScope:    {"OpEnterScope", []int{2}},
+	OpLeaveScope:    {"OpLeaveScope", []int{}},
+	OpEval:          {"OpEval", []int{2}},
+}
+
+// Lookup returns the definition of an opcode
+func Lookup(op byte) (*Definition, error) {
+	def, ok := definitions[Opcode(op)]
+	if !ok {
+		return nil, fmt.Errorf("opcode %d undefined", op)
+	}
+	return def, nil
+}
+
+// Make encodes an instruction with its operands (big-endian)
+func Make(op Opcode, operands ...int) []byte {
+	def, ok := definitions[op]
+	if !ok {
+		return []byte{}
+	}
+
+	length := 1
+	for _, w := range def.OperandWidths {
+		length += w
+	}
+
+	instruction := make([]byte, length)
+	instruction[0] = byte(op)
+
+	offset := 1
+	for i, o := range operands {
+		width := def.OperandWidths[i]
+		switch width {
+		case 2:
+			binary.BigEndian.PutUint16(instruction[offset:], uint16(o))
+		}
+		offset += width
+	}
+	return instruction
+}
+
+// ReadOperands decodes the operands of an instruction and returns
+// them together with the number of bytes read
+func ReadOperands(def *Definition, ins Instructions) ([]int, int) {
+	operands := make([]int, len(def.OperandWidths))
+	offset := 0
+
+	for i, width := range def.OperandWidths {
+		switch width {
+		case 2:
+			operands[i] = int(ReadUint16(ins[offset:]))
+		}
+		offset += width
+	}
+	return operands, offset
+}
+
+// ReadUint16 decodes a two-byte operand
+func ReadUint16(ins Instructions) uint16 {
+	return binary.BigEndian.Uint16(ins)
+}
+
+// String disassembles the instructions, one per line
+func (ins Instructions) String() string {
+	var out bytes.Buffer
+
+	i := 0
+	for i < len(ins) {
+		def, err := Lookup(ins[i])
+		if err != nil {
+			fmt.Fprintf(&out, "ERROR: %s\n", err)
+			i++
+			continue
+		}
+
+		operands, read := ReadOperands(def, ins[i+1:])
+		fmt.Fprintf(&out, "%04d %s\n", i, formatInstruction(def, operands))
+		i += 1 + read
+	}
+	return out.String()
+}
+
+func formatInstruction(def *Definition, operands []int) string {
+	out := def.Name
+	for _, o := range operands {
+		out += fmt.Sprintf(" %d", o)
+	}
+	return out
+}
@@ -0,0 +1,605 @@
+package compiler
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/object"
+	"fmt"
+)
+
+// maxOperand is the largest value a two-byte operand can hold
+const maxOperand = 1<<16 - 1
+
+// Bytecode is a compiled program or function body
+type Bytecode struct {
+	Instructions Instructions
+	Constants    []object.Object
+	Nodes        []ast.Node       // AST nodes referenced by name, operator, call, assignment and OpEval instructions
+	Statements   []int            // start offset of each top-level statement (programs only)
+	Functions    []*Function      // function literals, for OpClosure
+	Scopes       []*object.Layout // slots of nested scopes, for OpEnterScope
+	Fallbacks    []ast.Node       // nodes compiled to OpEval
+}
+
+// Function is a compiled function literal
+type Function struct {
+	Name          string
+	Parameters    []*ast.Parameter
+	RestParameter *ast.Identifier
+	Body          *ast.BlockStatement
+	IsAsync       bool
+	IsGenerator   bool
+
+	// Code runs the body; nil for async and generator functions, which the
+	// evaluator runs because they suspend
+	Code   *Bytecode
+	Layout *object.Layout // slots of the function's own variables
+	Outer  *object.Layout // slots of the scope the literal is in, nil at the top level
+
+	// SimpleParameters is set when every parameter is a plain name,
+	// bound straight to the slot of the same index
+	SimpleParameters bool
+}
+
+// loopScope collects the jumps that must be patched once a loop's
+// exit and continue offsets are known
+type loopScope struct {
+	breaks    []int // OpJump positions for thamo
+	continues []int // OpJump positions for chharo
+	signals   []int // OpLoopSignal positions
+}
+
+// Compiler turns AST nodes into Bytecode.
+// Every compiled statement leaves exactly one value (its completion value)
+// on the stack, mirroring what the evaluator returns for that statement.
+type Compiler struct {
+	instructions Instructions
+	constants    []object.Object
+	nodes        []ast.Node
+	statements   []int
+	functions    []*Function
+	scopes       []*object.Layout
+	fallbacks    []ast.Node
+	loops        []*loopScope
+	scope        *scope          // innermost scope with slots, nil at the top level
+	constNames   map[string]bool // names declared with sthir anywhere in the program
+	err          error
+}
+
+// New creates an empty compiler
+func New() *Compiler {
+	return &Compiler{constNames: map[string]bool{}}
+}
+
+// Compile compiles a whole program
+func Compile(program *ast.Program) (*Bytecode, error) {
+	c := New()
+	c.constNames = constantNames(program)
+	for _, stmt := range program.Statements {
+		c.statements = append(c.statements, len(c.instructions))
+		c.compileStatement(stmt)
+		c.emit(OpResult)
+	}
+	return c.bytecode()
+}
+
+func (c *Compiler) bytecode() (*Bytecode, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &Bytecode{
+		Instructions: c.instructions,
+		Constants:    c.constants,
+		Nodes:        c.nodes,
+		Statements:   c.statements,
+		Functions:    c.functions,
+		Scopes:       c.scopes,
+		Fallbacks:    c.fallbacks,
+	}, nil
+}
+
+// AllFallbacks returns the nodes compiled to OpEval in code and all the
+// function bodies it contains
+func AllFallbacks(code *Bytecode) []ast.Node {
+	nodes := append([]ast.Node{}, code.Fallbacks...)
+	for _, fn := range code.Functions {
+		if fn.Code != nil {
+			nodes = append(nodes, AllFallbacks(fn.Code)...)
+		}
+	}
+	return nodes
+}
+
+// ==================== Statements ====================
+
+func (c *Compiler) compileStatement(stmt ast.Statement) {
+	switch stmt := stmt.(type) {
+	case *ast.ExpressionStatement:
+		c.compileExpression(stmt.Expression)
+	case *ast.VariableDeclaration:
+		c.compileExpression(stmt.Value)
+		if stmt.IsConstant || stmt.IsGlobal {
+			c.emit(OpDefine, c.addNode(stmt))
+			return
+		}
+		c.define(stmt.Name)
+	case *ast.DeclarationList:
+		for i, decl := range stmt.Declarations {
+			c.compileStatement(decl)
+			if i < len(stmt.Declarations)-1 {
+				c.emit(OpPop)
+			}
+		}
+	case *ast.BlockStatement:
+		c.compileBlock(stmt)
+	case *ast.IfStatement:
+		c.compileIf(stmt)
+	case *ast.WhileStatement:
+		c.compileWhile(stmt)
+	case *ast.DoWhileStatement:
+		c.compileDoWhile(stmt)
+	case *ast.ForStatement:
+		c.compileFor(stmt)
+	case *ast.ReturnStatement:
+		c.compileExpression(stmt.ReturnValue)
+		c.emit(OpReturn)
+	case *ast.BreakStatement:
+		// Labeled statements run in the evaluator, so a labeled thamo
+		// becomes a signal for it
+		if loop := c.currentLoop(); loop != nil && stmt.Label == nil {
+			loop.breaks = append(loop.breaks, c.emit(OpJump, 0))
+			return
+		}
+		c.fallback(stmt)
+	case *ast.ContinueStatement:
+		if loop := c.currentLoop(); loop != nil && stmt.Label == nil {
+			loop.continues = append(loop.continues, c.emit(OpJump, 0))
+			return
+		}
+		c.fallback(stmt)
+	default:
+		c.fallback(stmt)
+	}
+}
+
+// compileBlock compiles statements in order; the last statement's value
+// is the block's value
+func (c *Compiler) compileBlock(block *ast.BlockStatement) {
+	if len(block.Statements) == 0 {
+		c.emit(OpNil)
+		return
+	}
+	for i, stmt := range block.Statements {
+		c.compileStatement(stmt)
+		if i < len(block.Statements)-1 {
+			c.endStatement()
+		}
+	}
+}
+
+// endStatement discards a statement value, stopping on control-flow results
+func (c *Compiler) endStatement() {
+	if loop := c.currentLoop(); loop != nil {
+		loop.signals = append(loop.signals, c.emit(OpLoopSignal, 0, 0))
+		return
+	}
+	c.emit(OpEndStmt)
+}
+
+func (c *Compiler) compileIf(stmt *ast.IfStatement) {
+	c.compileExpression(stmt.Condition)
+	jumpElse := c.emit(OpJumpIfFalse, 0)
+
+	c.compileBlock(stmt.Consequence)
+	jumpEnd := c.emit(OpJump, 0)
+
+	c.patch(jumpElse, len(c.instructions))
+	if stmt.Alternative != nil {
+		c.compileBlock(stmt.Alternative)
+	} else {
+		c.emit(OpNull)
+	}
+	c.patch(jumpEnd, len(c.instructions))
+}
+
+func (c *Compiler) compileWhile(stmt *ast.WhileStatement) {
+	loop := c.enterLoop()
+	start := len(c.instructions)
+
+	c.compileExpression(stmt.Condition)
+	jumpExit := c.emit(OpJumpIfFalse, 0)
+
+	c.compileBlock(stmt.Body)
+	c.leaveLoop()
+	c.bodySignal(loop)
+	c.emit(OpJump, start)
+
+	exit := len(c.instructions)
+	c.patch(jumpExit, exit)
+	c.patchLoop(loop, exit, start)
+	c.emit(OpNull)
+}
+
+func (c *Compiler) compileDoWhile(stmt *ast.DoWhileStatement) {
+	loop := c.enterLoop()
+	start := len(c.instructions)
+
+	c.compileBlock(stmt.Body)
+	c.leaveLoop()
+	c.bodySignal(loop)
+
+	condition := len(c.instructions)
+	c.compileExpression(stmt.Condition)
+	jumpExit := c.emit(OpJumpIfFalse, 0)
+	c.emit(OpJump, start)
+
+	exit := len(c.instructions)
+	c.patch(jumpExit, exit)
+	c.patchLoop(loop, exit, condition)
+	c.emit(OpNull)
+}
+
+func (c *Compiler) compileFor(stmt *ast.ForStatement) {
+	// The loop gets its own scope, like the evaluator's loopEnv
+	c.enterScope(declarations(nil, stmt.Init, stmt.Body))
+	c.emit(OpEnterScope, c.addScope(c.scope.layout))
+	if stmt.Init != nil {
+		c.compileStatement(stmt.Init)
+		c.emit(OpPop)
+	}
+
+	loop := c.enterLoop()
+	start := len(c.instructions)
+
+	jumpExit := -1
+	if stmt.Condition != nil {
+		c.compileExpression(stmt.Condition)
+		jumpExit = c.emit(OpJumpIfFalse, 0)
+	}
+
+	c.compileBlock(stmt.Body)
+	c.leaveLoop()
+	c.bodySignal(loop)
+
+	update := len(c.instructions)
+	if stmt.Update != nil {
+		c.compileExpression(stmt.Update)
+		c.emit(OpPop)
+	}
+	c.emit(OpJump, start)
+
+	exit := len(c.instructions)
+	if jumpExit >= 0 {
+		c.patch(jumpExit, exit)
+	}
+	c.patchLoop(loop, exit, update)
+	c.leaveScope()
+	c.emit(OpLeaveScope)
+	c.emit(OpNull)
+}
+
+// ==================== Expressions ====================
+
+func (c *Compiler) compileExpression(expr ast.Expression) {
+	switch expr := expr.(type) {
+	case nil:
+		c.emit(OpNil)
+	case *ast.NumberLiteral:
+		c.emit(OpConstant, c.addConstant(&object.Number{Value: expr.Value}))
+	case *ast.StringLiteral:
+		c.emit(OpConstant, c.addConstant(&object.String{Value: expr.Value}))
+	case *ast.BooleanLiteral:
+		c.emit(OpConstant, c.addConstant(object.NativeBoolToBooleanObject(expr.Value)))
+	case *ast.NullLiteral:
+		c.emit(OpNull)
+	case *ast.Identifier:
+		c.load(expr)
+	case *ast.UnaryExpression:
+		c.compileExpression(expr.Right)
+		c.emit(OpUnary, c.addNode(expr))
+	case *ast.BinaryExpression:
+		c.compileExpression(expr.Left)
+		if expr.Operator == "??" {
+			jumpEnd := c.emit(OpJumpIfNotNull, 0)
+			c.compileExpression(expr.Right)
+			c.patch(jumpEnd, len(c.instructions))
+			return
+		}
+		c.compileExpression(expr.Right)
+		c.emit(OpBinary, c.addNode(expr))
+	case *ast.ConditionalExpression:
+		c.compileExpression(expr.Condition)
+		jumpElse := c.emit(OpJumpIfFalse, 0)
+		c.compileExpression(expr.Consequence)
+		jumpEnd := c.emit(OpJump, 0)
+		c.patch(jumpElse, len(c.instructions))
+		c.compileExpression(expr.Alternative)
+		c.patch(jumpEnd, len(c.instructions))
+	case *ast.AssignmentExpression:
+		c.compileAssignment(expr)
+	case *ast.ArrayLiteral:
+		if hasSpread(expr.Elements) {
+			c.fallback(expr)
+			return
+		}
+		for _, el := range expr.Elements {
+			c.compileExpression(el)
+		}
+		c.emit(OpArray, len(expr.Elements))
+	case *ast.MapLiteral:
+		c.compileMap(expr)
+	case *ast.MemberExpression:
+		// a ?. anywhere in the chain can skip the rest of it
+		if isOptionalChain(expr) {
+			c.fallback(expr)
+			return
+		}
+		c.compileExpression(expr.Object)
+		if expr.Computed {
+			c.compileExpression(expr.Property)
+			c.emit(OpIndex, c.addNode(expr))
+			return
+		}
+		c.emit(OpGetMember, c.addNode(expr))
+	case *ast.CallExpression:
+		if hasSpread(expr.Arguments) || isOptionalChain(expr) {
+			c.fallback(expr)
+			return
+		}
+		c.compileExpression(expr.Function)
+		for _, arg := range expr.Arguments {
+			c.compileExpression(arg)
+		}
+		c.emit(OpCall, c.addNode(expr), len(expr.Arguments))
+	case *ast.FunctionLiteral:
+		c.compileFunction(&Function{
+			Parameters:    expr.Parameters,
+			RestParameter: expr.RestParameter,
+			Body:          expr.Body,
+			IsGenerator:   expr.IsGenerator,
+		}, expr.Name)
+	case *ast.AsyncFunctionLiteral:
+		c.compileFunction(&Function{
+			Parameters:    expr.Parameters,
+			RestParameter: expr.RestParameter,
+			Body:          expr.Body,
+			IsAsync:       true,
+			IsGenerator:   expr.IsGenerator,
+		}, expr.Name)
+	default:
+		c.fallback(expr)
+	}
+}
+
+// compileFunction compiles a function literal to OpClosure, binding its
+// name in the current scope like the evaluator does. The body is compiled
+// with its own scope of slots, nested in the current one, so that it can
+// reach the variables it captures by slot as well.
+func (c *Compiler) compileFunction(fn *Function, name *ast.Identifier) {
+	if name != nil {
+		fn.Name = name.Value
+	}
+	fn.Outer = layoutOf(c.scope)
+
+	params, simple := parameterNames(fn.Parameters, fn.RestParameter)
+	fn.Layout = object.NewLayout(declarations(params, fn.Body))
+	fn.SimpleParameters = simple
+
+	if !fn.IsAsync && !fn.IsGenerator {
+		body := &Compiler{
+			scope:      &scope{outer: c.scope, layout: fn.Layout},
+			constNames: c.constNames,
+		}
+		body.compileBlock(fn.Body)
+		fn.Code, body.err = body.bytecode()
+		if body.err != nil && c.err == nil {
+			c.err = body.err
+		}
+	}
+
+	c.functions = append(c.functions, fn)
+	c.emit(OpClosure, len(c.functions)-1)
+	if name != nil {
+		c.define(name)
+	}
+}
+
+// compileMap compiles a map literal whose keys are names or strings;
+// computed keys are left to the evaluator
+func (c *Compiler) compileMap(expr *ast.MapLiteral) {
+	for _, key := range expr.Keys {
+		switch key.(type) {
+		case *ast.Identifier, *ast.StringLiteral:
+		default:
+			c.fallback(expr)
+			return
+		}
+	}
+	for _, key := range expr.Keys {
+		c.compileExpression(expr.Pairs[key])
+	}
+	c.emit(OpMap, c.addNode(expr))
+}
+
+// load pushes a variable: from its slot if a scope declares it, otherwise
+// by name from the environment
+func (c *Compiler) load(ident *ast.Identifier) {
+	depth, slot, ok := c.scope.resolve(ident.Value)
+	switch {
+	case !ok:
+		c.emit(OpGetName, c.addNode(ident))
+	case depth == 0:
+		c.emit(OpGetLocal, slot, c.addNode(ident))
+	default:
+		c.emit(OpGetFree, depth, slot, c.addNode(ident))
+	}
+}
+
+// define binds the value on top of the stack to a name declared in the
+// current scope
+func (c *Compiler) define(ident *ast.Identifier) {
+	if c.scope != nil {
+		if slot, ok := c.scope.layout.Index(ident.Value); ok {
+			c.emit(OpDefineLocal, slot)
+			return
+		}
+	}
+	c.emit(OpDefine, c.addNode(ident))
+}
+
+// compileAssignment compiles plain and compound assignment to variables
+// and members; logical assignment (??=, ||=, &&=, which may skip the
+// value) is left to the evaluator
+func (c *Compiler) compileAssignment(expr *ast.AssignmentExpression) {
+	if expr.Operator == "??=" || expr.Operator == "||=" || expr.Operator == "&&=" {
+		c.fallback(expr)
+		return
+	}
+
+	switch target := expr.Name.(type) {
+	case *ast.Identifier:
+		c.compileExpression(expr.Value)
+		depth, slot, ok := c.scope.resolve(target.Value)
+		switch {
+		case !ok || c.constNames[target.Value]:
+			c.emit(OpAssign, c.addNode(expr))
+		case depth == 0:
+			c.emit(OpSetLocal, slot, c.addNode(expr))
+		default:
+			c.emit(OpSetFree, depth, slot, c.addNode(expr))
+		}
+	case *ast.MemberExpression:
+		if target.Optional {
+			c.fallback(expr)
+			return
+		}
+		// The evaluator evaluates the object, the value and then the key
+		c.compileExpression(target.Object)
+		c.compileExpression(expr.Value)
+		if target.Computed {
+			c.compileExpression(target.Property)
+			c.emit(OpSetIndex, c.addNode(expr))
+			return
+		}
+		c.emit(OpSetMember, c.addNode(expr))
+	default:
+		c.fallback(expr)
+	}
+}
+
+// isOptionalChain reports whether a member/call chain contains a ?. link
+func isOptionalChain(expr ast.Expression) bool {
+	for {
+		switch e := expr.(type) {
+		case *ast.MemberExpression:
+			if e.Optional {
+				return true
+			}
+			expr = e.Object
+		case *ast.CallExpression:
+			if e.Optional {
+				return true
+			}
+			expr = e.Function
+		default:
+			return false
+		}
+	}
+}
+
+func hasSpread(exprs []ast.Expression) bool {
+	for _, e := range exprs {
+		if _, ok := e.(*ast.SpreadElement); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ==================== Helpers ====================
+
+func (c *Compiler) emit(op Opcode, operands ...int) int {
+	for _, o := range operands {
+		if o > maxOperand && c.err == nil {
+			c.err = fmt.Errorf("bytecode operand too large: %d", o)
+		}
+	}
+	pos := len(c.instructions)
+	c.instructions = append(c.instructions, Make(op, operands...)...)
+	return pos
+}
+
+// patch rewrites the first operand of the instruction at pos
+func (c *Compiler) patch(pos, operand int) {
+	c.patchOperands(pos, operand)
+}
+
+func (c *Compiler) patchOperands(pos int, operands ...int) {
+	op := Opcode(c.instructions[pos])
+	copy(c.instructions[pos:], Make(op, operands...))
+}
+
+// fallback hands a node to the evaluator
+func (c *Compiler) fallback(node ast.Node) {
+	c.fallbacks = append(c.fallbacks, node)
+	c.emit(OpEval, c.addNode(node))
+}
+
+func (c *Compiler) addConstant(obj object.Object) int {
+	c.constants = append(c.constants, obj)
+	return len(c.constants) - 1
+}
+
+func (c *Compiler) addNode(node ast.Node) int {
+	c.nodes = append(c.nodes, node)
+	return len(c.nodes) - 1
+}
+
+func (c *Compiler) addScope(layout *object.Layout) int {
+	c.scopes = append(c.scopes, layout)
+	return len(c.scopes) - 1
+}
+
+// enterScope starts a nested scope with slots for names
+func (c *Compiler) enterScope(names []string) {
+	c.scope = &scope{outer: c.scope, layout: object.NewLayout(names)}
+}
+
+func (c *Compiler) leaveScope() {
+	c.scope = c.scope.outer
+}
+
+func (c *Compiler) enterLoop() *loopScope {
+	loop := &loopScope{}
+	c.loops = append(c.loops, loop)
+	return loop
+}
+
+// leaveLoop stops routing thamo/chharo to the innermost loop; the body's
+// own completion value is still checked with bodySignal afterwards
+func (c *Compiler) leaveLoop() {
+	c.loops = c.loops[:len(c.loops)-1]
+}
+
+// bodySignal checks the completion value of a loop body
+func (c *Compiler) bodySignal(loop *loopScope) {
+	loop.signals = append(loop.signals, c.emit(OpLoopSignal, 0, 0))
+}
+
+func (c *Compiler) currentLoop() *loopScope {
+	if len(c.loops) == 0 {
+		return nil
+	}
+	return c.loops[len(c.loops)-1]
+}
+
+func (c *Compiler) patchLoop(loop *loopScope, exit, cont int) {
+	for _, pos := range loop.breaks {
+		c.patch(pos, exit)
+	}
+	for _, pos := range loop.continues {
+		c.patch(pos, cont)
+	}
+	for _, pos := range loop.signals {
+		c.patchOperands(pos, exit, cont)
+	}
+}
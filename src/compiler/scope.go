@@ -0,0 +1,133 @@
+package compiler
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/object"
+)
+
+// scope is the compile-time view of an environment the VM creates with
+// slots: a function body or a for loop. Top-level variables have no scope;
+// they live in the program environment and are looked up by name.
+type scope struct {
+	outer  *scope
+	layout *object.Layout
+}
+
+// resolve finds the slot of a variable and how many scopes out it is
+func (s *scope) resolve(name string) (depth, slot int, ok bool) {
+	for ; s != nil; s = s.outer {
+		if slot, ok := s.layout.Index(name); ok {
+			return depth, slot, true
+		}
+		depth++
+	}
+	return 0, 0, false
+}
+
+// layoutOf returns the layout of a scope, nil for the top level
+func layoutOf(s *scope) *object.Layout {
+	if s == nil {
+		return nil
+	}
+	return s.layout
+}
+
+// declarations collects the variables nodes declare in the scope they run
+// in, the same environment the evaluator would store them in: blocks share
+// their enclosing scope, while functions, classes and loops other than
+// jotokkhon keep their own variables.
+func declarations(names []string, nodes ...ast.Node) []string {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		seen[name] = true
+	}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, node := range nodes {
+		ast.Inspect(node, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.VariableDeclaration:
+				if !n.IsGlobal {
+					add(n.Name.Value)
+				}
+			case *ast.DestructuringDeclaration:
+				if !n.IsGlobal {
+					for _, name := range n.Names() {
+						add(name.Value)
+					}
+				}
+			case *ast.FunctionLiteral:
+				if n.Name != nil {
+					add(n.Name.Value)
+				}
+				return false
+			case *ast.AsyncFunctionLiteral:
+				if n.Name != nil {
+					add(n.Name.Value)
+				}
+				return false
+			case *ast.ClassDeclaration:
+				add(n.Name.Value)
+				return false
+			case *ast.ForStatement, *ast.ForOfStatement, *ast.ForInStatement:
+				return false
+			}
+			return true
+		})
+	}
+	return names
+}
+
+// parameterNames returns the variables a function binds for its
+// parameters, and whether they are all plain distinct names that can be
+// bound straight to slots 0, 1, ... in order
+func parameterNames(params []*ast.Parameter, rest *ast.Identifier) ([]string, bool) {
+	var names []string
+	simple := true
+	for _, param := range params {
+		if param.Pattern != nil || param.Default != nil {
+			simple = false
+		}
+		for _, name := range param.Names() {
+			names = append(names, name.Value)
+		}
+	}
+	if rest != nil {
+		names = append(names, rest.Value)
+	}
+
+	if simple {
+		seen := make(map[string]bool, len(names))
+		for _, name := range names {
+			if seen[name] {
+				return names, false
+			}
+			seen[name] = true
+		}
+	}
+	return names, simple
+}
+
+// constantNames collects every name a program declares with sthir.
+// Assignments to them go through the environment, which rejects changing
+// a constant.
+func constantNames(program *ast.Program) map[string]bool {
+	names := make(map[string]bool)
+	ast.Inspect(program, func(n ast.Node) bool {
+		if decl, ok := n.(*ast.VariableDeclaration); ok && decl.IsConstant {
+			names[decl.Name.Value] = true
+		}
+		if decl, ok := n.(*ast.DestructuringDeclaration); ok && decl.IsConstant {
+			for _, name := range decl.Names() {
+				names[name.Value] = true
+			}
+		}
+		return true
+	})
+	return names
+}
@@ -6,7 +6,7 @@ import (
 )
 
 // evalDoWhileStatement evaluates: do { ... } jotokkhon (condition);
-func evalDoWhileStatement(stmt *ast.DoWhileStatement, env *object.Environment) object.Object {
+func evalDoWhileStatement(stmt *ast.DoWhileStatement, env *object.Environment, label string) object.Object {
 	for {
 		result := Eval(stmt.Body, env)
 		if result != nil {
@@ -14,8 +14,14 @@ func evalDoWhileStatement(stmt *ast.DoWhileStatement, env *object.Environment) o
 			case object.RETURN_OBJ, object.ERROR_OBJ, object.EXCEPTION_OBJ:
 				return result
 			case object.BREAK_OBJ:
+				if !ownsSignal(result, label) {
+					return result
+				}
 				return object.NULL
 			case object.CONTINUE_OBJ:
+				if !ownsSignal(result, label) {
+					return result
+				}
 				// continue to condition check
 			}
 		}
@@ -50,7 +56,7 @@ func evalDeleteExpression(node *ast.DeleteExpression, env *object.Environment) o
 			return object.FALSE
 		}
 		if _, exists := o.Pairs[key]; exists {
-			delete(o.Pairs, key)
+			o.Delete(key)
 			return object.TRUE
 		}
 		return object.TRUE
@@ -2,9 +2,9 @@ package evaluator
 
 import (
 	"BanglaCode/src/ast"
+	"BanglaCode/src/eventloop"
 	"BanglaCode/src/object"
 	"fmt"
-	"time"
 )
 
 // evalAsyncFunctionLiteral evaluates an async function literal and creates an async function object
@@ -23,6 +23,7 @@ func evalAsyncFunctionLiteral(node *ast.AsyncFunctionLiteral, env *object.Enviro
 		Body:          body,
 		Name:          name,
 		IsAsync:       true, // Mark as async
+		IsGenerator:   node.IsGenerator,
 	}
 
 	// If function has a name, bind it in the environment
@@ -33,12 +34,13 @@ func evalAsyncFunctionLiteral(node *ast.AsyncFunctionLiteral, env *object.Enviro
 	return fn
 }
 
-// evalAsyncFunctionCall executes an async function in a goroutine and returns a promise
+// evalAsyncFunctionCall runs an async function as a coroutine and returns a
+// promise for its result. The body runs straight away up to its first
+// opekha; the rest runs on the event loop once the awaited promise settles.
 func evalAsyncFunctionCall(fn *object.Function, args []object.Object, env *object.Environment) object.Object {
-	promise := object.CreatePromise()
+	promise := object.NewPromise()
 
-	// Spawn goroutine to execute async function
-	go func() {
+	eventloop.NewCoroutine(func() {
 		// Recover from panics in async functions
 		defer func() {
 			if r := recover(); r != nil {
@@ -48,7 +50,11 @@ func evalAsyncFunctionCall(fn *object.Function, args []object.Object, env *objec
 		}()
 
 		// Create new environment for function execution
-		extendedEnv := extendFunctionEnv(fn, args)
+		extendedEnv, failed := extendFunctionEnv(fn, args)
+		if failed != nil {
+			object.RejectPromise(promise, failed)
+			return
+		}
 
 		// Execute function body
 		result := Eval(fn.Body, extendedEnv)
@@ -69,16 +75,14 @@ func evalAsyncFunctionCall(fn *object.Function, args []object.Object, env *objec
 			return
 		}
 
-		// Resolve promise with result
-		object.ResolvePromise(promise, result)
-	}()
+		// Resolve promise with result, following it if it is a promise
+		object.ResolveWith(promise, result)
+	}).Resume()
 
 	return promise
 }
 
 // evalAwaitExpression waits for a promise to resolve or reject
-// FIXED: Removed race condition by relying on channel read directly
-// ADDED: 30-second timeout to prevent infinite blocking
 func evalAwaitExpression(node *ast.AwaitExpression, env *object.Environment) object.Object {
 	// Evaluate the expression that should produce a promise
 	value := Eval(node.Expression, env)
@@ -92,14 +96,12 @@ func evalAwaitExpression(node *ast.AwaitExpression, env *object.Environment) obj
 		return newError("opekha (await) can only be used with promises, got %s", value.Type())
 	}
 
-	// Wait for promise to complete with timeout
-	// Channels are thread-safe, no need to check state first
-	select {
-	case result := <-promise.ResultChan:
-		return result
-	case err := <-promise.ErrorChan:
-		return err
-	case <-time.After(30 * time.Second):
-		return newError("await timeout: promise did not resolve within 30 seconds")
-	}
+	return awaitPromise(promise)
+}
+
+// awaitPromise suspends the running async function until promise settles
+// (or runs the event loop, at the top level) and returns its value, or the
+// error it was rejected with
+func awaitPromise(promise *object.Promise) object.Object {
+	return object.AwaitPromise(promise, 0)
 }
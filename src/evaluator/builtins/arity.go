@@ -0,0 +1,107 @@
+package builtins
+
+// Arity is the number of arguments a builtin accepts; Max is -1 for
+// variadic builtins
+type Arity struct {
+	Min int
+	Max int
+}
+
+// Arities lists the argument counts of builtins that check them, so
+// tooling (banglacode lint) can report wrong calls before running the code
+var Arities = map[string]Arity{
+	// array
+	"dhokao":     {2, 2},
+	"berKoro":    {1, 1},
+	"chabi":      {1, 1},
+	"kato":       {2, 3},
+	"ulto":       {1, 1},
+	"ache":       {2, 2},
+	"saja":       {1, 1},
+	"manchitro":  {2, 2},
+	"chhanno":    {2, 2},
+	"sonkuchito": {2, 3},
+	"proti":      {2, 2},
+
+	// assert
+	"nishchit":        {1, 2},
+	"nishchit_soman":  {2, 3},
+	"nishchit_osoman": {2, 3},
+	"nishchit_felbe":  {1, 2},
+
+	// async
+	"ghumaao":         {1, 2},
+	"sob_proyash":     {1, 1},
+	"prothom_proyash": {1, 1},
+	"sob_nishpotti":   {1, 1},
+	"jekono_proyash":  {1, 1},
+	"somoy_shima":     {2, 2},
+	"Promise":         {1, 1},
+	"AbortController": {0, 0},
+
+	// http
+	"server_chalu": {2, -1},
+	"anun":         {1, 1},
+	"anun_async":   {1, 2},
+	"json_poro":    {1, 1},
+	"json_banao":   {1, 1},
+
+	// io
+	"poro":               {1, 1},
+	"lekho":              {2, 2},
+	"file_jog":           {2, 2},
+	"file_mochho":        {1, 1},
+	"file_nokol":         {2, 2},
+	"folder_mochho":      {1, 2},
+	"file_dekhun":        {2, 2},
+	"file_dekhun_bondho": {1, 1},
+	"poro_async":         {1, 1},
+	"lekho_async":        {2, 2},
+
+	// math
+	"borgomul": {1, 1},
+	"ghat":     {2, 2},
+	"niche":    {1, 1},
+	"upore":    {1, 1},
+	"kache":    {1, 1},
+	"niratek":  {1, 1},
+	"choto":    {2, -1},
+	"boro":     {2, -1},
+
+	// string
+	"dorghyo":    {1, 1},
+	"boroHater":  {1, 1},
+	"chotoHater": {1, 1},
+	"bhag":       {2, 2},
+	"joro":       {2, 2},
+	"chhanto":    {1, 1},
+	"khojo":      {2, 2},
+	"angsho":     {2, 3},
+	"bodlo":      {3, 3},
+
+	// tcp
+	"tcp_server_chalu": {2, 2},
+	"tcp_jukto":        {2, 2},
+	"tcp_pathao":       {2, 2},
+	"tcp_shuno":        {1, 1},
+	"tcp_bondho":       {1, 1},
+
+	// udp
+	"udp_server_chalu": {2, 2},
+	"udp_uttor":        {2, 2},
+	"udp_pathao":       {3, 3},
+	"udp_bondho":       {1, 1},
+
+	// util
+	"dhoron":  {1, 1},
+	"lipi":    {1, 1},
+	"jongate": {1, 1},
+	"sonkha":  {1, 1},
+	"ghum":    {1, 1},
+
+	// websocket
+	"websocket_server_chalu": {2, 2},
+	"websocket_jukto":        {1, 1},
+	"websocket_pathao":       {2, 2},
+	"websocket_bondho":       {1, 1},
+}
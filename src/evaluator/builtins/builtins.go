@@ -7,6 +7,7 @@ import (
 	"BanglaCode/src/evaluator/builtins/collections"
 	"BanglaCode/src/evaluator/builtins/crypto"
 	"BanglaCode/src/evaluator/builtins/database"
+	"BanglaCode/src/evaluator/builtins/database/cursor"
 	"BanglaCode/src/evaluator/builtins/errors"
 	"BanglaCode/src/evaluator/builtins/events"
 	mathpkg "BanglaCode/src/evaluator/builtins/math"
@@ -58,6 +59,7 @@ func init() {
 	for name, fn := range database.Builtins {
 		Builtins[name] = fn
 	}
+	cursor.SetJSONEncoder(stringifyJSON)
 
 	// Register event built-in functions
 	for name, fn := range events.Builtins {
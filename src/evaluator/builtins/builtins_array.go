@@ -55,7 +55,7 @@ func init() {
 
 			mapObj := args[0].(*object.Map)
 			keys := make([]object.Object, 0, len(mapObj.Pairs))
-			for key := range mapObj.Pairs {
+			for _, key := range mapObj.Keys() {
 				keys = append(keys, &object.String{Value: key})
 			}
 			return &object.Array{Elements: keys}
@@ -0,0 +1,321 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Assertion library used by banglacode test (and usable in any program).
+// A failed assertion throws an AssertionError, so it stops the current
+// test and can be caught with chesta/dhoro_bhul like any other error.
+
+// AssertionTimeout bounds how long nishchit_felbe waits for a promise
+var AssertionTimeout = 5 * time.Second
+
+func init() {
+	// nishchit (নিশ্চিত - make sure) - value must be truthy
+	Builtins["nishchit"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1 or 2", len(args))
+			}
+			if isTruthy(args[0]) {
+				return object.NULL
+			}
+			return assertionFailure(assertMessage(args, 1, fmt.Sprintf("expected a truthy value, got %s", formatValue(args[0]))), nil, nil)
+		},
+	}
+
+	// nishchit_soman (নিশ্চিত সমান - make sure equal) - deep equality with a diff
+	Builtins["nishchit_soman"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2 or 3", len(args))
+			}
+			actual, expected := args[0], args[1]
+			var diffs []string
+			deepDiff("", actual, expected, &diffs)
+			if len(diffs) == 0 {
+				return object.NULL
+			}
+			message := assertMessage(args, 2, "values are not equal")
+			message += "\n  expected: " + formatValue(expected) + "\n  actual:   " + formatValue(actual)
+			if len(diffs) > 1 || !strings.HasPrefix(diffs[0], ": ") {
+				message += "\n  differences:\n    " + strings.Join(diffs, "\n    ")
+			}
+			return assertionFailure(message, actual, expected)
+		},
+	}
+
+	// nishchit_osoman (নিশ্চিত অসমান - make sure not equal)
+	Builtins["nishchit_osoman"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=2 or 3", len(args))
+			}
+			if !DeepEqual(args[0], args[1]) {
+				return object.NULL
+			}
+			return assertionFailure(assertMessage(args, 2, "expected values to differ, both are "+formatValue(args[0])), args[0], args[1])
+		},
+	}
+
+	// nishchit_felbe (নিশ্চিত ফেলবে - make sure it throws) - calls fn (or
+	// awaits a promise) and expects an error; the optional second argument
+	// must appear in the error message. Returns the thrown value.
+	Builtins["nishchit_felbe"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1 or 2", len(args))
+			}
+
+			var result object.Object
+			switch target := args[0].(type) {
+			case *object.Function:
+				result = EvalFunc(target, []object.Object{})
+			case *object.Builtin:
+				result = target.Fn()
+			case *object.Promise:
+				result = target
+			default:
+				return newError("nishchit_felbe expects a function or promise, got %s", args[0].Type())
+			}
+			if promise, ok := result.(*object.Promise); ok {
+				result = awaitSettled(promise)
+			}
+
+			thrown, message, ok := thrownValue(result)
+			if !ok {
+				return assertionFailure("expected function to throw, but it returned "+formatValue(result), nil, nil)
+			}
+			if len(args) == 2 {
+				want, isStr := args[1].(*object.String)
+				if !isStr {
+					return newError("nishchit_felbe expected message must be STRING, got %s", args[1].Type())
+				}
+				if !strings.Contains(message, want.Value) {
+					return assertionFailure(fmt.Sprintf("expected error containing %q, got %q", want.Value, message), nil, nil)
+				}
+			}
+			return thrown
+		},
+	}
+}
+
+// assertMessage uses the caller's message argument at index i if given
+func assertMessage(args []object.Object, i int, fallback string) string {
+	if len(args) > i {
+		if s, ok := args[i].(*object.String); ok {
+			return s.Value + ": " + fallback
+		}
+	}
+	return fallback
+}
+
+// assertionFailure builds the exception thrown by a failed assertion
+func assertionFailure(message string, actual, expected object.Object) *object.Exception {
+	errorMap := object.NewMap()
+	errorMap.Set("message", &object.String{Value: message})
+	errorMap.Set("name", &object.String{Value: "AssertionError"})
+	errorMap.Set("stack", &object.String{Value: ""})
+	if actual != nil {
+		errorMap.Set("actual", actual)
+	}
+	if expected != nil {
+		errorMap.Set("expected", expected)
+	}
+	return &object.Exception{Message: "AssertionError: " + message, Value: errorMap}
+}
+
+// IsAssertionFailure reports whether obj is the exception thrown by a
+// failed assertion (as opposed to any other error)
+func IsAssertionFailure(obj object.Object) bool {
+	exc, ok := obj.(*object.Exception)
+	if !ok {
+		return false
+	}
+	errorMap, ok := exc.Value.(*object.Map)
+	if !ok {
+		return false
+	}
+	name, ok := errorMap.Pairs["name"].(*object.String)
+	return ok && name.Value == "AssertionError"
+}
+
+// thrownValue extracts the thrown value and its message from a call result
+func thrownValue(result object.Object) (object.Object, string, bool) {
+	switch r := result.(type) {
+	case *object.Exception:
+		value := r.Value
+		if value == nil {
+			value = &object.String{Value: r.Message}
+		}
+		return value, r.Message, true
+	case *object.Error:
+		return &object.String{Value: r.Message}, r.Message, true
+	}
+	return nil, "", false
+}
+
+// awaitSettled waits for a promise and returns its value, or the rejection
+// as an exception
+func awaitSettled(promise *object.Promise) object.Object {
+	result := object.AwaitPromise(promise, AssertionTimeout)
+	if promise.State == object.PROMISE_REJECTED {
+		switch result.(type) {
+		case *object.Exception, *object.Error:
+			return result
+		}
+		return &object.Exception{Message: result.Inspect(), Value: result}
+	}
+	return result
+}
+
+// DeepEqual compares values structurally: arrays element by element, maps,
+// sets and instances by their contents, everything else like ==
+func DeepEqual(a, b object.Object) bool {
+	var diffs []string
+	deepDiff("", a, b, &diffs)
+	return len(diffs) == 0
+}
+
+// deepDiff appends one line per difference between actual and expected,
+// prefixed with the path where they differ (e.g. "[1].naam")
+func deepDiff(path string, actual, expected object.Object, diffs *[]string) {
+	mismatch := func() {
+		*diffs = append(*diffs, fmt.Sprintf("%s: expected %s, got %s", path, formatValue(expected), formatValue(actual)))
+	}
+	if actual == nil || expected == nil {
+		if actual != expected {
+			mismatch()
+		}
+		return
+	}
+	if actual.Type() != expected.Type() {
+		mismatch()
+		return
+	}
+
+	switch e := expected.(type) {
+	case *object.Array:
+		a := actual.(*object.Array)
+		for i := 0; i < len(a.Elements) || i < len(e.Elements); i++ {
+			at := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(a.Elements):
+				*diffs = append(*diffs, fmt.Sprintf("%s: missing %s", at, formatValue(e.Elements[i])))
+			case i >= len(e.Elements):
+				*diffs = append(*diffs, fmt.Sprintf("%s: unexpected %s", at, formatValue(a.Elements[i])))
+			default:
+				deepDiff(at, a.Elements[i], e.Elements[i], diffs)
+			}
+		}
+	case *object.Map:
+		diffPairs(path, actual.(*object.Map).Pairs, e.Pairs, diffs)
+	case *object.Instance:
+		a := actual.(*object.Instance)
+		if a.Class != e.Class {
+			mismatch()
+			return
+		}
+		diffPairs(path, a.Properties, e.Properties, diffs)
+	case *object.Set:
+		a := actual.(*object.Set)
+		for key := range e.Elements {
+			if !a.Elements[key] {
+				*diffs = append(*diffs, fmt.Sprintf("%s: missing element %s", path, key))
+			}
+		}
+		for key := range a.Elements {
+			if !e.Elements[key] {
+				*diffs = append(*diffs, fmt.Sprintf("%s: unexpected element %s", path, key))
+			}
+		}
+	case *object.ES6Map:
+		a := actual.(*object.ES6Map)
+		for _, key := range e.Order {
+			at := fmt.Sprintf("%s.get(%s)", path, formatValue(e.Keys[key]))
+			if value, ok := a.Pairs[key]; ok {
+				deepDiff(at, value, e.Pairs[key], diffs)
+			} else {
+				*diffs = append(*diffs, fmt.Sprintf("%s: missing", at))
+			}
+		}
+		for _, key := range a.Order {
+			if _, ok := e.Pairs[key]; !ok {
+				*diffs = append(*diffs, fmt.Sprintf("%s.get(%s): unexpected", path, formatValue(a.Keys[key])))
+			}
+		}
+	default:
+		if !objectsEqual(actual, expected) {
+			mismatch()
+		}
+	}
+}
+
+func diffPairs(path string, actual, expected map[string]object.Object, diffs *[]string) {
+	keys := make([]string, 0, len(expected)+len(actual))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	for key := range actual {
+		if _, ok := expected[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		at := path + "." + key
+		e, inExpected := expected[key]
+		a, inActual := actual[key]
+		switch {
+		case !inActual:
+			*diffs = append(*diffs, fmt.Sprintf("%s: missing %s", at, formatValue(e)))
+		case !inExpected:
+			*diffs = append(*diffs, fmt.Sprintf("%s: unexpected %s", at, formatValue(a)))
+		default:
+			deepDiff(at, a, e, diffs)
+		}
+	}
+}
+
+// formatValue renders a value for assertion messages: strings are quoted
+// and map keys sorted so messages are stable
+func formatValue(obj object.Object) string {
+	switch v := obj.(type) {
+	case nil:
+		return "khali"
+	case *object.String:
+		return strconv.Quote(v.Value)
+	case *object.Array:
+		parts := make([]string, len(v.Elements))
+		for i, el := range v.Elements {
+			parts[i] = formatValue(el)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case *object.Map:
+		return "{" + formatPairs(v.Pairs) + "}"
+	case *object.Instance:
+		return v.Class.Name + " {" + formatPairs(v.Properties) + "}"
+	default:
+		return obj.Inspect()
+	}
+}
+
+func formatPairs(pairs map[string]object.Object) string {
+	keys := make([]string, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key + ": " + formatValue(pairs[key])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -1,15 +1,16 @@
 package builtins
 
 import (
+	"BanglaCode/src/eventloop"
 	"BanglaCode/src/object"
-	"sync"
 	"time"
 )
 
 func init() {
-	// ghumaao (ঘুমাও) - async sleep
+	// ghumaao (ঘুমাও) - async sleep; an abort signal cuts it short
 	Builtins["ghumaao"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
+			args, signal := object.TakeSignal(args)
 			if len(args) != 1 {
 				return newError("wrong number of arguments. got=%d, want=1", len(args))
 			}
@@ -18,82 +19,47 @@ func init() {
 			}
 
 			ms := int64(args[0].(*object.Number).Value)
-			promise := object.CreatePromise()
-
-			go func() {
-				time.Sleep(time.Duration(ms) * time.Millisecond)
+			promise := object.NewPromise()
+			timer := eventloop.AddTimer(time.Duration(ms)*time.Millisecond, 0, func() {
 				object.ResolvePromise(promise, object.NULL)
-			}()
+			})
+			if signal != nil {
+				object.RejectOnAbort(promise, signal)
+				promise.Observe(func() { eventloop.ClearTimer(timer) })
+			}
 
 			return promise
 		},
 	}
 
 	// sob_proyash (সব_প্রয়াস) - Promise.all
-	// FIXED: Now waits for all promises CONCURRENTLY instead of sequentially
 	Builtins["sob_proyash"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
-			if len(args) != 1 {
-				return newError("wrong number of arguments. got=%d, want=1", len(args))
+			promises, errObj := promiseList("sob_proyash", args)
+			if errObj != nil {
+				return errObj
 			}
-			if args[0].Type() != object.ARRAY_OBJ {
-				return newError("argument to `sob_proyash` must be ARRAY, got %s", args[0].Type())
-			}
-
-			arr := args[0].(*object.Array)
-			promises := make([]*object.Promise, len(arr.Elements))
 
-			// Validate all elements are promises
-			for i, el := range arr.Elements {
-				p, ok := el.(*object.Promise)
-				if !ok {
-					return newError("all elements must be promises, got %s at index %d", el.Type(), i)
-				}
-				promises[i] = p
+			resultPromise := object.NewPromise()
+			results := make([]object.Object, len(promises))
+			remaining := len(promises)
+			if remaining == 0 {
+				object.ResolvePromise(resultPromise, &object.Array{Elements: results})
 			}
 
-			resultPromise := object.CreatePromise()
-
-			go func() {
-				results := make([]object.Object, len(promises))
-				var wg sync.WaitGroup
-				var mu sync.Mutex
-				var firstError object.Object
-
-				// Wait for all promises concurrently
-				for i, p := range promises {
-					wg.Add(1)
-					go func(idx int, promise *object.Promise) {
-						defer wg.Done()
-
-						// Wait for this promise to complete
-						select {
-						case res := <-promise.ResultChan:
-							mu.Lock()
-							results[idx] = res
-							mu.Unlock()
-						case err := <-promise.ErrorChan:
-							mu.Lock()
-							if firstError == nil {
-								firstError = err
-							}
-							mu.Unlock()
-						}
-					}(i, p)
-				}
-
-				// Wait for all goroutines to complete
-				wg.Wait()
-
-				// If any promise rejected, reject the result promise
-				if firstError != nil {
-					object.RejectPromise(resultPromise, firstError)
-					return
-				}
-
-				// All promises resolved successfully
-				object.ResolvePromise(resultPromise, &object.Array{Elements: results})
-			}()
+			// Settle once every promise has resolved or the first one rejects
+			for i, p := range promises {
+				p.OnSettle(func() {
+					if p.State == object.PROMISE_REJECTED {
+						object.RejectPromise(resultPromise, p.Error)
+						return
+					}
+					results[i] = p.Value
+					if remaining--; remaining == 0 {
+						object.ResolvePromise(resultPromise, &object.Array{Elements: results})
+					}
+				})
+			}
 
 			return resultPromise
 		},
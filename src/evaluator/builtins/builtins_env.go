@@ -184,7 +184,7 @@ func builtinEnvAll(args ...object.Object) object.Object {
 	}
 	envVarsMu.RUnlock()
 
-	return &object.Map{Pairs: pairs}
+	return object.NewMapFrom(pairs)
 }
 
 // env_clear - Clear all loaded environment variables
@@ -2,18 +2,20 @@ package builtins
 
 import (
 	"BanglaCode/src/object"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 )
 
 func init() {
 	// HTTP Server - server_chalu (সার্ভার চালু - start server)
 	Builtins["server_chalu"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
-			if len(args) != 2 {
-				return newError("wrong number of arguments. got=%d, want=2", len(args))
+			if len(args) < 2 {
+				return newError("wrong number of arguments. got=%d, want=2 or more", len(args))
 			}
 			if args[0].Type() != object.NUMBER_OBJ {
 				return newError("first argument to `server_chalu` must be NUMBER (port), got %s", args[0].Type())
@@ -22,7 +24,7 @@ func init() {
 			port := int(args[0].(*object.Number).Value)
 
 			// Check if second argument is a Router (MAP with __router_id__) or Function
-			if args[1].Type() == object.MAP_OBJ {
+			if args[1].Type() == object.MAP_OBJ && len(args) == 2 {
 				// Router-based server
 				routerMap := args[1].(*object.Map)
 
@@ -30,82 +32,44 @@ func init() {
 					if routerID, ok := routerIDObj.(*object.String); ok {
 						if router, found := getRouter(routerID.Value); found {
 							fmt.Printf("🚀 Server cholche http://localhost:%d e (Router mode)\n", port)
-							err := http.ListenAndServe(fmt.Sprintf(":%d", port), router)
-							if err != nil {
-								return newError("server error: %s", err.Error())
-							}
-							return object.NULL
+							return serve(port, router)
 						}
 					}
 				}
 				return newError("invalid router object")
 			}
 
-			// Function-based server (backward compatible)
-			if args[1].Type() != object.FUNCTION_OBJ {
-				return newError("second argument to `server_chalu` must be FUNCTION (handler) or ROUTER, got %s", args[1].Type())
-			}
-
-			handler := args[1].(*object.Function)
-
-			http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-				reqMap := &object.Map{Pairs: make(map[string]object.Object)}
-				reqMap.Pairs["method"] = &object.String{Value: r.Method}
-				reqMap.Pairs["path"] = &object.String{Value: r.URL.Path}
-				reqMap.Pairs["query"] = &object.String{Value: r.URL.RawQuery}
-
-				headersMap := &object.Map{Pairs: make(map[string]object.Object)}
-				for k, v := range r.Header {
-					if len(v) > 0 {
-						headersMap.Pairs[k] = &object.String{Value: v[0]}
-					}
+			// Function-based server (backward compatible):
+			// server_chalu(port, [middleware...], handler)
+			chain := make([]*object.Function, 0, len(args)-1)
+			for i, arg := range args[1:] {
+				fn, ok := arg.(*object.Function)
+				if !ok && i == 0 {
+					return newError("second argument to `server_chalu` must be FUNCTION (handler) or ROUTER, got %s", arg.Type())
 				}
-				reqMap.Pairs["headers"] = headersMap
-
-				body, _ := io.ReadAll(r.Body)
-				reqMap.Pairs["body"] = &object.String{Value: string(body)}
-
-				resMap := &object.Map{Pairs: make(map[string]object.Object)}
-				resMap.Pairs["status"] = &object.Number{Value: 200}
-				resMap.Pairs["body"] = &object.String{Value: ""}
-				resMap.Pairs["headers"] = &object.Map{Pairs: make(map[string]object.Object)}
-
-				var result object.Object
-				if EvalFunc != nil {
-					result = EvalFunc(handler, []object.Object{reqMap, resMap})
-				}
-
-				if statusObj, ok := resMap.Pairs["status"]; ok {
-					if status, ok := statusObj.(*object.Number); ok {
-						w.WriteHeader(int(status.Value))
-					}
-				}
-
-				if headersObj, ok := resMap.Pairs["headers"]; ok {
-					if headers, ok := headersObj.(*object.Map); ok {
-						for k, v := range headers.Pairs {
-							w.Header().Set(k, v.Inspect())
-						}
-					}
+				if !ok {
+					return newError("middleware and handler arguments to `server_chalu` must be FUNCTION, got %s", arg.Type())
 				}
+				chain = append(chain, fn)
+			}
 
-				if bodyObj, ok := resMap.Pairs["body"]; ok {
-					fmt.Fprint(w, bodyObj.Inspect())
-				} else if result != nil && result != object.NULL {
-					fmt.Fprint(w, result.Inspect())
-				}
+			// Each server gets its own mux, so a script can run several
+			mux := http.NewServeMux()
+			mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+				reqMap := buildRequestMap(r, nil)
+				resMap := newResponseMap()
+				result := handleOnLoop(chain, nil, reqMap, resMap)
+				writeResponse(w, resMap, result)
 			})
 
 			fmt.Printf("🚀 Server cholche http://localhost:%d e\n", port)
-			err := http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
-			if err != nil {
-				return newError("server error: %s", err.Error())
-			}
-			return object.NULL
+			return serve(port, mux)
 		},
 	}
 
-	// HTTP GET - anun (আনুন - fetch/bring)
+	// HTTP GET - anun (আনুন - fetch/bring). The request runs off the event
+	// loop and anun waits for it like opekha does, so the script's own
+	// server can answer it meanwhile.
 	Builtins["anun"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
 			if len(args) != 1 {
@@ -114,62 +78,22 @@ func init() {
 			if args[0].Type() != object.STRING_OBJ {
 				return newError("argument to `anun` must be STRING, got %s", args[0].Type())
 			}
-			url := args[0].(*object.String).Value
-
-			resp, err := http.Get(url)
-			if err != nil {
-				return newError("HTTP error: %s", err.Error())
-			}
-			defer resp.Body.Close()
-
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				return newError("error reading response: %s", err.Error())
-			}
-
-			result := &object.Map{Pairs: make(map[string]object.Object)}
-			result.Pairs["status"] = &object.Number{Value: float64(resp.StatusCode)}
-			result.Pairs["body"] = &object.String{Value: string(body)}
-
-			return result
+			return object.AwaitPromise(fetch(args[0].(*object.String).Value, nil), 0)
 		},
 	}
 
-	// Async HTTP GET - anun_async (আনুন_async)
+	// Async HTTP GET - anun_async (আনুন_async); an abort signal cancels the
+	// request
 	Builtins["anun_async"] = &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
+			args, signal := object.TakeSignal(args)
 			if len(args) != 1 {
 				return newError("wrong number of arguments. got=%d, want=1", len(args))
 			}
 			if args[0].Type() != object.STRING_OBJ {
 				return newError("argument to `anun_async` must be STRING, got %s", args[0].Type())
 			}
-
-			url := args[0].(*object.String).Value
-			promise := object.CreatePromise()
-
-			go func() {
-				resp, err := http.Get(url)
-				if err != nil {
-					object.RejectPromise(promise, newError("HTTP error: %s", err.Error()))
-					return
-				}
-				defer resp.Body.Close()
-
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					object.RejectPromise(promise, newError("error reading response: %s", err.Error()))
-					return
-				}
-
-				result := &object.Map{Pairs: make(map[string]object.Object)}
-				result.Pairs["status"] = &object.Number{Value: float64(resp.StatusCode)}
-				result.Pairs["body"] = &object.String{Value: string(body)}
-
-				object.ResolvePromise(promise, result)
-			}()
-
-			return promise
+			return fetch(args[0].(*object.String).Value, signal)
 		},
 	}
 
@@ -209,14 +133,14 @@ func init() {
 			resMap := args[0].(*object.Map)
 
 			// Set body
-			resMap.Pairs["body"] = args[1]
+			resMap.Set("body", args[1])
 
 			// Set status (optional, default 200)
 			if len(args) >= 3 {
 				if args[2].Type() != object.NUMBER_OBJ {
 					return newError("third argument to `uttor` must be NUMBER (status), got %s", args[2].Type())
 				}
-				resMap.Pairs["status"] = args[2]
+				resMap.Set("status", args[2])
 			}
 
 			// Set content-type (optional)
@@ -226,7 +150,7 @@ func init() {
 				}
 				if headersObj, ok := resMap.Pairs["headers"]; ok {
 					if headers, ok := headersObj.(*object.Map); ok {
-						headers.Pairs["Content-Type"] = args[3]
+						headers.Set("Content-Type", args[3])
 					}
 				}
 			}
@@ -248,20 +172,20 @@ func init() {
 
 			// Convert data to JSON string
 			jsonStr := stringifyJSON(args[1])
-			resMap.Pairs["body"] = &object.String{Value: jsonStr}
+			resMap.Set("body", &object.String{Value: jsonStr})
 
 			// Set status (optional, default 200)
 			if len(args) >= 3 {
 				if args[2].Type() != object.NUMBER_OBJ {
 					return newError("third argument to `json_uttor` must be NUMBER (status), got %s", args[2].Type())
 				}
-				resMap.Pairs["status"] = args[2]
+				resMap.Set("status", args[2])
 			}
 
 			// Set content-type to JSON
 			if headersObj, ok := resMap.Pairs["headers"]; ok {
 				if headers, ok := headersObj.(*object.Map); ok {
-					headers.Pairs["Content-Type"] = &object.String{Value: "application/json; charset=utf-8"}
+					headers.Set("Content-Type", &object.String{Value: "application/json; charset=utf-8"})
 				}
 			}
 
@@ -270,13 +194,108 @@ func init() {
 	}
 }
 
+// fetch sends a GET request in the background and returns a promise of
+// its {status, body}; signal, if not nil, cancels it
+func fetch(url string, signal *object.AbortSignal) *object.Promise {
+	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
+
+	go func() {
+		defer stop()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			object.RejectPromise(promise, newError("HTTP error: %s", err.Error()))
+			return
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			object.RejectPromise(promise, newError("HTTP error: %s", err.Error()))
+			return
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			object.RejectPromise(promise, newError("error reading response: %s", err.Error()))
+			return
+		}
+
+		result := object.NewMap()
+		result.Set("status", &object.Number{Value: float64(resp.StatusCode)})
+		result.Set("body", &object.String{Value: string(body)})
+
+		object.ResolvePromise(promise, result)
+	}()
+
+	return promise
+}
+
 // parseJSON converts a JSON string to BanglaCode objects
 func parseJSON(jsonStr string) object.Object {
-	var data interface{}
-	if err := json.Unmarshal([]byte(jsonStr), &data); err != nil {
+	obj, err := DecodeJSON([]byte(jsonStr))
+	if err != nil {
 		return newError("JSON parse error: %s", err.Error())
 	}
-	return JsonToObject(data)
+	return obj
+}
+
+// DecodeJSON converts one JSON document to BanglaCode objects; object keys
+// keep the order they have in the document
+func DecodeJSON(data []byte) (object.Object, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	obj, err := decodeJSONValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid character after top-level value")
+	}
+	return obj, nil
+}
+
+func decodeJSONValue(dec *json.Decoder) (object.Object, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('['):
+		elements := []object.Object{}
+		for dec.More() {
+			el, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, el)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return &object.Array{Elements: elements}, nil
+	case json.Delim('{'):
+		m := object.NewMap()
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			val, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			m.Set(keyTok.(string), val)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+	return JsonToObject(tok), nil
 }
 
 // JsonToObject recursively converts Go values to BanglaCode objects
@@ -300,11 +319,17 @@ func JsonToObject(data interface{}) object.Object {
 		}
 		return &object.Array{Elements: elements}
 	case map[string]interface{}:
-		pairs := make(map[string]object.Object)
-		for key, val := range v {
-			pairs[key] = JsonToObject(val)
+		// Go maps have no order; use the sorted keys
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
 		}
-		return &object.Map{Pairs: pairs}
+		sort.Strings(keys)
+		m := object.NewMap()
+		for _, key := range keys {
+			m.Set(key, JsonToObject(v[key]))
+		}
+		return m
 	default:
 		return newError("unsupported JSON type")
 	}
@@ -338,12 +363,44 @@ func objectToJSON(obj object.Object) interface{} {
 		}
 		return arr
 	case *object.Map:
-		m := make(map[string]interface{})
-		for key, val := range v.Pairs {
-			m[key] = objectToJSON(val)
+		keys := v.Keys()
+		fields := make(jsonObject, len(keys))
+		for i, key := range keys {
+			fields[i] = jsonField{key: key, value: objectToJSON(v.Pairs[key])}
 		}
-		return m
+		return fields
 	default:
 		return obj.Inspect()
 	}
 }
+
+// jsonObject is a map ready for json.Marshal that keeps the map's key order
+type jsonObject []jsonField
+
+type jsonField struct {
+	key   string
+	value interface{}
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,163 @@
+package builtins
+
+import (
+	"BanglaCode/src/eventloop"
+	"BanglaCode/src/object"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+)
+
+// runHandlerChain runs middleware and the final handler in order.
+// Every function receives (req, res, next); calling next() runs the
+// rest of the chain, not calling it short-circuits the request. final,
+// when set, is what next() runs at the end of the chain.
+func runHandlerChain(chain []*object.Function, final func() object.Object, reqMap, resMap *object.Map) object.Object {
+	if EvalFunc == nil {
+		return object.NULL
+	}
+
+	var run func(i int) object.Object
+	run = func(i int) object.Object {
+		if i >= len(chain) {
+			if final != nil {
+				return final()
+			}
+			return object.NULL
+		}
+
+		called := false
+		next := &object.Builtin{
+			Fn: func(args ...object.Object) object.Object {
+				// next() only advances the chain once per middleware
+				if called {
+					return object.NULL
+				}
+				called = true
+				return run(i + 1)
+			},
+		}
+
+		return EvalFunc(chain[i], []object.Object{reqMap, resMap, next})
+	}
+
+	return run(0)
+}
+
+// handleOnLoop runs the handler chain for one request on the event loop and
+// waits for it; an async handler's promise is waited for too, so the
+// response is only written once it has settled
+func handleOnLoop(chain []*object.Function, final func() object.Object, reqMap, resMap *object.Map) object.Object {
+	var result object.Object
+	settled := make(chan struct{})
+	eventloop.Post(func() {
+		result = runHandlerChain(chain, final, reqMap, resMap)
+		promise, ok := result.(*object.Promise)
+		if !ok {
+			close(settled)
+			return
+		}
+		promise.OnSettle(func() {
+			result = promise.Value
+			if promise.State == object.PROMISE_REJECTED {
+				result = promise.Error
+			}
+			close(settled)
+		})
+	})
+	<-settled
+	return result
+}
+
+// notFound is the final handler of a request no route matches; it answers
+// the way http.NotFound does
+func notFound(resMap *object.Map) func() object.Object {
+	return func() object.Object {
+		resMap.Set("status", &object.Number{Value: http.StatusNotFound})
+		resMap.Set("body", &object.String{Value: "404 page not found\n"})
+		if headers, ok := resMap.Pairs["headers"].(*object.Map); ok {
+			headers.Set("Content-Type", &object.String{Value: "text/plain; charset=utf-8"})
+		}
+		return object.NULL
+	}
+}
+
+// serve starts an HTTP server on port without blocking: the port is bound
+// straight away, so a busy port is reported to the caller, and the server
+// keeps the event loop alive while it runs
+func serve(port int, handler http.Handler) object.Object {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return newError("server error: %s", err.Error())
+	}
+	eventloop.Ref()
+	go func() {
+		defer eventloop.Unref()
+		http.Serve(listener, handler)
+	}()
+	return object.NULL
+}
+
+// buildRequestMap converts an incoming HTTP request into the req map passed to handlers
+func buildRequestMap(r *http.Request, params map[string]string) *object.Map {
+	reqMap := object.NewMap()
+	reqMap.Set("method", &object.String{Value: r.Method})
+	reqMap.Set("path", &object.String{Value: r.URL.Path})
+	reqMap.Set("query", &object.String{Value: r.URL.RawQuery})
+
+	// Expose captured route parameters (/users/:id -> req.params.id)
+	paramsMap := object.NewMap()
+	for k, v := range params {
+		paramsMap.Set(k, &object.String{Value: v})
+	}
+	reqMap.Set("params", paramsMap)
+
+	// Parse headers
+	headersMap := object.NewMap()
+	for k, v := range r.Header {
+		if len(v) > 0 {
+			headersMap.Set(k, &object.String{Value: v[0]})
+		}
+	}
+	reqMap.Set("headers", headersMap)
+
+	// Read body
+	body, _ := io.ReadAll(r.Body)
+	reqMap.Set("body", &object.String{Value: string(body)})
+
+	return reqMap
+}
+
+// newResponseMap creates the res map passed to handlers
+func newResponseMap() *object.Map {
+	resMap := object.NewMap()
+	resMap.Set("status", &object.Number{Value: 200})
+	resMap.Set("body", &object.String{Value: ""})
+	resMap.Set("headers", object.NewMap())
+	return resMap
+}
+
+// writeResponse writes the res map filled in by handlers back to the client
+func writeResponse(w http.ResponseWriter, resMap *object.Map, result object.Object) {
+	// Headers must be set before the status line is written
+	if headersObj, ok := resMap.Pairs["headers"]; ok {
+		if headers, ok := headersObj.(*object.Map); ok {
+			for k, v := range headers.Pairs {
+				w.Header().Set(k, v.Inspect())
+			}
+		}
+	}
+
+	if statusObj, ok := resMap.Pairs["status"]; ok {
+		if status, ok := statusObj.(*object.Number); ok {
+			w.WriteHeader(int(status.Value))
+		}
+	}
+
+	if bodyObj, ok := resMap.Pairs["body"]; ok {
+		io.WriteString(w, bodyObj.Inspect())
+	} else if result != nil && result != object.NULL {
+		io.WriteString(w, result.Inspect())
+	}
+}
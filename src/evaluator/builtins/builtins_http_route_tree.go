@@ -0,0 +1,173 @@
+package builtins
+
+import (
+	"BanglaCode/src/object"
+	"strings"
+)
+
+// routeNode is a single segment in the router's route tree.
+// Static segments are matched first, then named parameters (:id),
+// then a trailing wildcard (* or *name).
+type routeNode struct {
+	static   map[string]*routeNode
+	param    *routeNode
+	optional bool // some route declares this param segment as :name?
+	wildcard *routeNode
+
+	handler    *object.Function
+	middleware []*object.Function // per-route middleware, run before handler
+	keys       []string           // parameter names captured along the path, in order
+	optionals  []bool             // for each key, whether this route declared it optional
+}
+
+// routeCapture is a captured parameter value; skipped optional
+// segments are recorded with ok=false so keys and values stay aligned
+type routeCapture struct {
+	value string
+	ok    bool
+}
+
+func newRouteNode() *routeNode {
+	return &routeNode{static: make(map[string]*routeNode)}
+}
+
+// splitRoutePath splits a path into its non-empty segments
+func splitRoutePath(path string) []string {
+	parts := strings.Split(path, "/")
+	segments := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			segments = append(segments, part)
+		}
+	}
+	return segments
+}
+
+// insert registers a handler (and its per-route middleware) for the given route pattern
+func (n *routeNode) insert(pattern string, handler *object.Function, middleware []*object.Function) {
+	node := n
+	keys := []string{}
+	optionals := []bool{}
+
+	for _, seg := range splitRoutePath(pattern) {
+		switch {
+		case strings.HasPrefix(seg, "*"):
+			name := strings.TrimPrefix(seg, "*")
+			if name == "" {
+				name = "*"
+			}
+			keys = append(keys, name)
+			optionals = append(optionals, false)
+			if node.wildcard == nil {
+				node.wildcard = newRouteNode()
+			}
+			node = node.wildcard
+		case strings.HasPrefix(seg, ":"):
+			name := strings.TrimPrefix(seg, ":")
+			optional := strings.HasSuffix(name, "?")
+			name = strings.TrimSuffix(name, "?")
+			keys = append(keys, name)
+			optionals = append(optionals, optional)
+			if node.param == nil {
+				node.param = newRouteNode()
+			}
+			// Other routes may share the node; each route's own optionals
+			// are checked when it matches
+			node.param.optional = node.param.optional || optional
+			node = node.param
+		default:
+			child, ok := node.static[seg]
+			if !ok {
+				child = newRouteNode()
+				node.static[seg] = child
+			}
+			node = child
+		}
+
+		// A wildcard always consumes the rest of the path
+		if strings.HasPrefix(seg, "*") {
+			break
+		}
+	}
+
+	node.handler = handler
+	node.middleware = middleware
+	node.keys = keys
+	node.optionals = optionals
+}
+
+// accepts reports whether the route registered at n allows the captures:
+// only the segments it declared optional may have been skipped
+func (n *routeNode) accepts(captures []routeCapture) bool {
+	for i, capture := range captures {
+		if !capture.ok && (i >= len(n.optionals) || !n.optionals[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// match walks the tree for the given segments and returns the matched node
+// together with the captured parameter values
+func (n *routeNode) match(segments []string, captures []routeCapture) (*routeNode, []routeCapture) {
+	if len(segments) == 0 {
+		if n.handler != nil && n.accepts(captures) {
+			return n, captures
+		}
+		// Optional parameters and wildcards may match nothing at all
+		if n.param != nil && n.param.optional {
+			if found, caps := n.param.match(segments, append(captures, routeCapture{})); found != nil {
+				return found, caps
+			}
+		}
+		if n.wildcard != nil && n.wildcard.handler != nil {
+			if caps := append(captures, routeCapture{value: "", ok: true}); n.wildcard.accepts(caps) {
+				return n.wildcard, caps
+			}
+		}
+		return nil, nil
+	}
+
+	seg := segments[0]
+
+	if child, ok := n.static[seg]; ok {
+		if found, caps := child.match(segments[1:], captures); found != nil {
+			return found, caps
+		}
+	}
+
+	if n.param != nil {
+		if found, caps := n.param.match(segments[1:], append(captures, routeCapture{value: seg, ok: true})); found != nil {
+			return found, caps
+		}
+		if n.param.optional {
+			if found, caps := n.param.match(segments, append(captures, routeCapture{})); found != nil {
+				return found, caps
+			}
+		}
+	}
+
+	if n.wildcard != nil && n.wildcard.handler != nil {
+		if caps := append(captures, routeCapture{value: strings.Join(segments, "/"), ok: true}); n.wildcard.accepts(caps) {
+			return n.wildcard, caps
+		}
+	}
+
+	return nil, nil
+}
+
+// lookup finds the route for a path and returns the named parameters
+func (n *routeNode) lookup(path string) (*routeNode, map[string]string) {
+	found, captures := n.match(splitRoutePath(path), make([]routeCapture, 0, 4))
+	if found == nil {
+		return nil, nil
+	}
+
+	params := make(map[string]string, len(found.keys))
+	for i, key := range found.keys {
+		if i < len(captures) && captures[i].ok {
+			params[key] = captures[i].value
+		}
+	}
+	return found, params
+}
@@ -3,38 +3,59 @@ package builtins
 import (
 	"BanglaCode/src/object"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
 	"sync"
 )
 
 // Router represents a modular HTTP router (similar to Express.js Router)
-// Supports all 7 common HTTP methods with Banglish method names
+// Supports all 7 common HTTP methods with Banglish method names.
+// Route patterns may contain named segments (/users/:id), optional
+// segments (/posts/:page?) and a trailing wildcard (/static/*).
 type Router struct {
-	basePath string
-	routes   map[string]map[string]*object.Function // method -> path -> handler
-	mu       sync.RWMutex
+	basePath   string
+	routes     map[string]*routeNode // method -> route tree
+	middleware []routerMiddleware    // middleware and mounted sub-routers, in registration order
+	mu         sync.RWMutex
+}
+
+// routerMiddleware is a middleware function or a sub-router registered
+// with router.bebohar()
+type routerMiddleware struct {
+	prefix  string // "/" applies to every request
+	handler *object.Function
+	router  *Router // set for a mounted sub-router instead of handler
 }
 
 // NewRouter creates a new router instance with support for all HTTP methods
 func NewRouter(basePath string) *Router {
 	return &Router{
 		basePath: basePath,
-		routes: map[string]map[string]*object.Function{
-			"GET":     make(map[string]*object.Function),
-			"POST":    make(map[string]*object.Function),
-			"PUT":     make(map[string]*object.Function),
-			"DELETE":  make(map[string]*object.Function),
-			"PATCH":   make(map[string]*object.Function),
-			"HEAD":    make(map[string]*object.Function),
-			"OPTIONS": make(map[string]*object.Function),
+		routes: map[string]*routeNode{
+			"GET":     newRouteNode(),
+			"POST":    newRouteNode(),
+			"PUT":     newRouteNode(),
+			"DELETE":  newRouteNode(),
+			"PATCH":   newRouteNode(),
+			"HEAD":    newRouteNode(),
+			"OPTIONS": newRouteNode(),
 		},
 	}
 }
 
+// normalizeMountPath ensures a path starts with "/" and has no trailing "/"
+func normalizeMountPath(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if strings.HasSuffix(path, "/") && path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return path
+}
+
 // AddRoute adds a route to the router
-func (r *Router) AddRoute(method, path string, handler *object.Function) {
+func (r *Router) AddRoute(method, path string, handler *object.Function, middleware ...*object.Function) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -45,16 +66,44 @@ func (r *Router) AddRoute(method, path string, handler *object.Function) {
 
 	// Store route
 	if r.routes[method] == nil {
-		r.routes[method] = make(map[string]*object.Function)
+		r.routes[method] = newRouteNode()
 	}
-	r.routes[method][path] = handler
+	r.routes[method].insert(path, handler, middleware)
+}
+
+// Use registers a middleware that runs for every request whose path
+// starts with prefix ("/" for all requests)
+func (r *Router) Use(prefix string, handler *object.Function) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.middleware = append(r.middleware, routerMiddleware{
+		prefix:  normalizeMountPath(prefix),
+		handler: handler,
+	})
 }
 
 // GetHandler finds a handler for the given method and path
 func (r *Router) GetHandler(method, path string) (*object.Function, bool) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	handler, _, ok := r.Match(method, path)
+	return handler, ok
+}
+
+// Match finds a handler for the given method and path and returns
+// the values captured by named and wildcard segments
+func (r *Router) Match(method, path string) (*object.Function, map[string]string, bool) {
+	chain, params, found := r.Resolve(method, path)
+	if !found {
+		return nil, nil, false
+	}
+	return chain[len(chain)-1], params, true
+}
 
+// Resolve returns the handler chain for a request: matching router
+// middleware, then per-route middleware, then the route handler. Middleware
+// applies whether or not a route matches; when none does, the chain holds
+// only the middleware and found is false.
+func (r *Router) Resolve(method, path string) (chain []*object.Function, params map[string]string, found bool) {
 	// Remove base path if present
 	if r.basePath != "" && strings.HasPrefix(path, r.basePath) {
 		path = strings.TrimPrefix(path, r.basePath)
@@ -63,293 +112,218 @@ func (r *Router) GetHandler(method, path string) (*object.Function, bool) {
 		}
 	}
 
-	handler, ok := r.routes[method][path]
-	return handler, ok
+	chain, route, params := r.resolve(method, path)
+	if route == nil {
+		return chain, nil, false
+	}
+	chain = append(chain, route.middleware...)
+	chain = append(chain, route.handler)
+	return chain, params, true
 }
 
-// MountSubRouter mounts a sub-router at a specific path
-func (r *Router) MountSubRouter(mountPath string, subRouter *Router) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+// resolve collects the middleware that applies to path, in registration
+// order, and finds its route. Mounted sub-routers are consulted now rather
+// than copied when mounted, so routes and middleware added to them later
+// still apply; the router's own routes are tried before theirs.
+func (r *Router) resolve(method, path string) ([]*object.Function, *routeNode, map[string]string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-	// Normalize mount path
-	if !strings.HasPrefix(mountPath, "/") {
-		mountPath = "/" + mountPath
-	}
-	if strings.HasSuffix(mountPath, "/") && mountPath != "/" {
-		mountPath = strings.TrimSuffix(mountPath, "/")
+	var route *routeNode
+	var params map[string]string
+	if tree, ok := r.routes[method]; ok {
+		route, params = tree.lookup(path)
 	}
 
-	// Update sub-router's base path
-	subRouter.basePath = r.basePath + mountPath
-
-	// Copy all routes from sub-router with updated paths
-	for method, routes := range subRouter.routes {
-		for path, handler := range routes {
-			fullPath := mountPath + path
-			if r.routes[method] == nil {
-				r.routes[method] = make(map[string]*object.Function)
-			}
-			r.routes[method][fullPath] = handler
+	middleware := make([]*object.Function, 0, len(r.middleware)+1)
+	for _, mw := range r.middleware {
+		if !pathHasPrefix(path, mw.prefix) {
+			continue
+		}
+		if mw.router == nil {
+			middleware = append(middleware, mw.handler)
+			continue
+		}
+		subMiddleware, subRoute, subParams := mw.router.resolve(method, mountedPath(path, mw.prefix))
+		middleware = append(middleware, subMiddleware...)
+		if route == nil && subRoute != nil {
+			route, params = subRoute, subParams
 		}
 	}
+	return middleware, route, params
 }
 
-// ServeHTTP implements http.Handler interface
-func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	handler, ok := r.GetHandler(req.Method, req.URL.Path)
-
-	if !ok {
-		http.NotFound(w, req)
-		return
+// mountedPath returns the part of path a router mounted at prefix sees
+func mountedPath(path, prefix string) string {
+	if prefix == "/" {
+		return path
 	}
-
-	// Build request object
-	reqMap := &object.Map{Pairs: make(map[string]object.Object)}
-	reqMap.Pairs["method"] = &object.String{Value: req.Method}
-	reqMap.Pairs["path"] = &object.String{Value: req.URL.Path}
-	reqMap.Pairs["query"] = &object.String{Value: req.URL.RawQuery}
-
-	// Parse headers
-	headersMap := &object.Map{Pairs: make(map[string]object.Object)}
-	for k, v := range req.Header {
-		if len(v) > 0 {
-			headersMap.Pairs[k] = &object.String{Value: v[0]}
-		}
-	}
-	reqMap.Pairs["headers"] = headersMap
-
-	// Read body
-	body, _ := io.ReadAll(req.Body)
-	reqMap.Pairs["body"] = &object.String{Value: string(body)}
-
-	// Build response object
-	resMap := &object.Map{Pairs: make(map[string]object.Object)}
-	resMap.Pairs["status"] = &object.Number{Value: 200}
-	resMap.Pairs["body"] = &object.String{Value: ""}
-	resMap.Pairs["headers"] = &object.Map{Pairs: make(map[string]object.Object)}
-
-	// Execute handler
-	var result object.Object
-	if EvalFunc != nil {
-		result = EvalFunc(handler, []object.Object{reqMap, resMap})
+	if path = strings.TrimPrefix(path, prefix); path == "" {
+		return "/"
 	}
+	return path
+}
 
-	// Write response
-	if statusObj, ok := resMap.Pairs["status"]; ok {
-		if status, ok := statusObj.(*object.Number); ok {
-			w.WriteHeader(int(status.Value))
-		}
+// pathHasPrefix reports whether path is prefix or lies below it
+func pathHasPrefix(path, prefix string) bool {
+	if prefix == "/" || path == prefix {
+		return true
 	}
+	return strings.HasPrefix(path, prefix+"/")
+}
 
-	if headersObj, ok := resMap.Pairs["headers"]; ok {
-		if headers, ok := headersObj.(*object.Map); ok {
-			for k, v := range headers.Pairs {
-				w.Header().Set(k, v.Inspect())
-			}
-		}
+// MountSubRouter mounts a sub-router at a specific path. Requests below
+// the path are resolved by the sub-router when they arrive.
+func (r *Router) MountSubRouter(mountPath string, subRouter *Router) error {
+	if subRouter.reaches(r) {
+		return fmt.Errorf("cannot mount a router inside itself")
 	}
 
-	if bodyObj, ok := resMap.Pairs["body"]; ok {
-		fmt.Fprint(w, bodyObj.Inspect())
-	} else if result != nil && result != object.NULL {
-		fmt.Fprint(w, result.Inspect())
-	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.middleware = append(r.middleware, routerMiddleware{
+		prefix: normalizeMountPath(mountPath),
+		router: subRouter,
+	})
+	return nil
 }
 
-func init() {
-	// router_banao (রাউটার বানাও - create router)
-	Builtins["router_banao"] = &object.Builtin{
-		Fn: func(args ...object.Object) object.Object {
-			router := NewRouter("")
+// reaches reports whether target is r or mounted somewhere below it
+func (r *Router) reaches(target *Router) bool {
+	if r == target {
+		return true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, mw := range r.middleware {
+		if mw.router != nil && mw.router.reaches(target) {
+			return true
+		}
+	}
+	return false
+}
 
-			// Create a map to represent the router with methods
-			routerMap := &object.Map{Pairs: make(map[string]object.Object)}
+// ServeHTTP implements http.Handler interface. The middleware runs before
+// the route lookup, so a request no route matches still passes through it
+// before getting a 404.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	chain, params, found := r.Resolve(req.Method, req.URL.Path)
 
-			// Store the actual router instance (we'll use this internally)
-			routerMap.Pairs["__internal_router__"] = &object.String{Value: fmt.Sprintf("%p", router)}
+	reqMap := buildRequestMap(req, params)
+	resMap := newResponseMap()
 
-			// Add ana method (আনা - GET - fetch)
-			routerMap.Pairs["ana"] = &object.Builtin{
-				Fn: func(args ...object.Object) object.Object {
-					if len(args) != 2 {
-						return newError("wrong number of arguments to router.ana(). got=%d, want=2", len(args))
-					}
-					if args[0].Type() != object.STRING_OBJ {
-						return newError("first argument to router.ana() must be STRING (path), got %s", args[0].Type())
-					}
-					if args[1].Type() != object.FUNCTION_OBJ {
-						return newError("second argument to router.ana() must be FUNCTION (handler), got %s", args[1].Type())
-					}
+	var final func() object.Object
+	if !found {
+		final = notFound(resMap)
+	}
+	result := handleOnLoop(chain, final, reqMap, resMap)
 
-					path := args[0].(*object.String).Value
-					handler := args[1].(*object.Function)
-					router.AddRoute("GET", path, handler)
+	writeResponse(w, resMap, result)
+}
 
-					return routerMap // Return router for chaining
-				},
+// routeMethodBuiltin creates a router method such as router.ana(path, [middleware...], handler)
+func routeMethodBuiltin(router *Router, routerMap *object.Map, name, method string) *object.Builtin {
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 {
+				return newError("wrong number of arguments to router.%s(). got=%d, want=2 or more", name, len(args))
 			}
-
-			// Add pathano method (পাঠানো - POST - send)
-			routerMap.Pairs["pathano"] = &object.Builtin{
-				Fn: func(args ...object.Object) object.Object {
-					if len(args) != 2 {
-						return newError("wrong number of arguments to router.pathano(). got=%d, want=2", len(args))
-					}
-					if args[0].Type() != object.STRING_OBJ {
-						return newError("first argument to router.pathano() must be STRING (path), got %s", args[0].Type())
-					}
-					if args[1].Type() != object.FUNCTION_OBJ {
-						return newError("second argument to router.pathano() must be FUNCTION (handler), got %s", args[1].Type())
-					}
-
-					path := args[0].(*object.String).Value
-					handler := args[1].(*object.Function)
-					router.AddRoute("POST", path, handler)
-
-					return routerMap
-				},
+			if args[0].Type() != object.STRING_OBJ {
+				return newError("first argument to router.%s() must be STRING (path), got %s", name, args[0].Type())
 			}
 
-			// Add bodlano method (বদলানো - PUT - update/change)
-			routerMap.Pairs["bodlano"] = &object.Builtin{
-				Fn: func(args ...object.Object) object.Object {
-					if len(args) != 2 {
-						return newError("wrong number of arguments to router.bodlano(). got=%d, want=2", len(args))
-					}
-					if args[0].Type() != object.STRING_OBJ {
-						return newError("first argument to router.bodlano() must be STRING (path), got %s", args[0].Type())
-					}
-					if args[1].Type() != object.FUNCTION_OBJ {
-						return newError("second argument to router.bodlano() must be FUNCTION (handler), got %s", args[1].Type())
-					}
-
-					path := args[0].(*object.String).Value
-					handler := args[1].(*object.Function)
-					router.AddRoute("PUT", path, handler)
-
-					return routerMap
-				},
+			funcs := make([]*object.Function, 0, len(args)-1)
+			for _, arg := range args[1:] {
+				fn, ok := arg.(*object.Function)
+				if !ok {
+					return newError("handler arguments to router.%s() must be FUNCTION, got %s", name, arg.Type())
+				}
+				funcs = append(funcs, fn)
 			}
 
-			// Add mujhe_felo method (মুছে ফেলো - DELETE - remove)
-			routerMap.Pairs["mujhe_felo"] = &object.Builtin{
-				Fn: func(args ...object.Object) object.Object {
-					if len(args) != 2 {
-						return newError("wrong number of arguments to router.mujhe_felo(). got=%d, want=2", len(args))
-					}
-					if args[0].Type() != object.STRING_OBJ {
-						return newError("first argument to router.mujhe_felo() must be STRING (path), got %s", args[0].Type())
-					}
-					if args[1].Type() != object.FUNCTION_OBJ {
-						return newError("second argument to router.mujhe_felo() must be FUNCTION (handler), got %s", args[1].Type())
-					}
-
-					path := args[0].(*object.String).Value
-					handler := args[1].(*object.Function)
-					router.AddRoute("DELETE", path, handler)
-
-					return routerMap
-				},
-			}
+			path := args[0].(*object.String).Value
+			handler := funcs[len(funcs)-1]
+			router.AddRoute(method, path, handler, funcs[:len(funcs)-1]...)
 
-			// Add songshodhon method (সংশোধন - PATCH - modify)
-			routerMap.Pairs["songshodhon"] = &object.Builtin{
-				Fn: func(args ...object.Object) object.Object {
-					if len(args) != 2 {
-						return newError("wrong number of arguments to router.songshodhon(). got=%d, want=2", len(args))
-					}
-					if args[0].Type() != object.STRING_OBJ {
-						return newError("first argument to router.songshodhon() must be STRING (path), got %s", args[0].Type())
-					}
-					if args[1].Type() != object.FUNCTION_OBJ {
-						return newError("second argument to router.songshodhon() must be FUNCTION (handler), got %s", args[1].Type())
-					}
+			return routerMap // Return router for chaining
+		},
+	}
+}
 
-					path := args[0].(*object.String).Value
-					handler := args[1].(*object.Function)
-					router.AddRoute("PATCH", path, handler)
+func init() {
+	// router_banao (রাউটার বানাও - create router)
+	Builtins["router_banao"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			router := NewRouter("")
 
-					return routerMap
-				},
-			}
+			// Create a map to represent the router with methods
+			routerMap := object.NewMap()
 
-			// Add matha method (মাথা - HEAD - retrieve headers)
-			routerMap.Pairs["matha"] = &object.Builtin{
+			// Store the actual router instance (we'll use this internally)
+			routerMap.Set("__internal_router__", &object.String{Value: fmt.Sprintf("%p", router)})
+
+			// ana (আনা - GET - fetch)
+			routerMap.Set("ana", routeMethodBuiltin(router, routerMap, "ana", "GET"))
+			// pathano (পাঠানো - POST - send)
+			routerMap.Set("pathano", routeMethodBuiltin(router, routerMap, "pathano", "POST"))
+			// bodlano (বদলানো - PUT - update/change)
+			routerMap.Set("bodlano", routeMethodBuiltin(router, routerMap, "bodlano", "PUT"))
+			// mujhe_felo (মুছে ফেলো - DELETE - remove)
+			routerMap.Set("mujhe_felo", routeMethodBuiltin(router, routerMap, "mujhe_felo", "DELETE"))
+			// songshodhon (সংশোধন - PATCH - modify)
+			routerMap.Set("songshodhon", routeMethodBuiltin(router, routerMap, "songshodhon", "PATCH"))
+			// matha (মাথা - HEAD - retrieve headers)
+			routerMap.Set("matha", routeMethodBuiltin(router, routerMap, "matha", "HEAD"))
+			// nirdharon (নির্ধারণ - OPTIONS - determine options)
+			routerMap.Set("nirdharon", routeMethodBuiltin(router, routerMap, "nirdharon", "OPTIONS"))
+
+			// Add bebohār method (ব্যবহার - use middleware or mount sub-router)
+			//   router.bebohar(middleware)
+			//   router.bebohar("/path", middleware)
+			//   router.bebohar("/path", subRouter)
+			routerMap.Set("bebohar", &object.Builtin{
 				Fn: func(args ...object.Object) object.Object {
-					if len(args) != 2 {
-						return newError("wrong number of arguments to router.matha(). got=%d, want=2", len(args))
-					}
-					if args[0].Type() != object.STRING_OBJ {
-						return newError("first argument to router.matha() must be STRING (path), got %s", args[0].Type())
-					}
-					if args[1].Type() != object.FUNCTION_OBJ {
-						return newError("second argument to router.matha() must be FUNCTION (handler), got %s", args[1].Type())
+					if len(args) == 1 {
+						fn, ok := args[0].(*object.Function)
+						if !ok {
+							return newError("argument to router.bebohar() must be FUNCTION (middleware), got %s", args[0].Type())
+						}
+						router.Use("/", fn)
+						return routerMap
 					}
-
-					path := args[0].(*object.String).Value
-					handler := args[1].(*object.Function)
-					router.AddRoute("HEAD", path, handler)
-
-					return routerMap
-				},
-			}
-
-			// Add nirdharon method (নির্ধারণ - OPTIONS - determine options)
-			routerMap.Pairs["nirdharon"] = &object.Builtin{
-				Fn: func(args ...object.Object) object.Object {
 					if len(args) != 2 {
-						return newError("wrong number of arguments to router.nirdharon(). got=%d, want=2", len(args))
+						return newError("wrong number of arguments to router.bebohar(). got=%d, want=1 or 2", len(args))
 					}
 					if args[0].Type() != object.STRING_OBJ {
-						return newError("first argument to router.nirdharon() must be STRING (path), got %s", args[0].Type())
-					}
-					if args[1].Type() != object.FUNCTION_OBJ {
-						return newError("second argument to router.nirdharon() must be FUNCTION (handler), got %s", args[1].Type())
+						return newError("first argument to router.bebohar() must be STRING (mount path), got %s", args[0].Type())
 					}
 
-					path := args[0].(*object.String).Value
-					handler := args[1].(*object.Function)
-					router.AddRoute("OPTIONS", path, handler)
+					mountPath := args[0].(*object.String).Value
 
-					return routerMap
-				},
-			}
-			// Add bebohār method (ব্যবহার - use/mount sub-router)
-			routerMap.Pairs["bebohar"] = &object.Builtin{
-				Fn: func(args ...object.Object) object.Object {
-					if len(args) != 2 {
-						return newError("wrong number of arguments to router.bebohar(). got=%d, want=2", len(args))
-					}
-					if args[0].Type() != object.STRING_OBJ {
-						return newError("first argument to router.bebohar() must be STRING (mount path), got %s", args[0].Type())
+					if fn, ok := args[1].(*object.Function); ok {
+						router.Use(mountPath, fn)
+						return routerMap
 					}
+
 					if args[1].Type() != object.MAP_OBJ {
-						return newError("second argument to router.bebohar() must be ROUTER (sub-router), got %s", args[1].Type())
+						return newError("second argument to router.bebohar() must be ROUTER (sub-router) or FUNCTION (middleware), got %s", args[1].Type())
 					}
 
-					mountPath := args[0].(*object.String).Value
-					subRouterMap := args[1].(*object.Map)
-
-					// Extract the internal router pointer
-					if internalObj, ok := subRouterMap.Pairs["__internal_router__"]; ok {
-						if _, ok := internalObj.(*object.String); ok {
-							// In a real implementation, we'd store routers in a global map
-							// For now, we'll recreate the logic
-							subRouter := NewRouter("")
-
-							// Mount the sub-router
-							router.MountSubRouter(mountPath, subRouter)
+					// Look up the sub-router in the registry and mount it
+					if subRouter, found := LookupRouter(args[1]); found {
+						if err := router.MountSubRouter(mountPath, subRouter); err != nil {
+							return newError("router.bebohar(): %v", err)
 						}
+						return routerMap
 					}
-
-					return routerMap
+					return newError("second argument to router.bebohar() must be ROUTER (sub-router), got invalid router object")
 				},
-			}
+			})
 
 			// Store router in global registry for server_chalu to use
 			registerRouter(router)
-			routerMap.Pairs["__router_id__"] = &object.String{Value: fmt.Sprintf("%p", router)}
+			routerMap.Set("__router_id__", &object.String{Value: fmt.Sprintf("%p", router)})
 
 			return routerMap
 		},
@@ -369,6 +343,19 @@ func registerRouter(r *Router) {
 	routerRegistry[id] = r
 }
 
+// LookupRouter returns the Router behind a router_banao() object
+func LookupRouter(obj object.Object) (*Router, bool) {
+	routerMap, ok := obj.(*object.Map)
+	if !ok {
+		return nil, false
+	}
+	idObj, ok := routerMap.Pairs["__router_id__"].(*object.String)
+	if !ok {
+		return nil, false
+	}
+	return getRouter(idObj.Value)
+}
+
 func getRouter(id string) (*Router, bool) {
 	routerRegistryMu.RLock()
 	defer routerRegistryMu.RUnlock()
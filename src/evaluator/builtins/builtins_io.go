@@ -1,6 +1,7 @@
 package builtins
 
 import (
+	"BanglaCode/src/eventloop"
 	"BanglaCode/src/object"
 	"io"
 	"os"
@@ -8,6 +9,9 @@ import (
 	"time"
 )
 
+// fileWatchers maps each file_dekhun watcher to its polling timer
+var fileWatchers = map[*object.Map]int{}
+
 func init() {
 	// Read file - poro (পড়ো - read)
 	Builtins["poro"] = &object.Builtin{
@@ -191,44 +195,30 @@ func init() {
 			lastModTime := initialInfo.ModTime()
 
 			// Create watcher object
-			watcher := &object.Map{
-				Pairs: make(map[string]object.Object),
-			}
-			watcher.Pairs["path"] = &object.String{Value: path}
-			watcher.Pairs["active"] = object.TRUE
-
-			// Start watching in goroutine
-			go func() {
-				ticker := time.NewTicker(1 * time.Second)
-				defer ticker.Stop()
-
-				for range ticker.C {
-					// Check if watcher is still active
-					if active, ok := watcher.Pairs["active"]; ok {
-						if b, ok := active.(*object.Boolean); ok && !b.Value {
-							return // Stop watching
-						}
-					}
-
-					// Check for file changes
-					info, err := os.Stat(path)
-					if err != nil {
-						continue // File might have been deleted
-					}
+			watcher := object.NewMap()
+			watcher.Set("path", &object.String{Value: path})
+			watcher.Set("active", object.TRUE)
+
+			// Poll once a second on the event loop until stopped
+			fileWatchers[watcher] = eventloop.AddTimer(time.Second, time.Second, func() {
+				// Check for file changes
+				info, err := os.Stat(path)
+				if err != nil {
+					return // File might have been deleted
+				}
 
-					if info.ModTime().After(lastModTime) {
-						lastModTime = info.ModTime()
+				if info.ModTime().After(lastModTime) {
+					lastModTime = info.ModTime()
 
-						// Call callback with event type and filename
-						if EvalFunc != nil {
-							EvalFunc(callback, []object.Object{
-								&object.String{Value: "change"},
-								&object.String{Value: filepath.Base(path)},
-							})
-						}
+					// Call callback with event type and filename
+					if EvalFunc != nil {
+						EvalFunc(callback, []object.Object{
+							&object.String{Value: "change"},
+							&object.String{Value: filepath.Base(path)},
+						})
 					}
 				}
-			}()
+			})
 
 			return watcher
 		},
@@ -245,7 +235,11 @@ func init() {
 			}
 
 			watcher := args[0].(*object.Map)
-			watcher.Pairs["active"] = object.FALSE
+			watcher.Set("active", object.FALSE)
+			if id, ok := fileWatchers[watcher]; ok {
+				eventloop.ClearTimer(id)
+				delete(fileWatchers, watcher)
+			}
 			return object.TRUE
 		},
 	}
@@ -308,8 +302,8 @@ func init() {
 func extendFunctionEnvForCallback(fn *object.Function, args []object.Object) *object.Environment {
 	env := object.NewEnclosedEnvironment(fn.Env)
 	for i, param := range fn.Parameters {
-		if i < len(args) {
-			env.Set(param.Value, args[i])
+		if i < len(args) && param.Name != nil {
+			env.Set(param.Name.Value, args[i])
 		}
 	}
 	return env
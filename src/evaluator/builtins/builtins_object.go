@@ -26,8 +26,8 @@ func registerObjectValues() {
 		}
 		mapObj := args[0].(*object.Map)
 		values := make([]object.Object, 0, len(mapObj.Pairs))
-		for _, value := range mapObj.Pairs {
-			values = append(values, value)
+		for _, key := range mapObj.Keys() {
+			values = append(values, mapObj.Pairs[key])
 		}
 		return &object.Array{Elements: values}
 	}}
@@ -43,8 +43,8 @@ func registerObjectEntries() {
 		}
 		mapObj := args[0].(*object.Map)
 		entries := make([]object.Object, 0, len(mapObj.Pairs))
-		for key, value := range mapObj.Pairs {
-			entry := &object.Array{Elements: []object.Object{&object.String{Value: key}, value}}
+		for _, key := range mapObj.Keys() {
+			entry := &object.Array{Elements: []object.Object{&object.String{Value: key}, mapObj.Pairs[key]}}
 			entries = append(entries, entry)
 		}
 		return &object.Array{Elements: entries}
@@ -65,8 +65,8 @@ func registerObjectAssign() {
 				return newError("argument %d to `mishra` must be MAP, got %s", i+1, args[i].Type())
 			}
 			source := args[i].(*object.Map)
-			for key, value := range source.Pairs {
-				target.Pairs[key] = value
+			for _, key := range source.Keys() {
+				target.Set(key, source.Pairs[key])
 			}
 		}
 		return target
@@ -99,7 +99,7 @@ func registerObjectFromEntries() {
 			return newError("argument to `jora_theke` must be ARRAY, got %s", args[0].Type())
 		}
 		entries := args[0].(*object.Array)
-		result := make(map[string]object.Object, len(entries.Elements))
+		result := object.NewMap()
 		for i, entryObj := range entries.Elements {
 			entry, ok := entryObj.(*object.Array)
 			if !ok || len(entry.Elements) < 2 {
@@ -109,9 +109,9 @@ func registerObjectFromEntries() {
 			if key == "" && entry.Elements[0].Type() != object.STRING_OBJ && entry.Elements[0].Type() != object.NUMBER_OBJ {
 				return newError("entry key at index %d must be STRING or NUMBER", i)
 			}
-			result[key] = entry.Elements[1]
+			result.Set(key, entry.Elements[1])
 		}
-		return &object.Map{Pairs: result}
+		return result
 	}}
 }
 
@@ -140,18 +140,19 @@ func registerObjectCreate() {
 		if args[0].Type() != object.MAP_OBJ && args[0].Type() != object.NULL_OBJ {
 			return newError("first argument to `notun_map` must be MAP or NULL, got %s", args[0].Type())
 		}
-		out := &object.Map{Pairs: make(map[string]object.Object)}
-		if args[0].Type() == object.MAP_OBJ {
-			for k, v := range args[0].(*object.Map).Pairs {
-				out.Pairs[k] = v
+		out := object.NewMap()
+		if proto, ok := args[0].(*object.Map); ok {
+			for _, k := range proto.Keys() {
+				out.Set(k, proto.Pairs[k])
 			}
 		}
 		if len(args) == 2 {
 			if args[1].Type() != object.MAP_OBJ {
 				return newError("second argument to `notun_map` must be MAP, got %s", args[1].Type())
 			}
-			for k, v := range args[1].(*object.Map).Pairs {
-				out.Pairs[k] = v
+			props := args[1].(*object.Map)
+			for _, k := range props.Keys() {
+				out.Set(k, props.Pairs[k])
 			}
 		}
 		return out
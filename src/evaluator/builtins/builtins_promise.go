@@ -0,0 +1,238 @@
+package builtins
+
+import (
+	"BanglaCode/src/eventloop"
+	"BanglaCode/src/object"
+	"fmt"
+	"time"
+)
+
+func init() {
+	Builtins["prothom_proyash"] = &object.Builtin{Fn: promiseRace}
+	Builtins["sob_nishpotti"] = &object.Builtin{Fn: promiseAllSettled}
+	Builtins["jekono_proyash"] = &object.Builtin{Fn: promiseAny}
+	Builtins["somoy_shima"] = &object.Builtin{Fn: promiseTimeout}
+
+	// Promise - notun Promise((resolve, reject) => ...) plus the combinators
+	// as static members, so callback-style APIs can be wrapped
+	Builtins["Promise"] = &object.Builtin{
+		Fn: newPromiseFromExecutor,
+		Members: map[string]object.Object{
+			"all":        Builtins["sob_proyash"],
+			"race":       Builtins["prothom_proyash"],
+			"allSettled": Builtins["sob_nishpotti"],
+			"any":        Builtins["jekono_proyash"],
+			"timeout":    Builtins["somoy_shima"],
+			"resolve": &object.Builtin{Fn: func(args ...object.Object) object.Object {
+				promise := object.NewPromise()
+				object.ResolveWith(promise, firstArg(args))
+				return promise
+			}},
+			"reject": &object.Builtin{Fn: func(args ...object.Object) object.Object {
+				promise := object.NewPromise()
+				object.RejectPromise(promise, object.Rejection(firstArg(args)))
+				return promise
+			}},
+		},
+	}
+
+	// AbortController - notun AbortController() gives {signal, abort}; pass
+	// the signal as the last argument of timers, HTTP and database calls
+	Builtins["AbortController"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 0 {
+				return newError("wrong number of arguments. got=%d, want=0", len(args))
+			}
+			signal := &object.AbortSignal{}
+			controller := object.NewMap()
+			controller.Set("signal", signal)
+			controller.Set("abort", &object.Builtin{Fn: func(args ...object.Object) object.Object {
+				signal.Abort(firstArg(args))
+				return object.NULL
+			}})
+			return controller
+		},
+	}
+}
+
+// newPromiseFromExecutor runs executor(resolve, reject) straight away; the
+// first call to either settles the promise and later calls are ignored. An
+// executor that throws rejects it.
+func newPromiseFromExecutor(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+	executor, ok := args[0].(*object.Function)
+	if !ok {
+		return newError("argument to `Promise` must be FUNCTION executor, got %s", args[0].Type())
+	}
+
+	promise := object.NewPromise()
+	done := false
+	resolve := &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		if !done {
+			done = true
+			object.ResolveWith(promise, firstArg(args))
+		}
+		return object.NULL
+	}}
+	reject := &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		if !done {
+			done = true
+			object.RejectPromise(promise, object.Rejection(firstArg(args)))
+		}
+		return object.NULL
+	}}
+
+	result := EvalFunc(executor, []object.Object{resolve, reject})
+	switch result.(type) {
+	case *object.Error, *object.Exception:
+		if !done {
+			done = true
+			object.RejectPromise(promise, result)
+		}
+	}
+	return promise
+}
+
+// promiseRace (prothom_proyash) settles the same way as the first promise
+// to settle
+func promiseRace(args ...object.Object) object.Object {
+	promises, errObj := promiseList("prothom_proyash", args)
+	if errObj != nil {
+		return errObj
+	}
+
+	resultPromise := object.NewPromise()
+	for _, p := range promises {
+		p.OnSettle(func() { object.SettleLike(resultPromise, p) })
+	}
+	return resultPromise
+}
+
+// promiseAllSettled (sob_nishpotti) waits for every promise and resolves to
+// one {status, value} or {status, reason} map per promise
+func promiseAllSettled(args ...object.Object) object.Object {
+	promises, errObj := promiseList("sob_nishpotti", args)
+	if errObj != nil {
+		return errObj
+	}
+
+	resultPromise := object.NewPromise()
+	results := make([]object.Object, len(promises))
+	remaining := len(promises)
+	if remaining == 0 {
+		object.ResolvePromise(resultPromise, &object.Array{Elements: results})
+	}
+
+	for i, p := range promises {
+		p.OnSettle(func() {
+			result, rejected := p.Result()
+			entry := object.NewMap()
+			if rejected {
+				entry.Set("status", &object.String{Value: "rejected"})
+				entry.Set("reason", object.RejectionReason(result))
+			} else {
+				entry.Set("status", &object.String{Value: "fulfilled"})
+				entry.Set("value", result)
+			}
+			results[i] = entry
+			if remaining--; remaining == 0 {
+				object.ResolvePromise(resultPromise, &object.Array{Elements: results})
+			}
+		})
+	}
+	return resultPromise
+}
+
+// promiseAny (jekono_proyash) resolves with the first promise to resolve, or
+// rejects with an AggregateError holding every reason once all have rejected
+func promiseAny(args ...object.Object) object.Object {
+	promises, errObj := promiseList("jekono_proyash", args)
+	if errObj != nil {
+		return errObj
+	}
+
+	resultPromise := object.NewPromise()
+	reasons := make([]object.Object, len(promises))
+	remaining := len(promises)
+	rejectAll := func() {
+		exc := object.NewException("AggregateError", "all promises were rejected")
+		exc.Value.(*object.Map).Set("errors", &object.Array{Elements: reasons})
+		object.RejectPromise(resultPromise, exc)
+	}
+	if remaining == 0 {
+		rejectAll()
+	}
+
+	for i, p := range promises {
+		p.OnSettle(func() {
+			result, rejected := p.Result()
+			if !rejected {
+				object.ResolvePromise(resultPromise, result)
+				return
+			}
+			reasons[i] = object.RejectionReason(result)
+			if remaining--; remaining == 0 {
+				rejectAll()
+			}
+		})
+	}
+	return resultPromise
+}
+
+// promiseTimeout (somoy_shima) settles like promise, or rejects with a
+// TimeoutError if it has not settled within ms milliseconds
+func promiseTimeout(args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+	promise, ok := args[0].(*object.Promise)
+	if !ok {
+		return newError("first argument to `somoy_shima` must be PROMISE, got %s", args[0].Type())
+	}
+	if args[1].Type() != object.NUMBER_OBJ {
+		return newError("second argument to `somoy_shima` must be NUMBER milliseconds, got %s", args[1].Type())
+	}
+
+	ms := int64(args[1].(*object.Number).Value)
+	resultPromise := object.NewPromise()
+	timer := eventloop.AddTimer(time.Duration(ms)*time.Millisecond, 0, func() {
+		message := fmt.Sprintf("promise did not settle within %dms", ms)
+		object.RejectPromise(resultPromise, object.NewException("TimeoutError", message))
+	})
+	promise.OnSettle(func() {
+		eventloop.ClearTimer(timer)
+		object.SettleLike(resultPromise, promise)
+	})
+	return resultPromise
+}
+
+// promiseList checks that a combinator got one array of promises
+func promiseList(name string, args []object.Object) ([]*object.Promise, *object.Error) {
+	if len(args) != 1 {
+		return nil, newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+	arr, ok := args[0].(*object.Array)
+	if !ok {
+		return nil, newError("argument to `%s` must be ARRAY, got %s", name, args[0].Type())
+	}
+
+	promises := make([]*object.Promise, len(arr.Elements))
+	for i, el := range arr.Elements {
+		p, ok := el.(*object.Promise)
+		if !ok {
+			return nil, newError("all elements must be promises, got %s at index %d", el.Type(), i)
+		}
+		promises[i] = p
+	}
+	return promises, nil
+}
+
+// firstArg returns the first argument, or khali when there is none
+func firstArg(args []object.Object) object.Object {
+	if len(args) == 0 {
+		return object.NULL
+	}
+	return args[0]
+}
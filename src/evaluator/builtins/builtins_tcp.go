@@ -1,8 +1,11 @@
 package builtins
 
 import (
+	"BanglaCode/src/eventloop"
 	"BanglaCode/src/object"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -46,16 +49,58 @@ func removeTCPConnection(id string) {
 	}
 }
 
+// tcpIterator makes a connection object iterable with ghuriye opekha:
+// each step reads the next chunk, like tcp_shuno, and the loop ends when
+// the connection is closed
+func tcpIterator(connID string) *object.Builtin {
+	next := &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			promise := object.CreatePromise()
+
+			conn, ok := getTCPConnection(connID)
+			if !ok {
+				object.ResolvePromise(promise, object.IteratorResult(object.NULL, true))
+				return promise
+			}
+
+			go func() {
+				buffer := make([]byte, 4096)
+				n, err := conn.Read(buffer)
+				if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+					object.ResolvePromise(promise, object.IteratorResult(object.NULL, true))
+					return
+				}
+				if err != nil {
+					object.RejectPromise(promise, newError("TCP read error: %s", err.Error()))
+					return
+				}
+				object.ResolvePromise(promise, object.IteratorResult(&object.String{Value: string(buffer[:n])}, false))
+			}()
+
+			return promise
+		},
+	}
+
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			iterator := object.NewMap()
+			iterator.Set("next", next)
+			return iterator
+		},
+	}
+}
+
 // handleTCPConnection handles incoming TCP connections with callback
 func handleTCPConnection(conn net.Conn, handler *object.Function) {
 	// Create connection object
-	connObj := &object.Map{Pairs: make(map[string]object.Object)}
+	connObj := object.NewMap()
 	connID := generateTCPConnectionID()
 	storeTCPConnection(connID, conn)
 
-	connObj.Pairs["id"] = &object.String{Value: connID}
-	connObj.Pairs["remote_addr"] = &object.String{Value: conn.RemoteAddr().String()}
-	connObj.Pairs["local_addr"] = &object.String{Value: conn.LocalAddr().String()}
+	connObj.Set("id", &object.String{Value: connID})
+	connObj.Set("remote_addr", &object.String{Value: conn.RemoteAddr().String()})
+	connObj.Set("local_addr", &object.String{Value: conn.LocalAddr().String()})
+	connObj.Set("iterator", tcpIterator(connID))
 
 	// Read data loop
 	buffer := make([]byte, 4096)
@@ -68,13 +113,15 @@ func handleTCPConnection(conn net.Conn, handler *object.Function) {
 		}
 
 		if n > 0 {
-			// Update connection object with received data
-			connObj.Pairs["data"] = &object.String{Value: string(buffer[:n])}
-
-			// Call user handler
-			if EvalFunc != nil {
-				EvalFunc(handler, []object.Object{connObj})
-			}
+			// Update connection object with received data and call the
+			// user handler on the event loop
+			data := &object.String{Value: string(buffer[:n])}
+			eventloop.Post(func() {
+				connObj.Set("data", data)
+				if EvalFunc != nil {
+					EvalFunc(handler, []object.Object{connObj})
+				}
+			})
 		}
 	}
 }
@@ -108,10 +155,16 @@ func init() {
 				return newError("TCP server error: %s", err.Error())
 			}
 
-			// Accept connections in goroutine
+			// Accept connections in goroutine; a listening server keeps
+			// the event loop alive
+			eventloop.Ref()
 			go func() {
 				for {
 					conn, err := listener.Accept()
+					if errors.Is(err, net.ErrClosed) {
+						eventloop.Unref()
+						return
+					}
 					if err != nil {
 						continue
 					}
@@ -161,15 +214,16 @@ func init() {
 				}
 
 				// Create connection object
-				connObj := &object.Map{Pairs: make(map[string]object.Object)}
+				connObj := object.NewMap()
 				connID := generateTCPConnectionID()
 				storeTCPConnection(connID, conn)
 
-				connObj.Pairs["id"] = &object.String{Value: connID}
-				connObj.Pairs["host"] = &object.String{Value: host}
-				connObj.Pairs["port"] = &object.Number{Value: float64(port)}
-				connObj.Pairs["remote_addr"] = &object.String{Value: conn.RemoteAddr().String()}
-				connObj.Pairs["local_addr"] = &object.String{Value: conn.LocalAddr().String()}
+				connObj.Set("id", &object.String{Value: connID})
+				connObj.Set("host", &object.String{Value: host})
+				connObj.Set("port", &object.Number{Value: float64(port)})
+				connObj.Set("remote_addr", &object.String{Value: conn.RemoteAddr().String()})
+				connObj.Set("local_addr", &object.String{Value: conn.LocalAddr().String()})
+				connObj.Set("iterator", tcpIterator(connID))
 
 				object.ResolvePromise(promise, connObj)
 			}()
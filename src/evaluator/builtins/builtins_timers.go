@@ -1,23 +1,11 @@
 package builtins
 
 import (
+	"BanglaCode/src/eventloop"
 	"BanglaCode/src/object"
-	"sync"
 	"time"
 )
 
-var (
-	timerMu     sync.Mutex
-	nextTimerID float64 = 1
-	timeouts            = map[int]chan struct{}{}
-	intervals           = map[int]*intervalControl{}
-)
-
-type intervalControl struct {
-	stop chan struct{}
-	done chan struct{}
-}
-
 func init() {
 	registerSetTimeout()
 	registerSetInterval()
@@ -27,28 +15,25 @@ func init() {
 
 func registerSetTimeout() {
 	Builtins["setTimeout"] = &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		args, signal := object.TakeSignal(args)
 		cb, cbArgs, ms, errObj := parseTimerArgs("setTimeout", args)
 		if errObj != nil {
 			return errObj
 		}
 
-		id, stopCh := newTimerID(true)
-		go func() {
-			select {
-			case <-time.After(time.Duration(ms) * time.Millisecond):
-				EvalFunc(cb, cbArgs)
-			case <-stopCh:
-			}
-			timerMu.Lock()
-			delete(timeouts, id)
-			timerMu.Unlock()
-		}()
+		stop := func() {}
+		id := eventloop.AddTimer(time.Duration(ms)*time.Millisecond, 0, func() {
+			stop()
+			EvalFunc(cb, cbArgs)
+		})
+		stop = clearOnAbort(id, signal)
 		return &object.Number{Value: float64(id)}
 	}}
 }
 
 func registerSetInterval() {
 	Builtins["setInterval"] = &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		args, signal := object.TakeSignal(args)
 		cb, cbArgs, ms, errObj := parseTimerArgs("setInterval", args)
 		if errObj != nil {
 			return errObj
@@ -57,40 +42,24 @@ func registerSetInterval() {
 			ms = 1
 		}
 
-		id, ctrl := newIntervalID()
-		go func() {
-			ticker := time.NewTicker(time.Duration(ms) * time.Millisecond)
-			defer ticker.Stop()
-			defer close(ctrl.done)
-			for {
-				select {
-				case <-ticker.C:
-					select {
-					case <-ctrl.stop:
-						removeInterval(id)
-						return
-					default:
-					}
-					EvalFunc(cb, cbArgs)
-				case <-ctrl.stop:
-					removeInterval(id)
-					return
-				}
-			}
-		}()
+		period := time.Duration(ms) * time.Millisecond
+		id := eventloop.AddTimer(period, period, func() {
+			EvalFunc(cb, cbArgs)
+		})
+		clearOnAbort(id, signal)
 		return &object.Number{Value: float64(id)}
 	}}
 }
 
 func registerClearTimeout() {
 	Builtins["clearTimeout"] = &object.Builtin{Fn: func(args ...object.Object) object.Object {
-		return clearTimeoutTimer(args)
+		return clearTimer(args)
 	}}
 }
 
 func registerClearInterval() {
 	Builtins["clearInterval"] = &object.Builtin{Fn: func(args ...object.Object) object.Object {
-		return clearIntervalTimer(args)
+		return clearTimer(args)
 	}}
 }
 
@@ -113,83 +82,24 @@ func parseTimerArgs(name string, args []object.Object) (*object.Function, []obje
 	return cb, cbArgs, ms, nil
 }
 
-func newTimerID(timeout bool) (int, chan struct{}) {
-	timerMu.Lock()
-	defer timerMu.Unlock()
-
-	id := int(nextTimerID)
-	nextTimerID++
-	stopCh := make(chan struct{}, 1)
-	if timeout {
-		timeouts[id] = stopCh
-	}
-	return id, stopCh
-}
-
-func newIntervalID() (int, *intervalControl) {
-	timerMu.Lock()
-	defer timerMu.Unlock()
-
-	id := int(nextTimerID)
-	nextTimerID++
-	ctrl := &intervalControl{
-		stop: make(chan struct{}),
-		done: make(chan struct{}),
-	}
-	intervals[id] = ctrl
-	return id, ctrl
-}
-
-func clearTimeoutTimer(args []object.Object) object.Object {
-	if len(args) != 1 {
-		return newError("wrong number of arguments. got=%d, want=1", len(args))
-	}
-	if args[0].Type() != object.NUMBER_OBJ {
-		return newError("argument must be NUMBER timer id, got %s", args[0].Type())
-	}
-	id := int(args[0].(*object.Number).Value)
-
-	timerMu.Lock()
-	ch, ok := timeouts[id]
-	if ok {
-		select {
-		case ch <- struct{}{}:
-		default:
-		}
-		delete(timeouts, id)
-	}
-	timerMu.Unlock()
-	return object.NULL
-}
-
-func clearIntervalTimer(args []object.Object) object.Object {
+// clearTimer cancels a setTimeout or setInterval by id; timers share one
+// id space, so either clear function cancels either kind
+func clearTimer(args []object.Object) object.Object {
 	if len(args) != 1 {
 		return newError("wrong number of arguments. got=%d, want=1", len(args))
 	}
 	if args[0].Type() != object.NUMBER_OBJ {
 		return newError("argument must be NUMBER timer id, got %s", args[0].Type())
 	}
-	id := int(args[0].(*object.Number).Value)
-
-	timerMu.Lock()
-	ctrl, ok := intervals[id]
-	timerMu.Unlock()
-	if ok {
-		close(ctrl.stop)
-		<-ctrl.done
-	}
+	eventloop.ClearTimer(int(args[0].(*object.Number).Value))
 	return object.NULL
 }
 
-func removeInterval(id int) {
-	timerMu.Lock()
-	delete(intervals, id)
-	timerMu.Unlock()
-}
-
-func clearTimer(args []object.Object, timeout bool) object.Object {
-	if timeout {
-		return clearTimeoutTimer(args)
+// clearOnAbort cancels a timer when signal aborts; the returned function
+// stops watching the signal
+func clearOnAbort(id int, signal *object.AbortSignal) func() {
+	if signal == nil {
+		return func() {}
 	}
-	return clearIntervalTimer(args)
+	return signal.OnAbort(func() { eventloop.ClearTimer(id) })
 }
@@ -1,7 +1,9 @@
 package builtins
 
 import (
+	"BanglaCode/src/eventloop"
 	"BanglaCode/src/object"
+	"errors"
 	"fmt"
 	"net"
 	"sync"
@@ -82,18 +84,24 @@ func init() {
 				return newError("UDP server error: %s", err.Error())
 			}
 
-			// Listen for packets in goroutine
+			// Listen for packets in goroutine; a listening server keeps
+			// the event loop alive until it is closed
+			eventloop.Ref()
 			go func() {
 				buffer := make([]byte, 4096)
 				for {
 					n, remoteAddr, err := conn.ReadFromUDP(buffer)
+					if errors.Is(err, net.ErrClosed) {
+						eventloop.Unref()
+						return
+					}
 					if err != nil {
 						continue
 					}
 
 					if n > 0 {
 						// Create packet object
-						packet := &object.Map{Pairs: make(map[string]object.Object)}
+						packet := object.NewMap()
 						connID := generateUDPConnectionID()
 
 						// Store connection for response capability
@@ -102,15 +110,17 @@ func init() {
 							RemoteAddr: remoteAddr,
 						})
 
-						packet.Pairs["id"] = &object.String{Value: connID}
-						packet.Pairs["data"] = &object.String{Value: string(buffer[:n])}
-						packet.Pairs["remote_addr"] = &object.String{Value: remoteAddr.String()}
-						packet.Pairs["local_addr"] = &object.String{Value: conn.LocalAddr().String()}
+						packet.Set("id", &object.String{Value: connID})
+						packet.Set("data", &object.String{Value: string(buffer[:n])})
+						packet.Set("remote_addr", &object.String{Value: remoteAddr.String()})
+						packet.Set("local_addr", &object.String{Value: conn.LocalAddr().String()})
 
-						// Call user handler
-						if EvalFunc != nil {
-							EvalFunc(handler, []object.Object{packet})
-						}
+						// Call user handler on the event loop
+						eventloop.Post(func() {
+							if EvalFunc != nil {
+								EvalFunc(handler, []object.Object{packet})
+							}
+						})
 					}
 				}
 			}()
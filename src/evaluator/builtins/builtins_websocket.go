@@ -1,6 +1,7 @@
 package builtins
 
 import (
+	"BanglaCode/src/eventloop"
 	"BanglaCode/src/object"
 	"fmt"
 	"net/http"
@@ -60,21 +61,23 @@ func removeWSConnection(id string) {
 // handleWebSocketConnection handles incoming WebSocket connections
 func handleWebSocketConnection(conn *websocket.Conn, handler *object.Function) {
 	// Create connection object
-	connObj := &object.Map{Pairs: make(map[string]object.Object)}
+	connObj := object.NewMap()
 	connID := generateWSConnectionID()
 	storeWSConnection(connID, conn)
 
-	connObj.Pairs["id"] = &object.String{Value: connID}
-	connObj.Pairs["remote_addr"] = &object.String{Value: conn.RemoteAddr().String()}
-	connObj.Pairs["local_addr"] = &object.String{Value: conn.LocalAddr().String()}
-	connObj.Pairs["connected"] = &object.Boolean{Value: true}
+	connObj.Set("id", &object.String{Value: connID})
+	connObj.Set("remote_addr", &object.String{Value: conn.RemoteAddr().String()})
+	connObj.Set("local_addr", &object.String{Value: conn.LocalAddr().String()})
+	connObj.Set("connected", &object.Boolean{Value: true})
 
 	// Read messages loop
 	for {
 		messageType, message, err := conn.ReadMessage()
 		if err != nil {
 			// Connection closed or error
-			connObj.Pairs["connected"] = &object.Boolean{Value: false}
+			eventloop.Post(func() {
+				connObj.Set("connected", &object.Boolean{Value: false})
+			})
 			removeWSConnection(connID)
 			break
 		}
@@ -91,14 +94,15 @@ func handleWebSocketConnection(conn *websocket.Conn, handler *object.Function) {
 			msgType = "pong"
 		}
 
-		// Update connection object with message data
-		connObj.Pairs["message"] = &object.String{Value: string(message)}
-		connObj.Pairs["type"] = &object.String{Value: msgType}
-
-		// Call user handler
-		if EvalFunc != nil {
-			EvalFunc(handler, []object.Object{connObj})
-		}
+		// Update connection object with message data and call the user
+		// handler on the event loop
+		eventloop.Post(func() {
+			connObj.Set("message", &object.String{Value: string(message)})
+			connObj.Set("type", &object.String{Value: msgType})
+			if EvalFunc != nil {
+				EvalFunc(handler, []object.Object{connObj})
+			}
+		})
 
 		// Break if close message
 		if messageType == websocket.CloseMessage {
@@ -142,8 +146,11 @@ func init() {
 				go handleWebSocketConnection(conn, handler)
 			})
 
-			// Start server in goroutine
+			// Start server in goroutine; it keeps the event loop alive
+			// while it runs
+			eventloop.Ref()
 			go func() {
+				defer eventloop.Unref()
 				addr := fmt.Sprintf(":%d", port)
 				if err := http.ListenAndServe(addr, nil); err != nil {
 					// Server error (ignore for now as it's in goroutine)
@@ -182,15 +189,15 @@ func init() {
 				}
 
 				// Create connection object
-				connObj := &object.Map{Pairs: make(map[string]object.Object)}
+				connObj := object.NewMap()
 				connID := generateWSConnectionID()
 				storeWSConnection(connID, conn)
 
-				connObj.Pairs["id"] = &object.String{Value: connID}
-				connObj.Pairs["url"] = &object.String{Value: url}
-				connObj.Pairs["connected"] = &object.Boolean{Value: true}
-				connObj.Pairs["remote_addr"] = &object.String{Value: conn.RemoteAddr().String()}
-				connObj.Pairs["local_addr"] = &object.String{Value: conn.LocalAddr().String()}
+				connObj.Set("id", &object.String{Value: connID})
+				connObj.Set("url", &object.String{Value: url})
+				connObj.Set("connected", &object.Boolean{Value: true})
+				connObj.Set("remote_addr", &object.String{Value: conn.RemoteAddr().String()})
+				connObj.Set("local_addr", &object.String{Value: conn.LocalAddr().String()})
 
 				object.ResolvePromise(promise, connObj)
 			}()
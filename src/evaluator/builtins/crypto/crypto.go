@@ -160,11 +160,11 @@ var Builtins = map[string]*object.Builtin{
 			})
 
 			// Return as map with privateKey and publicKey
-			result := make(map[string]object.Object)
-			result["privateKey"] = &object.String{Value: string(privateKeyPEM)}
-			result["publicKey"] = &object.String{Value: string(publicKeyPEM)}
+			result := object.NewMap()
+			result.Set("privateKey", &object.String{Value: string(privateKeyPEM)})
+			result.Set("publicKey", &object.String{Value: string(publicKeyPEM)})
 
-			return &object.Map{Pairs: result}
+			return result
 		},
 	},
 
@@ -0,0 +1,205 @@
+// Package cursor turns a lazily fetched result set into a BanglaCode
+// iterator, so that large queries can be read one row at a time with
+// ghuriye instead of being loaded into memory by db_query_*.
+package cursor
+
+import (
+	"BanglaCode/src/evaluator/builtins/streams"
+	"BanglaCode/src/object"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Source produces the rows of a cursor one at a time
+type Source interface {
+	// Next returns the next row, or nil once there are no more
+	Next() (*object.Map, error)
+	// Close releases the underlying rows or server-side cursor
+	Close() error
+}
+
+// encodeJSON serializes the rows pipe() writes to a stream
+var encodeJSON = func(obj object.Object) string { return obj.Inspect() }
+
+// SetJSONEncoder sets the encoder pipe() uses, the same one json_banao uses
+func SetJSONEncoder(fn func(object.Object) string) {
+	encodeJSON = fn
+}
+
+type cursor struct {
+	name   string
+	source Source
+	closed bool
+}
+
+// New wraps source in a cursor map:
+//
+//	next()       - {value: row, done: mittha}, or {done: sotti} at the end
+//	return()     - closes the cursor; ghuriye calls it on thamo, ferao or an error
+//	close()      - closes the cursor
+//	pipe(stream) - writes every remaining row to stream as a line of JSON
+//	iterator()   - the cursor itself, which makes it iterable
+//
+// The cursor closes itself when it runs out of rows or fails. name is the
+// builtin that opened it, used in error messages.
+func New(name string, source Source) *object.Map {
+	c := &cursor{name: name, source: source}
+
+	m := object.NewMap()
+	m.Set("next", method(func(args ...object.Object) object.Object { return c.next() }))
+	m.Set("return", method(func(args ...object.Object) object.Object {
+		if errObj := c.close(); errObj != nil {
+			return errObj
+		}
+		return doneResult()
+	}))
+	m.Set("close", method(func(args ...object.Object) object.Object {
+		if errObj := c.close(); errObj != nil {
+			return errObj
+		}
+		return object.NULL
+	}))
+	m.Set("pipe", method(c.pipe))
+	m.Set("iterator", method(func(args ...object.Object) object.Object { return m }))
+	return m
+}
+
+func method(fn object.BuiltinFunction) *object.Builtin {
+	return &object.Builtin{Fn: fn}
+}
+
+// next is the iterator protocol's next()
+func (c *cursor) next() object.Object {
+	row, errObj := c.read()
+	if errObj != nil {
+		return errObj
+	}
+	if row == nil {
+		return doneResult()
+	}
+	result := object.NewMap()
+	result.Set("value", row)
+	result.Set("done", object.FALSE)
+	return result
+}
+
+// read fetches the next row, closing the cursor once it is exhausted or fails
+func (c *cursor) read() (*object.Map, *object.Error) {
+	if c.closed {
+		return nil, nil
+	}
+	row, err := c.source.Next()
+	if err != nil {
+		c.close()
+		return nil, newError("%s: %s", c.name, err.Error())
+	}
+	if row == nil {
+		return nil, c.close()
+	}
+	return row, nil
+}
+
+func (c *cursor) close() *object.Error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	if err := c.source.Close(); err != nil {
+		return newError("%s: %s", c.name, err.Error())
+	}
+	return nil
+}
+
+// pipe writes the remaining rows to a stream as newline-delimited JSON and
+// returns how many it wrote. A readable stream is ended afterwards so that
+// ghuriye opekha over it finishes.
+func (c *cursor) pipe(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("pipe: wrong number of arguments. got=%d, want=1 (stream)", len(args))
+	}
+	stream, ok := args[0].(*object.Stream)
+	if !ok {
+		return newError("pipe: argument must be a Stream, got %s", args[0].Type())
+	}
+
+	written := 0
+	for {
+		row, errObj := c.read()
+		if errObj != nil {
+			return errObj
+		}
+		if row == nil {
+			break
+		}
+		line := &object.String{Value: encodeJSON(row) + "\n"}
+		if result := streams.Builtins["stream_lekho"].Fn(stream, line); result.Type() == object.ERROR_OBJ {
+			c.close()
+			return result
+		}
+		written++
+	}
+
+	if stream.StreamType == "readable" {
+		streams.Builtins["stream_shesh"].Fn(stream)
+	}
+	return &object.Number{Value: float64(written)}
+}
+
+func doneResult() *object.Map {
+	result := object.NewMap()
+	result.Set("value", object.NULL)
+	result.Set("done", object.TRUE)
+	return result
+}
+
+func newError(format string, a ...interface{}) *object.Error {
+	return &object.Error{Message: fmt.Sprintf(format, a...)}
+}
+
+// sqlRows reads a *sql.Rows one row at a time
+type sqlRows struct {
+	rows    *sql.Rows
+	columns []string
+	cancel  context.CancelFunc
+	convert func(interface{}) object.Object
+}
+
+// Rows is the Source for a SQL query. convert maps each column value to an
+// object the way the driver's db_query_* does, and cancel aborts the query
+// when the cursor is closed before its last row.
+func Rows(rows *sql.Rows, cancel context.CancelFunc, convert func(interface{}) object.Object) (Source, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		cancel()
+		return nil, err
+	}
+	return &sqlRows{rows: rows, columns: columns, cancel: cancel, convert: convert}, nil
+}
+
+func (r *sqlRows) Next() (*object.Map, error) {
+	if !r.rows.Next() {
+		return nil, r.rows.Err()
+	}
+
+	values := make([]interface{}, len(r.columns))
+	valuePtrs := make([]interface{}, len(r.columns))
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+	if err := r.rows.Scan(valuePtrs...); err != nil {
+		return nil, err
+	}
+
+	row := object.NewMap()
+	for i, col := range r.columns {
+		row.Set(col, r.convert(values[i]))
+	}
+	return row, nil
+}
+
+func (r *sqlRows) Close() error {
+	defer r.cancel()
+	return r.rows.Close()
+}
@@ -0,0 +1,311 @@
+package database
+
+import (
+	"BanglaCode/src/evaluator/builtins/database/sqlbuilder"
+	"BanglaCode/src/object"
+	"fmt"
+	"sort"
+)
+
+// model maps a sreni onto one SQL table
+type model struct {
+	class      *object.Class
+	conn       *object.DBConnection
+	dialect    *sqlbuilder.Dialect
+	table      string
+	primaryKey string
+	columns    []string // declared columns; nil means "every scalar property"
+}
+
+// registerModels registers db_model, which turns a sreni into a table model
+func registerModels() {
+	// db_model(User, conn) reads sthir table / primaryKey / columns from the
+	// sreni and adds User.khojo(), User.query(), ei.save() and ei.delete()
+	Builtins["db_model"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("db_model: wrong number of arguments. got=%d, want=2 (sreni, conn)", len(args))
+			}
+
+			class, ok := args[0].(*object.Class)
+			if !ok {
+				return newError("db_model: first argument must be CLASS, got %s", args[0].Type())
+			}
+			conn, ok := args[1].(*object.DBConnection)
+			if !ok {
+				return newError("db_model: second argument must be DB_CONNECTION, got %s", args[1].Type())
+			}
+
+			m, err := newModel(class, conn)
+			if err != nil {
+				return newError("db_model: %s", err.Error())
+			}
+			m.install()
+			return class
+		},
+	}
+}
+
+// newModel reads the table mapping declared on the sreni
+func newModel(class *object.Class, conn *object.DBConnection) (*model, error) {
+	dialect, ok := sqlbuilder.LookupDialect(conn.DBType)
+	if !ok {
+		return nil, fmt.Errorf("%s does not support SQL models. Supported: postgres, mysql, sqlite", conn.DBType)
+	}
+
+	m := &model{class: class, conn: conn, dialect: dialect, primaryKey: "id"}
+
+	table, ok := class.StaticProperties["table"].(*object.String)
+	if !ok {
+		return nil, fmt.Errorf("sreni %s must declare 'sthir table = \"name\";'", class.Name)
+	}
+	m.table = table.Value
+
+	if pk, exists := class.StaticProperties["primaryKey"]; exists {
+		str, ok := pk.(*object.String)
+		if !ok {
+			return nil, fmt.Errorf("%s.primaryKey must be STRING, got %s", class.Name, pk.Type())
+		}
+		m.primaryKey = str.Value
+	}
+
+	if cols, exists := class.StaticProperties["columns"]; exists {
+		arr, ok := cols.(*object.Array)
+		if !ok {
+			return nil, fmt.Errorf("%s.columns must be ARRAY, got %s", class.Name, cols.Type())
+		}
+		names, err := stringArgs(arr.Elements)
+		if err != nil {
+			return nil, fmt.Errorf("%s.columns: %s", class.Name, err.Error())
+		}
+		m.columns = names
+	}
+
+	// Check the names once here rather than on every query
+	q, err := m.query()
+	if err != nil {
+		return nil, err
+	}
+	if err := q.builder.Select(append([]string{m.primaryKey}, m.columns...)...); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// install adds the model's static and instance methods to the sreni
+func (m *model) install() {
+	m.class.StaticProperties["khojo"] = &object.Builtin{Fn: m.khojo}
+	m.class.StaticProperties["query"] = &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		q, err := m.query()
+		if err != nil {
+			return newError("%s.query: %s", m.class.Name, err.Error())
+		}
+		return q.object()
+	}}
+
+	if m.class.NativeMethods == nil {
+		m.class.NativeMethods = make(map[string]object.NativeMethod)
+	}
+	m.class.NativeMethods["save"] = m.save
+	m.class.NativeMethods["delete"] = m.delete
+}
+
+// query starts a builder on the model's table whose rows come back as instances
+func (m *model) query() (*tableQuery, error) {
+	builder, err := sqlbuilder.New(m.dialect, m.table)
+	if err != nil {
+		return nil, err
+	}
+	return &tableQuery{builder: builder, conn: m.conn, wrap: m.instance}, nil
+}
+
+// instance builds a sreni instance from a row without running shuru
+func (m *model) instance(row *object.Map) object.Object {
+	inst := &object.Instance{
+		Class:         m.class,
+		Properties:    make(map[string]object.Object, len(row.Pairs)),
+		PrivateFields: make(map[string]object.Object),
+	}
+	for key, val := range row.Pairs {
+		inst.Properties[key] = val
+	}
+	return inst
+}
+
+// khojo() returns every row, khojo({col: val}) the matching rows and
+// khojo(id) the row with that primary key or khali
+func (m *model) khojo(args ...object.Object) object.Object {
+	name := m.class.Name + ".khojo"
+	if len(args) > 1 {
+		return newError("%s: wrong number of arguments. got=%d, want=0 or 1", name, len(args))
+	}
+
+	q, err := m.query()
+	if err != nil {
+		return newError("%s: %s", name, err.Error())
+	}
+	if len(args) == 0 {
+		sqlText, params := q.builder.Build()
+		return q.rows(name, sqlText, params)
+	}
+
+	switch filter := args[0].(type) {
+	case *object.Map:
+		if err := whereClause("AND")(q.builder, args); err != nil {
+			return newError("%s: %s", name, err.Error())
+		}
+		sqlText, params := q.builder.Build()
+		return q.rows(name, sqlText, params)
+	case *object.Number, *object.String:
+		if err := q.builder.Where("AND", m.primaryKey, "=", filter); err != nil {
+			return newError("%s: %s", name, err.Error())
+		}
+		q.builder.Limit(1)
+		sqlText, params := q.builder.Build()
+		rows := q.rows(name, sqlText, params)
+		arr, ok := rows.(*object.Array)
+		if !ok {
+			return rows
+		}
+		if len(arr.Elements) == 0 {
+			return object.NULL
+		}
+		return arr.Elements[0]
+	default:
+		return newError("%s: argument must be MAP (filter) or the primary key, got %s", name, args[0].Type())
+	}
+}
+
+// save updates the row when the primary key is set and a row matches,
+// otherwise inserts it and stores the generated key on the instance
+func (m *model) save(inst *object.Instance, args ...object.Object) object.Object {
+	name := m.class.Name + ".save"
+	if len(args) != 0 {
+		return newError("%s: wrong number of arguments. got=%d, want=0", name, len(args))
+	}
+
+	columns, values := m.values(inst)
+	pk, hasPK := inst.Properties[m.primaryKey]
+	hasPK = hasPK && pk != object.NULL
+
+	if hasPK && len(columns) > 0 {
+		q, err := m.query()
+		if err != nil {
+			return newError("%s: %s", name, err.Error())
+		}
+		if err := q.builder.Update(columns, values); err != nil {
+			return newError("%s: %s", name, err.Error())
+		}
+		if err := q.builder.Where("AND", m.primaryKey, "=", pk); err != nil {
+			return newError("%s: %s", name, err.Error())
+		}
+		sqlText, params := q.builder.Build()
+		result := q.exec(name, sqlText, params)
+		resultMap, ok := result.(*object.Map)
+		if !ok {
+			return result
+		}
+		if affected, ok := resultMap.Pairs["rows_affected"].(*object.Number); ok && affected.Value > 0 {
+			return inst
+		}
+	}
+
+	// Insert, keeping an explicit primary key if one was given
+	if hasPK {
+		columns = append([]string{m.primaryKey}, columns...)
+		values = append([]object.Object{pk}, values...)
+	}
+	if len(columns) == 0 {
+		return newError("%s: %s has no columns to save", name, m.class.Name)
+	}
+
+	q, err := m.query()
+	if err != nil {
+		return newError("%s: %s", name, err.Error())
+	}
+	if err := q.builder.Insert(columns, [][]object.Object{values}); err != nil {
+		return newError("%s: %s", name, err.Error())
+	}
+	if m.dialect.Returning {
+		if err := q.builder.Returning(m.primaryKey); err != nil {
+			return newError("%s: %s", name, err.Error())
+		}
+	}
+
+	sqlText, params := q.builder.Build()
+	result := q.exec(name, sqlText, params)
+	resultMap, ok := result.(*object.Map)
+	if !ok {
+		return result
+	}
+
+	if !hasPK {
+		if rows, ok := resultMap.Pairs["rows"].(*object.Array); ok && len(rows.Elements) > 0 {
+			inst.Properties[m.primaryKey] = rows.Elements[0].(*object.Map).Pairs[m.primaryKey]
+		} else if id, ok := resultMap.Pairs["last_insert_id"].(*object.Number); ok && id.Value > 0 {
+			inst.Properties[m.primaryKey] = id
+		}
+	}
+	return inst
+}
+
+// delete removes the instance's row and reports whether one was deleted
+func (m *model) delete(inst *object.Instance, args ...object.Object) object.Object {
+	name := m.class.Name + ".delete"
+	if len(args) != 0 {
+		return newError("%s: wrong number of arguments. got=%d, want=0", name, len(args))
+	}
+
+	pk, ok := inst.Properties[m.primaryKey]
+	if !ok || pk == object.NULL {
+		return newError("%s: instance has no %s; save it first", name, m.primaryKey)
+	}
+
+	q, err := m.query()
+	if err != nil {
+		return newError("%s: %s", name, err.Error())
+	}
+	q.builder.Delete()
+	if err := q.builder.Where("AND", m.primaryKey, "=", pk); err != nil {
+		return newError("%s: %s", name, err.Error())
+	}
+
+	sqlText, params := q.builder.Build()
+	result := q.exec(name, sqlText, params)
+	resultMap, ok := result.(*object.Map)
+	if !ok {
+		return result
+	}
+	affected, _ := resultMap.Pairs["rows_affected"].(*object.Number)
+	return object.NativeBoolToBooleanObject(affected != nil && affected.Value > 0)
+}
+
+// values returns the non-key columns to persist and their values: the
+// declared columns, or every scalar property in name order
+func (m *model) values(inst *object.Instance) ([]string, []object.Object) {
+	columns := m.columns
+	if columns == nil {
+		for key, val := range inst.Properties {
+			switch val.(type) {
+			case *object.Number, *object.String, *object.Boolean, *object.Null:
+				columns = append(columns, key)
+			}
+		}
+		sort.Strings(columns)
+	}
+
+	var names []string
+	var values []object.Object
+	for _, col := range columns {
+		if col == m.primaryKey {
+			continue
+		}
+		val, ok := inst.Properties[col]
+		if !ok {
+			val = object.NULL
+		}
+		names = append(names, col)
+		values = append(values, val)
+	}
+	return names, values
+}
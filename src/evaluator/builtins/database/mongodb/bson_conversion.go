@@ -77,13 +77,13 @@ func bsonToObject(value interface{}) object.Object {
 		}
 		return &object.Array{Elements: elements}
 	case bson.M:
-		return &object.Map{Pairs: bsonToMap(v)}
+		return object.NewMapFrom(bsonToMap(v))
 	case map[string]interface{}:
 		pairs := make(map[string]object.Object)
 		for k, val := range v {
 			pairs[k] = bsonToObject(val)
 		}
-		return &object.Map{Pairs: pairs}
+		return object.NewMapFrom(pairs)
 	default:
 		return &object.String{Value: fmt.Sprintf("%v", v)}
 	}
@@ -1,7 +1,9 @@
 package mongodb
 
 import (
+	"BanglaCode/src/evaluator/builtins/database/cursor"
 	"BanglaCode/src/object"
+	"context"
 	"fmt"
 )
 
@@ -15,6 +17,7 @@ func init() {
 
 	// Document operations (synchronous)
 	registerBuiltin("db_khojo_mongodb", dbKhojoMongoDB)
+	registerBuiltin("db_cursor_mongodb", dbCursorMongoDB)
 	registerBuiltin("db_dhokao_mongodb", dbDhokaoMongoDB)
 	registerBuiltin("db_update_mongodb", dbUpdateMongoDB)
 	registerBuiltin("db_mujhe_mongodb", dbMujheMongoDB)
@@ -102,7 +105,7 @@ func dbKhojoMongoDB(args ...object.Object) object.Object {
 		return newError("db_khojo_mongodb: third argument must be MAP (filter), got %s", args[2].Type())
 	}
 
-	result, err := Find(conn, collectionName.Value, filter)
+	result, err := Find(context.Background(), conn, collectionName.Value, filter)
 	if err != nil {
 		return newError("db_khojo_mongodb: %s", err.Error())
 	}
@@ -114,6 +117,43 @@ func dbKhojoMongoDB(args ...object.Object) object.Object {
 	return dbResultToMap(result)
 }
 
+// db_cursor_mongodb - Find documents and read them lazily (synchronous)
+// Usage: ghuriye (doc of db_cursor_mongodb(conn, "events", {type: "click"}, {batch_size: 500})) { ... }
+func dbCursorMongoDB(args ...object.Object) object.Object {
+	if len(args) != 3 && len(args) != 4 {
+		return newError("db_cursor_mongodb: wrong number of arguments. got=%d, want=3 or 4 (conn, collection, filter, options?)", len(args))
+	}
+
+	conn, ok := args[0].(*object.DBConnection)
+	if !ok {
+		return newError("db_cursor_mongodb: first argument must be DB_CONNECTION, got %s", args[0].Type())
+	}
+
+	collectionName, ok := args[1].(*object.String)
+	if !ok {
+		return newError("db_cursor_mongodb: second argument must be STRING (collection name), got %s", args[1].Type())
+	}
+
+	filter, ok := args[2].(*object.Map)
+	if !ok {
+		return newError("db_cursor_mongodb: third argument must be MAP (filter), got %s", args[2].Type())
+	}
+
+	options := object.NewMap()
+	if len(args) == 4 {
+		if options, ok = args[3].(*object.Map); !ok {
+			return newError("db_cursor_mongodb: fourth argument must be MAP (options), got %s", args[3].Type())
+		}
+	}
+
+	source, err := Cursor(context.Background(), conn, collectionName.Value, filter, options)
+	if err != nil {
+		return newError("db_cursor_mongodb: %s", err.Error())
+	}
+
+	return cursor.New("db_cursor_mongodb", source)
+}
+
 // db_dhokao_mongodb - Insert document (synchronous)
 // Usage: db_dhokao_mongodb(conn, "users", {name: "Rahim", age: 30})
 func dbDhokaoMongoDB(args ...object.Object) object.Object {
@@ -136,7 +176,7 @@ func dbDhokaoMongoDB(args ...object.Object) object.Object {
 		return newError("db_dhokao_mongodb: third argument must be MAP (document), got %s", args[2].Type())
 	}
 
-	result, err := InsertOne(conn, collectionName.Value, document)
+	result, err := InsertOne(context.Background(), conn, collectionName.Value, document)
 	if err != nil {
 		return newError("db_dhokao_mongodb: %s", err.Error())
 	}
@@ -175,7 +215,7 @@ func dbUpdateMongoDB(args ...object.Object) object.Object {
 		return newError("db_update_mongodb: fourth argument must be MAP (update), got %s", args[3].Type())
 	}
 
-	result, err := UpdateMany(conn, collectionName.Value, filter, update)
+	result, err := UpdateMany(context.Background(), conn, collectionName.Value, filter, update)
 	if err != nil {
 		return newError("db_update_mongodb: %s", err.Error())
 	}
@@ -209,7 +249,7 @@ func dbMujheMongoDB(args ...object.Object) object.Object {
 		return newError("db_mujhe_mongodb: third argument must be MAP (filter), got %s", args[2].Type())
 	}
 
-	result, err := DeleteMany(conn, collectionName.Value, filter)
+	result, err := DeleteMany(context.Background(), conn, collectionName.Value, filter)
 	if err != nil {
 		return newError("db_mujhe_mongodb: %s", err.Error())
 	}
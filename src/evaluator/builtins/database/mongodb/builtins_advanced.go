@@ -76,7 +76,7 @@ func dbFindOneMongoDB(args ...object.Object) object.Object {
 		return object.NULL
 	}
 
-	return &object.Map{Pairs: result.Rows[0]}
+	return result.Row(0)
 }
 
 // db_count_mongodb - Count documents matching filter
@@ -7,6 +7,7 @@ import (
 // Async operations with promise-based responses
 
 func dbKhojoAsyncMongoDB(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
 	if len(args) != 3 {
 		return newError("db_khojo_async_mongodb: wrong number of arguments. got=%d, want=3", len(args))
 	}
@@ -27,9 +28,12 @@ func dbKhojoAsyncMongoDB(args ...object.Object) object.Object {
 	}
 
 	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
 
 	go func() {
-		result, err := Find(conn, collectionName.Value, filter)
+		defer stop()
+		result, err := Find(ctx, conn, collectionName.Value, filter)
 		if err != nil {
 			object.RejectPromise(promise, newError("db_khojo_async_mongodb: %s", err.Error()))
 			return
@@ -38,13 +42,38 @@ func dbKhojoAsyncMongoDB(args ...object.Object) object.Object {
 			object.RejectPromise(promise, result.Error)
 			return
 		}
-		object.ResolvePromise(promise, dbResultToMap(result))
+		found := dbResultToMap(result)
+		found.Set("iterator", rowsIterator(found.Pairs["rows"].(*object.Array)))
+		object.ResolvePromise(promise, found)
 	}()
 
 	return promise
 }
 
+// rowsIterator lets ghuriye opekha walk the documents a find returned:
+// ghuriye opekha (doc of db_khojo_async_mongodb(conn, "users", {})) { ... }
+func rowsIterator(rows *object.Array) *object.Builtin {
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			pos := 0
+			next := &object.Builtin{
+				Fn: func(args ...object.Object) object.Object {
+					if pos >= len(rows.Elements) {
+						return object.IteratorResult(object.NULL, true)
+					}
+					pos++
+					return object.IteratorResult(rows.Elements[pos-1], false)
+				},
+			}
+			iterator := object.NewMap()
+			iterator.Set("next", next)
+			return iterator
+		},
+	}
+}
+
 func dbDhokaoAsyncMongoDB(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
 	if len(args) != 3 {
 		return newError("db_dhokao_async_mongodb: wrong number of arguments. got=%d, want=3", len(args))
 	}
@@ -65,9 +94,12 @@ func dbDhokaoAsyncMongoDB(args ...object.Object) object.Object {
 	}
 
 	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
 
 	go func() {
-		result, err := InsertOne(conn, collectionName.Value, document)
+		defer stop()
+		result, err := InsertOne(ctx, conn, collectionName.Value, document)
 		if err != nil {
 			object.RejectPromise(promise, newError("db_dhokao_async_mongodb: %s", err.Error()))
 			return
@@ -83,6 +115,7 @@ func dbDhokaoAsyncMongoDB(args ...object.Object) object.Object {
 }
 
 func dbUpdateAsyncMongoDB(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
 	if len(args) != 4 {
 		return newError("db_update_async_mongodb: wrong number of arguments. got=%d, want=4", len(args))
 	}
@@ -108,9 +141,12 @@ func dbUpdateAsyncMongoDB(args ...object.Object) object.Object {
 	}
 
 	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
 
 	go func() {
-		result, err := UpdateMany(conn, collectionName.Value, filter, update)
+		defer stop()
+		result, err := UpdateMany(ctx, conn, collectionName.Value, filter, update)
 		if err != nil {
 			object.RejectPromise(promise, newError("db_update_async_mongodb: %s", err.Error()))
 			return
@@ -126,6 +162,7 @@ func dbUpdateAsyncMongoDB(args ...object.Object) object.Object {
 }
 
 func dbMujheAsyncMongoDB(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
 	if len(args) != 3 {
 		return newError("db_mujhe_async_mongodb: wrong number of arguments. got=%d, want=3", len(args))
 	}
@@ -146,9 +183,12 @@ func dbMujheAsyncMongoDB(args ...object.Object) object.Object {
 	}
 
 	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
 
 	go func() {
-		result, err := DeleteMany(conn, collectionName.Value, filter)
+		defer stop()
+		result, err := DeleteMany(ctx, conn, collectionName.Value, filter)
 		if err != nil {
 			object.RejectPromise(promise, newError("db_mujhe_async_mongodb: %s", err.Error()))
 			return
@@ -165,15 +205,15 @@ func dbMujheAsyncMongoDB(args ...object.Object) object.Object {
 
 // dbResultToMap converts a DBResult to a BanglaCode Map
 func dbResultToMap(result *object.DBResult) *object.Map {
-	pairs := make(map[string]object.Object)
+	pairs := object.NewMap()
 
 	rowsArray := &object.Array{Elements: make([]object.Object, len(result.Rows))}
-	for i, row := range result.Rows {
-		rowsArray.Elements[i] = &object.Map{Pairs: row}
+	for i := range result.Rows {
+		rowsArray.Elements[i] = result.Row(i)
 	}
 
-	pairs["rows"] = rowsArray
-	pairs["rows_affected"] = &object.Number{Value: float64(result.RowsAffected)}
+	pairs.Set("rows", rowsArray)
+	pairs.Set("rows_affected", &object.Number{Value: float64(result.RowsAffected)})
 
-	return &object.Map{Pairs: pairs}
+	return pairs
 }
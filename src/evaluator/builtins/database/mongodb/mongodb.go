@@ -1,6 +1,7 @@
 package mongodb
 
 import (
+	"BanglaCode/src/evaluator/builtins/database/cursor"
 	"BanglaCode/src/object"
 	"context"
 	"fmt"
@@ -121,7 +122,7 @@ func GetCollection(conn *object.DBConnection, collectionName string) (*mongo.Col
 }
 
 // Find finds documents matching a filter
-func Find(conn *object.DBConnection, collectionName string, filterMap *object.Map) (*object.DBResult, error) {
+func Find(parent context.Context, conn *object.DBConnection, collectionName string, filterMap *object.Map) (*object.DBResult, error) {
 	collection, _, err := GetCollection(conn, collectionName)
 	if err != nil {
 		return nil, err
@@ -130,7 +131,7 @@ func Find(conn *object.DBConnection, collectionName string, filterMap *object.Ma
 	// Convert filter map to BSON
 	filter := mapToBSON(filterMap)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(parent, 30*time.Second)
 	defer cancel()
 
 	// Find documents
@@ -164,7 +165,7 @@ func Find(conn *object.DBConnection, collectionName string, filterMap *object.Ma
 }
 
 // InsertOne inserts a single document
-func InsertOne(conn *object.DBConnection, collectionName string, doc *object.Map) (*object.DBResult, error) {
+func InsertOne(parent context.Context, conn *object.DBConnection, collectionName string, doc *object.Map) (*object.DBResult, error) {
 	collection, _, err := GetCollection(conn, collectionName)
 	if err != nil {
 		return nil, err
@@ -173,7 +174,7 @@ func InsertOne(conn *object.DBConnection, collectionName string, doc *object.Map
 	// Convert document to BSON
 	bsonDoc := mapToBSON(doc)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(parent, 10*time.Second)
 	defer cancel()
 
 	// Insert document
@@ -191,7 +192,7 @@ func InsertOne(conn *object.DBConnection, collectionName string, doc *object.Map
 }
 
 // UpdateMany updates documents matching a filter
-func UpdateMany(conn *object.DBConnection, collectionName string, filterMap, updateMap *object.Map) (*object.DBResult, error) {
+func UpdateMany(parent context.Context, conn *object.DBConnection, collectionName string, filterMap, updateMap *object.Map) (*object.DBResult, error) {
 	collection, _, err := GetCollection(conn, collectionName)
 	if err != nil {
 		return nil, err
@@ -201,7 +202,7 @@ func UpdateMany(conn *object.DBConnection, collectionName string, filterMap, upd
 	filter := mapToBSON(filterMap)
 	update := mapToBSON(updateMap)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(parent, 10*time.Second)
 	defer cancel()
 
 	// Update documents
@@ -217,7 +218,7 @@ func UpdateMany(conn *object.DBConnection, collectionName string, filterMap, upd
 }
 
 // DeleteMany deletes documents matching a filter
-func DeleteMany(conn *object.DBConnection, collectionName string, filterMap *object.Map) (*object.DBResult, error) {
+func DeleteMany(parent context.Context, conn *object.DBConnection, collectionName string, filterMap *object.Map) (*object.DBResult, error) {
 	collection, _, err := GetCollection(conn, collectionName)
 	if err != nil {
 		return nil, err
@@ -226,7 +227,7 @@ func DeleteMany(conn *object.DBConnection, collectionName string, filterMap *obj
 	// Convert filter to BSON
 	filter := mapToBSON(filterMap)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(parent, 10*time.Second)
 	defer cancel()
 
 	// Delete documents
@@ -368,8 +369,40 @@ func FindWithOptions(conn *object.DBConnection, collectionName string, filterMap
 	}
 
 	filter := mapToBSON(filterMap)
+	findOpts := findOptions(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
+	}
+	defer cursor.Close(ctx)
+
+	// Convert results
+	results := make([]map[string]object.Object, 0)
+	for cursor.Next(ctx) {
+		var doc bson.M
+		if err := cursor.Decode(&doc); err != nil {
+			return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
+		}
+		results = append(results, bsonToMap(doc))
+	}
 
-	// Build find options
+	if err := cursor.Err(); err != nil {
+		return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
+	}
+
+	return &object.DBResult{
+		Rows:         results,
+		RowsAffected: int64(len(results)),
+	}, nil
+}
+
+// findOptions reads sort, limit, skip, projection and batch_size into
+// driver find options
+func findOptions(opts *object.Map) *options.FindOptions {
 	findOpts := options.Find()
 
 	// Sort
@@ -400,33 +433,55 @@ func FindWithOptions(conn *object.DBConnection, collectionName string, filterMap
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+	// Batch size (documents fetched per round trip by a cursor)
+	if batchObj, ok := opts.Pairs["batch_size"]; ok {
+		if batchNum, ok := batchObj.(*object.Number); ok {
+			findOpts.SetBatchSize(int32(batchNum.Value))
+		}
+	}
 
-	cursor, err := collection.Find(ctx, filter, findOpts)
+	return findOpts
+}
+
+// Cursor finds documents matching a filter and returns them as a cursor
+// source that fetches one batch at a time. There is no timeout; closing
+// the source closes the server-side cursor.
+func Cursor(parent context.Context, conn *object.DBConnection, collectionName string, filterMap, opts *object.Map) (cursor.Source, error) {
+	collection, _, err := GetCollection(conn, collectionName)
 	if err != nil {
-		return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
+		return nil, err
 	}
-	defer cursor.Close(ctx)
 
-	// Convert results
-	results := make([]map[string]object.Object, 0)
-	for cursor.Next(ctx) {
-		var doc bson.M
-		if err := cursor.Decode(&doc); err != nil {
-			return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
-		}
-		results = append(results, bsonToMap(doc))
+	ctx, cancel := context.WithCancel(parent)
+	cur, err := collection.Find(ctx, mapToBSON(filterMap), findOptions(opts))
+	if err != nil {
+		cancel()
+		return nil, err
 	}
+	return &documentCursor{cursor: cur, ctx: ctx, cancel: cancel}, nil
+}
 
-	if err := cursor.Err(); err != nil {
-		return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
+// documentCursor reads a *mongo.Cursor one document at a time
+type documentCursor struct {
+	cursor *mongo.Cursor
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (c *documentCursor) Next() (*object.Map, error) {
+	if !c.cursor.Next(c.ctx) {
+		return nil, c.cursor.Err()
 	}
+	var doc bson.M
+	if err := c.cursor.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return object.NewMapFrom(bsonToMap(doc)), nil
+}
 
-	return &object.DBResult{
-		Rows:         results,
-		RowsAffected: int64(len(results)),
-	}, nil
+func (c *documentCursor) Close() error {
+	defer c.cancel()
+	return c.cursor.Close(context.Background())
 }
 
 // CreateIndex creates an index on a collection
@@ -1,7 +1,9 @@
 package mysql
 
 import (
+	"BanglaCode/src/evaluator/builtins/database/cursor"
 	"BanglaCode/src/object"
+	"context"
 	"database/sql"
 	"fmt"
 	"sync"
@@ -19,6 +21,7 @@ func init() {
 	registerBuiltin("db_query_mysql", dbQueryMySQL)
 	registerBuiltin("db_exec_mysql", dbExecMySQL)
 	registerBuiltin("db_proshno_mysql", dbProshnoMySQL)
+	registerBuiltin("db_cursor_mysql", dbCursorMySQL)
 
 	// Query operations (asynchronous)
 	registerBuiltin("db_query_async_mysql", dbQueryAsyncMySQL)
@@ -42,6 +45,29 @@ func newError(format string, a ...interface{}) *object.Error {
 	return &object.Error{Message: fmt.Sprintf(format, a...)}
 }
 
+// executor resolves the first argument of a query builtin: a connection, or
+// a transaction ID from db_transaction_shuru_mysql to run inside it
+func executor(name string, arg object.Object) (Executor, *object.Error) {
+	switch target := arg.(type) {
+	case *object.DBConnection:
+		db, err := Handle(target)
+		if err != nil {
+			return nil, newError("%s: %s", name, err.Error())
+		}
+		return db, nil
+	case *object.String:
+		transactionsMu.RLock()
+		tx, exists := transactions[target.Value]
+		transactionsMu.RUnlock()
+		if !exists {
+			return nil, newError("%s: transaction %s not found", name, target.Value)
+		}
+		return tx, nil
+	default:
+		return nil, newError("%s: first argument must be DB_CONNECTION or STRING (transaction ID), got %s", name, arg.Type())
+	}
+}
+
 // db_jukto_mysql - Connect to MySQL database
 func dbJuktoMySQL(args ...object.Object) object.Object {
 	if len(args) != 1 {
@@ -85,9 +111,9 @@ func dbQueryMySQL(args ...object.Object) object.Object {
 		return newError("db_query_mysql: wrong number of arguments. got=%d, want=2", len(args))
 	}
 
-	conn, ok := args[0].(*object.DBConnection)
-	if !ok {
-		return newError("db_query_mysql: first argument must be DB_CONNECTION, got %s", args[0].Type())
+	ex, errObj := executor("db_query_mysql", args[0])
+	if errObj != nil {
+		return errObj
 	}
 
 	query, ok := args[1].(*object.String)
@@ -95,7 +121,7 @@ func dbQueryMySQL(args ...object.Object) object.Object {
 		return newError("db_query_mysql: second argument must be STRING, got %s", args[1].Type())
 	}
 
-	result, err := Query(conn, query.Value)
+	result, err := Query(context.Background(), ex, query.Value)
 	if err != nil {
 		return newError("db_query_mysql: %s", err.Error())
 	}
@@ -113,9 +139,9 @@ func dbExecMySQL(args ...object.Object) object.Object {
 		return newError("db_exec_mysql: wrong number of arguments. got=%d, want=2", len(args))
 	}
 
-	conn, ok := args[0].(*object.DBConnection)
-	if !ok {
-		return newError("db_exec_mysql: first argument must be DB_CONNECTION, got %s", args[0].Type())
+	ex, errObj := executor("db_exec_mysql", args[0])
+	if errObj != nil {
+		return errObj
 	}
 
 	query, ok := args[1].(*object.String)
@@ -123,7 +149,7 @@ func dbExecMySQL(args ...object.Object) object.Object {
 		return newError("db_exec_mysql: second argument must be STRING, got %s", args[1].Type())
 	}
 
-	result, err := Exec(conn, query.Value)
+	result, err := Exec(context.Background(), ex, query.Value)
 	if err != nil {
 		return newError("db_exec_mysql: %s", err.Error())
 	}
@@ -141,9 +167,9 @@ func dbProshnoMySQL(args ...object.Object) object.Object {
 		return newError("db_proshno_mysql: wrong number of arguments. got=%d, want=3", len(args))
 	}
 
-	conn, ok := args[0].(*object.DBConnection)
-	if !ok {
-		return newError("db_proshno_mysql: first argument must be DB_CONNECTION, got %s", args[0].Type())
+	ex, errObj := executor("db_proshno_mysql", args[0])
+	if errObj != nil {
+		return errObj
 	}
 
 	query, ok := args[1].(*object.String)
@@ -156,7 +182,7 @@ func dbProshnoMySQL(args ...object.Object) object.Object {
 		return newError("db_proshno_mysql: third argument must be ARRAY, got %s", args[2].Type())
 	}
 
-	result, err := PreparedQuery(conn, query.Value, params.Elements)
+	result, err := PreparedQuery(context.Background(), ex, query.Value, params.Elements)
 	if err != nil {
 		return newError("db_proshno_mysql: %s", err.Error())
 	}
@@ -168,16 +194,51 @@ func dbProshnoMySQL(args ...object.Object) object.Object {
 	return dbResultToMap(result)
 }
 
+// db_cursor_mysql - Run a query and read its rows lazily (synchronous)
+// Usage: ghuriye (row of db_cursor_mysql(conn, "SELECT * FROM logs WHERE level = ?", ["error"])) { ... }
+func dbCursorMySQL(args ...object.Object) object.Object {
+	if len(args) != 2 && len(args) != 3 {
+		return newError("db_cursor_mysql: wrong number of arguments. got=%d, want=2 or 3 (conn, query, params?)", len(args))
+	}
+
+	ex, errObj := executor("db_cursor_mysql", args[0])
+	if errObj != nil {
+		return errObj
+	}
+
+	query, ok := args[1].(*object.String)
+	if !ok {
+		return newError("db_cursor_mysql: second argument must be STRING, got %s", args[1].Type())
+	}
+
+	var params []object.Object
+	if len(args) == 3 {
+		arr, ok := args[2].(*object.Array)
+		if !ok {
+			return newError("db_cursor_mysql: third argument must be ARRAY, got %s", args[2].Type())
+		}
+		params = arr.Elements
+	}
+
+	source, err := Cursor(context.Background(), ex, query.Value, params)
+	if err != nil {
+		return newError("db_cursor_mysql: %s", err.Error())
+	}
+
+	return cursor.New("db_cursor_mysql", source)
+}
+
 // Async functions
 
 func dbQueryAsyncMySQL(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
 	if len(args) != 2 {
 		return newError("db_query_async_mysql: wrong number of arguments. got=%d, want=2", len(args))
 	}
 
-	conn, ok := args[0].(*object.DBConnection)
-	if !ok {
-		return newError("db_query_async_mysql: first argument must be DB_CONNECTION, got %s", args[0].Type())
+	ex, errObj := executor("db_query_async_mysql", args[0])
+	if errObj != nil {
+		return errObj
 	}
 
 	query, ok := args[1].(*object.String)
@@ -186,9 +247,12 @@ func dbQueryAsyncMySQL(args ...object.Object) object.Object {
 	}
 
 	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
 
 	go func() {
-		result, err := Query(conn, query.Value)
+		defer stop()
+		result, err := Query(ctx, ex, query.Value)
 		if err != nil {
 			object.RejectPromise(promise, newError("db_query_async_mysql: %s", err.Error()))
 			return
@@ -204,13 +268,14 @@ func dbQueryAsyncMySQL(args ...object.Object) object.Object {
 }
 
 func dbExecAsyncMySQL(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
 	if len(args) != 2 {
 		return newError("db_exec_async_mysql: wrong number of arguments. got=%d, want=2", len(args))
 	}
 
-	conn, ok := args[0].(*object.DBConnection)
-	if !ok {
-		return newError("db_exec_async_mysql: first argument must be DB_CONNECTION, got %s", args[0].Type())
+	ex, errObj := executor("db_exec_async_mysql", args[0])
+	if errObj != nil {
+		return errObj
 	}
 
 	query, ok := args[1].(*object.String)
@@ -219,9 +284,12 @@ func dbExecAsyncMySQL(args ...object.Object) object.Object {
 	}
 
 	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
 
 	go func() {
-		result, err := Exec(conn, query.Value)
+		defer stop()
+		result, err := Exec(ctx, ex, query.Value)
 		if err != nil {
 			object.RejectPromise(promise, newError("db_exec_async_mysql: %s", err.Error()))
 			return
@@ -237,13 +305,14 @@ func dbExecAsyncMySQL(args ...object.Object) object.Object {
 }
 
 func dbProshnoAsyncMySQL(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
 	if len(args) != 3 {
 		return newError("db_proshno_async_mysql: wrong number of arguments. got=%d, want=3", len(args))
 	}
 
-	conn, ok := args[0].(*object.DBConnection)
-	if !ok {
-		return newError("db_proshno_async_mysql: first argument must be DB_CONNECTION, got %s", args[0].Type())
+	ex, errObj := executor("db_proshno_async_mysql", args[0])
+	if errObj != nil {
+		return errObj
 	}
 
 	query, ok := args[1].(*object.String)
@@ -257,9 +326,12 @@ func dbProshnoAsyncMySQL(args ...object.Object) object.Object {
 	}
 
 	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
 
 	go func() {
-		result, err := PreparedQuery(conn, query.Value, params.Elements)
+		defer stop()
+		result, err := PreparedQuery(ctx, ex, query.Value, params.Elements)
 		if err != nil {
 			object.RejectPromise(promise, newError("db_proshno_async_mysql: %s", err.Error()))
 			return
@@ -409,16 +481,16 @@ func dbBulkInsertMySQL(args ...object.Object) object.Object {
 }
 
 func dbResultToMap(result *object.DBResult) *object.Map {
-	pairs := make(map[string]object.Object)
+	pairs := object.NewMap()
 
 	rowsArray := &object.Array{Elements: make([]object.Object, len(result.Rows))}
-	for i, row := range result.Rows {
-		rowsArray.Elements[i] = &object.Map{Pairs: row}
+	for i := range result.Rows {
+		rowsArray.Elements[i] = result.Row(i)
 	}
 
-	pairs["rows"] = rowsArray
-	pairs["rows_affected"] = &object.Number{Value: float64(result.RowsAffected)}
-	pairs["last_insert_id"] = &object.Number{Value: float64(result.LastInsertID)}
+	pairs.Set("rows", rowsArray)
+	pairs.Set("rows_affected", &object.Number{Value: float64(result.RowsAffected)})
+	pairs.Set("last_insert_id", &object.Number{Value: float64(result.LastInsertID)})
 
-	return &object.Map{Pairs: pairs}
+	return pairs
 }
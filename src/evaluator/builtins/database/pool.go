@@ -1,12 +1,21 @@
 package database
 
 import (
+	"BanglaCode/src/evaluator/builtins/database/mongodb"
+	"BanglaCode/src/evaluator/builtins/database/mysql"
+	"BanglaCode/src/evaluator/builtins/database/postgres"
+	"BanglaCode/src/evaluator/builtins/database/redis"
+	"BanglaCode/src/evaluator/builtins/database/sqlite"
 	"BanglaCode/src/object"
+	"context"
 	"database/sql"
 	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // Global pool registry (thread-safe)
@@ -18,70 +27,108 @@ var (
 
 // PoolConfig defines connection pool configuration
 type PoolConfig struct {
-	MaxConns       int           // Maximum connections (default: 10)
-	MinConns       int           // Minimum idle connections (default: 2)
-	MaxIdleTime    time.Duration // Max idle time before closing (default: 5min)
-	ConnectTimeout time.Duration // Connection timeout (default: 10s)
+	MaxConns            int           // Maximum open connections (default: 10)
+	MinConns            int           // Connections kept open while idle (default: 2)
+	MaxIdleTime         time.Duration // Idle time before a connection above MinConns is closed (default: 5min)
+	MaxLifetime         time.Duration // Age at which a connection is replaced; 0 keeps it (default: 0)
+	HealthCheckInterval time.Duration // How often idle connections are pinged, and how long one may sit idle before Get pings it; 0 disables (default: 30s)
+	WaitTimeout         time.Duration // How long Get waits on an exhausted pool; 0 waits forever (default: 30s)
+	ConnectTimeout      time.Duration // Timeout of a health-check ping (default: 10s)
 }
 
 // DefaultPoolConfig returns default pool configuration
 func DefaultPoolConfig() PoolConfig {
 	return PoolConfig{
-		MaxConns:       10,
-		MinConns:       2,
-		MaxIdleTime:    5 * time.Minute,
-		ConnectTimeout: 10 * time.Second,
+		MaxConns:            10,
+		MinConns:            2,
+		MaxIdleTime:         5 * time.Minute,
+		HealthCheckInterval: 30 * time.Second,
+		WaitTimeout:         30 * time.Second,
+		ConnectTimeout:      10 * time.Second,
 	}
 }
 
+// pooledConn is an idle connection with the times the pool ages it by
+type pooledConn struct {
+	conn      *object.DBConnection
+	createdAt time.Time
+	idleSince time.Time
+	checkedAt time.Time // last returned or pinged successfully
+}
+
 // ConnectionPool manages a pool of database connections
 type ConnectionPool struct {
-	id          string
-	dbType      string
-	config      PoolConfig
-	connConfig  map[string]interface{}    // Connection configuration
-	conns       chan *object.DBConnection // Buffered channel for available connections
-	activeConns int64                     // Atomic counter for active connections
-	totalConns  int64                     // Atomic counter for total connections created
-	mu          sync.RWMutex
-	closed      bool
-	closeChan   chan struct{} // Signal to stop cleanup goroutine
+	id         string
+	dbType     string
+	config     PoolConfig
+	connConfig map[string]interface{}             // Connection configuration
+	idle       chan *pooledConn                   // Connections ready to be borrowed
+	slots      chan struct{}                      // One token per open connection
+	borrowed   map[*object.DBConnection]time.Time // Creation time of connections in use
+	mu         sync.RWMutex
+	closed     bool
+	closeChan  chan struct{} // Signal to stop the maintenance goroutine
+
+	// Atomic counters reported by Stats
+	createdConns   int64
+	closedConns    int64
+	expiredConns   int64
+	unhealthyConns int64
+	waitCount      int64
+	waitNanos      int64
+	waitTimeouts   int64
 }
 
-// NewConnectionPool creates a new connection pool
+// NewConnectionPool creates a new connection pool with the default
+// configuration and maxConns connections at most
 func NewConnectionPool(dbType string, connConfig map[string]interface{}, maxConns int) (*ConnectionPool, error) {
-	if maxConns <= 0 {
-		maxConns = 10
+	config := DefaultPoolConfig()
+	if maxConns > 0 {
+		config.MaxConns = maxConns
+	}
+	return NewConnectionPoolWithConfig(dbType, connConfig, config)
+}
+
+// NewConnectionPoolWithConfig creates a new connection pool
+func NewConnectionPoolWithConfig(dbType string, connConfig map[string]interface{}, config PoolConfig) (*ConnectionPool, error) {
+	if _, ok := poolDrivers[dbType]; !ok {
+		return nil, fmt.Errorf("unsupported database type '%s'", dbType)
 	}
+	if config.MaxConns <= 0 {
+		config.MaxConns = 10
+	}
+	config.MinConns = min(max(config.MinConns, 0), config.MaxConns)
 
 	id := atomic.AddInt64(&poolIDCounter, 1)
 	poolID := fmt.Sprintf("%s-pool-%d", dbType, id)
 
-	config := DefaultPoolConfig()
-	config.MaxConns = maxConns
-
 	pool := &ConnectionPool{
 		id:         poolID,
 		dbType:     dbType,
 		config:     config,
 		connConfig: connConfig,
-		conns:      make(chan *object.DBConnection, maxConns), // Buffered channel
+		idle:       make(chan *pooledConn, config.MaxConns),
+		slots:      make(chan struct{}, config.MaxConns),
+		borrowed:   make(map[*object.DBConnection]time.Time),
 		closeChan:  make(chan struct{}),
 	}
 
 	// Pre-allocate minimum connections
 	for i := 0; i < config.MinConns; i++ {
+		pool.slots <- struct{}{}
 		conn, err := pool.createConnection()
 		if err != nil {
+			<-pool.slots
 			// Clean up any created connections
 			pool.Close()
 			return nil, fmt.Errorf("failed to pre-allocate connection: %v", err)
 		}
-		pool.conns <- conn
+		now := time.Now()
+		pool.idle <- &pooledConn{conn: conn, createdAt: now, idleSince: now, checkedAt: now}
 	}
 
-	// Start cleanup goroutine
-	go pool.cleanupIdleConnections()
+	// Start maintenance goroutine
+	go pool.maintain()
 
 	// Register pool globally
 	poolsMutex.Lock()
@@ -103,93 +150,151 @@ func GetPool(poolID string) (*ConnectionPool, error) {
 	return pool, nil
 }
 
-// createConnection creates a new database connection
+// poolDriver opens and closes real connections for a pooled database type
+type poolDriver struct {
+	connect func(config *object.Map) (*object.DBConnection, error)
+	close   func(conn *object.DBConnection) error
+}
+
+var poolDrivers = map[string]poolDriver{
+	"postgres": {postgres.Connect, postgres.Close},
+	"mysql":    {mysql.Connect, mysql.Close},
+	"sqlite":   {sqlite.Connect, sqlite.Close},
+	"mongodb":  {mongodb.Connect, mongodb.Close},
+	"redis":    {redis.Connect, redis.Close},
+}
+
+// ID returns the pool's unique identifier
+func (p *ConnectionPool) ID() string {
+	return p.id
+}
+
+// createConnection creates a new database connection; the caller must hold
+// a slot for it
 func (p *ConnectionPool) createConnection() (*object.DBConnection, error) {
-	// This will be implemented by specific database connectors
-	// For now, create a placeholder connection
-	connID := generateConnID(p.dbType)
-
-	conn := &object.DBConnection{
-		ID:       connID,
-		DBType:   p.dbType,
-		PoolID:   p.id,
-		Metadata: make(map[string]object.Object),
+	config := object.NewMap()
+	for key, value := range p.connConfig {
+		config.Set(key, toObject(value))
+	}
+
+	conn, err := poolDrivers[p.dbType].connect(config)
+	if err != nil {
+		return nil, err
 	}
+	conn.PoolID = p.id
 
-	atomic.AddInt64(&p.totalConns, 1)
+	atomic.AddInt64(&p.createdConns, 1)
 	return conn, nil
 }
 
-// Get retrieves a connection from the pool (blocks if all connections in use)
+// Get borrows a connection from the pool. Idle connections are used first
+// (those past MaxLifetime or failing a ping are replaced); when every
+// connection is in use, Get waits up to WaitTimeout for one to be returned.
 func (p *ConnectionPool) Get() (*object.DBConnection, error) {
-	p.mu.RLock()
-	if p.closed {
-		p.mu.RUnlock()
-		return nil, fmt.Errorf("pool %s is closed", p.id)
-	}
-	p.mu.RUnlock()
-
-	select {
-	case conn := <-p.conns:
-		// Got connection from pool
-		atomic.AddInt64(&p.activeConns, 1)
-
-		// Validate connection (ping if SQL)
-		if err := p.validateConnection(conn); err != nil {
-			// Connection invalid, create new one
-			atomic.AddInt64(&p.activeConns, -1)
-			newConn, createErr := p.createConnection()
-			if createErr != nil {
-				return nil, fmt.Errorf("failed to create new connection: %v", createErr)
+	var waitStart time.Time
+	var timeout <-chan time.Time
+
+	for {
+		if p.isClosed() {
+			p.waited(waitStart)
+			return nil, fmt.Errorf("pool %s is closed", p.id)
+		}
+
+		select {
+		case pc := <-p.idle:
+			if conn := p.checkOut(pc); conn != nil {
+				p.waited(waitStart)
+				return conn, nil
 			}
-			atomic.AddInt64(&p.activeConns, 1)
-			return newConn, nil
+			continue
+		default:
 		}
 
-		return conn, nil
+		if waitStart.IsZero() {
+			select {
+			case p.slots <- struct{}{}:
+				return p.open()
+			default:
+			}
 
-	default:
-		// No idle connections, check if we can create more
-		total := atomic.LoadInt64(&p.totalConns)
-		if int(total) < p.config.MaxConns {
-			// Create new connection
-			conn, err := p.createConnection()
-			if err != nil {
-				return nil, fmt.Errorf("failed to create connection: %v", err)
+			// Pool exhausted, wait for a connection to be returned or closed
+			waitStart = time.Now()
+			atomic.AddInt64(&p.waitCount, 1)
+			if p.config.WaitTimeout > 0 {
+				timer := time.NewTimer(p.config.WaitTimeout)
+				defer timer.Stop()
+				timeout = timer.C
 			}
-			atomic.AddInt64(&p.activeConns, 1)
-			return conn, nil
 		}
 
-		// Pool exhausted, block until connection available
-		conn := <-p.conns
-		atomic.AddInt64(&p.activeConns, 1)
-
-		// Validate connection
-		if err := p.validateConnection(conn); err != nil {
-			atomic.AddInt64(&p.activeConns, -1)
-			newConn, createErr := p.createConnection()
-			if createErr != nil {
-				return nil, fmt.Errorf("failed to create new connection: %v", createErr)
+		select {
+		case pc := <-p.idle:
+			if conn := p.checkOut(pc); conn != nil {
+				p.waited(waitStart)
+				return conn, nil
 			}
-			atomic.AddInt64(&p.activeConns, 1)
-			return newConn, nil
+		case p.slots <- struct{}{}:
+			p.waited(waitStart)
+			return p.open()
+		case <-timeout:
+			p.waited(waitStart)
+			atomic.AddInt64(&p.waitTimeouts, 1)
+			return nil, fmt.Errorf("pool %s exhausted: no connection available after %s", p.id, p.config.WaitTimeout)
+		case <-p.closeChan:
 		}
+	}
+}
 
-		return conn, nil
+// waited records how long Get waited, if it had to
+func (p *ConnectionPool) waited(start time.Time) {
+	if !start.IsZero() {
+		atomic.AddInt64(&p.waitNanos, int64(time.Since(start)))
 	}
 }
 
-// Return returns a connection to the pool
-func (p *ConnectionPool) Return(conn *object.DBConnection) error {
-	p.mu.RLock()
-	if p.closed {
-		p.mu.RUnlock()
-		// Pool closed, close the connection
-		return p.closeConnection(conn)
+// open creates a connection in a slot Get just took and lends it
+func (p *ConnectionPool) open() (*object.DBConnection, error) {
+	conn, err := p.createConnection()
+	if err != nil {
+		<-p.slots
+		return nil, fmt.Errorf("failed to create connection: %v", err)
+	}
+	return p.lend(conn, time.Now()), nil
+}
+
+// checkOut lends an idle connection, or closes it and returns nil if it is
+// too old or no longer answers a ping. Only connections not returned or
+// pinged within HealthCheckInterval are pinged, so a busy pool does not pay
+// a round trip per borrow.
+func (p *ConnectionPool) checkOut(pc *pooledConn) *object.DBConnection {
+	now := time.Now()
+	if p.expired(pc.createdAt, now) {
+		p.discard(pc.conn, &p.expiredConns)
+		return nil
+	}
+	if p.stale(pc, now) {
+		if err := p.ping(pc.conn); err != nil {
+			p.discard(pc.conn, &p.unhealthyConns)
+			return nil
+		}
 	}
-	p.mu.RUnlock()
+	return p.lend(pc.conn, pc.createdAt)
+}
 
+// stale reports whether an idle connection is due a ping before it is lent
+func (p *ConnectionPool) stale(pc *pooledConn, now time.Time) bool {
+	return p.config.HealthCheckInterval > 0 && now.Sub(pc.checkedAt) >= p.config.HealthCheckInterval
+}
+
+func (p *ConnectionPool) lend(conn *object.DBConnection, createdAt time.Time) *object.DBConnection {
+	p.mu.Lock()
+	p.borrowed[conn] = createdAt
+	p.mu.Unlock()
+	return conn
+}
+
+// Return returns a connection to the pool
+func (p *ConnectionPool) Return(conn *object.DBConnection) error {
 	if conn == nil {
 		return fmt.Errorf("cannot return nil connection")
 	}
@@ -198,95 +303,164 @@ func (p *ConnectionPool) Return(conn *object.DBConnection) error {
 		return fmt.Errorf("connection belongs to different pool")
 	}
 
-	atomic.AddInt64(&p.activeConns, -1)
+	p.mu.Lock()
+	createdAt, ok := p.borrowed[conn]
+	delete(p.borrowed, conn)
+	p.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("connection %s is not borrowed from pool %s", conn.ID, p.id)
+	}
 
-	// Try to return to pool (non-blocking)
-	select {
-	case p.conns <- conn:
-		// Successfully returned to pool
+	now := time.Now()
+	if p.expired(createdAt, now) {
+		p.discard(conn, &p.expiredConns)
 		return nil
-	default:
-		// Pool full (shouldn't happen normally), close connection
-		atomic.AddInt64(&p.totalConns, -1)
-		return p.closeConnection(conn)
 	}
+	if !p.putIdle(&pooledConn{conn: conn, createdAt: createdAt, idleSince: now, checkedAt: now}) {
+		// Pool closed, close the connection
+		p.discard(conn, nil)
+	}
+	return nil
 }
 
-// validateConnection validates a connection (pings if SQL database)
-func (p *ConnectionPool) validateConnection(conn *object.DBConnection) error {
-	if conn.Native == nil {
-		return fmt.Errorf("connection has no native handle")
+// putIdle makes a connection available to Get again, unless the pool has
+// been closed. It never blocks: there are at most MaxConns connections.
+func (p *ConnectionPool) putIdle(pc *pooledConn) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return false
 	}
+	p.idle <- pc
+	return true
+}
 
-	// For SQL databases, ping to validate
-	if p.dbType == "postgres" || p.dbType == "mysql" {
-		if db, ok := conn.Native.(*sql.DB); ok {
-			return db.Ping()
-		}
+// expired reports whether a connection created at createdAt is past MaxLifetime
+func (p *ConnectionPool) expired(createdAt, now time.Time) bool {
+	return p.config.MaxLifetime > 0 && now.Sub(createdAt) >= p.config.MaxLifetime
+}
+
+// ping checks that a connection still reaches its server
+func (p *ConnectionPool) ping(conn *object.DBConnection) error {
+	ctx := context.Background()
+	if p.config.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.config.ConnectTimeout)
+		defer cancel()
 	}
 
-	// For other databases, assume valid (MongoDB/Redis have their own health checks)
+	switch native := conn.Native.(type) {
+	case nil:
+		return fmt.Errorf("connection has no native handle")
+	case *sql.DB:
+		return native.PingContext(ctx)
+	case *mongo.Client:
+		return native.Ping(ctx, nil)
+	case *goredis.Client:
+		return native.Ping(ctx).Err()
+	}
 	return nil
 }
 
+// discard closes a connection and frees its slot; reason is the counter
+// of why, if any
+func (p *ConnectionPool) discard(conn *object.DBConnection, reason *int64) {
+	if reason != nil {
+		atomic.AddInt64(reason, 1)
+	}
+	atomic.AddInt64(&p.closedConns, 1)
+	p.closeConnection(conn)
+	<-p.slots
+}
+
 // closeConnection closes a database connection
 func (p *ConnectionPool) closeConnection(conn *object.DBConnection) error {
 	if conn.Native == nil {
 		return nil
 	}
-
-	// Close native connection based on type
-	switch p.dbType {
-	case "postgres", "mysql":
-		if db, ok := conn.Native.(*sql.DB); ok {
-			return db.Close()
-		}
-		// MongoDB and Redis closers will be added when those connectors are implemented
-	}
-
-	return nil
+	return poolDrivers[p.dbType].close(conn)
 }
 
-// cleanupIdleConnections periodically closes idle connections
-func (p *ConnectionPool) cleanupIdleConnections() {
-	ticker := time.NewTicker(1 * time.Minute)
+// maintain runs HealthCheck every HealthCheckInterval, or every minute
+// when health checks are off, until the pool is closed
+func (p *ConnectionPool) maintain() {
+	interval := p.config.HealthCheckInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			// Check if we have more than MinConns idle
-			p.mu.RLock()
-			if p.closed {
-				p.mu.RUnlock()
-				return
+			p.HealthCheck()
+		case <-p.closeChan:
+			// Pool is closing
+			return
+		}
+	}
+}
+
+// HealthCheck goes over the idle connections once. It closes those past
+// MaxLifetime, those idle longer than MaxIdleTime while more than MinConns
+// are open, and, when health checks are on, those that fail a ping. Then
+// it reconnects until MinConns connections are open again.
+func (p *ConnectionPool) HealthCheck() {
+	now := time.Now()
+	for n := len(p.idle); n > 0; n-- {
+		var pc *pooledConn
+		select {
+		case pc = <-p.idle:
+		default:
+			n = 0
+			continue
+		}
+
+		switch {
+		case p.expired(pc.createdAt, now):
+			p.discard(pc.conn, &p.expiredConns)
+		case p.config.MaxIdleTime > 0 && now.Sub(pc.idleSince) >= p.config.MaxIdleTime && len(p.slots) > p.config.MinConns:
+			p.discard(pc.conn, nil)
+		case p.config.HealthCheckInterval > 0 && p.ping(pc.conn) != nil:
+			p.discard(pc.conn, &p.unhealthyConns)
+		default:
+			if p.config.HealthCheckInterval > 0 {
+				pc.checkedAt = now
 			}
-			p.mu.RUnlock()
-
-			// Try to close excess idle connections
-			idleCount := len(p.conns)
-			if idleCount > p.config.MinConns {
-				excess := idleCount - p.config.MinConns
-				for i := 0; i < excess; i++ {
-					select {
-					case conn := <-p.conns:
-						atomic.AddInt64(&p.totalConns, -1)
-						p.closeConnection(conn)
-					default:
-						// No more idle connections
-						break
-					}
-				}
+			if !p.putIdle(pc) {
+				p.discard(pc.conn, nil)
 			}
+		}
+	}
 
-		case <-p.closeChan:
-			// Pool is closing
+	for len(p.slots) < p.config.MinConns && !p.isClosed() {
+		select {
+		case p.slots <- struct{}{}:
+		default:
+			return
+		}
+		conn, err := p.createConnection()
+		if err != nil {
+			// The server may be down; try again on the next pass
+			<-p.slots
 			return
 		}
+		opened := time.Now()
+		if !p.putIdle(&pooledConn{conn: conn, createdAt: opened, idleSince: opened, checkedAt: opened}) {
+			p.discard(conn, nil)
+		}
 	}
 }
 
-// Close closes the connection pool and all connections
+func (p *ConnectionPool) isClosed() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.closed
+}
+
+// Close closes the connection pool and its idle connections; borrowed
+// connections are closed when they are returned
 func (p *ConnectionPool) Close() error {
 	p.mu.Lock()
 	if p.closed {
@@ -296,13 +470,17 @@ func (p *ConnectionPool) Close() error {
 	p.closed = true
 	p.mu.Unlock()
 
-	// Signal cleanup goroutine to stop
+	// Signal the maintenance goroutine and waiting Gets
 	close(p.closeChan)
 
 	// Close all idle connections
-	close(p.conns)
-	for conn := range p.conns {
-		p.closeConnection(conn)
+	for drained := false; !drained; {
+		select {
+		case pc := <-p.idle:
+			p.discard(pc.conn, nil)
+		default:
+			drained = true
+		}
 	}
 
 	// Remove from global registry
@@ -313,18 +491,35 @@ func (p *ConnectionPool) Close() error {
 	return nil
 }
 
-// Stats returns pool statistics
+// PoolStatKeys lists the keys of Stats in the order they are reported
+var PoolStatKeys = []string{
+	"id", "type", "max_conns", "min_conns", "total_conns", "active_conns", "idle_conns",
+	"wait_count", "wait_duration_ms", "wait_timeouts",
+	"created_conns", "closed_conns", "expired_conns", "unhealthy_conns", "closed",
+}
+
+// Stats returns pool statistics: open connections (total, active = in use,
+// idle), how often and how long Get had to wait, and how many connections
+// were created and closed, expired or evicted by a failed health check
 func (p *ConnectionPool) Stats() map[string]interface{} {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 
 	return map[string]interface{}{
-		"id":           p.id,
-		"type":         p.dbType,
-		"max_conns":    p.config.MaxConns,
-		"total_conns":  atomic.LoadInt64(&p.totalConns),
-		"active_conns": atomic.LoadInt64(&p.activeConns),
-		"idle_conns":   len(p.conns),
-		"closed":       p.closed,
+		"id":               p.id,
+		"type":             p.dbType,
+		"max_conns":        p.config.MaxConns,
+		"min_conns":        p.config.MinConns,
+		"total_conns":      int64(len(p.slots)),
+		"active_conns":     int64(len(p.borrowed)),
+		"idle_conns":       len(p.idle),
+		"wait_count":       atomic.LoadInt64(&p.waitCount),
+		"wait_duration_ms": float64(atomic.LoadInt64(&p.waitNanos)) / float64(time.Millisecond),
+		"wait_timeouts":    atomic.LoadInt64(&p.waitTimeouts),
+		"created_conns":    atomic.LoadInt64(&p.createdConns),
+		"closed_conns":     atomic.LoadInt64(&p.closedConns),
+		"expired_conns":    atomic.LoadInt64(&p.expiredConns),
+		"unhealthy_conns":  atomic.LoadInt64(&p.unhealthyConns),
+		"closed":           p.closed,
 	}
 }
@@ -0,0 +1,221 @@
+package database
+
+import (
+	"BanglaCode/src/object"
+	"time"
+)
+
+// registerPoolBuiltins registers the connection pool functions. The DB_POOL
+// value a script holds refers to a ConnectionPool by ID.
+func registerPoolBuiltins() {
+	// db_pool_banao - Create a connection pool
+	// Usage: db_pool_banao("postgres", config, 10, {wait_timeout: 5000, max_lifetime: 1800000})
+	Builtins["db_pool_banao"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) < 2 || len(args) > 4 {
+				return newError("db_pool_banao: wrong number of arguments. got=%d, want=2 to 4 (type, config, maxConns?, options?)", len(args))
+			}
+
+			dbType, ok := args[0].(*object.String)
+			if !ok {
+				return newError("db_pool_banao: first argument must be STRING (database type), got %s", args[0].Type())
+			}
+
+			config, ok := args[1].(*object.Map)
+			if !ok {
+				return newError("db_pool_banao: second argument must be MAP (config), got %s", args[1].Type())
+			}
+
+			poolConfig := DefaultPoolConfig()
+			if len(args) > 2 {
+				maxConns, ok := args[2].(*object.Number)
+				if !ok {
+					return newError("db_pool_banao: third argument must be NUMBER (maxConns), got %s", args[2].Type())
+				}
+				poolConfig.MaxConns = int(maxConns.Value)
+			}
+			if len(args) > 3 {
+				options, ok := args[3].(*object.Map)
+				if !ok {
+					return newError("db_pool_banao: fourth argument must be MAP (options), got %s", args[3].Type())
+				}
+				applyPoolOptions(&poolConfig, options)
+			}
+
+			connConfig := make(map[string]interface{}, len(config.Pairs))
+			for key, value := range config.Pairs {
+				connConfig[key] = fromObject(value)
+			}
+
+			pool, err := NewConnectionPoolWithConfig(poolType(dbType.Value), connConfig, poolConfig)
+			if err != nil {
+				return newError("db_pool_banao: %s", err.Error())
+			}
+
+			return &object.DBPool{
+				ID:       pool.ID(),
+				DBType:   pool.dbType,
+				MaxConns: pool.config.MaxConns,
+				Config:   config.Pairs,
+			}
+		},
+	}
+
+	// db_pool_nao - Borrow a connection from a pool
+	Builtins["db_pool_nao"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("db_pool_nao: wrong number of arguments. got=%d, want=1 (pool)", len(args))
+			}
+
+			pool, handle, errObj := poolArg("db_pool_nao", args[0])
+			if errObj != nil {
+				return errObj
+			}
+
+			conn, err := pool.Get()
+			if err != nil {
+				return newError("db_pool_nao: %s", err.Error())
+			}
+			syncPoolHandle(pool, handle)
+			return conn
+		},
+	}
+
+	// db_pool_ferot - Return a borrowed connection to its pool
+	Builtins["db_pool_ferot"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("db_pool_ferot: wrong number of arguments. got=%d, want=2 (pool, conn)", len(args))
+			}
+
+			pool, handle, errObj := poolArg("db_pool_ferot", args[0])
+			if errObj != nil {
+				return errObj
+			}
+
+			conn, ok := args[1].(*object.DBConnection)
+			if !ok {
+				return newError("db_pool_ferot: second argument must be DB_CONNECTION, got %s", args[1].Type())
+			}
+
+			if err := pool.Return(conn); err != nil {
+				return newError("db_pool_ferot: %s", err.Error())
+			}
+			syncPoolHandle(pool, handle)
+			return object.TRUE
+		},
+	}
+
+	// db_pool_bondho - Close a pool and its idle connections
+	Builtins["db_pool_bondho"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("db_pool_bondho: wrong number of arguments. got=%d, want=1 (pool)", len(args))
+			}
+
+			pool, _, errObj := poolArg("db_pool_bondho", args[0])
+			if errObj != nil {
+				return errObj
+			}
+
+			if err := pool.Close(); err != nil {
+				return newError("db_pool_bondho: %s", err.Error())
+			}
+			return object.TRUE
+		},
+	}
+
+	// db_pool_tothyo - Pool statistics, e.g. for a metrics endpoint
+	Builtins["db_pool_tothyo"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("db_pool_tothyo: wrong number of arguments. got=%d, want=1 (pool)", len(args))
+			}
+
+			pool, _, errObj := poolArg("db_pool_tothyo", args[0])
+			if errObj != nil {
+				return errObj
+			}
+
+			stats := pool.Stats()
+			result := object.NewMap()
+			for _, key := range PoolStatKeys {
+				result.Set(key, toObject(stats[key]))
+			}
+			return result
+		},
+	}
+
+	// db_pool_stats - Alias for db_pool_tothyo
+	Builtins["db_pool_stats"] = Builtins["db_pool_tothyo"]
+
+	// db_pool_porikkha - Run a health check now instead of waiting for the
+	// next interval, and return the resulting statistics
+	Builtins["db_pool_porikkha"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("db_pool_porikkha: wrong number of arguments. got=%d, want=1 (pool)", len(args))
+			}
+
+			pool, _, errObj := poolArg("db_pool_porikkha", args[0])
+			if errObj != nil {
+				return errObj
+			}
+
+			pool.HealthCheck()
+			return Builtins["db_pool_tothyo"].Fn(args[0])
+		},
+	}
+}
+
+// poolType normalizes the database type names db_jukto accepts
+func poolType(dbType string) string {
+	switch dbType {
+	case "postgresql":
+		return "postgres"
+	case "sqlite3":
+		return "sqlite"
+	case "mongo":
+		return "mongodb"
+	}
+	return dbType
+}
+
+// applyPoolOptions reads pool options; times are in milliseconds
+func applyPoolOptions(config *PoolConfig, options *object.Map) {
+	config.MinConns = int(extractConfigNumber(options, "min_conns", float64(config.MinConns)))
+
+	durations := map[string]*time.Duration{
+		"max_idle_time":         &config.MaxIdleTime,
+		"max_lifetime":          &config.MaxLifetime,
+		"health_check_interval": &config.HealthCheckInterval,
+		"wait_timeout":          &config.WaitTimeout,
+		"ping_timeout":          &config.ConnectTimeout,
+	}
+	for key, target := range durations {
+		ms := extractConfigNumber(options, key, float64(*target/time.Millisecond))
+		*target = time.Duration(ms * float64(time.Millisecond))
+	}
+}
+
+// poolArg resolves the pool argument of a db_pool_* builtin
+func poolArg(name string, arg object.Object) (*ConnectionPool, *object.DBPool, *object.Error) {
+	handle, ok := arg.(*object.DBPool)
+	if !ok {
+		return nil, nil, newError("%s: argument must be DB_POOL, got %s", name, arg.Type())
+	}
+	pool, err := GetPool(handle.ID)
+	if err != nil {
+		return nil, nil, newError("%s: %s", name, err.Error())
+	}
+	return pool, handle, nil
+}
+
+// syncPoolHandle keeps the script's pool value showing the connections in use
+func syncPoolHandle(pool *ConnectionPool, handle *object.DBPool) {
+	active := pool.Stats()["active_conns"].(int64)
+	handle.Mu.Lock()
+	handle.ActiveConns = int(active)
+	handle.Mu.Unlock()
+}
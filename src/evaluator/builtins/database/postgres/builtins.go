@@ -1,7 +1,9 @@
 package postgres
 
 import (
+	"BanglaCode/src/evaluator/builtins/database/cursor"
 	"BanglaCode/src/object"
+	"context"
 	"database/sql"
 	"fmt"
 	"sync"
@@ -19,6 +21,7 @@ func init() {
 	registerBuiltin("db_query_postgres", dbQueryPostgres)
 	registerBuiltin("db_exec_postgres", dbExecPostgres)
 	registerBuiltin("db_proshno_postgres", dbProshnoPostgres)
+	registerBuiltin("db_cursor_postgres", dbCursorPostgres)
 
 	// Query operations (asynchronous)
 	registerBuiltin("db_query_async_postgres", dbQueryAsyncPostgres)
@@ -43,6 +46,29 @@ func newError(format string, a ...interface{}) *object.Error {
 	return &object.Error{Message: fmt.Sprintf(format, a...)}
 }
 
+// executor resolves the first argument of a query builtin: a connection, or
+// a transaction ID from db_transaction_shuru_postgres to run inside it
+func executor(name string, arg object.Object) (Executor, *object.Error) {
+	switch target := arg.(type) {
+	case *object.DBConnection:
+		db, err := Handle(target)
+		if err != nil {
+			return nil, newError("%s: %s", name, err.Error())
+		}
+		return db, nil
+	case *object.String:
+		transactionsMu.RLock()
+		tx, exists := transactions[target.Value]
+		transactionsMu.RUnlock()
+		if !exists {
+			return nil, newError("%s: transaction %s not found", name, target.Value)
+		}
+		return tx, nil
+	default:
+		return nil, newError("%s: first argument must be DB_CONNECTION or STRING (transaction ID), got %s", name, arg.Type())
+	}
+}
+
 // db_jukto_postgres - Connect to PostgreSQL database
 // Usage: db_jukto("postgres", {host: "localhost", port: 5432, database: "mydb"})
 func dbJuktoPostgres(args ...object.Object) object.Object {
@@ -87,9 +113,9 @@ func dbQueryPostgres(args ...object.Object) object.Object {
 		return newError("db_query_postgres: wrong number of arguments. got=%d, want=2", len(args))
 	}
 
-	conn, ok := args[0].(*object.DBConnection)
-	if !ok {
-		return newError("db_query_postgres: first argument must be DB_CONNECTION, got %s", args[0].Type())
+	ex, errObj := executor("db_query_postgres", args[0])
+	if errObj != nil {
+		return errObj
 	}
 
 	query, ok := args[1].(*object.String)
@@ -97,7 +123,7 @@ func dbQueryPostgres(args ...object.Object) object.Object {
 		return newError("db_query_postgres: second argument must be STRING, got %s", args[1].Type())
 	}
 
-	result, err := Query(conn, query.Value)
+	result, err := Query(context.Background(), ex, query.Value)
 	if err != nil {
 		return newError("db_query_postgres: %s", err.Error())
 	}
@@ -116,9 +142,9 @@ func dbExecPostgres(args ...object.Object) object.Object {
 		return newError("db_exec_postgres: wrong number of arguments. got=%d, want=2", len(args))
 	}
 
-	conn, ok := args[0].(*object.DBConnection)
-	if !ok {
-		return newError("db_exec_postgres: first argument must be DB_CONNECTION, got %s", args[0].Type())
+	ex, errObj := executor("db_exec_postgres", args[0])
+	if errObj != nil {
+		return errObj
 	}
 
 	query, ok := args[1].(*object.String)
@@ -126,7 +152,7 @@ func dbExecPostgres(args ...object.Object) object.Object {
 		return newError("db_exec_postgres: second argument must be STRING, got %s", args[1].Type())
 	}
 
-	result, err := Exec(conn, query.Value)
+	result, err := Exec(context.Background(), ex, query.Value)
 	if err != nil {
 		return newError("db_exec_postgres: %s", err.Error())
 	}
@@ -144,9 +170,9 @@ func dbProshnoPostgres(args ...object.Object) object.Object {
 		return newError("db_proshno_postgres: wrong number of arguments. got=%d, want=3", len(args))
 	}
 
-	conn, ok := args[0].(*object.DBConnection)
-	if !ok {
-		return newError("db_proshno_postgres: first argument must be DB_CONNECTION, got %s", args[0].Type())
+	ex, errObj := executor("db_proshno_postgres", args[0])
+	if errObj != nil {
+		return errObj
 	}
 
 	query, ok := args[1].(*object.String)
@@ -159,7 +185,7 @@ func dbProshnoPostgres(args ...object.Object) object.Object {
 		return newError("db_proshno_postgres: third argument must be ARRAY, got %s", args[2].Type())
 	}
 
-	result, err := PreparedQuery(conn, query.Value, params.Elements)
+	result, err := PreparedQuery(context.Background(), ex, query.Value, params.Elements)
 	if err != nil {
 		return newError("db_proshno_postgres: %s", err.Error())
 	}
@@ -171,15 +197,50 @@ func dbProshnoPostgres(args ...object.Object) object.Object {
 	return dbResultToMap(result)
 }
 
+// db_cursor_postgres - Run a query and read its rows lazily (synchronous)
+// Usage: ghuriye (row of db_cursor_postgres(conn, "SELECT * FROM logs WHERE level = $1", ["error"])) { ... }
+func dbCursorPostgres(args ...object.Object) object.Object {
+	if len(args) != 2 && len(args) != 3 {
+		return newError("db_cursor_postgres: wrong number of arguments. got=%d, want=2 or 3 (conn, query, params?)", len(args))
+	}
+
+	ex, errObj := executor("db_cursor_postgres", args[0])
+	if errObj != nil {
+		return errObj
+	}
+
+	query, ok := args[1].(*object.String)
+	if !ok {
+		return newError("db_cursor_postgres: second argument must be STRING, got %s", args[1].Type())
+	}
+
+	var params []object.Object
+	if len(args) == 3 {
+		arr, ok := args[2].(*object.Array)
+		if !ok {
+			return newError("db_cursor_postgres: third argument must be ARRAY, got %s", args[2].Type())
+		}
+		params = arr.Elements
+	}
+
+	source, err := Cursor(context.Background(), ex, query.Value, params)
+	if err != nil {
+		return newError("db_cursor_postgres: %s", err.Error())
+	}
+
+	return cursor.New("db_cursor_postgres", source)
+}
+
 // db_query_async_postgres - Execute SELECT query (asynchronous)
 func dbQueryAsyncPostgres(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
 	if len(args) != 2 {
 		return newError("db_query_async_postgres: wrong number of arguments. got=%d, want=2", len(args))
 	}
 
-	conn, ok := args[0].(*object.DBConnection)
-	if !ok {
-		return newError("db_query_async_postgres: first argument must be DB_CONNECTION, got %s", args[0].Type())
+	ex, errObj := executor("db_query_async_postgres", args[0])
+	if errObj != nil {
+		return errObj
 	}
 
 	query, ok := args[1].(*object.String)
@@ -187,12 +248,15 @@ func dbQueryAsyncPostgres(args ...object.Object) object.Object {
 		return newError("db_query_async_postgres: second argument must be STRING, got %s", args[1].Type())
 	}
 
-	// Create promise
+	// Create promise; an abort signal rejects it and cancels the query
 	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
 
 	// Execute query asynchronously
 	go func() {
-		result, err := Query(conn, query.Value)
+		defer stop()
+		result, err := Query(ctx, ex, query.Value)
 		if err != nil {
 			object.RejectPromise(promise, newError("db_query_async_postgres: %s", err.Error()))
 			return
@@ -211,13 +275,14 @@ func dbQueryAsyncPostgres(args ...object.Object) object.Object {
 
 // db_exec_async_postgres - Execute INSERT/UPDATE/DELETE (asynchronous)
 func dbExecAsyncPostgres(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
 	if len(args) != 2 {
 		return newError("db_exec_async_postgres: wrong number of arguments. got=%d, want=2", len(args))
 	}
 
-	conn, ok := args[0].(*object.DBConnection)
-	if !ok {
-		return newError("db_exec_async_postgres: first argument must be DB_CONNECTION, got %s", args[0].Type())
+	ex, errObj := executor("db_exec_async_postgres", args[0])
+	if errObj != nil {
+		return errObj
 	}
 
 	query, ok := args[1].(*object.String)
@@ -225,12 +290,15 @@ func dbExecAsyncPostgres(args ...object.Object) object.Object {
 		return newError("db_exec_async_postgres: second argument must be STRING, got %s", args[1].Type())
 	}
 
-	// Create promise
+	// Create promise; an abort signal rejects it and cancels the query
 	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
 
 	// Execute query asynchronously
 	go func() {
-		result, err := Exec(conn, query.Value)
+		defer stop()
+		result, err := Exec(ctx, ex, query.Value)
 		if err != nil {
 			object.RejectPromise(promise, newError("db_exec_async_postgres: %s", err.Error()))
 			return
@@ -249,13 +317,14 @@ func dbExecAsyncPostgres(args ...object.Object) object.Object {
 
 // db_proshno_async_postgres - Execute parameterized query (async)
 func dbProshnoAsyncPostgres(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
 	if len(args) != 3 {
 		return newError("db_proshno_async_postgres: wrong number of arguments. got=%d, want=3", len(args))
 	}
 
-	conn, ok := args[0].(*object.DBConnection)
-	if !ok {
-		return newError("db_proshno_async_postgres: first argument must be DB_CONNECTION, got %s", args[0].Type())
+	ex, errObj := executor("db_proshno_async_postgres", args[0])
+	if errObj != nil {
+		return errObj
 	}
 
 	query, ok := args[1].(*object.String)
@@ -268,12 +337,15 @@ func dbProshnoAsyncPostgres(args ...object.Object) object.Object {
 		return newError("db_proshno_async_postgres: third argument must be ARRAY, got %s", args[2].Type())
 	}
 
-	// Create promise
+	// Create promise; an abort signal rejects it and cancels the query
 	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
 
 	// Execute query asynchronously
 	go func() {
-		result, err := PreparedQuery(conn, query.Value, params.Elements)
+		defer stop()
+		result, err := PreparedQuery(ctx, ex, query.Value, params.Elements)
 		if err != nil {
 			object.RejectPromise(promise, newError("db_proshno_async_postgres: %s", err.Error()))
 			return
@@ -432,17 +504,17 @@ func dbBulkInsertPostgres(args ...object.Object) object.Object {
 
 // Helper: Convert DBResult to Map for easier access
 func dbResultToMap(result *object.DBResult) *object.Map {
-	pairs := make(map[string]object.Object)
+	pairs := object.NewMap()
 
 	// Convert rows to array
 	rowsArray := &object.Array{Elements: make([]object.Object, len(result.Rows))}
-	for i, row := range result.Rows {
-		rowsArray.Elements[i] = &object.Map{Pairs: row}
+	for i := range result.Rows {
+		rowsArray.Elements[i] = result.Row(i)
 	}
 
-	pairs["rows"] = rowsArray
-	pairs["rows_affected"] = &object.Number{Value: float64(result.RowsAffected)}
-	pairs["last_insert_id"] = &object.Number{Value: float64(result.LastInsertID)}
+	pairs.Set("rows", rowsArray)
+	pairs.Set("rows_affected", &object.Number{Value: float64(result.RowsAffected)})
+	pairs.Set("last_insert_id", &object.Number{Value: float64(result.LastInsertID)})
 
-	return &object.Map{Pairs: pairs}
+	return pairs
 }
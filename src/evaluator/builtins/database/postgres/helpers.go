@@ -53,7 +53,7 @@ func ParsePostgresURL(url string) (*object.Map, error) {
 	}
 
 	// Create empty config map
-	config := &object.Map{Pairs: make(map[string]object.Object)}
+	config := object.NewMap()
 
 	// For now, return empty config (user should use map format)
 	// Full URL parsing can be added later
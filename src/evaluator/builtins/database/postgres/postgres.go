@@ -1,9 +1,12 @@
 package postgres
 
 import (
+	"BanglaCode/src/evaluator/builtins/database/cursor"
 	"BanglaCode/src/object"
+	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"sync"
 
 	_ "github.com/lib/pq" // PostgreSQL driver
@@ -92,14 +95,25 @@ func Close(conn *object.DBConnection) error {
 	return db.Close()
 }
 
-// Query executes a SELECT query
-func Query(conn *object.DBConnection, query string) (*object.DBResult, error) {
+// Executor runs statements on a connection (*sql.DB) or inside a
+// transaction (*sql.Tx)
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Handle returns the *sql.DB behind a PostgreSQL connection
+func Handle(conn *object.DBConnection) (*sql.DB, error) {
 	db, ok := conn.Native.(*sql.DB)
 	if !ok {
 		return nil, fmt.Errorf("invalid native connection type")
 	}
+	return db, nil
+}
 
-	rows, err := db.Query(query)
+// Query executes a SELECT query
+func Query(ctx context.Context, ex Executor, query string) (*object.DBResult, error) {
+	rows, err := ex.QueryContext(ctx, query)
 	if err != nil {
 		return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
 	}
@@ -115,13 +129,8 @@ func Query(conn *object.DBConnection, query string) (*object.DBResult, error) {
 }
 
 // Exec executes an INSERT, UPDATE, or DELETE statement
-func Exec(conn *object.DBConnection, query string) (*object.DBResult, error) {
-	db, ok := conn.Native.(*sql.DB)
-	if !ok {
-		return nil, fmt.Errorf("invalid native connection type")
-	}
-
-	result, err := db.Exec(query)
+func Exec(ctx context.Context, ex Executor, query string) (*object.DBResult, error) {
+	result, err := ex.ExecContext(ctx, query)
 	if err != nil {
 		return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
 	}
@@ -137,12 +146,7 @@ func Exec(conn *object.DBConnection, query string) (*object.DBResult, error) {
 }
 
 // PreparedQuery executes a parameterized query (SQL injection safe)
-func PreparedQuery(conn *object.DBConnection, query string, params []object.Object) (*object.DBResult, error) {
-	db, ok := conn.Native.(*sql.DB)
-	if !ok {
-		return nil, fmt.Errorf("invalid native connection type")
-	}
-
+func PreparedQuery(ctx context.Context, ex Executor, query string, params []object.Object) (*object.DBResult, error) {
 	// Convert BanglaCode objects to Go values
 	args := make([]interface{}, len(params))
 	for i, param := range params {
@@ -151,7 +155,7 @@ func PreparedQuery(conn *object.DBConnection, query string, params []object.Obje
 
 	// Check if query is SELECT or DML
 	if isSelectQuery(query) {
-		rows, err := db.Query(query, args...)
+		rows, err := ex.QueryContext(ctx, query, args...)
 		if err != nil {
 			return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
 		}
@@ -166,7 +170,7 @@ func PreparedQuery(conn *object.DBConnection, query string, params []object.Obje
 	}
 
 	// Execute DML (INSERT/UPDATE/DELETE)
-	result, err := db.Exec(query, args...)
+	result, err := ex.ExecContext(ctx, query, args...)
 	if err != nil {
 		return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
 	}
@@ -181,6 +185,23 @@ func PreparedQuery(conn *object.DBConnection, query string, params []object.Obje
 	}, nil
 }
 
+// Cursor runs a query and returns its rows as a cursor source that fetches
+// them lazily; closing the source closes the rows and cancels the query
+func Cursor(parent context.Context, ex Executor, query string, params []object.Object) (cursor.Source, error) {
+	args := make([]interface{}, len(params))
+	for i, param := range params {
+		args[i] = objectToGoValue(param)
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	rows, err := ex.QueryContext(ctx, query, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return cursor.Rows(rows, cancel, goValueToObject)
+}
+
 // BeginTransaction starts a new database transaction
 func BeginTransaction(conn *object.DBConnection) (*sql.Tx, error) {
 	db, ok := conn.Native.(*sql.DB)
@@ -365,6 +386,7 @@ func convertRows(rows *sql.Rows) (*object.DBResult, error) {
 
 	return &object.DBResult{
 		Rows:         result,
+		Columns:      columns,
 		RowsAffected: int64(len(result)),
 	}, nil
 }
@@ -397,8 +419,15 @@ func goValueToObject(value interface{}) object.Object {
 	}
 }
 
-// isSelectQuery checks if a query is a SELECT statement
+var returningClause = regexp.MustCompile(`(?i)\bRETURNING\b`)
+
+// isSelectQuery checks if a query returns rows: a SELECT statement or
+// a DML statement with a RETURNING clause
 func isSelectQuery(query string) bool {
+	if returningClause.MatchString(query) {
+		return true
+	}
+
 	trimmed := ""
 	for _, ch := range query {
 		if ch != ' ' && ch != '\t' && ch != '\n' && ch != '\r' {
@@ -0,0 +1,372 @@
+package database
+
+import (
+	"BanglaCode/src/evaluator/builtins/database/sqlbuilder"
+	"BanglaCode/src/object"
+	"fmt"
+)
+
+// tableQuery is the Go side of a db_table builder object. Every chained
+// call clones the builder, so a base query can be reused safely.
+type tableQuery struct {
+	builder *sqlbuilder.Builder
+	conn    *object.DBConnection                // nil when built for a dialect name only
+	wrap    func(row *object.Map) object.Object // turns result rows into model instances
+}
+
+// registerQueryBuilder registers db_table, the fluent query builder
+func registerQueryBuilder() {
+	// db_table(conn, "users") builds and runs parameterized queries;
+	// db_table("postgres", "users") only builds them (toSql)
+	Builtins["db_table"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return newError("db_table: wrong number of arguments. got=%d, want=2 (conn or dialect, table)", len(args))
+			}
+
+			table, ok := args[1].(*object.String)
+			if !ok {
+				return newError("db_table: second argument must be STRING (table name), got %s", args[1].Type())
+			}
+
+			q, err := newTableQuery(args[0], table.Value)
+			if err != nil {
+				return newError("db_table: %s", err.Error())
+			}
+			return q.object()
+		},
+	}
+}
+
+// newTableQuery starts a query on table for a connection or a dialect name
+func newTableQuery(target object.Object, table string) (*tableQuery, error) {
+	var conn *object.DBConnection
+	dialectName := ""
+
+	switch t := target.(type) {
+	case *object.DBConnection:
+		conn = t
+		dialectName = t.DBType
+	case *object.String:
+		dialectName = t.Value
+	default:
+		return nil, fmt.Errorf("first argument must be DB_CONNECTION or STRING (dialect), got %s", target.Type())
+	}
+
+	dialect, ok := sqlbuilder.LookupDialect(dialectName)
+	if !ok {
+		return nil, fmt.Errorf("%s does not support SQL queries. Supported: postgres, mysql, sqlite", dialectName)
+	}
+
+	builder, err := sqlbuilder.New(dialect, table)
+	if err != nil {
+		return nil, err
+	}
+	return &tableQuery{builder: builder, conn: conn}, nil
+}
+
+// object exposes the query as a map of chainable methods
+func (q *tableQuery) object() *object.Map {
+	m := object.NewMap()
+
+	// Building
+	m.Set("select", q.chain("select", func(b *sqlbuilder.Builder, args []object.Object) error {
+		columns, err := stringArgs(args)
+		if err != nil {
+			return err
+		}
+		return b.Select(columns...)
+	}))
+	m.Set("where", q.chain("where", whereClause("AND")))
+	m.Set("orWhere", q.chain("orWhere", whereClause("OR")))
+	m.Set("join", q.chain("join", joinClause("JOIN")))
+	m.Set("leftJoin", q.chain("leftJoin", joinClause("LEFT JOIN")))
+	m.Set("orderBy", q.chain("orderBy", func(b *sqlbuilder.Builder, args []object.Object) error {
+		if len(args) < 1 || len(args) > 2 {
+			return fmt.Errorf("wrong number of arguments. got=%d, want=1 or 2 (column, direction)", len(args))
+		}
+		names, err := stringArgs(args)
+		if err != nil {
+			return err
+		}
+		direction := ""
+		if len(names) == 2 {
+			direction = names[1]
+		}
+		return b.OrderBy(names[0], direction)
+	}))
+	m.Set("limit", q.chain("limit", func(b *sqlbuilder.Builder, args []object.Object) error {
+		n, err := countArg(args)
+		if err != nil {
+			return err
+		}
+		return b.Limit(n)
+	}))
+	m.Set("offset", q.chain("offset", func(b *sqlbuilder.Builder, args []object.Object) error {
+		n, err := countArg(args)
+		if err != nil {
+			return err
+		}
+		return b.Offset(n)
+	}))
+	m.Set("returning", q.chain("returning", func(b *sqlbuilder.Builder, args []object.Object) error {
+		columns, err := stringArgs(args)
+		if err != nil {
+			return err
+		}
+		return b.Returning(columns...)
+	}))
+	m.Set("insert", q.chain("insert", insertClause))
+	m.Set("update", q.chain("update", func(b *sqlbuilder.Builder, args []object.Object) error {
+		if len(args) != 1 {
+			return fmt.Errorf("wrong number of arguments. got=%d, want=1 (values map)", len(args))
+		}
+		values, ok := args[0].(*object.Map)
+		if !ok {
+			return fmt.Errorf("argument must be MAP, got %s", args[0].Type())
+		}
+		columns := values.Keys()
+		row := make([]object.Object, len(columns))
+		for i, col := range columns {
+			row[i] = values.Pairs[col]
+		}
+		return b.Update(columns, row)
+	}))
+	m.Set("delete", q.chain("delete", func(b *sqlbuilder.Builder, args []object.Object) error {
+		if len(args) != 0 {
+			return fmt.Errorf("wrong number of arguments. got=%d, want=0", len(args))
+		}
+		b.Delete()
+		return nil
+	}))
+
+	// Running
+	m.Set("toSql", &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		sqlText, params := q.builder.Build()
+		result := object.NewMap()
+		result.Set("sql", &object.String{Value: sqlText})
+		result.Set("params", &object.Array{Elements: params})
+		return result
+	}})
+	m.Set("run", &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		sqlText, params := q.builder.Build()
+		return q.exec("run", sqlText, params)
+	}})
+	m.Set("get", &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		sqlText, params := q.builder.Build()
+		return q.rows("get", sqlText, params)
+	}})
+	m.Set("first", &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		b := q.builder
+		if b.Kind() == sqlbuilder.Select {
+			b = b.Clone()
+			b.Limit(1)
+		}
+		sqlText, params := b.Build()
+		rows := q.rows("first", sqlText, params)
+		arr, ok := rows.(*object.Array)
+		if !ok {
+			return rows
+		}
+		if len(arr.Elements) == 0 {
+			return object.NULL
+		}
+		return arr.Elements[0]
+	}})
+	m.Set("count", &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		sqlText, params, err := q.builder.BuildCount()
+		if err != nil {
+			return newError("count: %s", err.Error())
+		}
+		result := q.exec("count", sqlText, params)
+		resultMap, ok := result.(*object.Map)
+		if !ok {
+			return result
+		}
+		rows := resultMap.Pairs["rows"].(*object.Array)
+		if len(rows.Elements) == 0 {
+			return &object.Number{Value: 0}
+		}
+		count := rows.Elements[0].(*object.Map).Pairs["count"]
+		if num, ok := count.(*object.Number); ok {
+			return num
+		}
+		// Some drivers hand back numeric columns as text
+		return &object.Number{Value: parseCount(count.Inspect())}
+	}})
+
+	return m
+}
+
+// chain wraps a builder step as a method returning a new query object
+func (q *tableQuery) chain(name string, apply func(b *sqlbuilder.Builder, args []object.Object) error) *object.Builtin {
+	return &object.Builtin{Fn: func(args ...object.Object) object.Object {
+		b := q.builder.Clone()
+		if err := apply(b, args); err != nil {
+			return newError("%s: %s", name, err.Error())
+		}
+		return (&tableQuery{builder: b, conn: q.conn, wrap: q.wrap}).object()
+	}}
+}
+
+// exec runs compiled SQL through the driver's prepared-statement builtin
+func (q *tableQuery) exec(name, sqlText string, params []object.Object) object.Object {
+	if q.conn == nil {
+		return newError("%s: query was built for a dialect only; use toSql() or pass a connection to db_table", name)
+	}
+	return Builtins["db_proshno"].Fn(q.conn, &object.String{Value: sqlText}, &object.Array{Elements: params})
+}
+
+// rows runs compiled SQL and returns its rows, as model instances if wrapped
+func (q *tableQuery) rows(name, sqlText string, params []object.Object) object.Object {
+	result := q.exec(name, sqlText, params)
+	resultMap, ok := result.(*object.Map)
+	if !ok {
+		return result
+	}
+
+	rows := resultMap.Pairs["rows"].(*object.Array)
+	if q.wrap != nil {
+		for i, row := range rows.Elements {
+			rows.Elements[i] = q.wrap(row.(*object.Map))
+		}
+	}
+	return rows
+}
+
+// whereClause accepts where(map), where(column, value) and
+// where(column, op, value)
+func whereClause(conj string) func(b *sqlbuilder.Builder, args []object.Object) error {
+	return func(b *sqlbuilder.Builder, args []object.Object) error {
+		switch len(args) {
+		case 1:
+			filter, ok := args[0].(*object.Map)
+			if !ok {
+				return fmt.Errorf("single argument must be MAP (column: value), got %s", args[0].Type())
+			}
+			for i, col := range filter.Keys() {
+				// Pairs of one map always belong together
+				c := conj
+				if i > 0 {
+					c = "AND"
+				}
+				if err := b.Where(c, col, "=", filter.Pairs[col]); err != nil {
+					return err
+				}
+			}
+			return nil
+		case 2, 3:
+			names, err := stringArgs(args[:len(args)-1])
+			if err != nil {
+				return err
+			}
+			op := "="
+			if len(names) == 2 {
+				op = names[1]
+			}
+			return b.Where(conj, names[0], op, args[len(args)-1])
+		default:
+			return fmt.Errorf("wrong number of arguments. got=%d, want=1 to 3", len(args))
+		}
+	}
+}
+
+// joinClause accepts join(table, left, right) and join(table, left, op, right)
+func joinClause(kind string) func(b *sqlbuilder.Builder, args []object.Object) error {
+	return func(b *sqlbuilder.Builder, args []object.Object) error {
+		if len(args) != 3 && len(args) != 4 {
+			return fmt.Errorf("wrong number of arguments. got=%d, want=3 or 4 (table, left, op, right)", len(args))
+		}
+		names, err := stringArgs(args)
+		if err != nil {
+			return err
+		}
+		if len(names) == 3 {
+			return b.Join(kind, names[0], names[1], "=", names[2])
+		}
+		return b.Join(kind, names[0], names[1], names[2], names[3])
+	}
+}
+
+// insertClause accepts one map or an array of maps with the same keys;
+// columns follow the key order of the first map
+func insertClause(b *sqlbuilder.Builder, args []object.Object) error {
+	if len(args) != 1 {
+		return fmt.Errorf("wrong number of arguments. got=%d, want=1 (map or array of maps)", len(args))
+	}
+
+	var records []object.Object
+	switch v := args[0].(type) {
+	case *object.Map:
+		records = []object.Object{v}
+	case *object.Array:
+		records = v.Elements
+	default:
+		return fmt.Errorf("argument must be MAP or ARRAY of maps, got %s", args[0].Type())
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("nothing to insert")
+	}
+
+	var columns []string
+	rows := make([][]object.Object, len(records))
+	for i, record := range records {
+		m, ok := record.(*object.Map)
+		if !ok {
+			return fmt.Errorf("row %d must be MAP, got %s", i, record.Type())
+		}
+		if i == 0 {
+			columns = m.Keys()
+		} else if len(m.Pairs) != len(columns) {
+			return fmt.Errorf("row %d has %d columns, expected %d", i, len(m.Pairs), len(columns))
+		}
+
+		rows[i] = make([]object.Object, len(columns))
+		for j, col := range columns {
+			val, ok := m.Pairs[col]
+			if !ok {
+				return fmt.Errorf("row %d is missing column '%s'", i, col)
+			}
+			rows[i][j] = val
+		}
+	}
+
+	return b.Insert(columns, rows)
+}
+
+// stringArgs converts arguments (or a single array of them) to strings
+func stringArgs(args []object.Object) ([]string, error) {
+	if len(args) == 1 {
+		if arr, ok := args[0].(*object.Array); ok {
+			args = arr.Elements
+		}
+	}
+
+	names := make([]string, len(args))
+	for i, arg := range args {
+		str, ok := arg.(*object.String)
+		if !ok {
+			return nil, fmt.Errorf("argument %d must be STRING, got %s", i+1, arg.Type())
+		}
+		names[i] = str.Value
+	}
+	return names, nil
+}
+
+// countArg reads the single whole-number argument of limit/offset
+func countArg(args []object.Object) (int, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("wrong number of arguments. got=%d, want=1", len(args))
+	}
+	num, ok := args[0].(*object.Number)
+	if !ok || num.Value != float64(int(num.Value)) {
+		return 0, fmt.Errorf("argument must be a whole NUMBER, got %s", args[0].Inspect())
+	}
+	return int(num.Value), nil
+}
+
+func parseCount(text string) float64 {
+	var n float64
+	fmt.Sscanf(text, "%g", &n)
+	return n
+}
@@ -145,7 +145,7 @@ func dbSetRedis(args ...object.Object) object.Object {
 		expiration = time.Duration(ttl.Value) * time.Second
 	}
 
-	if err := Set(conn, key.Value, value.Value, expiration); err != nil {
+	if err := Set(ctx, conn, key.Value, value.Value, expiration); err != nil {
 		return newError("db_set_redis: %s", err.Error())
 	}
 
@@ -168,7 +168,7 @@ func dbGetRedis(args ...object.Object) object.Object {
 		return newError("db_get_redis: second argument must be STRING (key), got %s", args[1].Type())
 	}
 
-	value, err := Get(conn, key.Value)
+	value, err := Get(ctx, conn, key.Value)
 	if err != nil {
 		return newError("db_get_redis: %s", err.Error())
 	}
@@ -230,6 +230,7 @@ func dbExpireRedis(args ...object.Object) object.Object {
 // Async functions
 
 func dbSetAsyncRedis(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
 	if len(args) < 3 || len(args) > 4 {
 		return newError("db_set_async_redis: wrong number of arguments. got=%d, want=3 or 4", len(args))
 	}
@@ -259,9 +260,12 @@ func dbSetAsyncRedis(args ...object.Object) object.Object {
 	}
 
 	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
 
 	go func() {
-		if err := Set(conn, key.Value, value.Value, expiration); err != nil {
+		defer stop()
+		if err := Set(ctx, conn, key.Value, value.Value, expiration); err != nil {
 			object.RejectPromise(promise, newError("db_set_async_redis: %s", err.Error()))
 			return
 		}
@@ -272,6 +276,7 @@ func dbSetAsyncRedis(args ...object.Object) object.Object {
 }
 
 func dbGetAsyncRedis(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
 	if len(args) != 2 {
 		return newError("db_get_async_redis: wrong number of arguments. got=%d, want=2", len(args))
 	}
@@ -287,9 +292,12 @@ func dbGetAsyncRedis(args ...object.Object) object.Object {
 	}
 
 	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
 
 	go func() {
-		value, err := Get(conn, key.Value)
+		defer stop()
+		value, err := Get(ctx, conn, key.Value)
 		if err != nil {
 			object.RejectPromise(promise, newError("db_get_async_redis: %s", err.Error()))
 			return
@@ -492,5 +500,5 @@ func dbHGetAllRedis(args ...object.Object) object.Object {
 		pairs[field] = &object.String{Value: value}
 	}
 
-	return &object.Map{Pairs: pairs}
+	return object.NewMapFrom(pairs)
 }
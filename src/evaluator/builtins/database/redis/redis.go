@@ -86,7 +86,7 @@ func Close(conn *object.DBConnection) error {
 // String operations
 
 // Set sets a key-value pair
-func Set(conn *object.DBConnection, key string, value string, expiration time.Duration) error {
+func Set(ctx context.Context, conn *object.DBConnection, key string, value string, expiration time.Duration) error {
 	client, ok := conn.Native.(*redis.Client)
 	if !ok {
 		return fmt.Errorf("invalid native connection type")
@@ -96,7 +96,7 @@ func Set(conn *object.DBConnection, key string, value string, expiration time.Du
 }
 
 // Get gets a value by key
-func Get(conn *object.DBConnection, key string) (string, error) {
+func Get(ctx context.Context, conn *object.DBConnection, key string) (string, error) {
 	client, ok := conn.Native.(*redis.Client)
 	if !ok {
 		return "", fmt.Errorf("invalid native connection type")
@@ -5,6 +5,7 @@ import (
 	"BanglaCode/src/evaluator/builtins/database/mysql"
 	"BanglaCode/src/evaluator/builtins/database/postgres"
 	"BanglaCode/src/evaluator/builtins/database/redis"
+	"BanglaCode/src/evaluator/builtins/database/sqlite"
 	"BanglaCode/src/object"
 )
 
@@ -22,6 +23,11 @@ func init() {
 		Builtins[name] = fn
 	}
 
+	// Merge SQLite built-ins
+	for name, fn := range sqlite.Builtins {
+		Builtins[name] = fn
+	}
+
 	// Merge MongoDB built-ins
 	for name, fn := range mongodb.Builtins {
 		Builtins[name] = fn
@@ -34,6 +40,13 @@ func init() {
 
 	// Register unified database functions (database-agnostic)
 	registerUnifiedBuiltins()
+
+	// Register connection pools
+	registerPoolBuiltins()
+
+	// Register the query builder and model layer on top of them
+	registerQueryBuilder()
+	registerModels()
 }
 
 // registerUnifiedBuiltins registers database-agnostic built-in functions
@@ -63,12 +76,14 @@ func registerUnifiedBuiltins() {
 				return postgres.Builtins["db_jukto_postgres"].Fn(config)
 			case "mysql":
 				return mysql.Builtins["db_jukto_mysql"].Fn(config)
+			case "sqlite", "sqlite3":
+				return sqlite.Builtins["db_jukto_sqlite"].Fn(config)
 			case "mongodb", "mongo":
 				return mongodb.Builtins["db_jukto_mongodb"].Fn(config)
 			case "redis":
 				return redis.Builtins["db_jukto_redis"].Fn(config)
 			default:
-				return newError("db_jukto: unsupported database type '%s'. Supported: postgres, mysql, mongodb, redis", dbType.Value)
+				return newError("db_jukto: unsupported database type '%s'. Supported: postgres, mysql, sqlite, mongodb, redis", dbType.Value)
 			}
 		},
 	}
@@ -91,6 +106,8 @@ func registerUnifiedBuiltins() {
 				return postgres.Builtins["db_bandho_postgres"].Fn(conn)
 			case "mysql":
 				return mysql.Builtins["db_bandho_mysql"].Fn(conn)
+			case "sqlite":
+				return sqlite.Builtins["db_bandho_sqlite"].Fn(conn)
 			case "mongodb":
 				return mongodb.Builtins["db_bandho_mongodb"].Fn(conn)
 			case "redis":
@@ -124,6 +141,8 @@ func registerUnifiedBuiltins() {
 				return postgres.Builtins["db_query_postgres"].Fn(conn, query)
 			case "mysql":
 				return mysql.Builtins["db_query_mysql"].Fn(conn, query)
+			case "sqlite":
+				return sqlite.Builtins["db_query_sqlite"].Fn(conn, query)
 			case "mongodb", "redis":
 				return newError("db_query: %s does not support SQL queries. Use database-specific functions", conn.DBType)
 			default:
@@ -155,6 +174,8 @@ func registerUnifiedBuiltins() {
 				return postgres.Builtins["db_exec_postgres"].Fn(conn, query)
 			case "mysql":
 				return mysql.Builtins["db_exec_mysql"].Fn(conn, query)
+			case "sqlite":
+				return sqlite.Builtins["db_exec_sqlite"].Fn(conn, query)
 			case "mongodb", "redis":
 				return newError("db_exec: %s does not support SQL statements. Use database-specific functions", conn.DBType)
 			default:
@@ -191,6 +212,8 @@ func registerUnifiedBuiltins() {
 				return postgres.Builtins["db_proshno_postgres"].Fn(conn, query, params)
 			case "mysql":
 				return mysql.Builtins["db_proshno_mysql"].Fn(conn, query, params)
+			case "sqlite":
+				return sqlite.Builtins["db_proshno_sqlite"].Fn(conn, query, params)
 			case "mongodb", "redis":
 				return newError("db_proshno: %s does not support SQL prepared statements", conn.DBType)
 			default:
@@ -198,4 +221,32 @@ func registerUnifiedBuiltins() {
 			}
 		},
 	}
+
+	// db_cursor - Universal lazy query function (for SQL databases only)
+	Builtins["db_cursor"] = &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 2 && len(args) != 3 {
+				return newError("db_cursor: wrong number of arguments. got=%d, want=2 or 3", len(args))
+			}
+
+			conn, ok := args[0].(*object.DBConnection)
+			if !ok {
+				return newError("db_cursor: first argument must be DB_CONNECTION, got %s", args[0].Type())
+			}
+
+			// Route to appropriate connector
+			switch conn.DBType {
+			case "postgres":
+				return postgres.Builtins["db_cursor_postgres"].Fn(args...)
+			case "mysql":
+				return mysql.Builtins["db_cursor_mysql"].Fn(args...)
+			case "sqlite":
+				return sqlite.Builtins["db_cursor_sqlite"].Fn(args...)
+			case "mongodb", "redis":
+				return newError("db_cursor: %s does not support SQL queries. Use database-specific functions", conn.DBType)
+			default:
+				return newError("db_cursor: unsupported connection type '%s'", conn.DBType)
+			}
+		},
+	}
 }
@@ -0,0 +1,477 @@
+// Package sqlbuilder compiles fluent query descriptions into parameterized
+// SQL for the PostgreSQL, MySQL and SQLite dialects. Values never enter the
+// SQL text: they are returned as parameters, and identifiers are checked
+// and quoted.
+package sqlbuilder
+
+import (
+	"BanglaCode/src/object"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Dialect describes how one database spells placeholders and identifiers
+type Dialect struct {
+	Name        string
+	Placeholder func(n int) string // n is 1-based
+	Quote       string             // identifier quote character
+	Returning   bool               // supports INSERT/UPDATE/DELETE ... RETURNING
+	NoLimit     string             // LIMIT that lets OFFSET stand alone ("" if not needed)
+}
+
+var dialects = map[string]*Dialect{
+	"postgres": {
+		Name:        "postgres",
+		Placeholder: func(n int) string { return "$" + strconv.Itoa(n) },
+		Quote:       `"`,
+		Returning:   true,
+	},
+	"mysql": {
+		Name:        "mysql",
+		Placeholder: func(int) string { return "?" },
+		Quote:       "`",
+		NoLimit:     "18446744073709551615",
+	},
+	"sqlite": {
+		Name:        "sqlite",
+		Placeholder: func(int) string { return "?" },
+		Quote:       `"`,
+		Returning:   true,
+		NoLimit:     "-1",
+	},
+}
+
+// LookupDialect returns the dialect for a database type such as "postgres"
+func LookupDialect(name string) (*Dialect, bool) {
+	switch name {
+	case "postgresql":
+		name = "postgres"
+	case "sqlite3":
+		name = "sqlite"
+	}
+	d, ok := dialects[name]
+	return d, ok
+}
+
+// Kind is the statement a builder produces
+type Kind int
+
+const (
+	Select Kind = iota
+	Insert
+	Update
+	Delete
+)
+
+type join struct {
+	kind  string // "JOIN" or "LEFT JOIN"
+	table string
+	on    string
+}
+
+type condition struct {
+	conj   string // "AND" or "OR"
+	column string
+	op     string
+	value  object.Object
+}
+
+// Builder describes one statement. Methods change the builder in place;
+// use Clone to branch a shared base query.
+type Builder struct {
+	dialect   *Dialect
+	table     string
+	kind      Kind
+	columns   []string // SELECT list, or INSERT/UPDATE columns
+	rows      [][]object.Object
+	joins     []join
+	wheres    []condition
+	orders    []string
+	limit     int
+	offset    int
+	returning []string
+}
+
+// New starts a SELECT * query on table
+func New(dialect *Dialect, table string) (*Builder, error) {
+	quoted, err := dialect.ident(table)
+	if err != nil {
+		return nil, err
+	}
+	return &Builder{dialect: dialect, table: quoted, limit: -1, offset: -1}, nil
+}
+
+// Clone returns an independent copy of the builder
+func (b *Builder) Clone() *Builder {
+	c := *b
+	c.columns = append([]string(nil), b.columns...)
+	c.rows = append([][]object.Object(nil), b.rows...)
+	c.joins = append([]join(nil), b.joins...)
+	c.wheres = append([]condition(nil), b.wheres...)
+	c.orders = append([]string(nil), b.orders...)
+	c.returning = append([]string(nil), b.returning...)
+	return &c
+}
+
+// Dialect returns the dialect the builder compiles for
+func (b *Builder) Dialect() *Dialect { return b.dialect }
+
+// Kind returns the statement the builder produces
+func (b *Builder) Kind() Kind { return b.kind }
+
+var (
+	identPart = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	aliased   = regexp.MustCompile(`(?i)^\s*(\S+)\s+as\s+(\S+)\s*$`)
+)
+
+// ident checks and quotes a possibly qualified name such as users.id or u.*
+func (d *Dialect) ident(name string) (string, error) {
+	parts := strings.Split(strings.TrimSpace(name), ".")
+	for i, part := range parts {
+		if part == "*" && i == len(parts)-1 {
+			continue
+		}
+		if !identPart.MatchString(part) {
+			return "", fmt.Errorf("invalid identifier '%s'", name)
+		}
+		parts[i] = d.Quote + part + d.Quote
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// Select sets the selected columns; "name AS alias" is allowed
+func (b *Builder) Select(columns ...string) error {
+	b.columns = b.columns[:0]
+	for _, col := range columns {
+		alias := ""
+		if m := aliased.FindStringSubmatch(col); m != nil {
+			col, alias = m[1], m[2]
+		}
+		quoted, err := b.dialect.ident(col)
+		if err != nil {
+			return err
+		}
+		if alias != "" {
+			quotedAlias, err := b.dialect.ident(alias)
+			if err != nil {
+				return err
+			}
+			quoted += " AS " + quotedAlias
+		}
+		b.columns = append(b.columns, quoted)
+	}
+	return nil
+}
+
+var whereOps = map[string]bool{
+	"=": true, "!=": true, "<>": true, "<": true, "<=": true, ">": true, ">=": true,
+	"LIKE": true, "NOT LIKE": true, "ILIKE": true, "IN": true, "NOT IN": true, "IS": true, "IS NOT": true,
+}
+
+// Where adds "column op value", joined to earlier conditions with conj
+// ("AND" or "OR"). A khali value with = or != compares with NULL.
+func (b *Builder) Where(conj, column, op string, value object.Object) error {
+	quoted, err := b.dialect.ident(column)
+	if err != nil {
+		return err
+	}
+
+	op = strings.ToUpper(strings.Join(strings.Fields(op), " "))
+	if !whereOps[op] {
+		return fmt.Errorf("unsupported operator '%s'", op)
+	}
+
+	_, isNull := value.(*object.Null)
+	switch op {
+	case "IN", "NOT IN":
+		if _, ok := value.(*object.Array); !ok {
+			return fmt.Errorf("%s needs an ARRAY value, got %s", op, value.Type())
+		}
+	case "IS", "IS NOT":
+		if !isNull {
+			return fmt.Errorf("%s only compares with khali", op)
+		}
+	case "=":
+		if isNull {
+			op = "IS"
+		}
+	case "!=", "<>":
+		if isNull {
+			op = "IS NOT"
+		}
+	}
+
+	b.wheres = append(b.wheres, condition{conj: conj, column: quoted, op: op, value: value})
+	return nil
+}
+
+var joinOps = map[string]bool{"=": true, "!=": true, "<>": true, "<": true, "<=": true, ">": true, ">=": true}
+
+// Join adds "kind table ON left op right"; kind is "JOIN" or "LEFT JOIN"
+func (b *Builder) Join(kind, table, left, op, right string) error {
+	if !joinOps[op] {
+		return fmt.Errorf("unsupported join operator '%s'", op)
+	}
+
+	names := []string{table, left, right}
+	for i, name := range names {
+		quoted, err := b.dialect.ident(name)
+		if err != nil {
+			return err
+		}
+		names[i] = quoted
+	}
+
+	b.joins = append(b.joins, join{kind: kind, table: names[0], on: names[1] + " " + op + " " + names[2]})
+	return nil
+}
+
+// OrderBy adds a sort column; direction is "asc" or "desc"
+func (b *Builder) OrderBy(column, direction string) error {
+	quoted, err := b.dialect.ident(column)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToUpper(direction) {
+	case "", "ASC":
+		b.orders = append(b.orders, quoted)
+	case "DESC":
+		b.orders = append(b.orders, quoted+" DESC")
+	default:
+		return fmt.Errorf("order direction must be 'asc' or 'desc', got '%s'", direction)
+	}
+	return nil
+}
+
+// Limit caps the number of rows
+func (b *Builder) Limit(n int) error {
+	if n < 0 {
+		return fmt.Errorf("limit must not be negative, got %d", n)
+	}
+	b.limit = n
+	return nil
+}
+
+// Offset skips the first n rows
+func (b *Builder) Offset(n int) error {
+	if n < 0 {
+		return fmt.Errorf("offset must not be negative, got %d", n)
+	}
+	b.offset = n
+	return nil
+}
+
+// Returning asks INSERT/UPDATE/DELETE to return columns of the changed rows
+func (b *Builder) Returning(columns ...string) error {
+	if !b.dialect.Returning {
+		return fmt.Errorf("%s does not support RETURNING", b.dialect.Name)
+	}
+
+	b.returning = b.returning[:0]
+	for _, col := range columns {
+		quoted, err := b.dialect.ident(col)
+		if err != nil {
+			return err
+		}
+		b.returning = append(b.returning, quoted)
+	}
+	return nil
+}
+
+// Insert turns the builder into an INSERT of rows, each holding one value
+// per column
+func (b *Builder) Insert(columns []string, rows [][]object.Object) error {
+	if len(columns) == 0 || len(rows) == 0 {
+		return fmt.Errorf("insert needs at least one column and one row")
+	}
+	if err := b.setColumns(columns); err != nil {
+		return err
+	}
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return fmt.Errorf("row %d has %d values, expected %d", i, len(row), len(columns))
+		}
+	}
+
+	b.kind = Insert
+	b.rows = rows
+	return nil
+}
+
+// Update turns the builder into an UPDATE setting columns to values
+func (b *Builder) Update(columns []string, values []object.Object) error {
+	if len(columns) == 0 {
+		return fmt.Errorf("update needs at least one column")
+	}
+	if len(values) != len(columns) {
+		return fmt.Errorf("update has %d values for %d columns", len(values), len(columns))
+	}
+	if err := b.setColumns(columns); err != nil {
+		return err
+	}
+
+	b.kind = Update
+	b.rows = [][]object.Object{values}
+	return nil
+}
+
+// Delete turns the builder into a DELETE of the matching rows
+func (b *Builder) Delete() {
+	b.kind = Delete
+}
+
+func (b *Builder) setColumns(columns []string) error {
+	b.columns = make([]string, len(columns))
+	for i, col := range columns {
+		quoted, err := b.dialect.ident(col)
+		if err != nil {
+			return err
+		}
+		b.columns[i] = quoted
+	}
+	return nil
+}
+
+// statement collects SQL text and its parameters in placeholder order
+type statement struct {
+	dialect *Dialect
+	sql     strings.Builder
+	params  []object.Object
+}
+
+func (s *statement) write(parts ...string) {
+	for _, part := range parts {
+		s.sql.WriteString(part)
+	}
+}
+
+func (s *statement) bind(value object.Object) string {
+	s.params = append(s.params, value)
+	return s.dialect.Placeholder(len(s.params))
+}
+
+// Build compiles the builder into SQL and its parameters
+func (b *Builder) Build() (string, []object.Object) {
+	s := &statement{dialect: b.dialect}
+
+	switch b.kind {
+	case Select:
+		columns := "*"
+		if len(b.columns) > 0 {
+			columns = strings.Join(b.columns, ", ")
+		}
+		s.write("SELECT ", columns, " FROM ", b.table)
+		b.writeJoins(s)
+		b.writeWhere(s)
+		if len(b.orders) > 0 {
+			s.write(" ORDER BY ", strings.Join(b.orders, ", "))
+		}
+		b.writeLimit(s)
+
+	case Insert:
+		s.write("INSERT INTO ", b.table, " (", strings.Join(b.columns, ", "), ") VALUES ")
+		for i, row := range b.rows {
+			if i > 0 {
+				s.write(", ")
+			}
+			s.write("(", b.bindAll(s, row), ")")
+		}
+		b.writeReturning(s)
+
+	case Update:
+		s.write("UPDATE ", b.table, " SET ")
+		for i, col := range b.columns {
+			if i > 0 {
+				s.write(", ")
+			}
+			s.write(col, " = ", s.bind(b.rows[0][i]))
+		}
+		b.writeWhere(s)
+		b.writeReturning(s)
+
+	case Delete:
+		s.write("DELETE FROM ", b.table)
+		b.writeWhere(s)
+		b.writeReturning(s)
+	}
+
+	return s.sql.String(), s.params
+}
+
+// BuildCount compiles a SELECT COUNT(*) over the builder's joins and
+// conditions
+func (b *Builder) BuildCount() (string, []object.Object, error) {
+	if b.kind != Select {
+		return "", nil, fmt.Errorf("count needs a select query")
+	}
+
+	s := &statement{dialect: b.dialect}
+	s.write("SELECT COUNT(*) AS ", b.dialect.Quote, "count", b.dialect.Quote, " FROM ", b.table)
+	b.writeJoins(s)
+	b.writeWhere(s)
+	return s.sql.String(), s.params, nil
+}
+
+func (b *Builder) bindAll(s *statement, values []object.Object) string {
+	placeholders := make([]string, len(values))
+	for i, value := range values {
+		placeholders[i] = s.bind(value)
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+func (b *Builder) writeJoins(s *statement) {
+	for _, j := range b.joins {
+		s.write(" ", j.kind, " ", j.table, " ON ", j.on)
+	}
+}
+
+func (b *Builder) writeWhere(s *statement) {
+	for i, cond := range b.wheres {
+		if i == 0 {
+			s.write(" WHERE ")
+		} else {
+			s.write(" ", cond.conj, " ")
+		}
+
+		switch cond.op {
+		case "IS", "IS NOT":
+			s.write(cond.column, " ", cond.op, " NULL")
+		case "IN", "NOT IN":
+			elements := cond.value.(*object.Array).Elements
+			if len(elements) == 0 {
+				// Nothing is IN an empty list; everything is NOT IN it
+				if cond.op == "IN" {
+					s.write("1 = 0")
+				} else {
+					s.write("1 = 1")
+				}
+				continue
+			}
+			s.write(cond.column, " ", cond.op, " (", b.bindAll(s, elements), ")")
+		default:
+			s.write(cond.column, " ", cond.op, " ", s.bind(cond.value))
+		}
+	}
+}
+
+func (b *Builder) writeLimit(s *statement) {
+	switch {
+	case b.limit >= 0:
+		s.write(" LIMIT ", strconv.Itoa(b.limit))
+	case b.offset >= 0 && b.dialect.NoLimit != "":
+		s.write(" LIMIT ", b.dialect.NoLimit)
+	}
+	if b.offset >= 0 {
+		s.write(" OFFSET ", strconv.Itoa(b.offset))
+	}
+}
+
+func (b *Builder) writeReturning(s *statement) {
+	if len(b.returning) > 0 {
+		s.write(" RETURNING ", strings.Join(b.returning, ", "))
+	}
+}
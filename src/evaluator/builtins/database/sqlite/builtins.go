@@ -0,0 +1,500 @@
+package sqlite
+
+import (
+	"BanglaCode/src/evaluator/builtins/database/cursor"
+	"BanglaCode/src/object"
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+)
+
+// Builtins holds all SQLite built-in functions
+var Builtins = make(map[string]*object.Builtin)
+
+func init() {
+	// Connection management
+	registerBuiltin("db_jukto_sqlite", dbJuktoSQLite)
+	registerBuiltin("db_bandho_sqlite", dbBandhoSQLite)
+
+	// Query operations (synchronous)
+	registerBuiltin("db_query_sqlite", dbQuerySQLite)
+	registerBuiltin("db_exec_sqlite", dbExecSQLite)
+	registerBuiltin("db_proshno_sqlite", dbProshnoSQLite)
+	registerBuiltin("db_cursor_sqlite", dbCursorSQLite)
+
+	// Query operations (asynchronous)
+	registerBuiltin("db_query_async_sqlite", dbQueryAsyncSQLite)
+	registerBuiltin("db_exec_async_sqlite", dbExecAsyncSQLite)
+	registerBuiltin("db_proshno_async_sqlite", dbProshnoAsyncSQLite)
+
+	// Transaction support
+	registerBuiltin("db_transaction_shuru_sqlite", dbTransactionShuruSQLite)
+	registerBuiltin("db_commit_sqlite", dbCommitSQLite)
+	registerBuiltin("db_rollback_sqlite", dbRollbackSQLite)
+
+	// Bulk operations
+	registerBuiltin("db_bulk_insert_sqlite", dbBulkInsertSQLite)
+}
+
+func registerBuiltin(name string, fn object.BuiltinFunction) {
+	Builtins[name] = &object.Builtin{Fn: fn}
+}
+
+func newError(format string, a ...interface{}) *object.Error {
+	return &object.Error{Message: fmt.Sprintf(format, a...)}
+}
+
+// executor resolves the first argument of a query builtin: a connection, or
+// a transaction ID from db_transaction_shuru_sqlite to run inside it
+func executor(name string, arg object.Object) (Executor, *object.Error) {
+	switch target := arg.(type) {
+	case *object.DBConnection:
+		db, err := Handle(target)
+		if err != nil {
+			return nil, newError("%s: %s", name, err.Error())
+		}
+		return db, nil
+	case *object.String:
+		transactionsMu.RLock()
+		tx, exists := transactions[target.Value]
+		transactionsMu.RUnlock()
+		if !exists {
+			return nil, newError("%s: transaction %s not found", name, target.Value)
+		}
+		return tx, nil
+	default:
+		return nil, newError("%s: first argument must be DB_CONNECTION or STRING (transaction ID), got %s", name, arg.Type())
+	}
+}
+
+// db_jukto_sqlite - Open a SQLite database
+// Usage: db_jukto_sqlite({database: "app.db"}) or db_jukto_sqlite({database: ":memory:"})
+func dbJuktoSQLite(args ...object.Object) object.Object {
+	if len(args) > 1 {
+		return newError("db_jukto_sqlite: wrong number of arguments. got=%d, want=0 or 1", len(args))
+	}
+
+	config := object.NewMap()
+	if len(args) == 1 {
+		var ok bool
+		config, ok = args[0].(*object.Map)
+		if !ok {
+			return newError("db_jukto_sqlite: argument must be a map, got %s", args[0].Type())
+		}
+	}
+
+	conn, err := Connect(config)
+	if err != nil {
+		return newError("db_jukto_sqlite: %s", err.Error())
+	}
+
+	return conn
+}
+
+// db_bandho_sqlite - Close SQLite connection
+func dbBandhoSQLite(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("db_bandho_sqlite: wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	conn, ok := args[0].(*object.DBConnection)
+	if !ok {
+		return newError("db_bandho_sqlite: argument must be DB_CONNECTION, got %s", args[0].Type())
+	}
+
+	if err := Close(conn); err != nil {
+		return newError("db_bandho_sqlite: %s", err.Error())
+	}
+
+	return object.TRUE
+}
+
+// db_query_sqlite - Execute SELECT query (synchronous)
+func dbQuerySQLite(args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return newError("db_query_sqlite: wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	ex, errObj := executor("db_query_sqlite", args[0])
+	if errObj != nil {
+		return errObj
+	}
+
+	query, ok := args[1].(*object.String)
+	if !ok {
+		return newError("db_query_sqlite: second argument must be STRING, got %s", args[1].Type())
+	}
+
+	result, err := Query(context.Background(), ex, query.Value)
+	if err != nil {
+		return newError("db_query_sqlite: %s", err.Error())
+	}
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return dbResultToMap(result)
+}
+
+// db_exec_sqlite - Execute INSERT/UPDATE/DELETE or DDL (synchronous)
+func dbExecSQLite(args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return newError("db_exec_sqlite: wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	ex, errObj := executor("db_exec_sqlite", args[0])
+	if errObj != nil {
+		return errObj
+	}
+
+	query, ok := args[1].(*object.String)
+	if !ok {
+		return newError("db_exec_sqlite: second argument must be STRING, got %s", args[1].Type())
+	}
+
+	result, err := Exec(context.Background(), ex, query.Value)
+	if err != nil {
+		return newError("db_exec_sqlite: %s", err.Error())
+	}
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return dbResultToMap(result)
+}
+
+// db_proshno_sqlite - Execute parameterized query (SQL injection safe)
+func dbProshnoSQLite(args ...object.Object) object.Object {
+	if len(args) != 3 {
+		return newError("db_proshno_sqlite: wrong number of arguments. got=%d, want=3", len(args))
+	}
+
+	ex, errObj := executor("db_proshno_sqlite", args[0])
+	if errObj != nil {
+		return errObj
+	}
+
+	query, ok := args[1].(*object.String)
+	if !ok {
+		return newError("db_proshno_sqlite: second argument must be STRING, got %s", args[1].Type())
+	}
+
+	params, ok := args[2].(*object.Array)
+	if !ok {
+		return newError("db_proshno_sqlite: third argument must be ARRAY, got %s", args[2].Type())
+	}
+
+	result, err := PreparedQuery(context.Background(), ex, query.Value, params.Elements)
+	if err != nil {
+		return newError("db_proshno_sqlite: %s", err.Error())
+	}
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return dbResultToMap(result)
+}
+
+// db_cursor_sqlite - Run a query and read its rows lazily (synchronous)
+// Usage: ghuriye (row of db_cursor_sqlite(conn, "SELECT * FROM logs WHERE level = ?", ["error"])) { ... }
+func dbCursorSQLite(args ...object.Object) object.Object {
+	if len(args) != 2 && len(args) != 3 {
+		return newError("db_cursor_sqlite: wrong number of arguments. got=%d, want=2 or 3 (conn, query, params?)", len(args))
+	}
+
+	ex, errObj := executor("db_cursor_sqlite", args[0])
+	if errObj != nil {
+		return errObj
+	}
+
+	query, ok := args[1].(*object.String)
+	if !ok {
+		return newError("db_cursor_sqlite: second argument must be STRING, got %s", args[1].Type())
+	}
+
+	var params []object.Object
+	if len(args) == 3 {
+		arr, ok := args[2].(*object.Array)
+		if !ok {
+			return newError("db_cursor_sqlite: third argument must be ARRAY, got %s", args[2].Type())
+		}
+		params = arr.Elements
+	}
+
+	source, err := Cursor(context.Background(), ex, query.Value, params)
+	if err != nil {
+		return newError("db_cursor_sqlite: %s", err.Error())
+	}
+
+	return cursor.New("db_cursor_sqlite", source)
+}
+
+// Async functions
+
+func dbQueryAsyncSQLite(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
+	if len(args) != 2 {
+		return newError("db_query_async_sqlite: wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	ex, errObj := executor("db_query_async_sqlite", args[0])
+	if errObj != nil {
+		return errObj
+	}
+
+	query, ok := args[1].(*object.String)
+	if !ok {
+		return newError("db_query_async_sqlite: second argument must be STRING, got %s", args[1].Type())
+	}
+
+	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
+
+	go func() {
+		defer stop()
+		result, err := Query(ctx, ex, query.Value)
+		if err != nil {
+			object.RejectPromise(promise, newError("db_query_async_sqlite: %s", err.Error()))
+			return
+		}
+		if result.Error != nil {
+			object.RejectPromise(promise, result.Error)
+			return
+		}
+		object.ResolvePromise(promise, dbResultToMap(result))
+	}()
+
+	return promise
+}
+
+func dbExecAsyncSQLite(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
+	if len(args) != 2 {
+		return newError("db_exec_async_sqlite: wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	ex, errObj := executor("db_exec_async_sqlite", args[0])
+	if errObj != nil {
+		return errObj
+	}
+
+	query, ok := args[1].(*object.String)
+	if !ok {
+		return newError("db_exec_async_sqlite: second argument must be STRING, got %s", args[1].Type())
+	}
+
+	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
+
+	go func() {
+		defer stop()
+		result, err := Exec(ctx, ex, query.Value)
+		if err != nil {
+			object.RejectPromise(promise, newError("db_exec_async_sqlite: %s", err.Error()))
+			return
+		}
+		if result.Error != nil {
+			object.RejectPromise(promise, result.Error)
+			return
+		}
+		object.ResolvePromise(promise, dbResultToMap(result))
+	}()
+
+	return promise
+}
+
+func dbProshnoAsyncSQLite(args ...object.Object) object.Object {
+	args, signal := object.TakeSignal(args)
+	if len(args) != 3 {
+		return newError("db_proshno_async_sqlite: wrong number of arguments. got=%d, want=3", len(args))
+	}
+
+	ex, errObj := executor("db_proshno_async_sqlite", args[0])
+	if errObj != nil {
+		return errObj
+	}
+
+	query, ok := args[1].(*object.String)
+	if !ok {
+		return newError("db_proshno_async_sqlite: second argument must be STRING, got %s", args[1].Type())
+	}
+
+	params, ok := args[2].(*object.Array)
+	if !ok {
+		return newError("db_proshno_async_sqlite: third argument must be ARRAY, got %s", args[2].Type())
+	}
+
+	promise := object.CreatePromise()
+	object.RejectOnAbort(promise, signal)
+	ctx, stop := signal.Context()
+
+	go func() {
+		defer stop()
+		result, err := PreparedQuery(ctx, ex, query.Value, params.Elements)
+		if err != nil {
+			object.RejectPromise(promise, newError("db_proshno_async_sqlite: %s", err.Error()))
+			return
+		}
+		if result.Error != nil {
+			object.RejectPromise(promise, result.Error)
+			return
+		}
+		object.ResolvePromise(promise, dbResultToMap(result))
+	}()
+
+	return promise
+}
+
+// Transaction support
+
+var transactions = make(map[string]*sql.Tx)
+var transactionsMu sync.RWMutex
+var txIDCounter int64
+
+func dbTransactionShuruSQLite(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("db_transaction_shuru_sqlite: wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	conn, ok := args[0].(*object.DBConnection)
+	if !ok {
+		return newError("db_transaction_shuru_sqlite: argument must be DB_CONNECTION, got %s", args[0].Type())
+	}
+
+	tx, err := BeginTransaction(conn)
+	if err != nil {
+		return newError("db_transaction_shuru_sqlite: %s", err.Error())
+	}
+
+	transactionsMu.Lock()
+	txIDCounter++
+	txID := fmt.Sprintf("tx-sqlite-%d", txIDCounter)
+	transactions[txID] = tx
+	transactionsMu.Unlock()
+
+	return &object.String{Value: txID}
+}
+
+func dbCommitSQLite(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("db_commit_sqlite: wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	txID, ok := args[0].(*object.String)
+	if !ok {
+		return newError("db_commit_sqlite: argument must be STRING (transaction ID), got %s", args[0].Type())
+	}
+
+	transactionsMu.Lock()
+	tx, exists := transactions[txID.Value]
+	if !exists {
+		transactionsMu.Unlock()
+		return newError("db_commit_sqlite: transaction %s not found", txID.Value)
+	}
+	delete(transactions, txID.Value)
+	transactionsMu.Unlock()
+
+	if err := Commit(tx); err != nil {
+		return newError("db_commit_sqlite: %s", err.Error())
+	}
+
+	return object.TRUE
+}
+
+func dbRollbackSQLite(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("db_rollback_sqlite: wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	txID, ok := args[0].(*object.String)
+	if !ok {
+		return newError("db_rollback_sqlite: argument must be STRING (transaction ID), got %s", args[0].Type())
+	}
+
+	transactionsMu.Lock()
+	tx, exists := transactions[txID.Value]
+	if !exists {
+		transactionsMu.Unlock()
+		return newError("db_rollback_sqlite: transaction %s not found", txID.Value)
+	}
+	delete(transactions, txID.Value)
+	transactionsMu.Unlock()
+
+	if err := Rollback(tx); err != nil {
+		return newError("db_rollback_sqlite: %s", err.Error())
+	}
+
+	return object.TRUE
+}
+
+// Bulk operations
+
+// db_bulk_insert_sqlite - Perform efficient bulk insert
+// Usage: db_bulk_insert_sqlite(conn, "users", ["name", "age"], [["Alice", 25], ["Bob", 30], ["Charlie", 35]])
+func dbBulkInsertSQLite(args ...object.Object) object.Object {
+	if len(args) != 4 {
+		return newError("db_bulk_insert_sqlite: wrong number of arguments. got=%d, want=4 (conn, table, columns, rows)", len(args))
+	}
+
+	ex, errObj := executor("db_bulk_insert_sqlite", args[0])
+	if errObj != nil {
+		return errObj
+	}
+
+	table, ok := args[1].(*object.String)
+	if !ok {
+		return newError("db_bulk_insert_sqlite: second argument must be STRING (table name), got %s", args[1].Type())
+	}
+
+	columnsArray, ok := args[2].(*object.Array)
+	if !ok {
+		return newError("db_bulk_insert_sqlite: third argument must be ARRAY (column names), got %s", args[2].Type())
+	}
+
+	// Convert column names array to string slice
+	columns := make([]string, len(columnsArray.Elements))
+	for i, elem := range columnsArray.Elements {
+		colName, ok := elem.(*object.String)
+		if !ok {
+			return newError("db_bulk_insert_sqlite: column name must be STRING, got %s", elem.Type())
+		}
+		columns[i] = colName.Value
+	}
+
+	rows, ok := args[3].(*object.Array)
+	if !ok {
+		return newError("db_bulk_insert_sqlite: fourth argument must be ARRAY (rows), got %s", args[3].Type())
+	}
+
+	result, err := BulkInsert(context.Background(), ex, table.Value, columns, rows)
+	if err != nil {
+		return newError("db_bulk_insert_sqlite: %s", err.Error())
+	}
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return dbResultToMap(result)
+}
+
+func dbResultToMap(result *object.DBResult) *object.Map {
+	pairs := object.NewMap()
+
+	rowsArray := &object.Array{Elements: make([]object.Object, len(result.Rows))}
+	for i := range result.Rows {
+		rowsArray.Elements[i] = result.Row(i)
+	}
+
+	pairs.Set("rows", rowsArray)
+	pairs.Set("rows_affected", &object.Number{Value: float64(result.RowsAffected)})
+	pairs.Set("last_insert_id", &object.Number{Value: float64(result.LastInsertID)})
+
+	return pairs
+}
@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"BanglaCode/src/object"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ValidateConnection checks if a connection is valid for SQLite operations
+func ValidateConnection(conn *object.DBConnection) error {
+	if conn == nil {
+		return fmt.Errorf("connection is nil")
+	}
+
+	if conn.DBType != "sqlite" {
+		return fmt.Errorf("expected sqlite connection, got %s", conn.DBType)
+	}
+
+	if conn.Native == nil {
+		return fmt.Errorf("native connection is nil")
+	}
+
+	return nil
+}
+
+// BuildDSN builds a SQLite DSN for a database file, or for a named
+// in-memory database when memory is set
+// Format: file:/name?vfs=memdb&_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)
+func BuildDSN(name string, memory bool, busyTimeout int) string {
+	dsn := name
+	if memory {
+		dsn = "file:/" + name + "?vfs=memdb"
+	}
+
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%s_pragma=busy_timeout(%d)&_pragma=foreign_keys(1)", dsn, sep, busyTimeout)
+}
+
+// IsSQLiteError checks if an error comes from SQLite
+func IsSQLiteError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	errMsg := err.Error()
+	return strings.Contains(errMsg, "SQLITE_") ||
+		strings.Contains(errMsg, "SQL logic error") ||
+		strings.Contains(errMsg, "constraint failed")
+}
+
+// GetQueryType returns the leading keyword of a SQL statement
+func GetQueryType(query string) string {
+	fields := strings.FieldsFunc(query, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '('
+	})
+	if len(fields) == 0 {
+		return "UNKNOWN"
+	}
+
+	switch keyword := strings.ToUpper(fields[0]); keyword {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "REPLACE", "CREATE", "ALTER", "DROP",
+		"WITH", "PRAGMA", "EXPLAIN", "VALUES":
+		return keyword
+	}
+
+	return "UNKNOWN"
+}
+
+var returningClause = regexp.MustCompile(`(?i)\bRETURNING\b`)
+
+// hasReturningClause reports whether a DML statement returns rows
+func hasReturningClause(query string) bool {
+	return returningClause.MatchString(query)
+}
@@ -0,0 +1,404 @@
+package sqlite
+
+import (
+	"BanglaCode/src/evaluator/builtins/database/cursor"
+	"BanglaCode/src/object"
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	_ "modernc.org/sqlite" // Pure-Go SQLite driver (no cgo)
+)
+
+var (
+	connections   = make(map[string]*sql.DB)
+	connectionsMu sync.RWMutex
+)
+
+// Executor runs statements against a database or an open transaction
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Connect opens a SQLite database file, or a private in-memory database
+// when database is ":memory:" (the default)
+func Connect(config *object.Map) (*object.DBConnection, error) {
+	// Extract connection parameters
+	database := extractString(config, "database", ":memory:")
+	shared := extractString(config, "shared", "")
+	busyTimeout := extractNumber(config, "busy_timeout", 5000)
+
+	// Generate unique connection ID
+	connID := generateConnID()
+
+	// In-memory databases live in the memdb VFS so every pooled *sql.DB
+	// connection sees the same data; "shared" names one across connects
+	memory := database == ":memory:"
+	name := database
+	if memory {
+		name = connID
+		if shared != "" {
+			name = "shared-" + shared
+		}
+	}
+
+	// Open connection
+	db, err := sql.Open("sqlite", BuildDSN(name, memory, int(busyTimeout)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %v", err)
+	}
+
+	// Test connection
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open database: %v", err)
+	}
+
+	// Configure connection pool; an in-memory database disappears with its
+	// last connection, so always keep one idle
+	maxConns := extractNumber(config, "max_conns", 10)
+	db.SetMaxOpenConns(int(maxConns))
+	db.SetMaxIdleConns(max(int(maxConns/2), 1))
+
+	// Store connection globally
+	connectionsMu.Lock()
+	connections[connID] = db
+	connectionsMu.Unlock()
+
+	// Create metadata
+	metadata := make(map[string]object.Object)
+	metadata["database"] = &object.String{Value: database}
+	metadata["memory"] = object.NativeBoolToBooleanObject(memory)
+
+	// Create DBConnection object
+	conn := &object.DBConnection{
+		ID:       connID,
+		DBType:   "sqlite",
+		Native:   db,
+		Metadata: metadata,
+	}
+
+	return conn, nil
+}
+
+// Close closes a SQLite connection
+func Close(conn *object.DBConnection) error {
+	db, err := Handle(conn)
+	if err != nil {
+		return err
+	}
+
+	// Remove from global registry
+	connectionsMu.Lock()
+	delete(connections, conn.ID)
+	connectionsMu.Unlock()
+
+	return db.Close()
+}
+
+// Handle returns the *sql.DB behind a SQLite connection
+func Handle(conn *object.DBConnection) (*sql.DB, error) {
+	if err := ValidateConnection(conn); err != nil {
+		return nil, err
+	}
+
+	db, ok := conn.Native.(*sql.DB)
+	if !ok {
+		return nil, fmt.Errorf("invalid native connection type")
+	}
+	return db, nil
+}
+
+// Query executes a SELECT query
+func Query(ctx context.Context, ex Executor, query string) (*object.DBResult, error) {
+	rows, err := ex.QueryContext(ctx, query)
+	if err != nil {
+		return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
+	}
+	defer rows.Close()
+
+	// Convert rows to BanglaCode objects
+	result, err := convertRows(rows)
+	if err != nil {
+		return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
+	}
+
+	return result, nil
+}
+
+// Exec executes an INSERT, UPDATE, DELETE or DDL statement
+func Exec(ctx context.Context, ex Executor, query string) (*object.DBResult, error) {
+	result, err := ex.ExecContext(ctx, query)
+	if err != nil {
+		return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
+	}
+
+	return execResult(result), nil
+}
+
+// PreparedQuery executes a parameterized query (SQL injection safe)
+func PreparedQuery(ctx context.Context, ex Executor, query string, params []object.Object) (*object.DBResult, error) {
+	// Convert BanglaCode objects to Go values
+	args := make([]interface{}, len(params))
+	for i, param := range params {
+		args[i] = objectToGoValue(param)
+	}
+
+	// Check if query returns rows or is DML
+	if isSelectQuery(query) {
+		rows, err := ex.QueryContext(ctx, query, args...)
+		if err != nil {
+			return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
+		}
+		defer rows.Close()
+
+		result, err := convertRows(rows)
+		if err != nil {
+			return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
+		}
+
+		return result, nil
+	}
+
+	// Execute DML (INSERT/UPDATE/DELETE)
+	result, err := ex.ExecContext(ctx, query, args...)
+	if err != nil {
+		return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
+	}
+
+	return execResult(result), nil
+}
+
+// Cursor runs a query and returns its rows as a cursor source that fetches
+// them lazily; closing the source closes the rows and cancels the query
+func Cursor(parent context.Context, ex Executor, query string, params []object.Object) (cursor.Source, error) {
+	args := make([]interface{}, len(params))
+	for i, param := range params {
+		args[i] = objectToGoValue(param)
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	rows, err := ex.QueryContext(ctx, query, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return cursor.Rows(rows, cancel, goValueToObject)
+}
+
+// BeginTransaction starts a new database transaction
+func BeginTransaction(conn *object.DBConnection) (*sql.Tx, error) {
+	db, err := Handle(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.Begin()
+}
+
+// Commit commits a transaction
+func Commit(tx *sql.Tx) error {
+	return tx.Commit()
+}
+
+// Rollback rolls back a transaction
+func Rollback(tx *sql.Tx) error {
+	return tx.Rollback()
+}
+
+// BulkInsert inserts many rows with a single multi-row INSERT statement
+// Example: BulkInsert(ctx, db, "users", ["name", "age"], [["Alice", 25], ["Bob", 30]])
+func BulkInsert(ctx context.Context, ex Executor, table string, columns []string, rows *object.Array) (*object.DBResult, error) {
+	if len(rows.Elements) == 0 {
+		return &object.DBResult{
+			Rows:         []map[string]object.Object{},
+			RowsAffected: 0,
+		}, nil
+	}
+
+	// Build multi-row INSERT statement
+	// INSERT INTO table (col1, col2) VALUES (?, ?), (?, ?), ...
+	numCols := len(columns)
+	numRows := len(rows.Elements)
+
+	// Build column names
+	colNames := ""
+	for i, col := range columns {
+		if i > 0 {
+			colNames += ", "
+		}
+		colNames += col
+	}
+
+	// Build placeholders and collect values
+	values := make([]interface{}, 0, numRows*numCols)
+	placeholders := ""
+
+	for i, rowObj := range rows.Elements {
+		rowArray, ok := rowObj.(*object.Array)
+		if !ok {
+			return nil, fmt.Errorf("row %d must be an array, got %s", i, rowObj.Type())
+		}
+
+		if len(rowArray.Elements) != numCols {
+			return nil, fmt.Errorf("row %d has %d columns, expected %d", i, len(rowArray.Elements), numCols)
+		}
+
+		if i > 0 {
+			placeholders += ", "
+		}
+		placeholders += "("
+
+		for j, valueObj := range rowArray.Elements {
+			if j > 0 {
+				placeholders += ", "
+			}
+			placeholders += "?"
+			values = append(values, objectToGoValue(valueObj))
+		}
+
+		placeholders += ")"
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", table, colNames, placeholders)
+
+	// Execute bulk insert
+	result, err := ex.ExecContext(ctx, query, values...)
+	if err != nil {
+		return &object.DBResult{Error: &object.Error{Message: err.Error()}}, nil
+	}
+
+	return execResult(result), nil
+}
+
+// Helper functions
+
+func execResult(result sql.Result) *object.DBResult {
+	rowsAffected, _ := result.RowsAffected()
+	lastInsertID, _ := result.LastInsertId()
+
+	return &object.DBResult{
+		Rows:         []map[string]object.Object{},
+		RowsAffected: rowsAffected,
+		LastInsertID: lastInsertID,
+	}
+}
+
+func extractString(config *object.Map, key string, defaultValue string) string {
+	if val, ok := config.Pairs[key]; ok {
+		if str, ok := val.(*object.String); ok {
+			return str.Value
+		}
+	}
+	return defaultValue
+}
+
+func extractNumber(config *object.Map, key string, defaultValue float64) float64 {
+	if val, ok := config.Pairs[key]; ok {
+		if num, ok := val.(*object.Number); ok {
+			return num.Value
+		}
+	}
+	return defaultValue
+}
+
+var connIDCounter int64
+
+func generateConnID() string {
+	return fmt.Sprintf("sqlite-%d", atomic.AddInt64(&connIDCounter, 1))
+}
+
+// objectToGoValue converts a BanglaCode object to a Go value; whole numbers
+// bind as integers so they work with LIMIT, OFFSET and rowid comparisons
+func objectToGoValue(obj object.Object) interface{} {
+	switch o := obj.(type) {
+	case *object.Number:
+		if o.Value == math.Trunc(o.Value) && math.Abs(o.Value) < 1<<53 {
+			return int64(o.Value)
+		}
+		return o.Value
+	case *object.String:
+		return o.Value
+	case *object.Boolean:
+		return o.Value
+	case *object.Null:
+		return nil
+	default:
+		return o.Inspect()
+	}
+}
+
+func convertRows(rows *sql.Rows) (*object.DBResult, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]object.Object, 0, 100)
+
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		valuePtrs := make([]interface{}, len(columns))
+		for i := range values {
+			valuePtrs[i] = &values[i]
+		}
+
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]object.Object, len(columns))
+		for i, col := range columns {
+			row[col] = goValueToObject(values[i])
+		}
+
+		result = append(result, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &object.DBResult{
+		Rows:         result,
+		Columns:      columns,
+		RowsAffected: int64(len(result)),
+	}, nil
+}
+
+func goValueToObject(value interface{}) object.Object {
+	if value == nil {
+		return object.NULL
+	}
+
+	switch v := value.(type) {
+	case int64:
+		return &object.Number{Value: float64(v)}
+	case float64:
+		return &object.Number{Value: v}
+	case string:
+		return &object.String{Value: v}
+	case bool:
+		return object.NativeBoolToBooleanObject(v)
+	case []byte:
+		return &object.String{Value: string(v)}
+	case time.Time:
+		return &object.String{Value: v.Format(time.RFC3339Nano)}
+	default:
+		return &object.String{Value: fmt.Sprintf("%v", v)}
+	}
+}
+
+// isSelectQuery reports whether a statement returns rows
+func isSelectQuery(query string) bool {
+	switch GetQueryType(query) {
+	case "SELECT", "WITH", "PRAGMA", "EXPLAIN", "VALUES":
+		return true
+	}
+	return hasReturningClause(query)
+}
@@ -0,0 +1,105 @@
+package builtins
+
+// Doc describes a builtin for editor tooling (hover, signature help)
+type Doc struct {
+	Signature string // e.g. "bhag(str, separator)"
+	Summary   string // one line, Bengali with an English hint
+}
+
+// Docs holds documentation for the most used builtins; the language server
+// falls back to a generic description for the rest
+var Docs = map[string]Doc{
+	// আউটপুট (variadic - accepts any number of arguments)
+	"dekho": {"dekho(...values)", "দেখো - মান প্রিন্ট করো। কনসোলে যেকোনো সংখ্যক মান প্রিন্ট করে (variadic)"},
+
+	// টাইপ ফাংশন
+	"dhoron":  {"dhoron(value)", "ধরন - ডেটা টাইপ জানো। মানের ধরন রিটার্ন করে"},
+	"lipi":    {"lipi(value)", "লিপি - স্ট্রিং এ রূপান্তর। মানকে স্ট্রিং এ রূপান্তর করে"},
+	"jongate": {"jongate(value)", "জনগতে - স্ট্রিং এ রূপান্তর। মানকে স্ট্রিং এ রূপান্তর করে (lipi এর alias, সাধারণত সংখ্যা কনক্যাটেনেশনের জন্য ব্যবহৃত)"},
+	"sonkha":  {"sonkha(value)", "সংখ্যা - নাম্বার এ রূপান্তর। মানকে সংখ্যায় রূপান্তর করে"},
+	"dorghyo": {"dorghyo(value)", "দৈর্ঘ্য - লেন্থ নাও। স্ট্রিং বা অ্যারের দৈর্ঘ্য রিটার্ন করে"},
+
+	// স্ট্রিং ফাংশন
+	"boroHater":  {"boroHater(str)", "বড় হাতের - আপারকেস। স্ট্রিংকে বড় হাতের অক্ষরে রূপান্তর করে"},
+	"chotoHater": {"chotoHater(str)", "ছোট হাতের - লোয়ারকেস। স্ট্রিংকে ছোট হাতের অক্ষরে রূপান্তর করে"},
+	"chhanto":    {"chhanto(str)", "ছাঁটো - ট্রিম করো। স্ট্রিং থেকে ফাঁকা জায়গা সরায়"},
+	"bhag":       {"bhag(str, separator)", "ভাগ - স্প্লিট করো। স্ট্রিংকে অ্যারেতে ভাগ করে"},
+	"joro":       {"joro(arr, separator)", "জোড়ো - জয়েন করো। অ্যারেকে স্ট্রিং এ জোড়ে"},
+	"khojo":      {"khojo(str, substr)", "খোঁজো - ইনডেক্স খুঁজো। সাবস্ট্রিংয়ের ইনডেক্স খুঁজে দেয় (-১ যদি না পাওয়া যায়)"},
+	"angsho":     {"angsho(str, start, end)", "অংশ - সাবস্ট্রিং নাও। শুরু থেকে শেষ পর্যন্ত সাবস্ট্রিং বের করে"},
+	"bodlo":      {"bodlo(str, old, new)", "বদলো - রিপ্লেস করো। সব জায়গায় পুরনো মানকে নতুন দিয়ে বদলায়"},
+
+	// অ্যারে ফাংশন
+	"dhokao":  {"dhokao(arr, value)", "ঢোকাও - পুশ করো। অ্যারেতে এলিমেন্ট যোগ করে"},
+	"berKoro": {"berKoro(arr)", "বের করো - পপ করো। শেষ এলিমেন্ট সরিয়ে রিটার্ন করে"},
+	"kato":    {"kato(arr, start, end)", "কাটো - স্লাইস করো। অ্যারের একটি অংশ বের করে"},
+	"ulto":    {"ulto(arr)", "উল্টো - রিভার্স করো। অ্যারে উল্টে দেয় (নতুন অ্যারে রিটার্ন করে)"},
+	"saja":    {"saja(arr)", "সাজা - সর্ট করো। অ্যারে সাজায় (নতুন অ্যারে রিটার্ন করে)"},
+	"ache":    {"ache(arr, value)", "আছে - খুঁজে দেখো। অ্যারেতে মান আছে কিনা চেক করে"},
+	"chabi":   {"chabi(map)", "চাবি - কী গুলো নাও। ম্যাপের সব কী এর অ্যারে রিটার্ন করে"},
+
+	// গাণিতিক ফাংশন
+	"borgomul": {"borgomul(x)", "বর্গমূল - স্কয়ার রুট। বর্গমূল রিটার্ন করে"},
+	"ghat":     {"ghat(base, exp)", "ঘাত - পাওয়ার। বেস কে এক্সপোনেন্ট এ উন্নীত করে"},
+	"niche":    {"niche(x)", "নিচে - ফ্লোর। নিচের দিকে রাউন্ড করে"},
+	"upore":    {"upore(x)", "উপরে - সিলিং। উপরের দিকে রাউন্ড করে"},
+	"kache":    {"kache(x)", "কাছে - রাউন্ড। কাছের পূর্ণসংখ্যায় রাউন্ড করে"},
+	"niratek":  {"niratek(x)", "নিরপেক্ষ - এবসোলিউট। নিরপেক্ষ মান রিটার্ন করে"},
+	"choto":    {"choto(...values)", "ছোট - মিনিমাম। সবচেয়ে ছোট মান রিটার্ন করে (variadic - ২+ আর্গুমেন্ট নেয়)"},
+	"boro":     {"boro(...values)", "বড় - ম্যাক্সিমাম। সবচেয়ে বড় মান রিটার্ন করে (variadic - ২+ আর্গুমেন্ট নেয়)"},
+	"lotto":    {"lotto()", "লটো - র্যান্ডম। ০ থেকে ১ এর মধ্যে র্যান্ডম নাম্বার রিটার্ন করে"},
+
+	// ইউটিলিটি ফাংশন
+	"somoy":  {"somoy()", "সময় - বর্তমান সময়। বর্তমান টাইমস্ট্যাম্প মিলিসেকেন্ডে রিটার্ন করে"},
+	"ghum":   {"ghum(milliseconds)", "ঘুম - স্লিপ করো। নির্দিষ্ট মিলিসেকেন্ড এর জন্য থামিয়ে রাখে"},
+	"nao":    {"nao(\"মান লেখো: \")", "নাও - ইনপুট নাও। কনসোল থেকে ইউজার ইনপুট পড়ে"},
+	"bondho": {"bondho(0)", "বন্ধ - এক্সিট করো। প্রোগ্রাম বন্ধ করে"},
+
+	// ফাইল ফাংশন
+	"poro":  {"poro(\"filename\")", "পড়ো - ফাইল পড়ো। ফাইল এর কন্টেন্ট স্ট্রিং হিসেবে পড়ে"},
+	"lekho": {"lekho(\"filename\", content)", "লেখো - ফাইল লেখো। ফাইলে কন্টেন্ট লেখে"},
+
+	// HTTP ফাংশন
+	"server_chalu": {"server_chalu(3000, handler)", "সার্ভার চালু - HTTP সার্ভার। নির্দিষ্ট পোর্টে HTTP সার্ভার চালু করে"},
+	"anun":         {"anun(\"url\")", "আনুন - HTTP GET রিকোয়েস্ট। HTTP GET রিকোয়েস্ট করে"},
+
+	// অ্যাসিঙ্ক্রোনাস ফাংশন
+	"ghumaao":         {"ghumaao(milliseconds, signal?)", "ঘুমাও - অ্যাসিঙ্ক স্লিপ। নির্দিষ্ট মিলিসেকেন্ড এর জন্য অপেক্ষা করে (প্রমিস রিটার্ন করে)"},
+	"anun_async":      {"anun_async(\"url\", signal?)", "আনুন অ্যাসিঙ্ক - HTTP GET অ্যাসিঙ্ক। অ্যাসিঙ্ক্রোনাস HTTP GET রিকোয়েস্ট করে (প্রমিস রিটার্ন করে)"},
+	"poro_async":      {"poro_async(\"filename\")", "পড়ো অ্যাসিঙ্ক - ফাইল পড়ো অ্যাসিঙ্ক। অ্যাসিঙ্ক্রোনাস ফাইল পড়ে (প্রমিস রিটার্ন করে)"},
+	"lekho_async":     {"lekho_async(\"filename\", content)", "লেখো অ্যাসিঙ্ক - ফাইল লেখো অ্যাসিঙ্ক। অ্যাসিঙ্ক্রোনাস ফাইলে লেখে (প্রমিস রিটার্ন করে)"},
+	"sob_proyash":     {"sob_proyash(promisesArray)", "সব প্রয়াস - Promise.all। সব প্রমিসের জন্য অপেক্ষা করে (Promise.all এর মতো)"},
+	"prothom_proyash": {"prothom_proyash(promisesArray)", "প্রথম প্রয়াস - Promise.race। যে প্রমিস প্রথমে শেষ হয় তার ফলাফল দেয়"},
+	"sob_nishpotti":   {"sob_nishpotti(promisesArray)", "সব নিষ্পত্তি - Promise.allSettled। সব প্রমিস শেষ হলে প্রতিটির {status, value/reason} দেয়"},
+	"jekono_proyash":  {"jekono_proyash(promisesArray)", "যেকোনো প্রয়াস - Promise.any। প্রথম সফল প্রমিসের মান দেয়; সব ব্যর্থ হলে AggregateError"},
+	"somoy_shima":     {"somoy_shima(promise, milliseconds)", "সময় সীমা - প্রমিস টাইমআউট। নির্দিষ্ট সময়ে শেষ না হলে TimeoutError দিয়ে রিজেক্ট করে"},
+	"Promise":         {"notun Promise((resolve, reject) => { ... })", "প্রমিস - নতুন প্রমিস তৈরি করে; .then/.catch/.finally এবং Promise.race/allSettled/any/resolve/reject আছে"},
+	"AbortController": {"notun AbortController()", "বাতিল নিয়ন্ত্রক - {signal, abort} দেয়; signal টাইমার, HTTP ও ডেটাবেস কলের শেষ আর্গুমেন্ট হিসেবে দিলে abort() সেগুলো বাতিল করে"},
+
+	// TCP নেটওয়ার্ক ফাংশন
+	"tcp_server_chalu": {"tcp_server_chalu(port, handler)", "টিসিপি সার্ভার চালু - TCP Server। নির্দিষ্ট পোর্টে TCP সার্ভার চালু করে। হ্যান্ডলার ফাংশন প্রতিটি সংযোগের জন্য কল হয়।"},
+	"tcp_jukto":        {"tcp_jukto(\"host\", port)", "টিসিপি যুক্ত - TCP Connect। TCP সার্ভারের সাথে সংযোগ তৈরি করে (অ্যাসিঙ্ক - প্রমিস রিটার্ন করে)। সংযোগ অবজেক্ট রিটার্ন করে।"},
+	"tcp_pathao":       {"tcp_pathao(conn, \"message\")", "টিসিপি পাঠাও - TCP Send। TCP সংযোগে ডেটা পাঠায়। conn হল সংযোগ অবজেক্ট।"},
+	"tcp_lekho":        {"tcp_lekho(conn, \"message\")", "টিসিপি লেখো - TCP Write। TCP সংযোগে ডেটা লেখে (tcp_pathao এর সমতুল্য)। conn হল সংযোগ অবজেক্ট।"},
+	"tcp_shuno":        {"tcp_shuno(conn)", "টিসিপি শুনো - TCP Read। TCP সংযোগ থেকে ডেটা পড়ে (অ্যাসিঙ্ক - প্রমিস রিটার্ন করে)। প্রাপ্ত স্ট্রিং রিটার্ন করে।"},
+	"tcp_bondho":       {"tcp_bondho(conn)", "টিসিপি বন্ধ - TCP Close। TCP সংযোগ বন্ধ করে। conn হল সংযোগ অবজেক্ট।"},
+
+	// UDP নেটওয়ার্ক ফাংশন
+	"udp_server_chalu": {"udp_server_chalu(port, handler)", "ইউডিপি সার্ভার চালু - UDP Server। নির্দিষ্ট পোর্টে UDP সার্ভার চালু করে। হ্যান্ডলার ফাংশন প্রতিটি প্যাকেটের জন্য কল হয়।"},
+	"udp_pathao":       {"udp_pathao(\"host\", port, \"message\")", "ইউডিপি পাঠাও - UDP Send। UDP প্যাকেট পাঠায় নির্দিষ্ট হোস্ট এবং পোর্টে।"},
+	"udp_uttor":        {"udp_uttor(packet, \"response\")", "ইউডিপি উত্তর - UDP Reply। UDP প্যাকেটের উত্তর পাঠায়। packet হল রিসিভড প্যাকেট অবজেক্ট।"},
+	"udp_shuno":        {"udp_shuno(port, handler)", "ইউডিপি শুনো - UDP Listen। UDP পোর্টে শোনা শুরু করে (udp_server_chalu এর সমতুল্য)।"},
+	"udp_bondho":       {"udp_bondho(server)", "ইউডিপি বন্ধ - UDP Close। UDP সার্ভার বন্ধ করে।"},
+
+	// WebSocket নেটওয়ার্ক ফাংশন
+	"websocket_server_chalu": {"websocket_server_chalu(port, handler)", "ওয়েবসকেট সার্ভার চালু - WebSocket Server। নির্দিষ্ট পোর্টে WebSocket সার্ভার চালু করে। হ্যান্ডলার ফাংশন প্রতিটি মেসেজের জন্য কল হয়।"},
+	"websocket_jukto":        {"websocket_jukto(\"ws://host:port\")", "ওয়েবসকেট যুক্ত - WebSocket Connect। WebSocket সার্ভারের সাথে সংযোগ তৈরি করে (অ্যাসিঙ্ক - প্রমিস রিটার্ন করে)।"},
+	"websocket_pathao":       {"websocket_pathao(conn, \"message\")", "ওয়েবসকেট পাঠাও - WebSocket Send। WebSocket সংযোগে মেসেজ পাঠায়।"},
+	"websocket_bondho":       {"websocket_bondho(conn)", "ওয়েবসকেট বন্ধ - WebSocket Close। WebSocket সংযোগ বন্ধ করে।"},
+
+	// পরীক্ষা (অ্যাসারশন) ফাংশন
+	"nishchit":        {"nishchit(value, \"message\"?)", "নিশ্চিত - Assert। মান সত্য না হলে AssertionError ফেলে।"},
+	"nishchit_soman":  {"nishchit_soman(actual, expected, \"message\"?)", "নিশ্চিত সমান - Assert Equal। দুটি মান গভীরভাবে তুলনা করে; আলাদা হলে পার্থক্যসহ AssertionError ফেলে।"},
+	"nishchit_osoman": {"nishchit_osoman(actual, expected, \"message\"?)", "নিশ্চিত অসমান - Assert Not Equal। দুটি মান সমান হলে AssertionError ফেলে।"},
+	"nishchit_felbe":  {"nishchit_felbe(fn, \"message\"?)", "নিশ্চিত ফেলবে - Assert Throws। ফাংশন (বা প্রমিস) এরর না ফেললে AssertionError ফেলে। ফেলা মানটি রিটার্ন করে।"},
+}
@@ -8,6 +8,16 @@ import (
 // Builtins holds error-related built-in functions
 var Builtins = map[string]*object.Builtin{}
 
+// newErrorMap creates an error object as a Map to make it accessible in
+// BanglaCode; stack is populated when it is thrown
+func newErrorMap(name, message string) *object.Map {
+	errorMap := object.NewMap()
+	errorMap.Set("message", &object.String{Value: message})
+	errorMap.Set("name", &object.String{Value: name})
+	errorMap.Set("stack", &object.String{Value: ""})
+	return errorMap
+}
+
 func init() {
 	// Register error constructor built-in functions
 
@@ -25,16 +35,7 @@ func init() {
 				message = args[0].Inspect()
 			}
 
-			// Create error object as a Map to make it accessible in BanglaCode
-			errorMap := &object.Map{
-				Pairs: map[string]object.Object{
-					"message": &object.String{Value: message},
-					"name":    &object.String{Value: "Error"},
-					"stack":   &object.String{Value: ""}, // Will be populated when thrown
-				},
-			}
-
-			return errorMap
+			return newErrorMap("Error", message)
 		},
 	}
 
@@ -52,15 +53,7 @@ func init() {
 				message = args[0].Inspect()
 			}
 
-			errorMap := &object.Map{
-				Pairs: map[string]object.Object{
-					"message": &object.String{Value: message},
-					"name":    &object.String{Value: "TypeError"},
-					"stack":   &object.String{Value: ""},
-				},
-			}
-
-			return errorMap
+			return newErrorMap("TypeError", message)
 		},
 	}
 
@@ -78,15 +71,7 @@ func init() {
 				message = args[0].Inspect()
 			}
 
-			errorMap := &object.Map{
-				Pairs: map[string]object.Object{
-					"message": &object.String{Value: message},
-					"name":    &object.String{Value: "ReferenceError"},
-					"stack":   &object.String{Value: ""},
-				},
-			}
-
-			return errorMap
+			return newErrorMap("ReferenceError", message)
 		},
 	}
 
@@ -104,15 +89,7 @@ func init() {
 				message = args[0].Inspect()
 			}
 
-			errorMap := &object.Map{
-				Pairs: map[string]object.Object{
-					"message": &object.String{Value: message},
-					"name":    &object.String{Value: "RangeError"},
-					"stack":   &object.String{Value: ""},
-				},
-			}
-
-			return errorMap
+			return newErrorMap("RangeError", message)
 		},
 	}
 
@@ -130,15 +107,7 @@ func init() {
 				message = args[0].Inspect()
 			}
 
-			errorMap := &object.Map{
-				Pairs: map[string]object.Object{
-					"message": &object.String{Value: message},
-					"name":    &object.String{Value: "SyntaxError"},
-					"stack":   &object.String{Value: ""},
-				},
-			}
-
-			return errorMap
+			return newErrorMap("SyntaxError", message)
 		},
 	}
 
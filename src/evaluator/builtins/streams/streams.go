@@ -154,8 +154,9 @@ func streamLekho(args ...object.Object) object.Object {
 	stream.Mu.Lock()
 	defer stream.Mu.Unlock()
 
-	if stream.StreamType != "writable" {
-		return &object.Error{Message: "stream_lekho() can only write to writable streams"}
+	// Writing to a readable stream feeds its readers
+	if stream.StreamType == "readable" && stream.IsEnded {
+		return &object.Error{Message: "Cannot write to ended stream"}
 	}
 
 	if stream.IsClosed {
@@ -178,6 +179,7 @@ func streamLekho(args ...object.Object) object.Object {
 
 	// Append to buffer
 	stream.Buffer = append(stream.Buffer, data...)
+	stream.Notify()
 
 	// Trigger data event if handler exists
 	if stream.OnData != nil && evalFunc != nil {
@@ -212,6 +214,7 @@ func streamBondho(args ...object.Object) object.Object {
 
 	stream.Mu.Lock()
 	stream.IsClosed = true
+	stream.Notify()
 	stream.Mu.Unlock()
 
 	return object.NULL
@@ -236,6 +239,7 @@ func streamShesh(args ...object.Object) object.Object {
 	if len(stream.Buffer) == 0 {
 		stream.IsClosed = true
 	}
+	stream.Notify()
 	stream.Mu.Unlock()
 
 	// Trigger end event if handler exists
@@ -281,6 +285,7 @@ func streamPipe(args ...object.Object) object.Object {
 
 	writable.Mu.Lock()
 	writable.Buffer = append(writable.Buffer, data...)
+	writable.Notify()
 	writable.Mu.Unlock()
 
 	return writable
@@ -186,27 +186,27 @@ func init() {
 			return newError("failed to get file info: %s", err.Error())
 		}
 
-		result := make(map[string]object.Object)
+		result := object.NewMap()
 
 		// Get UID and GID using platform-specific helper
 		if uid, gid, ok := getFileOwnership(info); ok {
-			result["uid"] = &object.Number{Value: float64(uid)}
-			result["gid"] = &object.Number{Value: float64(gid)}
+			result.Set("uid", &object.Number{Value: float64(uid)})
+			result.Set("gid", &object.Number{Value: float64(gid)})
 
 			// Try to get username from UID
 			if u, err := user.LookupId(fmt.Sprintf("%d", uid)); err == nil {
-				result["naam"] = &object.String{Value: u.Username}
+				result.Set("naam", &object.String{Value: u.Username})
 			} else {
-				result["naam"] = &object.String{Value: ""}
+				result.Set("naam", &object.String{Value: ""})
 			}
 		} else {
 			// Platform doesn't support UID/GID
-			result["uid"] = &object.Number{Value: 0}
-			result["gid"] = &object.Number{Value: 0}
-			result["naam"] = &object.String{Value: ""}
+			result.Set("uid", &object.Number{Value: 0})
+			result.Set("gid", &object.Number{Value: 0})
+			result.Set("naam", &object.String{Value: ""})
 		}
 
-		return &object.Map{Pairs: result}
+		return result
 	})
 
 	// file_malikan_set (ফাইল মালিকান সেট) - Change file owner
@@ -63,12 +63,12 @@ func init() {
 
 		elements := make([]object.Object, 0, len(interfaces))
 		for _, iface := range interfaces {
-			info := make(map[string]object.Object)
-			info["naam"] = &object.String{Value: iface.Name}
-			info["mtu"] = &object.Number{Value: float64(iface.MTU)}
-			info["mac"] = &object.String{Value: iface.HardwareAddr.String()}
+			info := object.NewMap()
+			info.Set("naam", &object.String{Value: iface.Name})
+			info.Set("mtu", &object.Number{Value: float64(iface.MTU)})
+			info.Set("mac", &object.String{Value: iface.HardwareAddr.String()})
 
-			elements = append(elements, &object.Map{Pairs: info})
+			elements = append(elements, info)
 		}
 
 		return &object.Array{Elements: elements}
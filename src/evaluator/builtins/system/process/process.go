@@ -95,12 +95,12 @@ func init() {
 		}
 
 		// Return result as map
-		result := make(map[string]object.Object)
-		result["output"] = &object.String{Value: stdout.String()}
-		result["error"] = &object.String{Value: stderr.String()}
-		result["code"] = &object.Number{Value: float64(exitCode)}
+		result := object.NewMap()
+		result.Set("output", &object.String{Value: stdout.String()})
+		result.Set("error", &object.String{Value: stderr.String()})
+		result.Set("code", &object.Number{Value: float64(exitCode)})
 
-		return &object.Map{Pairs: result}
+		return result
 	})
 
 	// ==================== Process Information ====================
@@ -257,10 +257,10 @@ func init() {
 		}
 
 		// Return process info
-		result := make(map[string]object.Object)
-		result["pid"] = &object.Number{Value: float64(cmd.Process.Pid)}
+		result := object.NewMap()
+		result.Set("pid", &object.Number{Value: float64(cmd.Process.Pid)})
 
-		return &object.Map{Pairs: result}
+		return result
 	})
 
 	// process_opekha (প্রসেস অপেক্ষা) - Wait for process by PID
@@ -284,10 +284,10 @@ func init() {
 			return newError("failed to wait for process: %s", err.Error())
 		}
 
-		result := make(map[string]object.Object)
-		result["code"] = &object.Number{Value: float64(state.ExitCode())}
+		result := object.NewMap()
+		result.Set("code", &object.Number{Value: float64(state.ExitCode())})
 
-		return &object.Map{Pairs: result}
+		return result
 	})
 
 	// ==================== Working Directory ====================
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"BanglaCode/src/ast"
+	"BanglaCode/src/eventloop"
 	"BanglaCode/src/object"
 	"fmt"
 	"sync"
@@ -241,8 +242,11 @@ func kajKormiShuno(args ...object.Object) object.Object {
 	worker.OnMessage = callback
 	worker.Mu.Unlock()
 
-	// Start goroutine to listen for worker responses
+	// Start goroutine to listen for worker responses; the callback runs on
+	// the event loop, which stays alive until the worker is terminated
+	eventloop.Ref()
 	go func() {
+		defer eventloop.Unref()
 		for {
 			select {
 			case msg, ok := <-worker.ResponseChan:
@@ -266,7 +270,9 @@ func kajKormiShuno(args ...object.Object) object.Object {
 					callbackEnv.Set("responseData", msg)
 
 					// Execute callback
-					evalFunc(callExpr, callbackEnv)
+					eventloop.Post(func() {
+						evalFunc(callExpr, callbackEnv)
+					})
 				}
 
 			case <-worker.StopChan:
@@ -2,6 +2,7 @@ package evaluator
 
 import (
 	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
 )
 
@@ -25,10 +26,12 @@ func evalClassDeclaration(cd *ast.ClassDeclaration, env *object.Environment) obj
 			methodName = method.Name.Value
 		}
 		fn := &object.Function{
-			Parameters: method.Parameters,
-			Body:       method.Body,
-			Env:        classEnv,
-			Name:       methodName,
+			Parameters:    method.Parameters,
+			RestParameter: method.RestParameter,
+			Body:          method.Body,
+			Env:           classEnv,
+			Name:          methodName,
+			IsGenerator:   method.IsGenerator,
 		}
 		class.Methods[methodName] = fn
 	}
@@ -36,7 +39,7 @@ func evalClassDeclaration(cd *ast.ClassDeclaration, env *object.Environment) obj
 	// Evaluate getters
 	for name, getter := range cd.Getters {
 		fn := &object.Function{
-			Parameters: []*ast.Identifier{}, // getters have no params
+			Parameters: []*ast.Parameter{}, // getters have no params
 			Body:       getter.Body,
 			Env:        classEnv,
 			Name:       name,
@@ -76,6 +79,15 @@ func evalNewExpression(ne *ast.NewExpression, env *object.Environment) object.Ob
 		return classObj
 	}
 
+	// Constructible builtins such as Promise and AbortController
+	if builtin, ok := classObj.(*object.Builtin); ok {
+		args := evalExpressions(ne.Arguments, env)
+		if len(args) == 1 && isError(args[0]) {
+			return args[0]
+		}
+		return builtin.Fn(args...)
+	}
+
 	class, ok := classObj.(*object.Class)
 	if !ok {
 		return newError("'%s' is not a class", ne.Class.String())
@@ -97,18 +109,24 @@ func evalNewExpression(ne *ast.NewExpression, env *object.Environment) object.Ob
 	// Call constructor if exists (method named "shuru")
 	if constructor, ok := class.Methods["shuru"]; ok {
 		// Check argument count
-		if len(args) != len(constructor.Parameters) {
-			return newError("constructor expects %d argument(s), got %d",
-				len(constructor.Parameters), len(args))
+		required, total := ast.RequiredParameters(constructor.Parameters), len(constructor.Parameters)
+		if len(args) < required || len(args) > total {
+			if required == total {
+				return newError("constructor expects %d argument(s), got %d", total, len(args))
+			}
+			return newError("constructor expects %d to %d argument(s), got %d", required, total, len(args))
 		}
 
-		// Create constructor environment
-		constructorEnv := object.NewEnclosedEnvironment(constructor.Env)
-		constructorEnv.Set("ei", instance)
-
-		// Bind parameters
-		for i, param := range constructor.Parameters {
-			constructorEnv.Set(param.Value, args[i])
+		// Create constructor environment; 'ei' is the new instance, which
+		// default values can use too
+		instanceEnv := object.NewEnclosedEnvironment(constructor.Env)
+		instanceEnv.Set("ei", instance)
+		constructorEnv, failed := extendFunctionEnv(&object.Function{
+			Parameters: constructor.Parameters,
+			Env:        instanceEnv,
+		}, args)
+		if failed != nil {
+			return failed
 		}
 
 		// Execute constructor
@@ -126,35 +144,21 @@ func evalNewExpression(ne *ast.NewExpression, env *object.Environment) object.Ob
 
 // applyFunction applies a function to arguments (wrapper for backward compatibility)
 func applyFunction(fn object.Object, args []object.Object, env *object.Environment) object.Object {
-	return applyFunctionWithPosition(fn, args, env, 0, 0, nil)
+	return applyFunctionWithPosition(fn, args, env, lexer.Token{}, nil)
 }
 
 // applyFunctionWithPosition applies a function with position info for error reporting
-func applyFunctionWithPosition(fn object.Object, args []object.Object, env *object.Environment, line, col int, callExpr ast.Expression) object.Object {
+func applyFunctionWithPosition(fn object.Object, args []object.Object, env *object.Environment, call lexer.Token, callExpr ast.Expression) object.Object {
 	switch fn := fn.(type) {
 	case *object.Function:
-		// Check argument count (considering rest parameter)
-		minParams := len(fn.Parameters)
-		actual := len(args)
-
-		if fn.RestParameter == nil {
-			// No rest parameter: exact match required
-			if actual != minParams {
-				funcName := fn.Name
-				if funcName == "" {
-					funcName = "anonymous function"
-				}
-				return newErrorAt(line, col, "function '%s' expects %d argument(s) but got %d", funcName, minParams, actual)
-			}
-		} else {
-			// Has rest parameter: at least minParams required
-			if actual < minParams {
-				funcName := fn.Name
-				if funcName == "" {
-					funcName = "anonymous function"
-				}
-				return newErrorAt(line, col, "function '%s' expects at least %d argument(s) but got %d", funcName, minParams, actual)
-			}
+		if errObj := checkArgumentCount(fn, len(args), call); errObj != nil {
+			return errObj
+		}
+
+		// Generator functions, async ones included, return a generator
+		// object on call
+		if fn.IsGenerator {
+			return evalGeneratorFunction(fn, args, env)
 		}
 
 		// Check if function is async - if so, execute in goroutine and return promise
@@ -162,15 +166,16 @@ func applyFunctionWithPosition(fn object.Object, args []object.Object, env *obje
 			return evalAsyncFunctionCall(fn, args, env)
 		}
 
-		// Generator functions return a generator object on call
-		if fn.IsGenerator {
-			return evalGeneratorFunction(fn, args, env)
-		}
-
 		// Regular synchronous function execution
-		extendedEnv := extendFunctionEnv(fn, args)
-		evaluated := Eval(fn.Body, extendedEnv)
-		return unwrapReturnValue(evaluated)
+		extendedEnv, failed := extendFunctionEnv(fn, args)
+		if failed != nil {
+			return locateError(failed, call)
+		}
+		evaluated := unwrapReturnValue(Eval(fn.Body, extendedEnv))
+		if errObj, ok := evaluated.(*object.Error); ok {
+			recordCallFrame(errObj, fn, call)
+		}
+		return evaluated
 
 	case *object.Builtin:
 		return fn.Fn(args...)
@@ -182,20 +187,75 @@ func applyFunctionWithPosition(fn object.Object, args []object.Object, env *obje
 			funcName = ident.Value
 		}
 		if fn == nil || fn.Type() == object.NULL_OBJ {
-			return newErrorAt(line, col, "'%s' is not defined or is null", funcName)
+			return newErrorAt(call, "'%s' is not defined or is null", funcName)
+		}
+		return newErrorAt(call, "'%s' is not a function (got %s)", funcName, fn.Type())
+	}
+}
+
+// checkArgumentCount verifies the number of arguments passed to a user function
+func checkArgumentCount(fn *object.Function, actual int, call lexer.Token) *object.Error {
+	minParams := ast.RequiredParameters(fn.Parameters)
+	maxParams := len(fn.Parameters)
+	funcName := fn.Name
+	if funcName == "" {
+		funcName = "anonymous function"
+	}
+
+	if fn.RestParameter == nil {
+		// No rest parameter: between the required and all parameters
+		if minParams == maxParams && actual != minParams {
+			return newErrorAt(call, "function '%s' expects %d argument(s) but got %d", funcName, minParams, actual)
 		}
-		return newErrorAt(line, col, "'%s' is not a function (got %s)", funcName, fn.Type())
+		if actual < minParams || actual > maxParams {
+			return newErrorAt(call, "function '%s' expects %d to %d argument(s) but got %d", funcName, minParams, maxParams, actual)
+		}
+	} else if actual < minParams {
+		// Has rest parameter: at least minParams required
+		return newErrorAt(call, "function '%s' expects at least %d argument(s) but got %d", funcName, minParams, actual)
 	}
+	return nil
 }
 
-// extendFunctionEnv creates a new environment for function execution
-func extendFunctionEnv(fn *object.Function, args []object.Object) *object.Environment {
+// recordCallFrame adds the function an error is leaving to its stack trace.
+// The innermost frame points at the error itself; the caller's frame is
+// left pending at the call site until the error leaves the caller too.
+func recordCallFrame(errObj *object.Error, fn *object.Function, call lexer.Token) {
+	if len(errObj.Stack) == 0 {
+		errObj.AddStackFrame(fn.Name, errObj.File, errObj.Line, errObj.Column)
+	} else {
+		errObj.Stack[len(errObj.Stack)-1].Function = fn.Name
+	}
+	errObj.AddStackFrame("", call.File, call.Line, call.Column)
+}
+
+// extendFunctionEnv creates a new environment for function execution.
+// Default values are evaluated in it, so they can use 'ei' and earlier
+// parameters; a default or pattern that fails returns the error instead.
+func extendFunctionEnv(fn *object.Function, args []object.Object) (*object.Environment, object.Object) {
 	env := object.NewEnclosedEnvironment(fn.Env)
 
-	// Bind regular parameters
+	// Check if we need to bind 'ei' (this)
+	if ei, ok := fn.Env.Get("ei"); ok {
+		env.Set("ei", ei)
+	}
+
+	if errObj := bindParameters(env, fn, args); errObj != nil {
+		return nil, errObj
+	}
+	return env, nil
+}
+
+// bindParameters binds the arguments of a call to fn's parameters in env
+func bindParameters(env *object.Environment, fn *object.Function, args []object.Object) object.Object {
+	// Bind regular parameters; a missing or khali argument takes the default
 	for paramIdx, param := range fn.Parameters {
+		var arg object.Object = object.NULL
 		if paramIdx < len(args) {
-			env.Set(param.Value, args[paramIdx])
+			arg = args[paramIdx]
+		}
+		if errObj := bindParameter(env, param, arg); errObj != nil {
+			return errObj
 		}
 	}
 
@@ -208,10 +268,5 @@ func extendFunctionEnv(fn *object.Function, args []object.Object) *object.Enviro
 		env.Set(fn.RestParameter.Value, &object.Array{Elements: restArgs})
 	}
 
-	// Check if we need to bind 'ei' (this)
-	if ei, ok := fn.Env.Get("ei"); ok {
-		env.Set("ei", ei)
-	}
-
-	return env
+	return nil
 }
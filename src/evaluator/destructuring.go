@@ -5,46 +5,185 @@ import (
 	"BanglaCode/src/object"
 )
 
-func evalArrayDestructuringDeclaration(node *ast.ArrayDestructuringDeclaration, env *object.Environment) object.Object {
+func evalDestructuringDeclaration(node *ast.DestructuringDeclaration, env *object.Environment) object.Object {
 	source := Eval(node.Source, env)
 	if isError(source) {
 		return source
 	}
-	arr, ok := source.(*object.Array)
-	if !ok {
-		return newError("array destructuring source must be ARRAY, got %s", source.Type())
+	if errObj := destructure(node.Pattern, source, env, func(name string, val object.Object) object.Object {
+		bindValue(env, name, val, node.IsConstant, node.IsGlobal)
+		return nil
+	}); errObj != nil {
+		return errObj
 	}
 
-	for i, name := range node.Names {
-		var val object.Object = object.NULL
-		if i < len(arr.Elements) {
-			val = arr.Elements[i]
+	return source
+}
+
+// bindElement binds value to a name, a nested pattern or a member, taking
+// the default, evaluated in env, when the value is khali. Declarations,
+// parameters, assignments and nested patterns all bind through it.
+func bindElement(el *ast.BindingElement, value object.Object, env *object.Environment, bind func(string, object.Object) object.Object) object.Object {
+	if el.Default != nil && value.Type() == object.NULL_OBJ {
+		value = Eval(el.Default, env)
+		if isError(value) || isException(value) {
+			return value
 		}
-		bindValue(env, name.Value, val, node.IsConstant, node.IsGlobal)
 	}
+	if el.Member != nil {
+		obj := Eval(el.Member.Object, env)
+		if isError(obj) || isException(obj) {
+			return obj
+		}
+		if result := assignMember(obj, el.Member, "=", value, env); isError(result) || isException(result) {
+			return result
+		}
+		return nil
+	}
+	if el.Pattern == nil {
+		return bind(el.Name.Value, value)
+	}
+	return destructure(el.Pattern, value, env, bind)
+}
 
-	return source
+// destructure binds the names in an array or object pattern to the parts
+// of source
+func destructure(pattern ast.Expression, source object.Object, env *object.Environment, bind func(string, object.Object) object.Object) object.Object {
+	switch pat := pattern.(type) {
+	case *ast.ArrayPattern:
+		return destructureArray(pat, source, env, bind)
+	case *ast.ObjectPattern:
+		return destructureObject(pat, source, env, bind)
+	}
+	return newError("invalid destructuring pattern")
 }
 
-func evalObjectDestructuringDeclaration(node *ast.ObjectDestructuringDeclaration, env *object.Environment) object.Object {
-	source := Eval(node.Source, env)
+// destructureArray binds each element to the value at its position, khali
+// past the end, and the rest to an array of what is left. Without a rest,
+// an iterable source is read only as far as there are elements.
+func destructureArray(pattern *ast.ArrayPattern, source object.Object, env *object.Environment, bind func(string, object.Object) object.Object) object.Object {
+	values, rest, failed := destructureElements(len(pattern.Elements), pattern.Rest != nil, source, env)
+	if failed != nil {
+		return failed
+	}
+	for i, el := range pattern.Elements {
+		if errObj := bindElement(el, values[i], env, bind); errObj != nil {
+			return errObj
+		}
+	}
+	if pattern.Rest != nil {
+		return bind(pattern.Rest.Value, &object.Array{Elements: rest})
+	}
+	return nil
+}
+
+// destructureElements reads the first count elements of an array or
+// iterable, padding with khali past the end. With rest set it reads the
+// remaining elements too and returns them separately.
+func destructureElements(count int, rest bool, source object.Object, env *object.Environment) ([]object.Object, []object.Object, object.Object) {
+	it, failed := iterateSequence(source, env)
+	if failed != nil {
+		return nil, nil, failed
+	}
+	if it == nil {
+		return nil, nil, newError("array destructuring source must be ARRAY or iterable, got %s", source.Type())
+	}
+
+	values := make([]object.Object, count)
+	done := false
+	for i := range values {
+		values[i] = object.NULL
+		if !done {
+			var next object.Object
+			next, done, failed = it.next()
+			if failed != nil {
+				return nil, nil, failed
+			}
+			if !done {
+				values[i] = next
+			}
+		}
+	}
+
+	remaining := []object.Object{}
+	for rest && !done {
+		var next object.Object
+		next, done, failed = it.next()
+		if failed != nil {
+			return nil, nil, failed
+		}
+		if !done {
+			remaining = append(remaining, next)
+		}
+	}
+	if !done {
+		if failed := it.close(); failed != nil {
+			return nil, nil, failed
+		}
+	}
+	return values, remaining, nil
+}
+
+// evalDestructuringAssignment evaluates [a, [b], ...c] = source for
+// existing variables and members. The source is read before any target
+// changes, so [a, b] = [b, a] swaps.
+func evalDestructuringAssignment(pattern ast.Expression, ae *ast.AssignmentExpression, env *object.Environment) object.Object {
+	source := Eval(ae.Value, env)
 	if isError(source) {
 		return source
 	}
+	if errObj := destructure(pattern, source, env, func(name string, val object.Object) object.Object {
+		if env.IsConstant(name) {
+			return newErrorAt(ae.Token, "'%s' ekti sthir (constant), eitake bodlano jabe na", name)
+		}
+		env.Update(name, val)
+		return nil
+	}); errObj != nil {
+		return errObj
+	}
+	return source
+}
+
+// destructureObject binds each key's target to its value, khali when the
+// map has no such key, and the rest to a map of the keys not named
+func destructureObject(pattern *ast.ObjectPattern, source object.Object, env *object.Environment, bind func(string, object.Object) object.Object) object.Object {
 	m, ok := source.(*object.Map)
 	if !ok {
 		return newError("object destructuring source must be MAP, got %s", source.Type())
 	}
 
-	for i, key := range node.Keys {
+	for i, key := range pattern.Keys {
 		val, exists := m.Pairs[key]
 		if !exists {
 			val = object.NULL
 		}
-		bindValue(env, node.Names[i].Value, val, node.IsConstant, node.IsGlobal)
+		if errObj := bindElement(pattern.Values[i], val, env, bind); errObj != nil {
+			return errObj
+		}
 	}
 
-	return source
+	if pattern.Rest != nil {
+		named := make(map[string]bool, len(pattern.Keys))
+		for _, key := range pattern.Keys {
+			named[key] = true
+		}
+		rest := object.NewMap()
+		for _, key := range m.Keys() {
+			if !named[key] {
+				rest.Set(key, m.Pairs[key])
+			}
+		}
+		return bind(pattern.Rest.Value, rest)
+	}
+	return nil
+}
+
+// bindParameter binds a call argument to a parameter's name or pattern
+func bindParameter(env *object.Environment, param *ast.Parameter, arg object.Object) object.Object {
+	return bindElement(param, arg, env, func(name string, val object.Object) object.Object {
+		env.Set(name, val)
+		return nil
+	})
 }
 
 func bindValue(env *object.Environment, name string, val object.Object, isConstant, isGlobal bool) {
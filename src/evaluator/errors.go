@@ -60,7 +60,7 @@ func evalThrowStatement(ts *ast.ThrowStatement, env *object.Environment) object.
 						if ts.Token.Line > 0 {
 							stackTrace += " (line " + string(rune(ts.Token.Line)) + ")"
 						}
-						errorMap.Pairs["stack"] = &object.String{Value: stackTrace}
+						errorMap.Set("stack", &object.String{Value: stackTrace})
 
 						// Convert to exception for throwing
 						var message string
@@ -77,6 +77,11 @@ func evalThrowStatement(ts *ast.ThrowStatement, env *object.Environment) object.
 	}
 
 	// Convert to exception
+	return newException(value)
+}
+
+// newException wraps a thrown value
+func newException(value object.Object) *object.Exception {
 	var message string
 	switch v := value.(type) {
 	case *object.String:
@@ -24,11 +24,9 @@ func init() {
 // evalFunctionCall evaluates a function with the given arguments
 // Used by builtins that need to call back into the evaluator
 func evalFunctionCall(handler *object.Function, args []object.Object) object.Object {
-	env := object.NewEnclosedEnvironment(handler.Env)
-	for i, param := range handler.Parameters {
-		if i < len(args) {
-			env.Set(param.Value, args[i])
-		}
+	env, failed := extendFunctionEnv(handler, args)
+	if failed != nil {
+		return failed
 	}
 	result := Eval(handler.Body, env)
 	return unwrapReturnValue(result)
@@ -72,10 +70,16 @@ func evalStatementNode(node ast.Node, env *object.Environment) (object.Object, b
 			env.Set(node.Name.Value, val)
 		}
 		return val, true
-	case *ast.ArrayDestructuringDeclaration:
-		return evalArrayDestructuringDeclaration(node, env), true
-	case *ast.ObjectDestructuringDeclaration:
-		return evalObjectDestructuringDeclaration(node, env), true
+	case *ast.DeclarationList:
+		var val object.Object = object.NULL
+		for _, decl := range node.Declarations {
+			if val = Eval(decl, env); isError(val) {
+				return val, true
+			}
+		}
+		return val, true
+	case *ast.DestructuringDeclaration:
+		return evalDestructuringDeclaration(node, env), true
 	}
 	return evalFlowStatementNode(node, env)
 }
@@ -85,15 +89,15 @@ func evalFlowStatementNode(node ast.Node, env *object.Environment) (object.Objec
 	case *ast.IfStatement:
 		return evalIfStatement(node, env), true
 	case *ast.WhileStatement:
-		return evalWhileStatement(node, env), true
+		return evalWhileStatement(node, env, ""), true
 	case *ast.DoWhileStatement:
-		return evalDoWhileStatement(node, env), true
+		return evalDoWhileStatement(node, env, ""), true
 	case *ast.ForStatement:
-		return evalForStatement(node, env), true
+		return evalForStatement(node, env, ""), true
 	case *ast.ForOfStatement:
-		return evalForOfStatement(node, env), true
+		return locateError(evalForOfStatement(node, env, ""), node.Token), true
 	case *ast.ForInStatement:
-		return evalForInStatement(node, env), true
+		return locateError(evalForInStatement(node, env, ""), node.Token), true
 	case *ast.ReturnStatement:
 		val := Eval(node.ReturnValue, env)
 		if isError(val) {
@@ -101,9 +105,17 @@ func evalFlowStatementNode(node ast.Node, env *object.Environment) (object.Objec
 		}
 		return &object.ReturnValue{Value: val}, true
 	case *ast.BreakStatement:
+		if node.Label != nil {
+			return &object.Break{Label: node.Label.Value}, true
+		}
 		return object.BREAK, true
 	case *ast.ContinueStatement:
+		if node.Label != nil {
+			return &object.Continue{Label: node.Label.Value}, true
+		}
 		return object.CONTINUE, true
+	case *ast.LabeledStatement:
+		return evalLabeledStatement(node, env), true
 	case *ast.SwitchStatement:
 		return evalSwitchStatement(node, env), true
 	}
@@ -115,7 +127,7 @@ func evalControlNode(node ast.Node, env *object.Environment) (object.Object, boo
 	case *ast.ClassDeclaration:
 		return evalClassDeclaration(node, env), true
 	case *ast.ImportStatement:
-		return evalImportStatement(node, env), true
+		return locateError(evalImportStatement(node, env), node.Token), true
 	case *ast.ExportStatement:
 		return evalExportStatement(node, env), true
 	case *ast.TryCatchStatement:
@@ -159,23 +171,32 @@ func evalExpressionNode(node ast.Node, env *object.Environment) (object.Object,
 		if isError(right) {
 			return right, true
 		}
-		return evalUnaryExpression(node.Operator, right), true
+		return locateError(evalUnaryExpression(node.Operator, right), node.Token), true
 	case *ast.BinaryExpression:
 		return evalBinaryNode(node, env), true
 	case *ast.DeleteExpression:
 		return evalDeleteExpression(node, env), true
 	case *ast.AssignmentExpression:
-		return evalAssignmentExpression(node, env), true
+		return locateError(evalAssignmentExpression(node, env), node.Token), true
 	case *ast.CallExpression:
-		return evalCallExpression(node, env), true
+		return endChain(evalCallExpression(node, env)), true
 	case *ast.MemberExpression:
-		return evalMemberExpression(node, env), true
+		return endChain(locateError(evalMemberExpression(node, env), node.Token)), true
+	case *ast.ConditionalExpression:
+		condition := Eval(node.Condition, env)
+		if isError(condition) {
+			return condition, true
+		}
+		if isTruthy(condition) {
+			return Eval(node.Consequence, env), true
+		}
+		return Eval(node.Alternative, env), true
 	case *ast.FunctionLiteral:
 		return buildFunctionLiteral(node, env), true
 	case *ast.YieldExpression:
-		return newError("utpadan (yield) can only be used inside generator function"), true
+		return evalYieldExpression(node, env), true
 	case *ast.NewExpression:
-		return evalNewExpression(node, env), true
+		return locateError(evalNewExpression(node, env), node.Token), true
 	case *ast.SpreadElement:
 		return evalSpreadElement(node, env), true
 	case *ast.AsyncFunctionLiteral:
@@ -191,23 +212,34 @@ func evalBinaryNode(node *ast.BinaryExpression, env *object.Environment) object.
 	if isError(left) {
 		return left
 	}
+	// a ?? b only evaluates b when a is khali
+	if node.Operator == "??" {
+		if !isNullish(left) {
+			return left
+		}
+		return Eval(node.Right, env)
+	}
 	right := Eval(node.Right, env)
 	if isError(right) {
 		return right
 	}
-	return evalBinaryExpression(node.Operator, left, right)
+	return locateError(evalBinaryExpression(node.Operator, left, right), node.Token)
 }
 
+// evalCallExpression may return chainEnd (see evalChainLink)
 func evalCallExpression(node *ast.CallExpression, env *object.Environment) object.Object {
-	function := Eval(node.Function, env)
-	if isError(function) {
+	function := evalChainLink(node.Function, env)
+	if isError(function) || function == chainEnd {
 		return function
 	}
+	if node.Optional && isNullish(function) {
+		return chainEnd
+	}
 	args := evalExpressions(node.Arguments, env)
 	if len(args) == 1 && isError(args[0]) {
 		return args[0]
 	}
-	return applyFunctionWithPosition(function, args, env, node.Token.Line, node.Token.Column, node.Function)
+	return locateError(applyFunctionWithPosition(function, args, env, node.Token, node.Function), node.Token)
 }
 
 func buildFunctionLiteral(node *ast.FunctionLiteral, env *object.Environment) object.Object {
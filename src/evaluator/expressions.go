@@ -10,13 +10,18 @@ import (
 	"strings"
 )
 
-// evalUnaryExpression evaluates unary expressions (!, -, na)
+// evalUnaryExpression evaluates unary expressions (!, -, na, ~)
 func evalUnaryExpression(operator string, right object.Object) object.Object {
 	switch operator {
 	case "!", "na":
 		return evalBangOperator(right)
 	case "-":
 		return evalMinusOperator(right)
+	case "~":
+		if right.Type() != object.NUMBER_OBJ {
+			return newError("unknown operator: ~%s", right.Type())
+		}
+		return &object.Number{Value: float64(^toInt32(right.(*object.Number).Value))}
 	default:
 		return newError("unknown operator: %s%s", operator, right.Type())
 	}
@@ -100,6 +105,18 @@ func evalNumberBinaryExpression(operator string, left, right object.Object) obje
 		return &object.Number{Value: float64(int64(leftVal) % int64(rightVal))}
 	case "**":
 		return &object.Number{Value: math.Pow(leftVal, rightVal)}
+	case "&":
+		return &object.Number{Value: float64(toInt32(leftVal) & toInt32(rightVal))}
+	case "|":
+		return &object.Number{Value: float64(toInt32(leftVal) | toInt32(rightVal))}
+	case "^":
+		return &object.Number{Value: float64(toInt32(leftVal) ^ toInt32(rightVal))}
+	case "<<":
+		return &object.Number{Value: float64(toInt32(leftVal) << (toUint32(rightVal) & 31))}
+	case ">>":
+		return &object.Number{Value: float64(toInt32(leftVal) >> (toUint32(rightVal) & 31))}
+	case ">>>":
+		return &object.Number{Value: float64(toUint32(leftVal) >> (toUint32(rightVal) & 31))}
 	case "<":
 		return boolToObject(leftVal < rightVal)
 	case ">":
@@ -151,15 +168,31 @@ func evalAssignmentExpression(ae *ast.AssignmentExpression, env *object.Environm
 		return evalMemberAssignment(member, ae.Operator, ae.Value, env)
 	}
 
+	if pattern, ok := ae.Name.(*ast.ArrayPattern); ok {
+		return evalDestructuringAssignment(pattern, ae, env)
+	}
+
 	// Handle simple variable assignment
 	ident, ok := ae.Name.(*ast.Identifier)
 	if !ok {
 		return newError("invalid assignment target")
 	}
 
+	// Logical assignment (??=, ||=, &&=) only evaluates and assigns the
+	// value when the current one calls for it
+	if isLogicalAssignment(ae.Operator) {
+		current, ok := env.Get(ident.Value)
+		if !ok {
+			return newErrorAt(ae.Token, "variable '%s' is not defined", ident.Value)
+		}
+		if !needsAssignment(ae.Operator, current) {
+			return current
+		}
+	}
+
 	// Check if trying to reassign a constant
 	if env.IsConstant(ident.Value) {
-		return newErrorAt(ae.Token.Line, ae.Token.Column, "'%s' ekti sthir (constant), eitake bodlano jabe na", ident.Value)
+		return newErrorAt(ae.Token, "'%s' ekti sthir (constant), eitake bodlano jabe na", ident.Value)
 	}
 
 	value := Eval(ae.Value, env)
@@ -169,19 +202,18 @@ func evalAssignmentExpression(ae *ast.AssignmentExpression, env *object.Environm
 
 	// Handle compound assignment operators
 	switch ae.Operator {
-	case "=":
+	case "=", "??=", "||=", "&&=":
 		env.Update(ident.Value, value)
 		return value
-	case "+=", "-=", "*=", "/=":
+	case "+=", "-=", "*=", "/=", "%=", "**=", "&=", "|=", "^=", "<<=", ">>=", ">>>=":
 		current, ok := env.Get(ident.Value)
 		if !ok {
-			return newErrorAt(ae.Token.Line, ae.Token.Column, "variable '%s' is not defined", ident.Value)
+			return newErrorAt(ae.Token, "variable '%s' is not defined", ident.Value)
 		}
 
 		// Calculate new value based on operator
 		var result object.Object
-		op := string(ae.Operator[0]) // Get first char: +, -, *, /
-		result = evalBinaryExpression(op, current, value)
+		result = evalBinaryExpression(compoundOperator(ae.Operator), current, value)
 
 		if isError(result) {
 			return result
@@ -194,11 +226,35 @@ func evalAssignmentExpression(ae *ast.AssignmentExpression, env *object.Environm
 	}
 }
 
+// compoundOperator returns the binary operator of a compound assignment:
+// + for +=, >>> for >>>=
+func compoundOperator(assign string) string {
+	return strings.TrimSuffix(assign, "=")
+}
+
+func isLogicalAssignment(operator string) bool {
+	return operator == "??=" || operator == "||=" || operator == "&&="
+}
+
+// needsAssignment reports whether a logical assignment assigns, given the
+// target's current value
+func needsAssignment(operator string, current object.Object) bool {
+	switch operator {
+	case "??=":
+		return isNullish(current)
+	case "||=":
+		return !isTruthy(current)
+	default: // &&=
+		return isTruthy(current)
+	}
+}
+
 // evalMapLiteral evaluates map/object literals
 func evalMapLiteral(node *ast.MapLiteral, env *object.Environment) object.Object {
-	pairs := make(map[string]object.Object)
+	m := object.NewMap()
 
-	for keyNode, valueNode := range node.Pairs {
+	for _, keyNode := range node.Keys {
+		valueNode := node.Pairs[keyNode]
 		var keyStr string
 
 		// Handle identifier keys as string keys (JS-like object syntax)
@@ -225,10 +281,10 @@ func evalMapLiteral(node *ast.MapLiteral, env *object.Environment) object.Object
 			return value
 		}
 
-		pairs[keyStr] = value
+		m.Set(keyStr, value)
 	}
 
-	return &object.Map{Pairs: pairs}
+	return m
 }
 
 // evalSpreadElement evaluates spread expression (returns a marker for special handling)
@@ -239,12 +295,30 @@ func evalSpreadElement(node *ast.SpreadElement, env *object.Environment) object.
 		return evaluated
 	}
 
-	// Spread must be used with arrays
-	if _, ok := evaluated.(*object.Array); !ok {
-		return newError("spread operator requires an array, got %s", evaluated.Type())
+	// Spread takes arrays and iterables
+	values, failed := spreadValues(evaluated, env)
+	if failed != nil {
+		return failed
 	}
+	if arr, ok := evaluated.(*object.Array); ok {
+		return arr
+	}
+	return &object.Array{Elements: values}
+}
 
-	return evaluated
+// spreadValues returns the values ...x expands to
+func spreadValues(evaluated object.Object, env *object.Environment) ([]object.Object, object.Object) {
+	if arr, ok := evaluated.(*object.Array); ok {
+		return arr.Elements, nil
+	}
+	it, failed := iterateSequence(evaluated, env)
+	if failed != nil {
+		return nil, failed
+	}
+	if it == nil {
+		return nil, newError("spread operator requires an array or iterable, got %s", evaluated.Type())
+	}
+	return collect(it)
 }
 
 // evalTemplateLiteral evaluates template literals with ${expression} interpolation
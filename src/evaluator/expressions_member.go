@@ -7,16 +7,32 @@ import (
 
 // evalMemberAssignment handles assignment to object properties or array elements
 func evalMemberAssignment(member *ast.MemberExpression, operator string, value ast.Expression, env *object.Environment) object.Object {
+	if member.Optional {
+		return newError("invalid assignment target")
+	}
 	obj := Eval(member.Object, env)
 	if isError(obj) {
 		return obj
 	}
 
+	if isLogicalAssignment(operator) {
+		current := accessMember(obj, member, env)
+		if isError(current) || !needsAssignment(operator, current) {
+			return current
+		}
+		operator = "="
+	}
+
 	val := Eval(value, env)
 	if isError(val) {
 		return val
 	}
 
+	return assignMember(obj, member, operator, val, env)
+}
+
+// assignMember stores an evaluated value into obj as member describes
+func assignMember(obj object.Object, member *ast.MemberExpression, operator string, val object.Object, env *object.Environment) object.Object {
 	switch o := obj.(type) {
 	case *object.Array:
 		return assignArrayMember(o, member, operator, val, env)
@@ -35,13 +51,56 @@ func evalMemberAssignment(member *ast.MemberExpression, operator string, value a
 	}
 }
 
-// evalMemberExpression evaluates member access (obj.prop or arr[idx])
+// chainEnd marks an optional chain (a?.b.c) whose ?. met khali: the rest
+// of the chain is skipped and the whole chain evaluates to khali
+var chainEnd object.Object = shortCircuit{}
+
+type shortCircuit struct{}
+
+func (shortCircuit) Type() object.ObjectType { return object.NULL_OBJ }
+func (shortCircuit) Inspect() string         { return "khali" }
+
+// evalChainLink evaluates the object of a member access or the callee of a
+// call. Links of the same chain may return chainEnd, which the caller
+// passes on instead of using.
+func evalChainLink(node ast.Expression, env *object.Environment) object.Object {
+	switch node := node.(type) {
+	case *ast.MemberExpression:
+		return locateError(evalMemberExpression(node, env), node.Token)
+	case *ast.CallExpression:
+		return evalCallExpression(node, env)
+	}
+	return Eval(node, env)
+}
+
+// endChain turns the chainEnd of a finished optional chain into khali
+func endChain(obj object.Object) object.Object {
+	if obj == chainEnd {
+		return object.NULL
+	}
+	return obj
+}
+
+// isNullish reports whether ?? and ?. treat obj as missing
+func isNullish(obj object.Object) bool {
+	return obj == nil || obj == object.NULL
+}
+
+// evalMemberExpression evaluates member access (obj.prop or arr[idx]); it
+// may return chainEnd (see evalChainLink)
 func evalMemberExpression(me *ast.MemberExpression, env *object.Environment) object.Object {
-	obj := Eval(me.Object, env)
-	if isError(obj) {
+	obj := evalChainLink(me.Object, env)
+	if isError(obj) || obj == chainEnd {
 		return obj
 	}
+	if me.Optional && isNullish(obj) {
+		return chainEnd
+	}
+	return accessMember(obj, me, env)
+}
 
+// accessMember reads a member of an already evaluated object
+func accessMember(obj object.Object, me *ast.MemberExpression, env *object.Environment) object.Object {
 	switch o := obj.(type) {
 	case *object.Array:
 		return accessArrayMember(o, me, env)
@@ -67,6 +126,15 @@ func evalMemberExpression(me *ast.MemberExpression, env *object.Environment) obj
 	case *object.Generator:
 		return accessGeneratorMember(o, me)
 
+	case *object.Promise:
+		return accessPromiseMember(o, me)
+
+	case *object.AbortSignal:
+		return accessAbortSignalMember(o, me)
+
+	case *object.Builtin:
+		return accessBuiltinMember(o, me)
+
 	default:
 		return newError("member access not supported on %s", obj.Type())
 	}
@@ -77,6 +145,11 @@ func assignArrayMember(arr *object.Array, member *ast.MemberExpression, operator
 	if isError(index) {
 		return index
 	}
+	return assignArrayIndex(arr, index, operator, val)
+}
+
+// assignArrayIndex stores val at an evaluated index
+func assignArrayIndex(arr *object.Array, index object.Object, operator string, val object.Object) object.Object {
 	if index.Type() != object.NUMBER_OBJ {
 		return newError("array index must be a number, got %s", index.Type())
 	}
@@ -87,7 +160,7 @@ func assignArrayMember(arr *object.Array, member *ast.MemberExpression, operator
 
 	if operator != "=" {
 		current := arr.Elements[idx]
-		op := string(operator[0])
+		op := compoundOperator(operator)
 		val = evalBinaryExpression(op, current, val)
 		if isError(val) {
 			return val
@@ -103,20 +176,24 @@ func assignMapMember(m *object.Map, member *ast.MemberExpression, operator strin
 	if errObj != nil {
 		return errObj
 	}
+	return assignMapKey(m, key, operator, val)
+}
 
+// assignMapKey stores val under a resolved key
+func assignMapKey(m *object.Map, key string, operator string, val object.Object) object.Object {
 	if operator != "=" {
 		current, ok := m.Pairs[key]
 		if !ok {
 			return newError("key '%s' not found in map", key)
 		}
-		op := string(operator[0])
+		op := compoundOperator(operator)
 		val = evalBinaryExpression(op, current, val)
 		if isError(val) {
 			return val
 		}
 	}
 
-	m.Pairs[key] = val
+	m.Set(key, val)
 	return val
 }
 
@@ -136,7 +213,9 @@ func assignInstanceMember(inst *object.Instance, member *ast.MemberExpression, o
 
 		// Bind the value to the setter parameter
 		if len(setter.Parameters) > 0 {
-			boundEnv.Set(setter.Parameters[0].Value, val)
+			if errObj := bindParameter(boundEnv, setter.Parameters[0], val); errObj != nil {
+				return errObj
+			}
 		}
 
 		result := Eval(setter.Body, boundEnv)
@@ -154,7 +233,7 @@ func assignInstanceMember(inst *object.Instance, member *ast.MemberExpression, o
 			if !ok {
 				return newError("private property '%s' not found", propName)
 			}
-			op := string(operator[0])
+			op := compoundOperator(operator)
 			val = evalBinaryExpression(op, current, val)
 			if isError(val) {
 				return val
@@ -170,7 +249,7 @@ func assignInstanceMember(inst *object.Instance, member *ast.MemberExpression, o
 		if !ok {
 			return newError("property '%s' not found", propName)
 		}
-		op := string(operator[0])
+		op := compoundOperator(operator)
 		val = evalBinaryExpression(op, current, val)
 		if isError(val) {
 			return val
@@ -206,8 +285,11 @@ func accessInstanceMember(inst *object.Instance, me *ast.MemberExpression) objec
 		return newError("invalid property name")
 	}
 
-	propName := ident.Value
+	return instanceMember(inst, ident.Value)
+}
 
+// instanceMember reads a field, getter or method (bound to the instance)
+func instanceMember(inst *object.Instance, propName string) object.Object {
 	// Check if property is private (starts with _)
 	if len(propName) > 0 && propName[0] == '_' {
 		// Access private field
@@ -236,13 +318,22 @@ func accessInstanceMember(inst *object.Instance, me *ast.MemberExpression) objec
 		boundEnv := object.NewEnclosedEnvironment(method.Env)
 		boundEnv.Set("ei", inst)
 		return &object.Function{
-			Parameters: method.Parameters,
-			Body:       method.Body,
-			Env:        boundEnv,
-			Name:       method.Name,
+			Parameters:    method.Parameters,
+			RestParameter: method.RestParameter,
+			Body:          method.Body,
+			Env:           boundEnv,
+			Name:          method.Name,
+			IsGenerator:   method.IsGenerator,
 		}
 	}
 
+	// Check if a Go-implemented method exists
+	if native, ok := inst.Class.NativeMethods[propName]; ok {
+		return &object.Builtin{Fn: func(args ...object.Object) object.Object {
+			return native(inst, args...)
+		}}
+	}
+
 	return object.NULL
 }
 
@@ -269,34 +360,32 @@ func accessGeneratorMember(gen *object.Generator, me *ast.MemberExpression) obje
 		return newError("invalid generator member")
 	}
 
+	var mode object.GeneratorMode
 	switch ident.Value {
 	case "next":
-		return &object.Builtin{
-			Fn: func(args ...object.Object) object.Object {
-				return generatorNext(gen)
-			},
-		}
+		mode = object.GeneratorNext
 	case "return":
-		return &object.Builtin{
-			Fn: func(args ...object.Object) object.Object {
-				if len(args) > 0 {
-					return generatorReturn(gen, args[0])
-				}
-				return generatorReturn(gen, object.NULL)
-			},
-		}
+		mode = object.GeneratorReturn
 	case "throw":
-		return &object.Builtin{
-			Fn: func(args ...object.Object) object.Object {
-				if len(args) > 0 {
-					return generatorThrow(gen, args[0])
-				}
-				return generatorThrow(gen, &object.String{Value: "generator throw"})
-			},
-		}
+		mode = object.GeneratorThrow
 	default:
 		return object.NULL
 	}
+
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			var value object.Object = object.NULL
+			if len(args) > 0 {
+				value = args[0]
+			} else if mode == object.GeneratorThrow {
+				value = &object.String{Value: "generator throw"}
+			}
+			if gen.Async {
+				return resumeAsyncGenerator(gen, mode, value)
+			}
+			return resumeGenerator(gen, mode, value)
+		},
+	}
 }
 
 // assignClassMember assigns to static properties of a class
@@ -313,7 +402,7 @@ func assignClassMember(class *object.Class, member *ast.MemberExpression, operat
 		if !ok {
 			return newError("static property '%s' not found in class '%s'", propName, class.Name)
 		}
-		op := string(operator[0])
+		op := compoundOperator(operator)
 		val = evalBinaryExpression(op, current, val)
 		if isError(val) {
 			return val
@@ -2,151 +2,258 @@ package evaluator
 
 import (
 	"BanglaCode/src/ast"
+	"BanglaCode/src/eventloop"
 	"BanglaCode/src/object"
 )
 
-type yieldResult struct {
-	Value object.Object
+// generatorBinding is the name under which a generator's environment
+// holds the generator, for the utpadan expressions in its body to find.
+// utpadan is a keyword, so no variable in a program can have this name.
+const generatorBinding = "utpadan"
+
+// generatorReturn unwinds a generator body after return(v). It travels
+// like an error, so it leaves loops and passes through chesta blocks
+// (running their shesh) without ever being caught.
+type generatorReturn struct {
+	value object.Object
 }
 
-func (yr *yieldResult) Type() object.ObjectType { return object.GENERATOR_OBJ }
-func (yr *yieldResult) Inspect() string         { return "yield " + yr.Value.Inspect() }
+func (gr *generatorReturn) Type() object.ObjectType { return object.ERROR_OBJ }
+func (gr *generatorReturn) Inspect() string         { return "return " + gr.value.Inspect() }
 
 func evalGeneratorFunction(fn *object.Function, args []object.Object, env *object.Environment) object.Object {
-	extendedEnv := extendFunctionEnv(fn, args)
-	return &object.Generator{
+	extendedEnv, failed := extendFunctionEnv(fn, args)
+	if failed != nil {
+		return failed
+	}
+	gen := &object.Generator{
 		Function: fn,
 		Env:      extendedEnv,
 		State:    "suspended",
 		Value:    object.NULL,
-		Index:    0,
-		Done:     false,
+		Async:    fn.IsAsync,
+	}
+	extendedEnv.Set(generatorBinding, gen)
+	return gen
+}
+
+// resumeGenerator implements next(v), return(v) and throw(e): it returns
+// the iterator result {value, done}, or the error the body ended with
+func resumeGenerator(gen *object.Generator, mode object.GeneratorMode, value object.Object) object.Object {
+	out := stepGenerator(gen, object.GeneratorSignal{Mode: mode, Value: value})
+	if out.Mode == object.GeneratorThrow {
+		return out.Value
 	}
+	return object.IteratorResult(out.Value, out.Mode == object.GeneratorReturn)
+}
+
+// resumeAsyncGenerator is resumeGenerator for async generators: it returns
+// a promise of the result, and the generator only resumes once the
+// requests made before this one have finished
+func resumeAsyncGenerator(gen *object.Generator, mode object.GeneratorMode, value object.Object) *object.Promise {
+	promise := object.NewPromise()
+	previous, finished := takeTurn(gen)
+
+	eventloop.NewCoroutine(func() {
+		defer object.ResolvePromise(finished, object.NULL)
+		if previous != nil {
+			awaitPromise(previous)
+		}
+		result := resumeGenerator(gen, mode, value)
+		if isError(result) || isException(result) {
+			object.RejectPromise(promise, result)
+			return
+		}
+		object.ResolvePromise(promise, result)
+	}).Resume()
+
+	return promise
 }
 
-func generatorNext(gen *object.Generator) *object.Map {
-	if gen.Done {
-		return generatorResult(object.NULL, true)
+// takeTurn queues a request to an async generator. The request may resume
+// the generator once previous (nil when the queue is empty) has settled,
+// and must resolve finished when it is done.
+func takeTurn(gen *object.Generator) (previous, finished *object.Promise) {
+	previous = gen.Pending
+	finished = object.NewPromise()
+	gen.Pending = finished
+	return previous, finished
+}
+
+// stepGenerator resumes gen with a signal and waits until the body yields
+// (GeneratorNext), returns (GeneratorReturn) or fails (GeneratorThrow)
+func stepGenerator(gen *object.Generator, in object.GeneratorSignal) object.GeneratorSignal {
+	if gen.State == "executing" {
+		return object.GeneratorSignal{Mode: object.GeneratorThrow, Value: newError("generator is already running")}
+	}
+
+	// A finished generator, or one closed before it started, runs nothing
+	if gen.Done || (gen.Resume == nil && in.Mode != object.GeneratorNext) {
+		gen.Done = true
+		gen.State = "completed"
+		switch in.Mode {
+		case object.GeneratorThrow:
+			return object.GeneratorSignal{Mode: object.GeneratorThrow, Value: newException(in.Value)}
+		case object.GeneratorReturn:
+			return in
+		}
+		return object.GeneratorSignal{Mode: object.GeneratorReturn, Value: object.NULL}
 	}
 
 	gen.State = "executing"
-	stmts := gen.Function.Body.Statements
-	for i := gen.Index; i < len(stmts); i++ {
-		result := evalGeneratorStatement(stmts[i], gen)
-
-		switch v := result.(type) {
-		case *yieldResult:
-			gen.Index = i + 1
-			gen.State = "suspended"
-			gen.Value = v.Value
-			return generatorResult(v.Value, false)
-		case *object.ReturnValue:
-			gen.Done = true
-			gen.State = "completed"
-			gen.Value = v.Value
-			return generatorResult(v.Value, true)
-		case *object.Exception:
-			gen.Done = true
-			gen.State = "completed"
-			return &object.Map{
-				Pairs: map[string]object.Object{
-					"value": &object.String{Value: v.Inspect()},
-					"done":  object.TRUE,
-				},
+	if gen.Resume == nil {
+		startGenerator(gen)
+	} else {
+		gen.Resume <- in
+	}
+	out := <-gen.Yield
+
+	gen.Value = out.Value
+	if out.Mode == object.GeneratorNext {
+		gen.State = "suspended"
+	} else {
+		gen.Done = true
+		gen.State = "completed"
+	}
+	return out
+}
+
+// startGenerator runs the generator body on its own goroutine; it runs
+// until the first utpadan, and sends its result on Yield when it ends
+func startGenerator(gen *object.Generator) {
+	gen.Resume = make(chan object.GeneratorSignal)
+	gen.Yield = make(chan object.GeneratorSignal)
+
+	go func() {
+		out := object.GeneratorSignal{Mode: object.GeneratorReturn, Value: object.NULL}
+		defer func() {
+			if r := recover(); r != nil {
+				out = object.GeneratorSignal{Mode: object.GeneratorThrow, Value: newError("panic in generator: %v", r)}
 			}
+			gen.Yield <- out
+		}()
+
+		switch result := Eval(gen.Function.Body, gen.Env).(type) {
+		case *object.ReturnValue:
+			out.Value = result.Value
+		case *generatorReturn:
+			out.Value = result.value
+		case *object.Error, *object.Exception:
+			out = object.GeneratorSignal{Mode: object.GeneratorThrow, Value: result}
 		}
+	}()
+}
+
+// evalYieldExpression suspends the running generator until its caller
+// resumes it; the expression's value is what next(v) sends in
+func evalYieldExpression(node *ast.YieldExpression, env *object.Environment) object.Object {
+	binding, _ := env.Get(generatorBinding)
+	gen, ok := binding.(*object.Generator)
+	if !ok {
+		return newError("utpadan (yield) can only be used inside generator function")
+	}
 
-		if isError(result) {
-			gen.Done = true
-			gen.State = "completed"
-			return generatorResult(result, true)
+	var value object.Object = object.NULL
+	if node.Expression != nil {
+		value = Eval(node.Expression, env)
+		if isError(value) || isException(value) {
+			return value
 		}
 	}
 
-	gen.Done = true
-	gen.State = "completed"
-	gen.Value = object.NULL
-	return generatorResult(object.NULL, true)
-}
+	// An async generator yields what its promises resolve to
+	if promise, ok := value.(*object.Promise); ok && gen.Async && !node.Delegate {
+		if value = awaitPromise(promise); isError(value) || isException(value) {
+			return value
+		}
+	}
 
-func generatorReturn(gen *object.Generator, value object.Object) *object.Map {
-	if value == nil {
-		value = object.NULL
+	if node.Delegate {
+		return delegateYield(gen, value, env)
 	}
-	gen.Done = true
-	gen.State = "completed"
-	gen.Value = value
-	return generatorResult(value, true)
+	return resumeValue(exchange(gen, value))
+}
+
+// exchange hands a yielded value to the caller waiting in stepGenerator
+// and blocks until the generator is resumed
+func exchange(gen *object.Generator, value object.Object) object.GeneratorSignal {
+	gen.Yield <- object.GeneratorSignal{Mode: object.GeneratorNext, Value: value}
+	return <-gen.Resume
 }
 
-func generatorThrow(gen *object.Generator, thrown object.Object) object.Object {
-	if thrown == nil {
-		thrown = &object.String{Value: "generator throw"}
+// resumeValue turns the signal a generator was resumed with into the
+// result of its utpadan expression
+func resumeValue(in object.GeneratorSignal) object.Object {
+	switch in.Mode {
+	case object.GeneratorThrow:
+		return newException(in.Value)
+	case object.GeneratorReturn:
+		return &generatorReturn{value: in.Value}
 	}
-	gen.Done = true
-	gen.State = "completed"
-	return &object.Exception{Message: thrown.Inspect(), Value: thrown}
+	return in.Value
 }
 
-func generatorResult(value object.Object, done bool) *object.Map {
-	doneObj := object.FALSE
-	if done {
-		doneObj = object.TRUE
+// delegateYield runs utpadan* iterable: each value of the iterable is
+// yielded in turn. An inner generator also gets what next(v), throw(e)
+// and return(v) send in, and its return value is the expression's value.
+func delegateYield(gen *object.Generator, iterable object.Object, env *object.Environment) object.Object {
+	if inner, ok := iterable.(*object.Generator); ok {
+		if inner.Async && !gen.Async {
+			return newError("utpadan* in a kaj* cannot delegate to an async generator")
+		}
+		in := object.GeneratorSignal{Mode: object.GeneratorNext, Value: object.NULL}
+		for {
+			out := stepInner(inner, in)
+			switch out.Mode {
+			case object.GeneratorThrow:
+				return out.Value
+			case object.GeneratorReturn:
+				if in.Mode == object.GeneratorReturn {
+					return &generatorReturn{value: out.Value}
+				}
+				return out.Value
+			}
+			in = exchange(gen, out.Value)
+		}
 	}
-	if value == nil {
-		value = object.NULL
+
+	var it iterator
+	var failed object.Object
+	if gen.Async {
+		it, failed = iterateAsync(iterable, env)
+	} else {
+		it, failed = iterate(iterable, env)
 	}
-	return &object.Map{
-		Pairs: map[string]object.Object{
-			"value": value,
-			"done":  doneObj,
-		},
+	if failed != nil {
+		return failed
 	}
-}
-
-func evalGeneratorStatement(stmt ast.Statement, gen *object.Generator) object.Object {
-	switch s := stmt.(type) {
-	case *ast.ExpressionStatement:
-		return evalGeneratorExpression(s.Expression, gen)
-	case *ast.ReturnStatement:
-		val := evalGeneratorExpression(s.ReturnValue, gen)
-		if isError(val) {
-			return val
+	for {
+		value, done, failed := it.next()
+		if failed != nil {
+			return failed
 		}
-		return &object.ReturnValue{Value: val}
-	case *ast.VariableDeclaration:
-		val := Eval(s.Value, gen.Env)
-		if isError(val) {
-			return val
+		if done {
+			return object.NULL
 		}
-		if s.IsConstant {
-			gen.Env.SetConstant(s.Name.Value, val)
-		} else if s.IsGlobal {
-			gen.Env.SetGlobal(s.Name.Value, val)
-		} else {
-			gen.Env.Set(s.Name.Value, val)
+		if in := exchange(gen, value); in.Mode != object.GeneratorNext {
+			if failed := it.close(); failed != nil {
+				return failed
+			}
+			return resumeValue(in)
 		}
-		return val
-	default:
-		return Eval(stmt, gen.Env)
 	}
 }
 
-func evalGeneratorExpression(expr ast.Expression, gen *object.Generator) object.Object {
-	if expr == nil {
-		return object.NULL
-	}
-	switch e := expr.(type) {
-	case *ast.YieldExpression:
-		var value object.Object = object.NULL
-		if e.Expression != nil {
-			value = Eval(e.Expression, gen.Env)
-			if isError(value) {
-				return value
-			}
-		}
-		return &yieldResult{Value: value}
-	default:
-		return Eval(expr, gen.Env)
+// stepInner is stepGenerator for a generator resumed from Go code, which
+// has to queue behind any pending next() of an async generator
+func stepInner(gen *object.Generator, in object.GeneratorSignal) object.GeneratorSignal {
+	if !gen.Async {
+		return stepGenerator(gen, in)
+	}
+	previous, finished := takeTurn(gen)
+	defer object.ResolvePromise(finished, object.NULL)
+	if previous != nil {
+		awaitPromise(previous)
 	}
+	return stepGenerator(gen, in)
 }
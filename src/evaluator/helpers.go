@@ -1,8 +1,10 @@
 package evaluator
 
 import (
+	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
 	"fmt"
+	"math"
 )
 
 // newError creates a new error object without position info
@@ -10,9 +12,25 @@ func newError(format string, a ...interface{}) *object.Error {
 	return &object.Error{Message: fmt.Sprintf(format, a...)}
 }
 
-// newErrorAt creates a new error object with line and column info
-func newErrorAt(line, column int, format string, a ...interface{}) *object.Error {
-	return &object.Error{Message: fmt.Sprintf(format, a...), Line: line, Column: column}
+// newErrorAt creates a new error object at the position of tok
+func newErrorAt(tok lexer.Token, format string, a ...interface{}) *object.Error {
+	return &object.Error{Message: fmt.Sprintf(format, a...), File: tok.File, Line: tok.Line, Column: tok.Column}
+}
+
+// locateError stamps an error that has no source position yet with the
+// position of tok, so errors from builtins and operators still point at
+// the code that triggered them
+func locateError(obj object.Object, tok lexer.Token) object.Object {
+	errObj, ok := obj.(*object.Error)
+	if !ok || errObj.File != "" || tok.Line == 0 {
+		return obj
+	}
+	if errObj.Line == 0 {
+		errObj.Line = tok.Line
+		errObj.Column = tok.Column
+	}
+	errObj.File = tok.File
+	return errObj
 }
 
 // isError checks if an object is an error
@@ -45,6 +63,21 @@ func isTruthy(obj object.Object) bool {
 	}
 }
 
+// toUint32 converts a number to an unsigned 32-bit integer the way the
+// JavaScript bitwise operators do: truncate, then wrap modulo 2^32
+// (NaN and infinities become 0)
+func toUint32(f float64) uint32 {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0
+	}
+	return uint32(int64(math.Mod(math.Trunc(f), 1<<32)))
+}
+
+// toInt32 is toUint32 read as a signed integer
+func toInt32(f float64) int32 {
+	return int32(toUint32(f))
+}
+
 // objectsEqual checks if two objects are equal
 func objectsEqual(left, right object.Object) bool {
 	if left.Type() != right.Type() {
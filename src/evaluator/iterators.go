@@ -0,0 +1,292 @@
+package evaluator
+
+import (
+	"BanglaCode/src/eventloop"
+	"BanglaCode/src/object"
+)
+
+// iteratorMethod is the method that makes a map or class instance
+// iterable: it returns an iterator, i.e. a generator or anything with a
+// next() method returning {value, done}
+const iteratorMethod = "iterator"
+
+// iterator walks an iterable value one element at a time; for-of, spread,
+// array destructuring and utpadan* all read values through it
+type iterator interface {
+	// next returns the next value, or done once there are no more; a
+	// non-nil failure (error or exception) ends the iteration
+	next() (value object.Object, done bool, failure object.Object)
+	// close tells the iterator that its consumer stopped early, so that
+	// a generator can run its shesh blocks
+	close() object.Object
+}
+
+// iterate returns an iterator over arrays, strings and maps (their
+// values), generators, and maps and instances with an iterator() method
+func iterate(iterable object.Object, env *object.Environment) (iterator, object.Object) {
+	if it, ok, failed := protocolIterator(iterable, env); ok || failed != nil {
+		return it, failed
+	}
+
+	elements, err := toForOfElements(iterable)
+	if err != nil {
+		return nil, err
+	}
+	return &elementIterator{elements: elements}, nil
+}
+
+// iterateAsync returns the iterator ghuriye opekha reads. Besides what
+// iterate takes it reads async generators and readable streams, and it
+// awaits values and next() results that are promises. A promise for the
+// iterable itself is awaited first.
+func iterateAsync(iterable object.Object, env *object.Environment) (iterator, object.Object) {
+	if promise, ok := iterable.(*object.Promise); ok {
+		if iterable = awaitPromise(promise); isError(iterable) || isException(iterable) {
+			return nil, iterable
+		}
+	}
+
+	if stream, ok := iterable.(*object.Stream); ok {
+		if stream.StreamType != "readable" {
+			return nil, newError("ghuriye opekha can only read readable streams, got a %s stream", stream.StreamType)
+		}
+		return &streamIterator{stream: stream}, nil
+	}
+
+	it, failed := iterate(iterable, env)
+	if failed != nil {
+		return nil, failed
+	}
+	switch it := it.(type) {
+	case *generatorIterator:
+		it.async = true
+	case *objectIterator:
+		it.async = true
+	}
+	return &awaitingIterator{inner: it}, nil
+}
+
+// iterateSequence is iterate for spread and array destructuring, which
+// take arrays and the iterator protocol but not strings or maps
+func iterateSequence(iterable object.Object, env *object.Environment) (iterator, object.Object) {
+	if arr, ok := iterable.(*object.Array); ok {
+		return &elementIterator{elements: arr.Elements}, nil
+	}
+	if it, ok, failed := protocolIterator(iterable, env); ok || failed != nil {
+		return it, failed
+	}
+	return nil, nil
+}
+
+// protocolIterator returns the iterator of a generator or of a value with
+// an iterator() method; ok is false for any other value
+func protocolIterator(iterable object.Object, env *object.Environment) (iterator, bool, object.Object) {
+	if gen, ok := iterable.(*object.Generator); ok {
+		return &generatorIterator{gen: gen}, true, nil
+	}
+
+	method, ok := methodOf(iterable, iteratorMethod)
+	if !ok {
+		return nil, false, nil
+	}
+	target := applyFunction(method, []object.Object{}, env)
+	if isError(target) || isException(target) {
+		return nil, true, target
+	}
+	if gen, ok := target.(*object.Generator); ok {
+		return &generatorIterator{gen: gen}, true, nil
+	}
+	if _, ok := methodOf(target, "next"); !ok {
+		return nil, true, newError("iterator() must return an object with a next() method, got %s", target.Type())
+	}
+	return &objectIterator{target: target, env: env}, true, nil
+}
+
+// collect reads an iterator to the end
+func collect(it iterator) ([]object.Object, object.Object) {
+	values := []object.Object{}
+	for {
+		value, done, failed := it.next()
+		if failed != nil {
+			return nil, failed
+		}
+		if done {
+			return values, nil
+		}
+		values = append(values, value)
+	}
+}
+
+// methodOf looks up a callable member of a map or class instance
+func methodOf(obj object.Object, name string) (object.Object, bool) {
+	var member object.Object
+	switch o := obj.(type) {
+	case *object.Map:
+		member = o.Pairs[name]
+	case *object.Instance:
+		member = instanceMember(o, name)
+	}
+
+	switch member.(type) {
+	case *object.Function, *object.Builtin:
+		return member, true
+	}
+	return nil, false
+}
+
+// elementIterator walks values that are already known
+type elementIterator struct {
+	elements []object.Object
+	pos      int
+}
+
+func (it *elementIterator) next() (object.Object, bool, object.Object) {
+	if it.pos >= len(it.elements) {
+		return nil, true, nil
+	}
+	it.pos++
+	return it.elements[it.pos-1], false, nil
+}
+
+func (it *elementIterator) close() object.Object { return nil }
+
+// generatorIterator resumes a generator for each value; async is set when
+// ghuriye opekha reads it, the only way to read an async generator
+type generatorIterator struct {
+	gen   *object.Generator
+	async bool
+}
+
+func (it *generatorIterator) next() (object.Object, bool, object.Object) {
+	if it.gen.Async && !it.async {
+		return nil, false, newError("an async generator can only be read with ghuriye opekha")
+	}
+	out := stepInner(it.gen, object.GeneratorSignal{Mode: object.GeneratorNext, Value: object.NULL})
+	switch out.Mode {
+	case object.GeneratorThrow:
+		return nil, false, out.Value
+	case object.GeneratorReturn:
+		return nil, true, nil
+	}
+	return out.Value, false, nil
+}
+
+func (it *generatorIterator) close() object.Object {
+	if it.gen.Done {
+		return nil
+	}
+	if out := stepInner(it.gen, object.GeneratorSignal{Mode: object.GeneratorReturn, Value: object.NULL}); out.Mode == object.GeneratorThrow {
+		return out.Value
+	}
+	return nil
+}
+
+// objectIterator calls next() on a user iterator object, and its
+// optional return() when closed early. When async, next() may return a
+// promise of its result.
+type objectIterator struct {
+	target object.Object
+	env    *object.Environment
+	async  bool
+}
+
+func (it *objectIterator) next() (object.Object, bool, object.Object) {
+	method, _ := methodOf(it.target, "next")
+	result := applyFunction(method, []object.Object{}, it.env)
+	if promise, ok := result.(*object.Promise); ok && it.async {
+		result = awaitPromise(promise)
+	}
+	if isError(result) || isException(result) {
+		return nil, false, result
+	}
+
+	var value, done object.Object = object.NULL, object.FALSE
+	switch r := result.(type) {
+	case *object.Map:
+		if v, ok := r.Pairs["value"]; ok {
+			value = v
+		}
+		if d, ok := r.Pairs["done"]; ok {
+			done = d
+		}
+	case *object.Instance:
+		if v, ok := r.Properties["value"]; ok {
+			value = v
+		}
+		if d, ok := r.Properties["done"]; ok {
+			done = d
+		}
+	default:
+		return nil, false, newError("iterator next() must return a MAP like {value, done}, got %s", result.Type())
+	}
+
+	if isTruthy(done) {
+		return nil, true, nil
+	}
+	return value, false, nil
+}
+
+func (it *objectIterator) close() object.Object {
+	method, ok := methodOf(it.target, "return")
+	if !ok {
+		return nil
+	}
+	if result := applyFunction(method, []object.Object{}, it.env); isError(result) || isException(result) {
+		return result
+	}
+	return nil
+}
+
+// awaitingIterator awaits the values of another iterator that are promises
+type awaitingIterator struct {
+	inner iterator
+}
+
+func (it *awaitingIterator) next() (object.Object, bool, object.Object) {
+	value, done, failed := it.inner.next()
+	if promise, ok := value.(*object.Promise); ok && !done && failed == nil {
+		if value = awaitPromise(promise); isError(value) || isException(value) {
+			return nil, false, value
+		}
+	}
+	return value, done, failed
+}
+
+func (it *awaitingIterator) close() object.Object { return it.inner.close() }
+
+// streamIterator reads whatever a readable stream has buffered as one
+// chunk, waiting for more until the stream ends
+type streamIterator struct {
+	stream *object.Stream
+}
+
+func (it *streamIterator) next() (object.Object, bool, object.Object) {
+	s := it.stream
+	ready := func() bool {
+		s.Mu.RLock()
+		defer s.Mu.RUnlock()
+		return len(s.Buffer) > 0 || s.IsEnded || s.IsClosed
+	}
+	subscribe := func(wake func()) {
+		s.Mu.Lock()
+		defer s.Mu.Unlock()
+		s.OnChange(wake)
+	}
+	if !eventloop.Wait(ready, subscribe, 0) {
+		return nil, false, newError("stream can never receive more data: nothing is left to run")
+	}
+
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	if len(s.Buffer) > 0 {
+		chunk := string(s.Buffer)
+		s.Buffer = s.Buffer[:0]
+		if s.IsEnded {
+			s.IsClosed = true
+		}
+		return &object.String{Value: chunk}, false, nil
+	}
+	return nil, true, nil
+}
+
+func (it *streamIterator) close() object.Object { return nil }
@@ -3,40 +3,66 @@ package evaluator
 import (
 	"BanglaCode/src/ast"
 	"BanglaCode/src/object"
-	"sort"
 )
 
-func evalForOfStatement(stmt *ast.ForOfStatement, env *object.Environment) object.Object {
+func evalForOfStatement(stmt *ast.ForOfStatement, env *object.Environment, label string) object.Object {
 	iterable := Eval(stmt.Iterable, env)
 	if isError(iterable) {
 		return iterable
 	}
 
 	loopEnv := object.NewEnclosedEnvironment(env)
-	elements, err := toForOfElements(iterable)
-	if err != nil {
-		return err
+	var it iterator
+	var failed object.Object
+	if stmt.Await {
+		it, failed = iterateAsync(iterable, env)
+	} else {
+		it, failed = iterate(iterable, env)
+	}
+	if failed != nil {
+		return failed
 	}
 
-	for _, el := range elements {
+	for {
+		el, done, failed := it.next()
+		if failed != nil {
+			return failed
+		}
+		if done {
+			return object.NULL
+		}
 		loopEnv.Update(stmt.VarName.Value, el)
 		result := Eval(stmt.Body, loopEnv)
 		if result != nil {
+			// Leaving early closes the iterator (a generator runs its shesh)
 			switch result.Type() {
 			case object.RETURN_OBJ, object.ERROR_OBJ, object.EXCEPTION_OBJ:
+				if failed := it.close(); failed != nil {
+					return failed
+				}
 				return result
 			case object.BREAK_OBJ:
+				if failed := it.close(); failed != nil {
+					return failed
+				}
+				if !ownsSignal(result, label) {
+					return result
+				}
 				return object.NULL
 			case object.CONTINUE_OBJ:
-				continue
+				if ownsSignal(result, label) {
+					continue
+				}
+				if failed := it.close(); failed != nil {
+					return failed
+				}
+				return result
 			}
 		}
 	}
-
-	return object.NULL
 }
 
-func evalForInStatement(stmt *ast.ForInStatement, env *object.Environment) object.Object {
+func evalForInStatement(stmt *ast.ForInStatement, env *object.Environment, label string) object.Object {
 	target := Eval(stmt.Object, env)
 	if isError(target) {
 		return target
@@ -56,8 +82,14 @@ func evalForInStatement(stmt *ast.ForInStatement, env *object.Environment) objec
 			case object.RETURN_OBJ, object.ERROR_OBJ, object.EXCEPTION_OBJ:
 				return result
 			case object.BREAK_OBJ:
+				if !ownsSignal(result, label) {
+					return result
+				}
 				return object.NULL
 			case object.CONTINUE_OBJ:
+				if !ownsSignal(result, label) {
+					return result
+				}
 				continue
 			}
 		}
@@ -82,11 +114,7 @@ func toForOfElements(iterable object.Object) ([]object.Object, *object.Error) {
 		return elements, nil
 
 	case *object.Map:
-		keys := make([]string, 0, len(it.Pairs))
-		for k := range it.Pairs {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
+		keys := it.Keys()
 		elements := make([]object.Object, 0, len(keys))
 		for _, k := range keys {
 			elements = append(elements, it.Pairs[k])
@@ -94,17 +122,13 @@ func toForOfElements(iterable object.Object) ([]object.Object, *object.Error) {
 		return elements, nil
 	}
 
-	return nil, newError("for...of target must be ARRAY, STRING, or MAP, got %s", iterable.Type())
+	return nil, newError("for...of target must be ARRAY, STRING, MAP or iterable, got %s", iterable.Type())
 }
 
 func toForInKeys(target object.Object) ([]object.Object, *object.Error) {
 	switch it := target.(type) {
 	case *object.Map:
-		keys := make([]string, 0, len(it.Pairs))
-		for k := range it.Pairs {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
+		keys := it.Keys()
 		out := make([]object.Object, 0, len(keys))
 		for _, k := range keys {
 			out = append(out, &object.String{Value: k})
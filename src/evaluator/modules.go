@@ -5,10 +5,12 @@ import (
 	"BanglaCode/src/evaluator/builtins"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
+	"BanglaCode/src/packages"
 	"BanglaCode/src/parser"
-	"encoding/json"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 )
@@ -16,6 +18,7 @@ import (
 // Module cache to prevent circular imports
 var (
 	moduleCache = make(map[string]*object.Module)
+	loading     = make(map[string]bool) // modules being evaluated right now
 	moduleMutex sync.RWMutex
 	currentDir  = "."
 )
@@ -25,12 +28,38 @@ func SetCurrentDir(dir string) {
 	currentDir = dir
 }
 
+// ResetModules forgets every loaded module so the next ano re-reads the
+// file from disk (used by the test runner between files and in watch mode)
+func ResetModules() {
+	moduleMutex.Lock()
+	defer moduleMutex.Unlock()
+	moduleCache = make(map[string]*object.Module)
+}
+
+// displayPath shortens a module path for error messages: relative to the
+// working directory when the module lives below it, absolute otherwise
+func displayPath(fullPath string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return fullPath
+	}
+	abs, err := filepath.Abs(fullPath)
+	if err != nil {
+		return fullPath
+	}
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return abs
+	}
+	return rel
+}
+
 // evalImportStatement evaluates import statements
 func evalImportStatement(is *ast.ImportStatement, env *object.Environment) object.Object {
 	modulePath := is.Path.Value
 
-	// Resolve relative path
-	fullPath := filepath.Join(currentDir, modulePath)
+	// Resolve relative path, falling back to installed packages
+	fullPath := packages.Resolve(currentDir, modulePath)
 
 	// Check if it's a JSON file
 	if strings.HasSuffix(modulePath, ".json") {
@@ -45,6 +74,10 @@ func evalImportStatement(is *ast.ImportStatement, env *object.Environment) objec
 		importModuleExports(mod, is.Alias, env)
 		return mod
 	}
+	if loading[fullPath] {
+		moduleMutex.RUnlock()
+		return newError("circular import of module '%s'", modulePath)
+	}
 	moduleMutex.RUnlock()
 
 	// Read module file
@@ -56,15 +89,28 @@ func evalImportStatement(is *ast.ImportStatement, env *object.Environment) objec
 	// Create module environment
 	moduleEnv := object.NewEnvironment()
 
-	// Parse module
-	l := lexer.New(string(content))
+	// Parse module, recording its file name in every token
+	l := lexer.NewWithFile(string(content), displayPath(fullPath))
 	p := parser.New(l)
 	program := p.ParseProgram()
 
-	if len(p.Errors()) != 0 {
-		return newError("parse error in module '%s': %s", modulePath, p.Errors()[0])
+	if diagnostics := p.Diagnostics(); len(diagnostics) != 0 {
+		messages := make([]string, len(diagnostics))
+		for i, d := range diagnostics {
+			messages[i] = d.String()
+		}
+		return newError("parse error in module '%s': %s", modulePath, strings.Join(messages, "; "))
 	}
 
+	moduleMutex.Lock()
+	loading[fullPath] = true
+	moduleMutex.Unlock()
+	defer func() {
+		moduleMutex.Lock()
+		delete(loading, fullPath)
+		moduleMutex.Unlock()
+	}()
+
 	// Save current directory and set module directory
 	oldDir := currentDir
 	currentDir = filepath.Dir(fullPath)
@@ -108,6 +154,14 @@ func evalImportStatement(is *ast.ImportStatement, env *object.Environment) objec
 				return result
 			}
 
+		case *ast.ImportStatement:
+			// Load the module's own dependencies (packages depend on packages)
+			result = evalImportStatement(s, moduleEnv)
+			if isError(result) {
+				currentDir = oldDir
+				return result
+			}
+
 		case *ast.VariableDeclaration:
 			// Skip non-exported variable/constant/global declarations
 			// These should only be evaluated if explicitly exported with pathao
@@ -148,9 +202,10 @@ func importModuleExports(mod *object.Module, alias *ast.Identifier, env *object.
 	if alias != nil {
 		// Import as namespace: ano "math.bang" hisabe math;
 		// Access via: math.add(1, 2)
-		modMap := &object.Map{Pairs: make(map[string]object.Object)}
-		for k, v := range mod.Exports {
-			modMap.Pairs[k] = v
+		// Like JS namespace objects, exports enumerate in sorted order
+		modMap := object.NewMap()
+		for _, k := range slices.Sorted(maps.Keys(mod.Exports)) {
+			modMap.Set(k, mod.Exports[k])
 		}
 		env.Set(alias.Value, modMap)
 	} else {
@@ -174,22 +229,22 @@ func evalExportStatement(es *ast.ExportStatement, env *object.Environment) objec
 	if exports, ok := env.Get("__exports__"); ok {
 		exportsMap = exports.(*object.Map)
 	} else {
-		exportsMap = &object.Map{Pairs: make(map[string]object.Object)}
+		exportsMap = object.NewMap()
 		env.Set("__exports__", exportsMap)
 	}
 
 	// Add to exports based on statement type
 	switch stmt := es.Statement.(type) {
 	case *ast.VariableDeclaration:
-		exportsMap.Pairs[stmt.Name.Value] = result
+		exportsMap.Set(stmt.Name.Value, result)
 	case *ast.ExpressionStatement:
 		if fn, ok := stmt.Expression.(*ast.FunctionLiteral); ok && fn.Name != nil {
 			if val, ok := env.Get(fn.Name.Value); ok {
-				exportsMap.Pairs[fn.Name.Value] = val
+				exportsMap.Set(fn.Name.Value, val)
 			}
 		}
 	case *ast.ClassDeclaration:
-		exportsMap.Pairs[stmt.Name.Value] = result
+		exportsMap.Set(stmt.Name.Value, result)
 	}
 
 	return result
@@ -202,14 +257,12 @@ func evalJSONImport(fullPath, modulePath string, alias *ast.Identifier, env *obj
 		return newError("cannot import JSON '%s': %s", modulePath, err.Error())
 	}
 
-	var jsonData interface{}
-	if err := json.Unmarshal(content, &jsonData); err != nil {
+	// Convert JSON to BanglaCode object
+	obj, err := builtins.DecodeJSON(content)
+	if err != nil {
 		return newError("invalid JSON in '%s': %s", modulePath, err.Error())
 	}
 
-	// Convert JSON to BanglaCode object
-	obj := builtins.JsonToObject(jsonData)
-
 	// If alias provided, set with alias name, otherwise error (JSON requires alias)
 	if alias != nil {
 		env.Set(alias.Value, obj)
@@ -0,0 +1,168 @@
+package evaluator
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/object"
+)
+
+// accessPromiseMember returns the chaining methods of a promise:
+// then(onResolved, onRejected), catch(onRejected) and finally(fn)
+func accessPromiseMember(promise *object.Promise, me *ast.MemberExpression) object.Object {
+	ident, ok := me.Property.(*ast.Identifier)
+	if !ok {
+		return newError("invalid promise member")
+	}
+
+	switch ident.Value {
+	case "then":
+		return &object.Builtin{Fn: func(args ...object.Object) object.Object {
+			if len(args) > 2 {
+				return newError("then expects at most 2 arguments, got %d", len(args))
+			}
+			return chainPromise(promise, handlerArg(args, 0), handlerArg(args, 1))
+		}}
+	case "catch":
+		return &object.Builtin{Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("catch expects 1 argument, got %d", len(args))
+			}
+			return chainPromise(promise, nil, handlerArg(args, 0))
+		}}
+	case "finally":
+		return &object.Builtin{Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("finally expects 1 argument, got %d", len(args))
+			}
+			return finallyPromise(promise, handlerArg(args, 0))
+		}}
+	default:
+		return newError("Promise has no property '%s'", ident.Value)
+	}
+}
+
+// chainPromise returns a promise for the result of the handler matching how
+// promise settles; without that handler the result passes straight through
+func chainPromise(promise *object.Promise, onResolved, onRejected object.Object) object.Object {
+	if errObj := checkHandler(onResolved); errObj != nil {
+		return errObj
+	}
+	if errObj := checkHandler(onRejected); errObj != nil {
+		return errObj
+	}
+
+	next := object.NewPromise()
+	promise.OnSettle(func() {
+		result, rejected := promise.Result()
+		handler, arg := onResolved, result
+		if rejected {
+			handler, arg = onRejected, object.RejectionReason(result)
+		}
+		if handler == nil {
+			object.SettleLike(next, promise)
+			return
+		}
+
+		out := callHandler(handler, arg)
+		if isError(out) || isException(out) {
+			object.RejectPromise(next, out)
+			return
+		}
+		object.ResolveWith(next, out)
+	})
+	return next
+}
+
+// finallyPromise runs fn however promise settles, then passes its result on
+// unchanged, unless fn throws or returns a promise that rejects
+func finallyPromise(promise *object.Promise, fn object.Object) object.Object {
+	if errObj := checkHandler(fn); errObj != nil {
+		return errObj
+	}
+
+	next := object.NewPromise()
+	promise.OnSettle(func() {
+		if fn == nil {
+			object.SettleLike(next, promise)
+			return
+		}
+
+		out := callHandler(fn)
+		if isError(out) || isException(out) {
+			object.RejectPromise(next, out)
+			return
+		}
+		waitFor, ok := out.(*object.Promise)
+		if !ok {
+			object.SettleLike(next, promise)
+			return
+		}
+		waitFor.OnSettle(func() {
+			if result, rejected := waitFor.Result(); rejected {
+				object.RejectPromise(next, result)
+				return
+			}
+			object.SettleLike(next, promise)
+		})
+	})
+	return next
+}
+
+// handlerArg returns args[i], treating a missing or khali handler as none
+func handlerArg(args []object.Object, i int) object.Object {
+	if i >= len(args) || args[i] == object.NULL {
+		return nil
+	}
+	return args[i]
+}
+
+func checkHandler(handler object.Object) *object.Error {
+	switch handler.(type) {
+	case nil, *object.Function, *object.Builtin:
+		return nil
+	}
+	return newError("promise handler must be a function, got %s", handler.Type())
+}
+
+// callHandler calls a promise handler, dropping arguments it does not take
+// so that kaj() { ... } works as a handler too
+func callHandler(handler object.Object, args ...object.Object) object.Object {
+	if fn, ok := handler.(*object.Function); ok && fn.RestParameter == nil && len(args) > len(fn.Parameters) {
+		args = args[:len(fn.Parameters)]
+	}
+	return applyFunction(handler, args, nil)
+}
+
+// accessAbortSignalMember reads the state of an abort signal
+func accessAbortSignalMember(signal *object.AbortSignal, me *ast.MemberExpression) object.Object {
+	ident, ok := me.Property.(*ast.Identifier)
+	if !ok {
+		return newError("invalid AbortSignal member")
+	}
+
+	signal.Mu.Lock()
+	defer signal.Mu.Unlock()
+	switch ident.Value {
+	case "aborted":
+		return object.NativeBoolToBooleanObject(signal.Aborted)
+	case "reason":
+		if signal.Reason == nil {
+			return object.NULL
+		}
+		return signal.Reason
+	default:
+		return newError("AbortSignal has no property '%s'", ident.Value)
+	}
+}
+
+// accessBuiltinMember reads a static member of a builtin, such as
+// Promise.race
+func accessBuiltinMember(builtin *object.Builtin, me *ast.MemberExpression) object.Object {
+	ident, ok := me.Property.(*ast.Identifier)
+	if !ok {
+		return newError("invalid builtin member")
+	}
+	if member, ok := builtin.Members[ident.Value]; ok {
+		return member
+	}
+	return newError("member access not supported on %s", builtin.Type())
+}
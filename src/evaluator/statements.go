@@ -60,8 +60,9 @@ func evalIfStatement(ie *ast.IfStatement, env *object.Environment) object.Object
 	return object.NULL
 }
 
-// evalWhileStatement evaluates while loops
-func evalWhileStatement(ws *ast.WhileStatement, env *object.Environment) object.Object {
+// evalWhileStatement evaluates while loops; label names the loop for
+// thamo label / chharo label, "" when it has none
+func evalWhileStatement(ws *ast.WhileStatement, env *object.Environment, label string) object.Object {
 	for {
 		condition := Eval(ws.Condition, env)
 		if isError(condition) {
@@ -81,8 +82,14 @@ func evalWhileStatement(ws *ast.WhileStatement, env *object.Environment) object.
 			case object.ERROR_OBJ:
 				return result
 			case object.BREAK_OBJ:
+				if !ownsSignal(result, label) {
+					return result
+				}
 				return object.NULL
 			case object.CONTINUE_OBJ:
+				if !ownsSignal(result, label) {
+					return result
+				}
 				continue
 			case object.EXCEPTION_OBJ:
 				return result
@@ -94,7 +101,7 @@ func evalWhileStatement(ws *ast.WhileStatement, env *object.Environment) object.
 }
 
 // evalForStatement evaluates for loops
-func evalForStatement(fs *ast.ForStatement, env *object.Environment) object.Object {
+func evalForStatement(fs *ast.ForStatement, env *object.Environment, label string) object.Object {
 	// Create new scope for loop
 	loopEnv := object.NewEnclosedEnvironment(env)
 
@@ -128,8 +135,14 @@ func evalForStatement(fs *ast.ForStatement, env *object.Environment) object.Obje
 			case object.ERROR_OBJ:
 				return result
 			case object.BREAK_OBJ:
+				if !ownsSignal(result, label) {
+					return result
+				}
 				return object.NULL
 			case object.CONTINUE_OBJ:
+				if !ownsSignal(result, label) {
+					return result
+				}
 				// Continue to update step
 			case object.EXCEPTION_OBJ:
 				return result
@@ -148,6 +161,47 @@ func evalForStatement(fs *ast.ForStatement, env *object.Environment) object.Obje
 	return object.NULL
 }
 
+// evalLabeledStatement evaluates "label: statement". A labeled loop
+// consumes thamo label and chharo label itself; any other labeled
+// statement ends early on thamo label.
+func evalLabeledStatement(node *ast.LabeledStatement, env *object.Environment) object.Object {
+	label := node.Label.Value
+
+	var result object.Object
+	switch body := node.Body.(type) {
+	case *ast.WhileStatement:
+		result = evalWhileStatement(body, env, label)
+	case *ast.DoWhileStatement:
+		result = evalDoWhileStatement(body, env, label)
+	case *ast.ForStatement:
+		result = evalForStatement(body, env, label)
+	case *ast.ForOfStatement:
+		result = locateError(evalForOfStatement(body, env, label), body.Token)
+	case *ast.ForInStatement:
+		result = locateError(evalForInStatement(body, env, label), body.Token)
+	default:
+		result = Eval(body, env)
+	}
+
+	if b, ok := result.(*object.Break); ok && b.Label == label {
+		return object.NULL
+	}
+	return result
+}
+
+// ownsSignal reports whether a loop labeled label ("" for none) handles a
+// thamo/chharo result: unlabeled ones stop at the innermost loop, labeled
+// ones travel out to the loop with their label
+func ownsSignal(signal object.Object, label string) bool {
+	switch s := signal.(type) {
+	case *object.Break:
+		return s.Label == "" || s.Label == label
+	case *object.Continue:
+		return s.Label == "" || s.Label == label
+	}
+	return false
+}
+
 // evalIdentifier evaluates variable references
 func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object {
 	if val, ok := env.Get(node.Value); ok {
@@ -158,7 +212,7 @@ func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object
 		return builtin
 	}
 
-	return newErrorAt(node.Token.Line, node.Token.Column, "variable '%s' is not defined", node.Value)
+	return newErrorAt(node.Token, "variable '%s' is not defined", node.Value)
 }
 
 // evalExpressions evaluates a list of expressions, handling spread elements
@@ -172,12 +226,11 @@ func evalExpressions(exps []ast.Expression, env *object.Environment) []object.Ob
 			if isError(evaluated) {
 				return []object.Object{evaluated}
 			}
-			// Spread must be an array
-			if arr, ok := evaluated.(*object.Array); ok {
-				result = append(result, arr.Elements...)
-			} else {
-				return []object.Object{newError("spread operator requires an array, got %s", evaluated.Type())}
+			values, failed := spreadValues(evaluated, env)
+			if failed != nil {
+				return []object.Object{failed}
 			}
+			result = append(result, values...)
 		} else {
 			evaluated := Eval(e, env)
 			if isError(evaluated) {
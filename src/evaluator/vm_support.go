@@ -0,0 +1,99 @@
+package evaluator
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/object"
+)
+
+// The helpers below expose the evaluator's operator and call semantics to
+// the bytecode VM (src/vm), so both backends behave identically.
+
+// BinaryOp applies a binary operator to two evaluated operands
+func BinaryOp(operator string, left, right object.Object) object.Object {
+	return evalBinaryExpression(operator, left, right)
+}
+
+// UnaryOp applies a unary operator to an evaluated operand
+func UnaryOp(operator string, right object.Object) object.Object {
+	return evalUnaryExpression(operator, right)
+}
+
+// CompoundOperator returns the binary operator of a compound assignment
+func CompoundOperator(assign string) string {
+	return compoundOperator(assign)
+}
+
+// IsTruthy reports whether a value counts as true in conditions
+func IsTruthy(obj object.Object) bool {
+	return isTruthy(obj)
+}
+
+// CheckArguments returns an error if fn cannot be called with argc arguments
+func CheckArguments(fn *object.Function, argc int, call lexer.Token) *object.Error {
+	return checkArgumentCount(fn, argc, call)
+}
+
+// ExtendFunctionEnv creates the call environment for a user function, or
+// returns the error raised while binding its parameters
+func ExtendFunctionEnv(fn *object.Function, args []object.Object) (*object.Environment, object.Object) {
+	return extendFunctionEnv(fn, args)
+}
+
+// ApplyFunction calls any callable value (user function, async or generator
+// function, builtin) the same way a call expression does
+func ApplyFunction(fn object.Object, args []object.Object, env *object.Environment, call lexer.Token, callExpr ast.Expression) object.Object {
+	return applyFunctionWithPosition(fn, args, env, call, callExpr)
+}
+
+// LocateError gives a position-less error the source position of tok
+func LocateError(obj object.Object, tok lexer.Token) object.Object {
+	return locateError(obj, tok)
+}
+
+// RecordCallFrame adds fn to the stack trace of an error leaving it
+func RecordCallFrame(errObj *object.Error, fn *object.Function, call lexer.Token) {
+	recordCallFrame(errObj, fn, call)
+}
+
+// BindParameters binds the arguments of a call to fn's parameters in env,
+// returning the error raised by a default or pattern
+func BindParameters(env *object.Environment, fn *object.Function, args []object.Object) object.Object {
+	return bindParameters(env, fn, args)
+}
+
+// AccessMember reads obj.prop for an evaluated object
+func AccessMember(obj object.Object, me *ast.MemberExpression, env *object.Environment) object.Object {
+	return accessMember(obj, me, env)
+}
+
+// AccessIndex reads obj[key] for an evaluated object and key
+func AccessIndex(obj, key object.Object, me *ast.MemberExpression, env *object.Environment) object.Object {
+	switch o := obj.(type) {
+	case *object.Array:
+		return evalArrayIndex(o, key)
+	case *object.Map:
+		if val, ok := o.Pairs[getMapKey(key)]; ok {
+			return val
+		}
+		return object.NULL
+	}
+	return accessMember(obj, me, env)
+}
+
+// AssignMember performs obj.prop = val (or a compound assignment)
+func AssignMember(obj object.Object, member *ast.MemberExpression, operator string, val object.Object, env *object.Environment) object.Object {
+	return assignMember(obj, member, operator, val, env)
+}
+
+// AssignIndex performs obj[key] = val (or a compound assignment) for an
+// evaluated key
+func AssignIndex(obj, key object.Object, member *ast.MemberExpression, operator string, val object.Object, env *object.Environment) object.Object {
+	switch o := obj.(type) {
+	case *object.Array:
+		return assignArrayIndex(o, key, operator, val)
+	case *object.Map:
+		return assignMapKey(o, getMapKey(key), operator, val)
+	}
+	return assignMember(obj, member, operator, val, env)
+}
@@ -0,0 +1,86 @@
+package eventloop
+
+import "time"
+
+// Coroutine runs code that can pause, such as the body of an async function.
+// It has its own goroutine, but that goroutine only runs while whoever
+// resumed it waits, so it never runs alongside other BanglaCode.
+type Coroutine struct {
+	resume chan struct{}
+	yield  chan struct{}
+	done   bool
+}
+
+// stack holds the coroutines being run, innermost last; it is only touched
+// by the baton holder
+var stack []*Coroutine
+
+// NewCoroutine creates a paused coroutine that runs body when first resumed
+func NewCoroutine(body func()) *Coroutine {
+	c := &Coroutine{resume: make(chan struct{}), yield: make(chan struct{})}
+	go func() {
+		<-c.resume
+		defer func() {
+			c.done = true
+			c.yield <- struct{}{}
+		}()
+		body()
+	}()
+	return c
+}
+
+// Current returns the innermost running coroutine, or nil at the top level
+func Current() *Coroutine {
+	if len(stack) == 0 {
+		return nil
+	}
+	return stack[len(stack)-1]
+}
+
+// Resume runs c until it suspends or finishes
+func (c *Coroutine) Resume() {
+	if c.done {
+		return
+	}
+	stack = append(stack, c)
+	c.resume <- struct{}{}
+	<-c.yield
+	stack = stack[:len(stack)-1]
+}
+
+// Suspend pauses c until something resumes it; it must be called by the
+// code c is running
+func (c *Coroutine) Suspend() {
+	c.yield <- struct{}{}
+	<-c.resume
+}
+
+// Wait pauses the running code until ready reports true, letting the loop
+// run other work meanwhile. Inside a coroutine it suspends and relies on
+// subscribe to call wake (from a loop task) once ready holds; at the top
+// level it runs the loop itself. It returns false if ready still does not
+// hold after the timeout (when non-zero) or once no work is left.
+func Wait(ready func() bool, subscribe func(wake func()), timeout time.Duration) bool {
+	c := Current()
+	if c == nil {
+		return RunUntil(ready, timeout)
+	}
+
+	woken := false
+	wake := func() {
+		if !woken {
+			woken = true
+			c.Resume()
+		}
+	}
+	subscribe(wake)
+	timer := 0
+	if timeout > 0 {
+		timer = AddTimer(timeout, 0, wake)
+	}
+	c.Suspend()
+	if timer != 0 {
+		ClearTimer(timer)
+	}
+	return ready()
+}
@@ -0,0 +1,219 @@
+// Package eventloop runs every piece of BanglaCode on one logical thread.
+// Timer callbacks, promise continuations, network handlers and resumed async
+// functions are queued here and run one at a time by whoever holds the
+// baton: the program entry point while it evaluates and drains the loop, or
+// a background runner once nothing else does. After every macrotask the
+// microtask queue is drained, so promise callbacks always run before the
+// next timer or handler, the same as in JavaScript.
+package eventloop
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	// baton is held by whoever is running BanglaCode; everything that
+	// evaluates code must hold it
+	baton sync.Mutex
+
+	// mu guards the queues, the timer heap and refs
+	mu         sync.Mutex
+	macrotasks []func()
+	microtasks []func()
+	refs       int
+	wake       = make(chan struct{})
+
+	// checkpoint runs whenever the microtask queue has drained after a task
+	checkpoint func()
+)
+
+func init() {
+	go runner()
+}
+
+// Lock takes the baton; entry points hold it while they evaluate a program
+// and drain the loop. It is not reentrant.
+func Lock() { baton.Lock() }
+
+// Unlock releases the baton so the background runner can process work
+func Unlock() { baton.Unlock() }
+
+// Post queues a macrotask. It is safe to call from any goroutine.
+func Post(task func()) {
+	mu.Lock()
+	macrotasks = append(macrotasks, task)
+	notifyLocked()
+	mu.Unlock()
+}
+
+// QueueMicrotask queues a task that runs before the next macrotask
+func QueueMicrotask(task func()) {
+	mu.Lock()
+	microtasks = append(microtasks, task)
+	notifyLocked()
+	mu.Unlock()
+}
+
+// Ref keeps the loop alive until the matching Unref; builtins call it while
+// work that will post back to the loop (a query, a listening server) is
+// still outstanding
+func Ref() {
+	mu.Lock()
+	refs++
+	mu.Unlock()
+}
+
+// Unref releases a Ref
+func Unref() {
+	mu.Lock()
+	refs--
+	notifyLocked()
+	mu.Unlock()
+}
+
+// notifyLocked wakes everything waiting for work; the caller holds mu
+func notifyLocked() {
+	close(wake)
+	wake = make(chan struct{})
+}
+
+// OnCheckpoint sets fn to run at the end of every microtask checkpoint,
+// i.e. each time the microtask queue drains, under the baton. Promises use
+// it to report rejections nobody handled.
+func OnCheckpoint(fn func()) {
+	mu.Lock()
+	checkpoint = fn
+	mu.Unlock()
+}
+
+// endOfTask runs the checkpoint hook if no microtasks are left
+func endOfTask() {
+	mu.Lock()
+	fn := checkpoint
+	drained := len(microtasks) == 0
+	mu.Unlock()
+	if fn != nil && drained {
+		fn()
+	}
+}
+
+// Run processes work until none is left: no queued tasks, no timers and no
+// outstanding Refs. The caller holds the baton.
+func Run() {
+	// The program that just ran was the first task
+	endOfTask()
+	for step(true, time.Time{}) {
+	}
+}
+
+// RunUntil processes work until done reports true. It returns false if the
+// loop ran out of work first or the timeout (when non-zero) passed. The
+// caller holds the baton.
+func RunUntil(done func() bool, timeout time.Duration) bool {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	endOfTask()
+	for !done() {
+		if !step(true, deadline) {
+			return done()
+		}
+	}
+	return true
+}
+
+// step runs the next task. When block is set it waits for one to become
+// ready, giving up at the deadline or once no work can arrive any more. A
+// wake-up without a task, such as a promise settled by another goroutine,
+// also returns true so that RunUntil rechecks its condition.
+func step(block bool, deadline time.Time) bool {
+	for {
+		mu.Lock()
+		task := nextTaskLocked(time.Now())
+		if task != nil {
+			mu.Unlock()
+			task()
+			endOfTask()
+			return true
+		}
+		if !block || (len(timers) == 0 && refs == 0) {
+			mu.Unlock()
+			return false
+		}
+		signal := wake
+		wait, hasTimer := untilNextTimerLocked()
+		mu.Unlock()
+
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return false
+			}
+			if !hasTimer || remaining < wait {
+				wait, hasTimer = remaining, true
+			}
+		}
+		if !hasTimer {
+			<-signal
+			return true
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-signal:
+			timer.Stop()
+			return true
+		case <-timer.C:
+		}
+	}
+}
+
+// nextTaskLocked pops the next runnable task: microtasks first, then due
+// timers and macrotasks in the order they became ready
+func nextTaskLocked(now time.Time) func() {
+	if len(microtasks) > 0 {
+		task := microtasks[0]
+		microtasks[0] = nil
+		microtasks = microtasks[1:]
+		return task
+	}
+	queueDueTimersLocked(now)
+	if len(macrotasks) > 0 {
+		task := macrotasks[0]
+		macrotasks[0] = nil
+		macrotasks = macrotasks[1:]
+		return task
+	}
+	return nil
+}
+
+// runner processes work whenever it is ready and nobody else holds the
+// baton, e.g. after the REPL or a test has finished evaluating
+func runner() {
+	for {
+		mu.Lock()
+		ready := len(microtasks) > 0 || len(macrotasks) > 0
+		signal := wake
+		wait, hasTimer := untilNextTimerLocked()
+		mu.Unlock()
+
+		if ready || (hasTimer && wait <= 0) {
+			baton.Lock()
+			for step(false, time.Time{}) {
+			}
+			baton.Unlock()
+			continue
+		}
+		if !hasTimer {
+			<-signal
+			continue
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-signal:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
@@ -0,0 +1,129 @@
+package eventloop
+
+import (
+	"container/heap"
+	"time"
+)
+
+// timer is a pending setTimeout or setInterval
+type timer struct {
+	id     int
+	when   time.Time
+	seq    int // breaks ties so timers due together fire in creation order
+	period time.Duration
+	task   func()
+	index  int // position in the heap, -1 once removed
+}
+
+// timerHeap orders pending timers by due time
+type timerHeap []*timer
+
+func (h timerHeap) Len() int { return len(h) }
+func (h timerHeap) Less(i, j int) bool {
+	if h[i].when.Equal(h[j].when) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].when.Before(h[j].when)
+}
+func (h timerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *timerHeap) Push(x any) {
+	t := x.(*timer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+func (h *timerHeap) Pop() any {
+	old := *h
+	t := old[len(old)-1]
+	old[len(old)-1] = nil
+	t.index = -1
+	*h = old[:len(old)-1]
+	return t
+}
+
+var (
+	timers      timerHeap
+	timersByID  = map[int]*timer{}
+	nextTimerID = 1
+	timerSeq    int
+)
+
+// AddTimer schedules task after delay and returns the timer id. A non-zero
+// period repeats it until cleared, measuring each period from the end of the
+// previous run. Pending timers keep the loop alive.
+func AddTimer(delay, period time.Duration, task func()) int {
+	mu.Lock()
+	defer mu.Unlock()
+
+	t := &timer{id: nextTimerID, period: period, task: task}
+	nextTimerID++
+	timersByID[t.id] = t
+	scheduleLocked(t, delay)
+	return t.id
+}
+
+// ClearTimer cancels a timer; unknown or already fired ids are ignored
+func ClearTimer(id int) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	t, ok := timersByID[id]
+	if !ok {
+		return
+	}
+	delete(timersByID, id)
+	if t.index >= 0 {
+		heap.Remove(&timers, t.index)
+	}
+	notifyLocked()
+}
+
+func scheduleLocked(t *timer, delay time.Duration) {
+	t.when = time.Now().Add(delay)
+	t.seq = timerSeq
+	timerSeq++
+	heap.Push(&timers, t)
+	notifyLocked()
+}
+
+// queueDueTimersLocked moves every due timer onto the macrotask queue
+func queueDueTimersLocked(now time.Time) {
+	for len(timers) > 0 && !timers[0].when.After(now) {
+		t := heap.Pop(&timers).(*timer)
+		macrotasks = append(macrotasks, func() { fire(t) })
+	}
+}
+
+// fire runs a due timer and re-arms it if it repeats
+func fire(t *timer) {
+	mu.Lock()
+	_, active := timersByID[t.id]
+	if active && t.period == 0 {
+		delete(timersByID, t.id)
+	}
+	mu.Unlock()
+	if !active {
+		return
+	}
+
+	t.task()
+
+	if t.period > 0 {
+		mu.Lock()
+		if _, ok := timersByID[t.id]; ok {
+			scheduleLocked(t, t.period)
+		}
+		mu.Unlock()
+	}
+}
+
+// untilNextTimerLocked reports how long until the earliest timer is due
+func untilNextTimerLocked() (time.Duration, bool) {
+	if len(timers) == 0 {
+		return 0, false
+	}
+	return time.Until(timers[0].when), true
+}
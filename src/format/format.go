@@ -0,0 +1,261 @@
+// Package format implements banglacode fmt. It prints a parsed program in
+// the one canonical BanglaCode layout: four-space indentation, one
+// statement per line, normalized spacing and semicolons. Comments and
+// single blank lines between statements are kept. Formatting formatted
+// code returns it unchanged.
+package format
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/parser"
+	"bytes"
+	"math"
+	"strings"
+)
+
+// SyntaxError is returned for sources that do not parse
+type SyntaxError struct {
+	Diagnostics []parser.Diagnostic
+}
+
+func (e *SyntaxError) Error() string {
+	lines := make([]string, len(e.Diagnostics))
+	for i, d := range e.Diagnostics {
+		lines[i] = d.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Source formats a BanglaCode source file; filename is only used in
+// syntax error messages
+func Source(src []byte, filename string) ([]byte, error) {
+	text := string(src)
+
+	// The token stream tells where lines end and where comments sit
+	l := lexer.NewWithFile(text, filename)
+	var tokens []lexer.Token
+	for tok := l.NextToken(); tok.Type != lexer.EOF; tok = l.NextToken() {
+		tokens = append(tokens, tok)
+	}
+
+	p := parser.New(lexer.NewWithFile(text, filename))
+	program := p.ParseProgram()
+	if len(p.Diagnostics()) != 0 {
+		return nil, &SyntaxError{Diagnostics: p.Diagnostics()}
+	}
+
+	pr := newPrinter(tokens, l.Comments())
+	pr.stmtList(program.Statements)
+	pr.flushComments(pos{line: math.MaxInt})
+	if usesBengaliKeywords(tokens) {
+		return bengaliKeywords(pr.out.Bytes()), nil
+	}
+	return pr.out.Bytes(), nil
+}
+
+// usesBengaliKeywords reports whether the source spells keywords in
+// Bengali script (ধরো rather than dhoro)
+func usesBengaliKeywords(tokens []lexer.Token) bool {
+	for _, tok := range tokens {
+		// && and || are spellings of ebong and ba too, but not Bengali ones
+		if tok.Raw != "" && lexer.LookupIdent(tok.Raw) != lexer.IDENT {
+			return true
+		}
+	}
+	return false
+}
+
+// bengaliKeywords respells the keywords of printed code in Bengali script.
+// The printer always writes Banglish; a file written in Bengali script
+// stays in Bengali script.
+func bengaliKeywords(src []byte) []byte {
+	lines := strings.Split(string(src), "\n")
+	l := lexer.New(string(src))
+	var out []string // lines rebuilt so far
+	line, rest, col := 1, lines[0], 1
+	var cur strings.Builder
+	for tok := l.NextToken(); tok.Type != lexer.EOF; tok = l.NextToken() {
+		bengali, ok := lexer.BengaliSpelling(tok.Literal)
+		if !ok || tok.Type == lexer.IDENT || tok.Raw != "" {
+			continue
+		}
+		for line < tok.Line {
+			cur.WriteString(rest)
+			out = append(out, cur.String())
+			cur.Reset()
+			line++
+			rest, col = lines[line-1], 1
+		}
+		// Copy up to the keyword, then write it in Bengali
+		runes := []rune(rest)
+		cur.WriteString(string(runes[:tok.Column-col]))
+		cur.WriteString(bengali)
+		rest = string(runes[tok.Column-col+tok.Width():])
+		col = tok.Column + tok.Width()
+	}
+	cur.WriteString(rest)
+	out = append(out, cur.String())
+	out = append(out, lines[line:]...)
+	return []byte(strings.Join(out, "\n"))
+}
+
+// pos is a source position (1-based line and rune column)
+type pos struct {
+	line, column int
+}
+
+func posOf(tok lexer.Token) pos {
+	return pos{tok.Line, tok.Column}
+}
+
+// printer writes the canonical form of a program to out
+type printer struct {
+	out       bytes.Buffer
+	indent    int
+	lineStart bool // nothing written on the current output line yet
+
+	tokens   []lexer.Token
+	index    map[pos]int // token position -> index in tokens
+	comments []lexer.Comment
+	next     int         // first comment not printed yet
+	prevEnd  map[pos]int // token/comment position -> line the code or comment before it ends on
+}
+
+func newPrinter(tokens []lexer.Token, comments []lexer.Comment) *printer {
+	p := &printer{
+		lineStart: true,
+		tokens:    tokens,
+		index:     make(map[pos]int, len(tokens)),
+		comments:  comments,
+		prevEnd:   make(map[pos]int, len(tokens)+len(comments)),
+	}
+
+	// Walk tokens and comments together in source order
+	end, c := 0, 0
+	for i, tok := range tokens {
+		at := posOf(tok)
+		for c < len(comments) && (comments[c].Line < at.line || comments[c].Line == at.line && comments[c].Column < at.column) {
+			p.prevEnd[pos{comments[c].Line, comments[c].Column}] = end
+			end = comments[c].Line
+			c++
+		}
+		p.index[at] = i
+		p.prevEnd[at] = end
+		end = tok.Line + strings.Count(tok.Literal, "\n")
+	}
+	for ; c < len(comments); c++ {
+		p.prevEnd[pos{comments[c].Line, comments[c].Column}] = end
+		end = comments[c].Line
+	}
+	return p
+}
+
+// ==================== Output ====================
+
+func (p *printer) write(s string) {
+	if s == "" {
+		return
+	}
+	if p.lineStart {
+		p.out.WriteString(strings.Repeat("    ", p.indent))
+		p.lineStart = false
+	}
+	p.out.WriteString(s)
+}
+
+func (p *printer) newline() {
+	p.out.WriteByte('\n')
+	p.lineStart = true
+}
+
+// blankLine separates what follows with one empty line, except at the
+// start of the file and right after an opening bracket
+func (p *printer) blankLine() {
+	b := p.out.Bytes()
+	if len(b) < 2 || b[len(b)-2] == '\n' || strings.IndexByte("{[(", b[len(b)-2]) >= 0 {
+		return
+	}
+	p.newline()
+}
+
+// lineFor prepares a new output line for the construct starting at tok:
+// comments before it are printed first, and a blank line in the source
+// before it is kept
+func (p *printer) lineFor(tok lexer.Token) {
+	if tok.Line == 0 {
+		return
+	}
+	at := posOf(tok)
+	p.flushComments(at)
+	if end, ok := p.prevEnd[at]; ok && end > 0 && tok.Line-end > 1 {
+		p.blankLine()
+	}
+}
+
+// flushComments prints every comment that comes before at. A comment that
+// followed code on its line stays at the end of the last printed line;
+// the others get a line of their own.
+func (p *printer) flushComments(at pos) {
+	for p.next < len(p.comments) && p.comments[p.next].Line < at.line {
+		c := p.comments[p.next]
+		p.next++
+
+		if c.Trailing && p.lineStart && p.out.Len() > 0 && !bytes.HasSuffix(p.out.Bytes(), []byte("\n\n")) {
+			p.out.Truncate(p.out.Len() - 1)
+			p.lineStart = false
+			p.write(" " + c.Text)
+			p.newline()
+			continue
+		}
+		if !p.lineStart {
+			p.newline()
+		}
+		if end := p.prevEnd[pos{c.Line, c.Column}]; end > 0 && c.Line-end > 1 {
+			p.blankLine()
+		}
+		p.write(c.Text)
+		p.newline()
+	}
+}
+
+// hasCommentsBefore reports whether an unprinted comment precedes tok
+func (p *printer) hasCommentsBefore(tok lexer.Token) bool {
+	return p.next < len(p.comments) && p.comments[p.next].Line < tok.Line
+}
+
+// tokenAfter returns the token following tok in the source
+func (p *printer) tokenAfter(tok lexer.Token) lexer.Token {
+	if i, ok := p.index[posOf(tok)]; ok && i+1 < len(p.tokens) {
+		return p.tokens[i+1]
+	}
+	return lexer.Token{}
+}
+
+// tokenBefore returns the token preceding tok in the source
+func (p *printer) tokenBefore(tok lexer.Token) lexer.Token {
+	if i, ok := p.index[posOf(tok)]; ok && i > 0 {
+		return p.tokens[i-1]
+	}
+	return lexer.Token{}
+}
+
+// firstToken is the leftmost token of an expression; the Token field of
+// infix nodes is their operator
+func firstToken(node ast.Node) lexer.Token {
+	switch n := node.(type) {
+	case *ast.BinaryExpression:
+		return firstToken(n.Left)
+	case *ast.AssignmentExpression:
+		return firstToken(n.Name)
+	case *ast.CallExpression:
+		return firstToken(n.Function)
+	case *ast.MemberExpression:
+		return firstToken(n.Object)
+	case *ast.FunctionLiteral:
+		if n.Token.Type == lexer.ARROW && len(n.Parameters) > 0 {
+			return ast.TokenOf(n.Parameters[0].Target())
+		}
+	}
+	return ast.TokenOf(node)
+}
@@ -0,0 +1,673 @@
+package format
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/parser"
+	"sort"
+)
+
+// ==================== Statements ====================
+
+// stmtList prints statements one per line
+func (p *printer) stmtList(statements []ast.Statement) {
+	for _, stmt := range statements {
+		p.lineFor(ast.TokenOf(stmt))
+		p.statement(stmt)
+		p.newline()
+	}
+}
+
+// block prints { statements } with the statements indented; comments
+// before the closing brace stay inside the block
+func (p *printer) block(b *ast.BlockStatement) {
+	p.write("{")
+	if len(b.Statements) == 0 && !p.hasCommentsBefore(b.Rbrace) {
+		p.write("}")
+		return
+	}
+	p.newline()
+	p.indent++
+	p.stmtList(b.Statements)
+	if b.Rbrace.Line > 0 {
+		p.flushComments(posOf(b.Rbrace))
+	}
+	p.indent--
+	p.write("}")
+}
+
+func (p *printer) statement(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		p.variable(s)
+		p.write(";")
+	case *ast.DeclarationList:
+		for i, d := range s.Declarations {
+			if i == 0 {
+				p.variable(d)
+				continue
+			}
+			p.write(", " + d.Name.Value + " = ")
+			p.expr(d.Value, parser.LOWEST)
+		}
+		p.write(";")
+	case *ast.DestructuringDeclaration:
+		p.write(declKeyword(s.IsConstant, s.IsGlobal) + " ")
+		p.pattern(s.Pattern)
+		p.write(" = ")
+		p.expr(s.Source, parser.LOWEST)
+		p.write(";")
+	case *ast.ExpressionStatement:
+		p.expr(s.Expression, parser.LOWEST)
+		if !isDeclaration(s.Expression) {
+			p.write(";")
+		}
+	case *ast.ReturnStatement:
+		p.write("ferao")
+		if s.ReturnValue != nil {
+			p.write(" ")
+			p.expr(s.ReturnValue, parser.LOWEST)
+		}
+		p.write(";")
+	case *ast.ThrowStatement:
+		p.write("felo ")
+		p.expr(s.Value, parser.LOWEST)
+		p.write(";")
+	case *ast.BreakStatement:
+		p.write("thamo")
+		if s.Label != nil {
+			p.write(" " + s.Label.Value)
+		}
+		p.write(";")
+	case *ast.ContinueStatement:
+		p.write("chharo")
+		if s.Label != nil {
+			p.write(" " + s.Label.Value)
+		}
+		p.write(";")
+	case *ast.LabeledStatement:
+		p.write(s.Label.Value + ": ")
+		p.statement(s.Body)
+
+	case *ast.IfStatement:
+		p.write("jodi (")
+		p.expr(s.Condition, parser.LOWEST)
+		p.write(") ")
+		p.block(s.Consequence)
+		if s.Alternative != nil {
+			p.write(" nahole ")
+			// "nahole jodi" is parsed into a block holding the nested if
+			if s.Alternative.Token.Type != lexer.LBRACE && len(s.Alternative.Statements) == 1 {
+				if elseIf, ok := s.Alternative.Statements[0].(*ast.IfStatement); ok {
+					p.statement(elseIf)
+					return
+				}
+			}
+			p.block(s.Alternative)
+		}
+	case *ast.WhileStatement:
+		p.write("jotokkhon (")
+		p.expr(s.Condition, parser.LOWEST)
+		p.write(") ")
+		p.block(s.Body)
+	case *ast.DoWhileStatement:
+		p.write("do ")
+		p.block(s.Body)
+		p.write(" jotokkhon (")
+		p.expr(s.Condition, parser.LOWEST)
+		p.write(");")
+	case *ast.ForStatement:
+		p.write("ghuriye (")
+		switch init := s.Init.(type) {
+		case nil:
+		case *ast.VariableDeclaration:
+			p.variable(init)
+		case *ast.ExpressionStatement:
+			p.expr(init.Expression, parser.LOWEST)
+		default:
+			p.statement(init)
+			p.out.Truncate(p.out.Len() - 1) // the statement's own ';'
+		}
+		p.write(";")
+		if s.Condition != nil {
+			p.write(" ")
+			p.expr(s.Condition, parser.LOWEST)
+		}
+		p.write(";")
+		if s.Update != nil {
+			p.write(" ")
+			p.expr(s.Update, parser.LOWEST)
+		}
+		p.write(") ")
+		p.block(s.Body)
+	case *ast.ForOfStatement:
+		p.write("ghuriye ")
+		if s.Await {
+			p.write("opekha ")
+		}
+		p.write("(" + s.VarName.Value + " of ")
+		p.expr(s.Iterable, parser.LOWEST)
+		p.write(") ")
+		p.block(s.Body)
+	case *ast.ForInStatement:
+		p.write("ghuriye (" + s.VarName.Value + " in ")
+		p.expr(s.Object, parser.LOWEST)
+		p.write(") ")
+		p.block(s.Body)
+
+	case *ast.TryCatchStatement:
+		p.write("chesta ")
+		p.block(s.TryBlock)
+		if s.CatchBlock != nil {
+			p.write(" dhoro_bhul ")
+			if s.CatchParam != nil {
+				p.write("(" + s.CatchParam.Value + ") ")
+			}
+			p.block(s.CatchBlock)
+		}
+		if s.FinallyBlock != nil {
+			p.write(" shesh ")
+			p.block(s.FinallyBlock)
+		}
+	case *ast.SwitchStatement:
+		p.switchStatement(s)
+	case *ast.ClassDeclaration:
+		p.class(s)
+
+	case *ast.ImportStatement:
+		p.write("ano " + quote(s.Path.Value))
+		if s.Alias != nil {
+			p.write(" hisabe " + s.Alias.Value)
+		}
+		p.write(";")
+	case *ast.ExportStatement:
+		p.write("pathao ")
+		p.statement(s.Statement)
+	}
+}
+
+// variable prints a declaration without its semicolon (also used in the
+// init clause of ghuriye)
+func (p *printer) variable(s *ast.VariableDeclaration) {
+	p.write(declKeyword(s.IsConstant, s.IsGlobal) + " " + s.Name.Value + " = ")
+	p.expr(s.Value, parser.LOWEST)
+}
+
+func declKeyword(isConstant, isGlobal bool) string {
+	switch {
+	case isConstant:
+		return "sthir"
+	case isGlobal:
+		return "bishwo"
+	}
+	return "dhoro"
+}
+
+// isDeclaration reports whether an expression statement declares a named
+// function, which is written without a semicolon
+func isDeclaration(expr ast.Expression) bool {
+	switch fn := expr.(type) {
+	case *ast.FunctionLiteral:
+		return fn.Name != nil && fn.Token.Type == lexer.KAJ
+	case *ast.AsyncFunctionLiteral:
+		return fn.Name != nil
+	}
+	return false
+}
+
+func (p *printer) switchStatement(s *ast.SwitchStatement) {
+	p.write("bikolpo (")
+	p.expr(s.Expr, parser.LOWEST)
+	p.write(") {")
+	if len(s.Cases) == 0 && s.Default == nil && !p.hasCommentsBefore(s.Rbrace) {
+		p.write("}")
+		return
+	}
+	p.newline()
+	p.indent++
+	for _, c := range s.Cases {
+		p.lineFor(c.Token)
+		p.write("khetre ")
+		p.expr(c.Value, parser.LOWEST)
+		p.write(" ")
+		p.block(c.Body)
+		p.newline()
+	}
+	if s.Default != nil {
+		p.lineFor(p.tokenBefore(s.Default.Token)) // the manchito keyword
+		p.write("manchito ")
+		p.block(s.Default)
+		p.newline()
+	}
+	if s.Rbrace.Line > 0 {
+		p.flushComments(posOf(s.Rbrace))
+	}
+	p.indent--
+	p.write("}")
+}
+
+// class prints a sreni with its members in source order
+func (p *printer) class(c *ast.ClassDeclaration) {
+	type member struct {
+		start lexer.Token
+		print func()
+	}
+	var members []member
+
+	for _, m := range c.Methods {
+		m := m
+		members = append(members, member{m.Token, func() {
+			if m.Token.Type == lexer.SHURU {
+				p.write("shuru")
+			} else {
+				p.write(p.kajKeyword(m) + " " + m.Name.Value)
+			}
+			p.params(m.Parameters, m.RestParameter)
+			p.write(" ")
+			p.block(m.Body)
+		}})
+	}
+	for _, accessors := range []map[string]*ast.FunctionLiteral{c.Getters, c.Setters} {
+		for _, fn := range accessors {
+			fn := fn
+			members = append(members, member{fn.Token, func() {
+				p.write(fn.Token.Literal + " " + fn.Name.Value)
+				p.params(fn.Parameters, fn.RestParameter)
+				p.write(" ")
+				p.block(fn.Body)
+			}})
+		}
+	}
+	for name, value := range c.StaticProperties {
+		name, value := name, value
+		start := firstToken(value)
+		// sthir name = value
+		if kw := p.tokenBefore(p.tokenBefore(p.tokenBefore(start))); kw.Type == lexer.STHIR {
+			start = kw
+		}
+		members = append(members, member{start, func() {
+			p.write("sthir " + name + " = ")
+			p.expr(value, parser.LOWEST)
+			p.write(";")
+		}})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i].start, members[j].start
+		return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+	})
+
+	p.write("sreni " + c.Name.Value + " {")
+	if len(members) == 0 && !p.hasCommentsBefore(c.Rbrace) {
+		p.write("}")
+		return
+	}
+	p.newline()
+	p.indent++
+	for _, m := range members {
+		p.lineFor(m.start)
+		m.print()
+		p.newline()
+	}
+	if c.Rbrace.Line > 0 {
+		p.flushComments(posOf(c.Rbrace))
+	}
+	p.indent--
+	p.write("}")
+}
+
+// kajKeyword is "kaj", or "kaj*" when the source marks a generator with *
+func (p *printer) kajKeyword(fn *ast.FunctionLiteral) string {
+	if fn.IsGenerator && p.tokenAfter(fn.Token).Type == lexer.ASTERISK {
+		return "kaj*"
+	}
+	return "kaj"
+}
+
+func (p *printer) params(params []*ast.Parameter, rest *ast.Identifier) {
+	p.write("(")
+	for i, param := range params {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.element(param)
+	}
+	if rest != nil {
+		if len(params) > 0 {
+			p.write(", ")
+		}
+		p.write("..." + rest.Value)
+	}
+	p.write(")")
+}
+
+// element prints a parameter or an element of a destructuring pattern
+func (p *printer) element(el *ast.BindingElement) {
+	if el.Pattern != nil {
+		p.pattern(el.Pattern)
+	} else if el.Member != nil {
+		p.expr(el.Member, parser.INDEX)
+	} else {
+		p.write(el.Name.Value)
+	}
+	if el.Default != nil {
+		p.write(" = ")
+		p.expr(el.Default, parser.LOWEST)
+	}
+}
+
+// pattern prints an array or object destructuring pattern
+func (p *printer) pattern(pattern ast.Expression) {
+	switch pat := pattern.(type) {
+	case *ast.ArrayPattern:
+		p.write("[")
+		for i, el := range pat.Elements {
+			if i > 0 {
+				p.write(", ")
+			}
+			p.element(el)
+		}
+		p.rest(pat.Rest, len(pat.Elements) > 0)
+		p.write("]")
+	case *ast.ObjectPattern:
+		p.write("{")
+		for i, key := range pat.Keys {
+			if i > 0 {
+				p.write(", ")
+			}
+			if el := pat.Values[i]; el.Name == nil || el.Name.Value != key {
+				p.write(key + ": ")
+			}
+			p.element(pat.Values[i])
+		}
+		p.rest(pat.Rest, len(pat.Keys) > 0)
+		p.write("}")
+	}
+}
+
+// rest prints the ...name that ends a pattern, if there is one
+func (p *printer) rest(rest *ast.Identifier, after bool) {
+	if rest == nil {
+		return
+	}
+	if after {
+		p.write(", ")
+	}
+	p.write("..." + rest.Value)
+}
+
+// ==================== Expressions ====================
+
+// primary binds tighter than any operator
+const primary = parser.INDEX + 1
+
+// precedence of an expression as an operand
+func precedence(expr ast.Expression) int {
+	switch e := expr.(type) {
+	case *ast.AssignmentExpression, *ast.YieldExpression:
+		return parser.ASSIGN
+	case *ast.ConditionalExpression:
+		return parser.TERNARY
+	case *ast.BinaryExpression:
+		return parser.Precedence(e.Token.Type)
+	case *ast.FunctionLiteral:
+		if e.Token.Type == lexer.ARROW {
+			return parser.ARROWP
+		}
+	case *ast.UnaryExpression, *ast.AwaitExpression, *ast.DeleteExpression, *ast.SpreadElement:
+		return parser.PREFIX
+	case *ast.CallExpression, *ast.NewExpression:
+		return parser.CALL
+	case *ast.MemberExpression:
+		return parser.INDEX
+	}
+	return primary
+}
+
+// expr prints an expression, in parentheses if it binds looser than min
+func (p *printer) expr(expr ast.Expression, min int) {
+	if precedence(expr) < min {
+		p.write("(")
+		defer p.write(")")
+	}
+
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		p.write(e.Value)
+	case *ast.NumberLiteral:
+		p.write(e.Token.Spelling())
+	case *ast.StringLiteral:
+		p.write(quote(e.Value))
+	case *ast.TemplateLiteral:
+		p.write("`" + e.Value + "`")
+	case *ast.BooleanLiteral:
+		p.write(e.Token.Literal)
+	case *ast.NullLiteral:
+		p.write("khali")
+
+	case *ast.BinaryExpression:
+		prec := parser.Precedence(e.Token.Type)
+		left, right := prec, prec+1
+		if e.Operator == "**" { // right-associative, and (-a) ** b keeps its parentheses
+			left, right = parser.PREFIX+1, prec
+		}
+		p.expr(e.Left, left)
+		p.write(" " + e.Token.Spelling() + " ") // && stays &&
+		p.expr(e.Right, right)
+	case *ast.ConditionalExpression:
+		p.expr(e.Condition, parser.TERNARY+1)
+		p.write(" ? ")
+		p.expr(e.Consequence, parser.LOWEST)
+		p.write(" : ")
+		p.expr(e.Alternative, parser.TERNARY)
+	case *ast.ArrayPattern:
+		p.pattern(e)
+	case *ast.AssignmentExpression:
+		p.expr(e.Name, parser.CALL)
+		p.write(" " + e.Operator + " ")
+		p.expr(e.Value, parser.ASSIGN+1)
+	case *ast.UnaryExpression:
+		if e.Operator == "na" {
+			p.write("na ")
+		} else {
+			p.write(e.Operator)
+		}
+		// -(-x) is "- -x": "--x" would read as a decrement in JavaScript
+		if inner, ok := e.Right.(*ast.UnaryExpression); ok && inner.Operator == e.Operator && (e.Operator == "-" || e.Operator == "+") {
+			p.write(" ")
+		}
+		p.expr(e.Right, parser.PREFIX)
+	case *ast.AwaitExpression:
+		p.write("opekha ")
+		p.expr(e.Expression, parser.PREFIX)
+	case *ast.YieldExpression:
+		p.write("utpadan")
+		if e.Delegate {
+			p.write("*")
+		}
+		if e.Expression != nil {
+			p.write(" ")
+			p.expr(e.Expression, parser.LOWEST)
+		}
+	case *ast.DeleteExpression:
+		p.write("delete ")
+		p.expr(e.Target, parser.PREFIX)
+	case *ast.SpreadElement:
+		p.write("...")
+		p.expr(e.Argument, parser.LOWEST)
+
+	case *ast.CallExpression:
+		p.expr(e.Function, parser.CALL)
+		if e.Optional {
+			p.write("?.")
+		}
+		p.list("(", e.Arguments, ")", false)
+	case *ast.NewExpression:
+		p.write("notun ")
+		p.expr(e.Class, parser.INDEX)
+		p.list("(", e.Arguments, ")", false)
+	case *ast.MemberExpression:
+		p.expr(e.Object, parser.CALL)
+		if e.Optional {
+			p.write("?.")
+		}
+		if e.Computed {
+			p.write("[")
+			p.expr(e.Property, parser.LOWEST)
+			p.write("]")
+		} else {
+			if !e.Optional {
+				p.write(".")
+			}
+			p.expr(e.Property, primary)
+		}
+
+	case *ast.ArrayLiteral:
+		p.list("[", e.Elements, "]", p.spansLines(e.Token, e.Elements))
+	case *ast.MapLiteral:
+		p.mapLiteral(e)
+	case *ast.FunctionLiteral:
+		p.function(e)
+	case *ast.AsyncFunctionLiteral:
+		p.write("proyash kaj")
+		if e.IsGenerator {
+			p.write("*")
+		}
+		if e.Name != nil {
+			p.write(" " + e.Name.Value)
+		}
+		p.params(e.Parameters, e.RestParameter)
+		p.write(" ")
+		p.block(e.Body)
+	}
+}
+
+func (p *printer) function(fn *ast.FunctionLiteral) {
+	if fn.Token.Type == lexer.ARROW {
+		p.params(fn.Parameters, fn.RestParameter)
+		p.write(" => ")
+		if body, ok := arrowExpressionBody(fn.Body); ok {
+			if _, isMap := body.(*ast.MapLiteral); isMap {
+				p.write("(")
+				defer p.write(")")
+			}
+			p.expr(body, parser.LOWEST)
+			return
+		}
+		p.block(fn.Body)
+		return
+	}
+
+	p.write(p.kajKeyword(fn))
+	if fn.Name != nil {
+		p.write(" " + fn.Name.Value)
+	}
+	p.params(fn.Parameters, fn.RestParameter)
+	p.write(" ")
+	p.block(fn.Body)
+}
+
+// arrowExpressionBody returns the expression of an arrow function written
+// without braces (x => x * 2). The parser wraps it in a block and a ferao
+// that both sit at the position of the expression's end, which a written
+// "{ ferao ...; }" cannot.
+func arrowExpressionBody(body *ast.BlockStatement) (ast.Expression, bool) {
+	if body == nil || len(body.Statements) != 1 || body.Rbrace.Line != 0 {
+		return nil, false
+	}
+	ret, ok := body.Statements[0].(*ast.ReturnStatement)
+	if !ok || ret.Token.Line != body.Token.Line || ret.Token.Column != body.Token.Column {
+		return nil, false
+	}
+	return ret.ReturnValue, ret.ReturnValue != nil
+}
+
+// spansLines reports whether a bracketed list was written over several
+// lines, which the formatter keeps
+func (p *printer) spansLines(open lexer.Token, elements []ast.Expression) bool {
+	for _, el := range elements {
+		if firstToken(el).Line != open.Line {
+			return true
+		}
+	}
+	return false
+}
+
+// list prints comma-separated elements between open and close, either on
+// one line or one element per line
+func (p *printer) list(open string, elements []ast.Expression, close string, multiline bool) {
+	p.write(open)
+	if !multiline {
+		for i, el := range elements {
+			if i > 0 {
+				p.write(", ")
+			}
+			p.expr(el, parser.LOWEST)
+		}
+		p.write(close)
+		return
+	}
+
+	p.newline()
+	p.indent++
+	for i, el := range elements {
+		p.lineFor(firstToken(el))
+		p.expr(el, parser.LOWEST)
+		if i < len(elements)-1 {
+			p.write(",")
+		}
+		p.newline()
+	}
+	p.indent--
+	p.write(close)
+}
+
+// mapLiteral prints {key: value} pairs in source order
+func (p *printer) mapLiteral(m *ast.MapLiteral) {
+	keys := m.Keys
+
+	p.write("{")
+	if len(keys) == 0 {
+		p.write("}")
+		return
+	}
+	multiline := p.spansLines(m.Token, keys)
+	if multiline {
+		p.newline()
+		p.indent++
+	}
+	for i, key := range keys {
+		if multiline {
+			p.lineFor(firstToken(key))
+		} else if i > 0 {
+			p.write(", ")
+		}
+		p.expr(key, parser.LOWEST)
+		p.write(": ")
+		p.expr(m.Pairs[key], parser.LOWEST)
+		if multiline {
+			if i < len(keys)-1 {
+				p.write(",")
+			}
+			p.newline()
+		}
+	}
+	if multiline {
+		p.indent--
+	}
+	p.write("}")
+}
+
+// quote writes a string literal with double quotes, or single quotes when
+// it contains an unescaped double quote (strings keep their escapes as
+// written, so the quote character cannot be escaped instead)
+func quote(s string) string {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return "'" + s + "'"
+		}
+	}
+	return `"` + s + `"`
+}
@@ -1,17 +1,31 @@
 package lexer
 
 import (
+	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // Lexer represents the lexical analyzer
 type Lexer struct {
 	input        string
-	position     int  // current position in input (points to current char)
-	readPosition int  // current reading position in input (after current char)
-	ch           byte // current char under examination
-	line         int  // current line number
-	column       int  // current column number
+	position     int    // current byte offset in input (points to current char)
+	readPosition int    // byte offset after the current char
+	ch           rune   // current char under examination
+	line         int    // current line number
+	column       int    // current column number, counted in runes
+	file         string // source file name, copied into every token
+	lastLine     int    // line the previous token ended on
+	comments     []Comment
+}
+
+// Comment is a // comment. The parser never sees comments; tools that
+// rewrite source (banglacode fmt) read them back with Lexer.Comments.
+type Comment struct {
+	Text     string // the comment including "//", without the line break
+	Line     int
+	Column   int
+	Trailing bool // code precedes the comment on the same line
 }
 
 // New creates a new Lexer instance
@@ -25,15 +39,34 @@ func New(input string) *Lexer {
 	return l
 }
 
-// readChar advances the lexer position and updates current character
+// NewWithFile creates a Lexer whose tokens record the source file name
+func NewWithFile(input, file string) *Lexer {
+	l := New(input)
+	l.file = file
+	return l
+}
+
+// File returns the source file name given to NewWithFile
+func (l *Lexer) File() string {
+	return l.file
+}
+
+// Comments returns the comments read so far, in source order
+func (l *Lexer) Comments() []Comment {
+	return l.comments
+}
+
+// readChar advances the lexer position and updates current character.
+// Characters are runes, so a Bengali letter is one column, not three bytes.
 func (l *Lexer) readChar() {
+	width := 1
 	if l.readPosition >= len(l.input) {
 		l.ch = 0 // ASCII code for "NUL" (end of input)
-	} else {
-		l.ch = l.input[l.readPosition]
+	} else if l.ch = rune(l.input[l.readPosition]); l.ch >= utf8.RuneSelf {
+		l.ch, width = utf8.DecodeRuneInString(l.input[l.readPosition:])
 	}
 	l.position = l.readPosition
-	l.readPosition++
+	l.readPosition += width
 	l.column++
 
 	// Track newlines for error reporting
@@ -44,18 +77,26 @@ func (l *Lexer) readChar() {
 }
 
 // peekChar returns the next character without advancing position
-func (l *Lexer) peekChar() byte {
+func (l *Lexer) peekChar() rune {
 	if l.readPosition >= len(l.input) {
 		return 0
 	}
-	return l.input[l.readPosition]
+	r, _ := utf8.DecodeRuneInString(l.input[l.readPosition:])
+	return r
 }
 
 // NextToken returns the next token from the input
 func (l *Lexer) NextToken() Token {
+	tok := l.nextToken()
+	tok.File = l.file
+	l.lastLine = tok.Line + strings.Count(tok.Literal, "\n")
+	return tok
+}
+
+func (l *Lexer) nextToken() Token {
 	l.skipWhitespace()
 	if l.consumeComment() {
-		return l.NextToken()
+		return l.nextToken()
 	}
 	if tok, ok := l.readStringOrTemplateToken(); ok {
 		return tok
@@ -74,7 +115,11 @@ func (l *Lexer) consumeComment() bool {
 	if l.ch != '/' || l.peekChar() != '/' {
 		return false
 	}
+	comment := Comment{Line: l.line, Column: l.column, Trailing: l.lastLine == l.line}
+	start := l.position
 	l.skipComment()
+	comment.Text = strings.TrimRight(l.input[start:l.position], " \t\r")
+	l.comments = append(l.comments, comment)
 	return true
 }
 
@@ -91,7 +136,7 @@ func (l *Lexer) readStringOrTemplateToken() (Token, bool) {
 	}
 }
 
-func (l *Lexer) readQuotedToken(quote byte) Token {
+func (l *Lexer) readQuotedToken(quote rune) Token {
 	tok := Token{Type: STRING, Line: l.line, Column: l.column}
 	tok.Literal = l.readString(quote)
 	l.readChar()
@@ -108,13 +153,24 @@ func (l *Lexer) readTemplateToken() Token {
 func (l *Lexer) readIdentifierOrNumberToken() (Token, bool) {
 	if isLetter(l.ch) {
 		tok := Token{Line: l.line, Column: l.column}
-		tok.Literal = l.readIdentifier()
+		raw := l.readIdentifier()
+		tok.Literal = NormalizeBengali(raw)
 		tok.Type = LookupIdent(tok.Literal)
+		if keyword, ok := bengaliKeywords[tok.Literal]; ok {
+			tok.Literal = keyword
+		}
+		if tok.Literal != raw {
+			tok.Raw = raw
+		}
 		return tok, true
 	}
 	if isDigit(l.ch) {
 		tok := Token{Type: NUMBER, Line: l.line, Column: l.column}
-		tok.Literal = l.readNumber()
+		raw := l.readNumber()
+		tok.Literal = strings.Map(asciiDigit, raw)
+		if tok.Literal != raw {
+			tok.Raw = raw
+		}
 		return tok, true
 	}
 	return Token{}, false
@@ -124,12 +180,15 @@ func (l *Lexer) readSymbolToken() (Token, bool) {
 	if tok, ok := l.readTwoCharOperator(); ok {
 		return tok, true
 	}
+	if tok, ok := l.readOperator(); ok {
+		return tok, true
+	}
 	switch l.ch {
 	case '.':
 		return l.readDotToken()
 	case 0:
 		return NewToken(EOF, "", l.line, l.column), false
-	case ',', ';', ':', '(', ')', '{', '}', '[', ']', '%':
+	case ',', ';', ':', '(', ')', '{', '}', '[', ']':
 		return NewToken(singleCharTokenType(l.ch), string(l.ch), l.line, l.column), true
 	default:
 		return NewToken(ILLEGAL, string(l.ch), l.line, l.column), true
@@ -156,11 +215,6 @@ func (l *Lexer) readTwoCharOperator() (Token, bool) {
 			return l.makeTwoCharToken(MINUS_ASSIGN), true
 		}
 		return NewToken(MINUS, string(l.ch), l.line, l.column), true
-	case '*':
-		if l.peekChar() == '=' {
-			return l.makeTwoCharToken(ASTERISK_ASSIGN), true
-		}
-		return NewToken(ASTERISK, string(l.ch), l.line, l.column), true
 	case '/':
 		if l.peekChar() == '=' {
 			return l.makeTwoCharToken(SLASH_ASSIGN), true
@@ -171,21 +225,53 @@ func (l *Lexer) readTwoCharOperator() (Token, bool) {
 			return l.makeTwoCharToken(NOT_EQ), true
 		}
 		return NewToken(BANG, string(l.ch), l.line, l.column), true
-	case '<':
-		if l.peekChar() == '=' {
-			return l.makeTwoCharToken(LTE), true
-		}
-		return NewToken(LT, string(l.ch), l.line, l.column), true
-	case '>':
-		if l.peekChar() == '=' {
-			return l.makeTwoCharToken(GTE), true
-		}
-		return NewToken(GT, string(l.ch), l.line, l.column), true
 	default:
 		return Token{}, false
 	}
 }
 
+// operators maps the operators read by readOperator to their token types.
+// Every prefix of an operator in the table is in the table too.
+var operators = map[string]TokenType{
+	"*": ASTERISK, "*=": ASTERISK_ASSIGN, "**": POWER, "**=": POWER_ASSIGN,
+	"%": PERCENT, "%=": PERCENT_ASSIGN,
+	"<": LT, "<=": LTE, "<<": SHL, "<<=": SHL_ASSIGN,
+	">": GT, ">=": GTE, ">>": SHR, ">>=": SHR_ASSIGN, ">>>": USHR, ">>>=": USHR_ASSIGN,
+	"&": AMPERSAND, "&=": AND_BIT_ASSIGN, "&&": EBONG, "&&=": AND_ASSIGN,
+	"|": PIPE, "|=": OR_BIT_ASSIGN, "||": BA, "||=": OR_ASSIGN,
+	"^": CARET, "^=": XOR_ASSIGN,
+	"~": TILDE,
+	"?": QUESTION, "??": NULLISH, "??=": NULLISH_ASSIGN, "?.": OPTIONAL,
+}
+
+// symbolKeywords are the JavaScript spellings of ebong and ba
+var symbolKeywords = map[string]string{"&&": "ebong", "||": "ba"}
+
+// readOperator reads the longest operator in the operators table that
+// starts at the current character
+func (l *Lexer) readOperator() (Token, bool) {
+	text := string(l.ch)
+	tokenType, ok := operators[text]
+	if !ok {
+		return Token{}, false
+	}
+	line, column := l.line, l.column
+	for {
+		longer, ok := operators[text+string(l.peekChar())]
+		if !ok {
+			break
+		}
+		l.readChar()
+		text += string(l.ch)
+		tokenType = longer
+	}
+	tok := NewToken(tokenType, text, line, column)
+	if keyword, ok := symbolKeywords[text]; ok {
+		tok.Literal, tok.Raw = keyword, text
+	}
+	return tok, true
+}
+
 func (l *Lexer) makeTwoCharToken(tokenType TokenType) Token {
 	ch := l.ch
 	line := l.line
@@ -205,7 +291,7 @@ func (l *Lexer) readDotToken() (Token, bool) {
 	return NewToken(DOT, string(l.ch), l.line, l.column), true
 }
 
-func singleCharTokenType(ch byte) TokenType {
+func singleCharTokenType(ch rune) TokenType {
 	switch ch {
 	case ',':
 		return COMMA
@@ -233,7 +319,7 @@ func singleCharTokenType(ch byte) TokenType {
 // readIdentifier reads an identifier (variable name, keyword, etc.)
 func (l *Lexer) readIdentifier() string {
 	position := l.position
-	for isLetter(l.ch) || isDigit(l.ch) {
+	for isLetter(l.ch) || isDigit(l.ch) || isMark(l.ch) {
 		l.readChar()
 	}
 	return l.input[position:l.position]
@@ -258,7 +344,7 @@ func (l *Lexer) readNumber() string {
 }
 
 // readString reads a string literal
-func (l *Lexer) readString(quote byte) string {
+func (l *Lexer) readString(quote rune) string {
 	position := l.position + 1 // skip opening quote
 	for {
 		l.readChar()
@@ -321,12 +407,26 @@ func (l *Lexer) skipComment() {
 	}
 }
 
-// isLetter checks if a character is a letter or underscore
-func isLetter(ch byte) bool {
-	return unicode.IsLetter(rune(ch)) || ch == '_'
+// isLetter checks if a character is a letter (any script) or underscore
+func isLetter(ch rune) bool {
+	return unicode.IsLetter(ch) || ch == '_'
 }
 
-// isDigit checks if a character is a digit
-func isDigit(ch byte) bool {
-	return '0' <= ch && ch <= '9'
+// isMark reports characters that can only follow a letter: vowel signs
+// and the hasanta (্) of Bengali, and the zero-width joiners used with them
+func isMark(ch rune) bool {
+	return unicode.IsMark(ch) || ch == '\u200c' || ch == '\u200d'
+}
+
+// isDigit checks if a character is a digit, 0-9 or Bengali ০-৯
+func isDigit(ch rune) bool {
+	return '0' <= ch && ch <= '9' || '০' <= ch && ch <= '৯'
+}
+
+// asciiDigit turns Bengali digits into 0-9 and keeps other characters
+func asciiDigit(ch rune) rune {
+	if '০' <= ch && ch <= '৯' {
+		return '0' + ch - '০'
+	}
+	return ch
 }
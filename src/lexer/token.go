@@ -1,5 +1,11 @@
 package lexer
 
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
 // TokenType represents the type of token
 type TokenType string
 
@@ -9,6 +15,24 @@ type Token struct {
 	Literal string
 	Line    int
 	Column  int
+	File    string // source file, empty for unnamed input (REPL, tests)
+	// Raw is the source text when it differs from Literal: Bengali-script
+	// keywords (Literal "dhoro" for ধরো) and numbers (Literal "42" for ৪২)
+	Raw string
+}
+
+// Spelling returns the token as written in the source
+func (t Token) Spelling() string {
+	if t.Raw != "" {
+		return t.Raw
+	}
+	return t.Literal
+}
+
+// Width is the length of the token in the source, in characters (runes),
+// the unit of Column
+func (t Token) Width() int {
+	return utf8.RuneCountInString(t.Spelling())
 }
 
 // Token types
@@ -30,6 +54,16 @@ const (
 	ASTERISK = "*"
 	SLASH    = "/"
 	PERCENT  = "%"
+	POWER    = "**"
+
+	// Bitwise operators (on 32-bit integers, as in JavaScript)
+	AMPERSAND = "&"
+	PIPE      = "|"
+	CARET     = "^"
+	TILDE     = "~"
+	SHL       = "<<"
+	SHR       = ">>"
+	USHR      = ">>>" // unsigned (zero-fill) right shift
 
 	// Comparison operators
 	EQ     = "=="
@@ -40,14 +74,30 @@ const (
 	GTE    = ">="
 
 	// Logical operators
-	BANG = "!"
-	IN   = "IN"
+	BANG     = "!"
+	IN       = "IN"
+	QUESTION = "?"  // cond ? a : b
+	NULLISH  = "??" // a ?? b
+	OPTIONAL = "?." // obj?.prop, obj?.[i], f?.()
 
 	// Compound assignment
 	PLUS_ASSIGN     = "+="
 	MINUS_ASSIGN    = "-="
 	ASTERISK_ASSIGN = "*="
 	SLASH_ASSIGN    = "/="
+	PERCENT_ASSIGN  = "%="
+	POWER_ASSIGN    = "**="
+	AND_BIT_ASSIGN  = "&="
+	OR_BIT_ASSIGN   = "|="
+	XOR_ASSIGN      = "^="
+	SHL_ASSIGN      = "<<="
+	SHR_ASSIGN      = ">>="
+	USHR_ASSIGN     = ">>>="
+
+	// Logical assignment
+	NULLISH_ASSIGN = "??="
+	OR_ASSIGN      = "||="
+	AND_ASSIGN     = "&&="
 
 	// Delimiters
 	COMMA     = ","
@@ -150,11 +200,105 @@ var keywords = map[string]TokenType{
 	"utpadan":    UTPADAN,
 }
 
-// LookupIdent checks if an identifier is a keyword
+// bengaliSpellings gives each Banglish keyword its Bengali-script
+// spelling (বাংলা লিপি). The English keywords (do, in, of, ...) have none.
+var bengaliSpellings = map[string]string{
+	"dhoro":      "ধরো",
+	"sthir":      "স্থির",
+	"bishwo":     "বিশ্ব",
+	"jodi":       "যদি",
+	"nahole":     "নাহলে",
+	"jotokkhon":  "যতক্ষণ",
+	"ghuriye":    "ঘুরিয়ে",
+	"kaj":        "কাজ",
+	"ferao":      "ফেরাও",
+	"sreni":      "শ্রেণী",
+	"shuru":      "শুরু",
+	"notun":      "নতুন",
+	"sotti":      "সত্যি",
+	"mittha":     "মিথ্যা",
+	"khali":      "খালি",
+	"ebong":      "এবং",
+	"ba":         "বা",
+	"na":         "না",
+	"thamo":      "থামো",
+	"chharo":     "ছাড়ো",
+	"ano":        "আনো",
+	"pathao":     "পাঠাও",
+	"hisabe":     "হিসাবে",
+	"chesta":     "চেষ্টা",
+	"dhoro_bhul": "ধরো_ভুল",
+	"shesh":      "শেষ",
+	"felo":       "ফেলো",
+	"proyash":    "প্রয়াস",
+	"opekha":     "অপেক্ষা",
+	"bikolpo":    "বিকল্প",
+	"khetre":     "ক্ষেত্রে",
+	"manchito":   "মানচিত্র",
+	"pao":        "পাও",
+	"utpadan":    "উৎপাদন",
+}
+
+// bengaliKeywords maps the Bengali-script spellings back to the Banglish
+// keywords
+var bengaliKeywords = map[string]string{}
+
+func init() {
+	for keyword, bengali := range bengaliSpellings {
+		bengaliKeywords[NormalizeBengali(bengali)] = keyword
+	}
+}
+
+// Some Bengali letters can be typed two ways: য় as one code point or as
+// য + ়, ো as one code point or as ে + া. Keyboards differ, so names are
+// compared in one form (the NFC one).
+var bengaliForms = strings.NewReplacer(
+	"\u09dc", "\u09a1\u09bc", // ড়
+	"\u09dd", "\u09a2\u09bc", // ঢ়
+	"\u09df", "\u09af\u09bc", // য়
+	"\u09c7\u09be", "\u09cb", // ো
+	"\u09c7\u09d7", "\u09cc", // ৌ
+)
+
+// NormalizeBengali rewrites letters that have two Unicode spellings in
+// one fixed form, so both spellings name the same identifier
+func NormalizeBengali(s string) string {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return bengaliForms.Replace(s)
+		}
+	}
+	return s
+}
+
+// BengaliSpelling returns the Bengali-script spelling of a Banglish keyword
+func BengaliSpelling(keyword string) (string, bool) {
+	bengali, ok := bengaliSpellings[keyword]
+	return bengali, ok
+}
+
+// Keywords returns every keyword, Banglish and Bengali script, sorted
+// (used for editor completion)
+func Keywords() []string {
+	names := make([]string, 0, len(keywords)+len(bengaliSpellings))
+	for name := range keywords {
+		names = append(names, name)
+	}
+	for _, bengali := range bengaliSpellings {
+		names = append(names, bengali)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupIdent checks if an identifier is a keyword, in either script
 func LookupIdent(ident string) TokenType {
 	if tok, ok := keywords[ident]; ok {
 		return tok
 	}
+	if keyword, ok := bengaliKeywords[NormalizeBengali(ident)]; ok {
+		return keywords[keyword]
+	}
 	return IDENT
 }
 
@@ -0,0 +1,79 @@
+package lint
+
+import (
+	"BanglaCode/src/parser"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ConfigFile is the name of the lint config file, looked up from the
+// linted file's directory upwards
+const ConfigFile = ".banglalint.json"
+
+// Config turns rules off or changes their severity:
+//
+//	{"rules": {"shadow": "off", "unused-variable": "error"}}
+type Config struct {
+	Rules map[string]string `json:"rules"`
+	Path  string            `json:"-"` // file the config was read from
+}
+
+// LoadConfig reads and validates a config file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{Path: path}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	for name, level := range cfg.Rules {
+		if _, ok := rules[name]; !ok {
+			return nil, fmt.Errorf("%s: unknown rule %q", path, name)
+		}
+		switch level {
+		case "off", "warning", "error":
+		default:
+			return nil, fmt.Errorf("%s: rule %q: level must be \"off\", \"warning\" or \"error\", got %q", path, name, level)
+		}
+	}
+	return cfg, nil
+}
+
+// FindConfig loads the nearest config file in dir or its parents; it
+// returns nil (every rule at its default) when there is none
+func FindConfig(dir string) (*Config, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		path := filepath.Join(dir, ConfigFile)
+		if _, err := os.Stat(path); err == nil {
+			return LoadConfig(path)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// severity of a rule under this config; 0 means the rule is off
+func (c *Config) severity(r *Rule) parser.Severity {
+	if c != nil {
+		switch c.Rules[r.Name] {
+		case "off":
+			return 0
+		case "warning":
+			return parser.SeverityWarning
+		case "error":
+			return parser.SeverityError
+		}
+	}
+	return r.Severity
+}
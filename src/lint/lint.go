@@ -0,0 +1,204 @@
+// Package lint implements banglacode lint, a static checker that walks the
+// AST looking for likely bugs (unused variables, unreachable code, calls
+// to unknown builtins, ...) without running the program.
+package lint
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/packages"
+	"BanglaCode/src/parser"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Rule is one check. Rules register themselves in init() and can be
+// turned off or changed in severity through the config file.
+type Rule struct {
+	Name        string
+	Description string
+	Severity    parser.Severity // default severity
+	check       func(p *pass)
+}
+
+// rules is the registry of every known rule, by name
+var rules = map[string]*Rule{}
+
+func register(r *Rule) {
+	rules[r.Name] = r
+}
+
+// Rules lists the registered rules sorted by name
+func Rules() []*Rule {
+	list := make([]*Rule, 0, len(rules))
+	for _, r := range rules {
+		list = append(list, r)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// pass is the state a rule works on while checking one file
+type pass struct {
+	program *ast.Program
+	*analysis
+	rule        *Rule
+	severity    parser.Severity
+	file        string
+	diagnostics []parser.Diagnostic
+}
+
+// report records a problem at tok
+func (p *pass) report(tok lexer.Token, format string, args ...any) {
+	p.reportRange(tok, tok, format, args...)
+}
+
+// reportRange records a problem spanning the tokens from start to end
+func (p *pass) reportRange(start, end lexer.Token, format string, args ...any) {
+	width := end.Width()
+	if width == 0 {
+		width = 1
+	}
+	p.diagnostics = append(p.diagnostics, parser.Diagnostic{
+		Severity: p.severity,
+		Code:     p.rule.Name,
+		Message:  fmt.Sprintf(format, args...),
+		File:     p.file,
+		Start:    parser.Position{Line: start.Line, Column: start.Column},
+		End:      parser.Position{Line: end.Line, Column: end.Column + width},
+	})
+}
+
+// Source lints one file. Syntax errors are returned instead of lint
+// problems, since the rules need a complete AST.
+func Source(src []byte, filename string, cfg *Config) []parser.Diagnostic {
+	l := lexer.NewWithFile(string(src), filename)
+	p := parser.New(l)
+	program := p.ParseProgram()
+	if len(p.Diagnostics()) != 0 {
+		return p.Diagnostics()
+	}
+
+	a := analyze(program, filename)
+	suppressed := suppressions(l.Comments())
+
+	var all []parser.Diagnostic
+	for _, rule := range Rules() {
+		severity := cfg.severity(rule)
+		if severity == 0 || suppressed.file[rule.Name] || suppressed.file[""] {
+			continue
+		}
+		ps := &pass{program: program, analysis: a, rule: rule, severity: severity, file: filename}
+		rule.check(ps)
+		for _, d := range ps.diagnostics {
+			if !suppressed.covers(d) {
+				all = append(all, d)
+			}
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		a, b := all[i].Start, all[j].Start
+		return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+	})
+	return all
+}
+
+// ==================== Inline suppression ====================
+
+// suppressed holds the rules turned off by comments; the empty rule name
+// stands for every rule
+type suppressed struct {
+	file  map[string]bool
+	lines map[int]map[string]bool
+}
+
+func (s suppressed) covers(d parser.Diagnostic) bool {
+	rules := s.lines[d.Start.Line]
+	return rules[""] || rules[d.Code]
+}
+
+// suppressions reads the lint comments of a file:
+//
+//	// lint-disable rule1, rule2       whole file
+//	// lint-disable-next-line rule1    the following line
+//	code; // lint-disable-line rule1   this line
+//
+// Without rule names every rule is disabled.
+func suppressions(comments []lexer.Comment) suppressed {
+	s := suppressed{file: map[string]bool{}, lines: map[int]map[string]bool{}}
+	for _, c := range comments {
+		text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		directive, rest, _ := strings.Cut(text, " ")
+
+		var target map[string]bool
+		switch directive {
+		case "lint-disable":
+			target = s.file
+		case "lint-disable-line", "lint-disable-next-line":
+			line := c.Line
+			if directive == "lint-disable-next-line" {
+				line++
+			}
+			if s.lines[line] == nil {
+				s.lines[line] = map[string]bool{}
+			}
+			target = s.lines[line]
+		default:
+			continue
+		}
+
+		names := strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+		if len(names) == 0 {
+			target[""] = true
+		}
+		for _, name := range names {
+			target[name] = true
+		}
+	}
+	return s
+}
+
+// ==================== Files ====================
+
+// Discover expands directories into the BanglaCode files below them,
+// skipping hidden directories and installed packages
+func Discover(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				name := d.Name()
+				if p != path && (strings.HasPrefix(name, ".") || name == packages.ModulesDir || name == "node_modules") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			switch filepath.Ext(p) {
+			case ".bang", ".bangla", ".bong":
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,274 @@
+package lint
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/parser"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	register(&Rule{
+		Name:        "unused-variable",
+		Description: "dhoro/sthir variable that is never read",
+		Severity:    parser.SeverityWarning,
+		check:       checkUnusedVariables,
+	})
+	register(&Rule{
+		Name:        "const-reassign",
+		Description: "assignment to a sthir (constant)",
+		Severity:    parser.SeverityError,
+		check:       checkConstReassign,
+	})
+	register(&Rule{
+		Name:        "unreachable-code",
+		Description: "statements after ferao, felo, thamo or chharo",
+		Severity:    parser.SeverityWarning,
+		check:       checkUnreachable,
+	})
+	register(&Rule{
+		Name:        "unknown-function",
+		Description: "call to a function that is neither declared nor a builtin",
+		Severity:    parser.SeverityError,
+		check:       checkUnknownFunctions,
+	})
+	register(&Rule{
+		Name:        "builtin-arity",
+		Description: "builtin called with the wrong number of arguments",
+		Severity:    parser.SeverityError,
+		check:       checkBuiltinArity,
+	})
+	register(&Rule{
+		Name:        "shadow",
+		Description: "declaration that hides a variable of an outer scope or a builtin",
+		Severity:    parser.SeverityWarning,
+		check:       checkShadowing,
+	})
+	register(&Rule{
+		Name:        "await-outside-async",
+		Description: "opekha inside a function that is not proyash kaj",
+		Severity:    parser.SeverityWarning,
+		check:       checkAwaitOutsideAsync,
+	})
+}
+
+// ==================== Variables ====================
+
+func checkUnusedVariables(p *pass) {
+	for _, s := range p.scopes {
+		for _, b := range s.bindings {
+			if b.kind != bindVariable && b.kind != bindConstant {
+				continue
+			}
+			// _naam marks a variable as intentionally unused
+			if b.exported || b.reads > 0 || strings.HasPrefix(b.name.Value, "_") {
+				continue
+			}
+			p.report(b.name.Token, "'%s' is declared but never used", b.name.Value)
+		}
+	}
+}
+
+func checkConstReassign(p *pass) {
+	for _, ref := range p.references {
+		if ref.assign != nil && ref.binding != nil && ref.binding.kind == bindConstant {
+			p.report(ref.ident.Token, "cannot assign to '%s': it is a sthir (constant) declared at line %d",
+				ref.ident.Value, ref.binding.name.Token.Line)
+		}
+	}
+}
+
+func checkShadowing(p *pass) {
+	for _, s := range p.scopes {
+		for _, b := range s.bindings {
+			if b.kind == bindImport && b.name.Token.Type == lexer.ANO {
+				continue // names of "ano" without hisabe belong to the module
+			}
+			if s.parent != nil {
+				// an outer name declared further down is not in scope yet
+				if outer, ok := s.parent.lookupBefore(b.name.Value, b.name.Token); ok {
+					p.report(b.name.Token, "'%s' shadows the variable declared at line %d", b.name.Value, outer.name.Token.Line)
+					continue
+				}
+			}
+			if _, ok := builtins.Builtins[b.name.Value]; ok {
+				p.report(b.name.Token, "'%s' shadows the builtin function of the same name", b.name.Value)
+			}
+		}
+	}
+}
+
+// ==================== Calls ====================
+
+func checkUnknownFunctions(p *pass) {
+	for _, ref := range p.references {
+		name := ref.ident.Value
+		if ref.call == nil || ref.binding != nil || implicit[name] || ref.scope.isOpen() {
+			continue
+		}
+		if _, ok := builtins.Builtins[name]; ok {
+			continue
+		}
+		p.report(ref.ident.Token, "unknown function '%s': it is not declared and is not a builtin", name)
+		if guess := closestName(name, ref.scope); guess != "" {
+			p.diagnostics[len(p.diagnostics)-1].Fix = "did you mean '" + guess + "'?"
+		}
+	}
+}
+
+func checkBuiltinArity(p *pass) {
+	for _, ref := range p.references {
+		if ref.call == nil || ref.binding != nil {
+			continue
+		}
+		arity, ok := builtins.Arities[ref.ident.Value]
+		if !ok {
+			continue
+		}
+		got := len(ref.call.Arguments)
+		for _, arg := range ref.call.Arguments {
+			if _, spread := arg.(*ast.SpreadElement); spread {
+				got = -1 // unknown until run time
+			}
+		}
+		if got < 0 || got >= arity.Min && (arity.Max < 0 || got <= arity.Max) {
+			continue
+		}
+
+		var want string
+		switch {
+		case arity.Max < 0:
+			want = "at least " + strconv.Itoa(arity.Min)
+		case arity.Min == arity.Max:
+			want = strconv.Itoa(arity.Min)
+		default:
+			want = strconv.Itoa(arity.Min) + " to " + strconv.Itoa(arity.Max)
+		}
+		p.report(ref.ident.Token, "%s expects %s argument(s), got %d", ref.ident.Value, want, got)
+	}
+}
+
+// closestName suggests a builtin or visible name within two edits of a
+// misspelled one
+func closestName(name string, s *scope) string {
+	best, bestDist := "", 3
+	try := func(candidate string) {
+		if d := editDistance(name, candidate); d < bestDist || d == bestDist && candidate < best {
+			best, bestDist = candidate, d
+		}
+	}
+	for candidate := range builtins.Builtins {
+		try(candidate)
+	}
+	for sc := s; sc != nil; sc = sc.parent {
+		for candidate := range sc.names {
+			try(candidate)
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// ==================== Control flow ====================
+
+func checkUnreachable(p *pass) {
+	ast.Inspect(p.program, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Program:
+			unreachableAfter(p, n.Statements)
+		case *ast.BlockStatement:
+			unreachableAfter(p, n.Statements)
+		}
+		return true
+	})
+}
+
+// unreachableAfter reports the first statement that follows one which
+// always leaves the block
+func unreachableAfter(p *pass, statements []ast.Statement) {
+	for i, stmt := range statements[:max(len(statements)-1, 0)] {
+		if exit := exitKeyword(stmt); exit != "" {
+			p.report(ast.TokenOf(statements[i+1]), "unreachable code after %s", exit)
+			return
+		}
+	}
+}
+
+// exitKeyword names the statement that makes stmt always leave its block,
+// or returns "" if control can continue after it
+func exitKeyword(stmt ast.Statement) string {
+	switch s := stmt.(type) {
+	case *ast.ReturnStatement:
+		return "ferao"
+	case *ast.ThrowStatement:
+		return "felo"
+	case *ast.BreakStatement:
+		return "thamo"
+	case *ast.ContinueStatement:
+		return "chharo"
+	case *ast.IfStatement:
+		// jodi ... nahole ... where every branch leaves
+		if s.Alternative != nil && blockExits(s.Consequence) && blockExits(s.Alternative) {
+			return "jodi/nahole"
+		}
+	}
+	return ""
+}
+
+func blockExits(b *ast.BlockStatement) bool {
+	for _, stmt := range b.Statements {
+		if exitKeyword(stmt) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func checkAwaitOutsideAsync(p *pass) {
+	var visit func(root ast.Node, async bool)
+	visit = func(root ast.Node, async bool) {
+		ast.Inspect(root, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FunctionLiteral:
+				visit(n.Body, false)
+				return false
+			case *ast.AsyncFunctionLiteral:
+				visit(n.Body, true)
+				return false
+			case *ast.AwaitExpression:
+				if !async {
+					p.report(n.Token, "opekha used outside proyash kaj; mark the function proyash")
+				}
+			case *ast.ForOfStatement:
+				if n.Await && !async {
+					p.report(n.Token, "ghuriye opekha used outside proyash kaj; mark the function proyash")
+				}
+			}
+			return true
+		})
+	}
+	// opekha is allowed at the top level of a file
+	visit(p.program, true)
+}
@@ -0,0 +1,521 @@
+package lint
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/packages"
+	"BanglaCode/src/parser"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// bindingKind tells how a name was introduced
+type bindingKind int
+
+const (
+	bindVariable bindingKind = iota // dhoro
+	bindConstant                    // sthir
+	bindGlobal                      // bishwo
+	bindParameter
+	bindFunction
+	bindClass
+	bindImport
+	bindCatch
+	bindLoop // ghuriye (x of ...) / (x in ...)
+)
+
+// binding is one declared name
+type binding struct {
+	name     *ast.Identifier
+	kind     bindingKind
+	scope    *scope
+	exported bool // declared behind pathao
+	reads    int  // references other than plain "x = ..." assignments
+}
+
+// scope is the part of a program where a name is visible. As in the
+// evaluator, only functions, dhoro_bhul blocks and ghuriye loops open a
+// new scope; the blocks of jodi, jotokkhon etc. share their parent's.
+type scope struct {
+	parent   *scope
+	bindings []*binding
+	names    map[string]*binding
+	// open is set when an "ano" without hisabe could not be read, so any
+	// name may come from that module
+	open bool
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{parent: parent, names: make(map[string]*binding)}
+}
+
+// declare adds a name to the scope; declaring it again (dhoro x twice)
+// refers to the same variable, as it does at run time
+func (s *scope) declare(name *ast.Identifier, kind bindingKind) *binding {
+	if b, ok := s.names[name.Value]; ok {
+		return b
+	}
+	b := &binding{name: name, kind: kind, scope: s}
+	s.bindings = append(s.bindings, b)
+	s.names[name.Value] = b
+	return b
+}
+
+// lookup finds the binding a name refers to. Declarations anywhere in a
+// scope count, because functions may use names declared after them.
+func (s *scope) lookup(name string) (*binding, bool) {
+	for sc := s; sc != nil; sc = sc.parent {
+		if b, ok := sc.names[name]; ok {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// lookupBefore is lookup restricted to bindings declared before pos, the
+// names already in scope when the code at pos runs
+func (s *scope) lookupBefore(name string, pos lexer.Token) (*binding, bool) {
+	for sc := s; sc != nil; sc = sc.parent {
+		if b, ok := sc.names[name]; ok && before(b.name.Token, pos) {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// before reports whether token a comes before token b in the source
+func before(a, b lexer.Token) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+}
+
+// isOpen reports whether an unresolved import may declare names in s
+func (s *scope) isOpen() bool {
+	for sc := s; sc != nil; sc = sc.parent {
+		if sc.open {
+			return true
+		}
+	}
+	return false
+}
+
+// reference is one use of a name
+type reference struct {
+	ident   *ast.Identifier
+	scope   *scope
+	binding *binding                  // nil for builtins and unknown names
+	assign  *ast.AssignmentExpression // set when the name is assigned to
+	call    *ast.CallExpression       // set when the name is called
+}
+
+// implicit names exist at run time without a declaration
+var implicit = map[string]bool{
+	"ei":       true, // this, inside sreni methods
+	"porikkha": true, // test files (banglacode test)
+	"describe": true,
+}
+
+// analysis is the scope information shared by the rules
+type analysis struct {
+	scopes     []*scope
+	references []*reference
+}
+
+// resolver walks a program once, declaring names and recording references
+type resolver struct {
+	dir string // directory of the file, for "ano" without hisabe
+	analysis
+}
+
+func analyze(program *ast.Program, filename string) *analysis {
+	r := &resolver{dir: filepath.Dir(filename)}
+	top := r.open(nil)
+	r.statements(program.Statements, top)
+
+	for _, ref := range r.references {
+		ref.binding, _ = ref.scope.lookup(ref.ident.Value)
+		if ref.binding != nil && (ref.assign == nil || ref.assign.Operator != "=") {
+			ref.binding.reads++
+		}
+	}
+	return &r.analysis
+}
+
+func (r *resolver) open(parent *scope) *scope {
+	s := newScope(parent)
+	r.scopes = append(r.scopes, s)
+	return s
+}
+
+func (r *resolver) use(ident *ast.Identifier, s *scope) *reference {
+	ref := &reference{ident: ident, scope: s}
+	r.references = append(r.references, ref)
+	return ref
+}
+
+// ==================== Statements ====================
+
+func (r *resolver) statements(statements []ast.Statement, s *scope) {
+	for _, stmt := range statements {
+		r.statement(stmt, s, false)
+	}
+}
+
+func (r *resolver) block(b *ast.BlockStatement, s *scope) {
+	if b != nil {
+		r.statements(b.Statements, s)
+	}
+}
+
+func (r *resolver) statement(stmt ast.Statement, s *scope, exported bool) {
+	switch n := stmt.(type) {
+	case *ast.VariableDeclaration:
+		r.expr(n.Value, s)
+		b := s.declare(n.Name, declarationKind(n.IsConstant, n.IsGlobal))
+		b.exported = b.exported || exported
+	case *ast.DeclarationList:
+		for _, d := range n.Declarations {
+			r.statement(d, s, exported)
+		}
+	case *ast.DestructuringDeclaration:
+		r.expr(n.Source, s)
+		for _, b := range r.pattern(n.Pattern, s, declarationKind(n.IsConstant, n.IsGlobal)) {
+			b.exported = b.exported || exported
+		}
+	case *ast.ExpressionStatement:
+		r.expr(n.Expression, s)
+		if exported {
+			if fn := functionName(n.Expression); fn != nil {
+				s.names[fn.Value].exported = true
+			}
+		}
+	case *ast.ReturnStatement:
+		r.expr(n.ReturnValue, s)
+	case *ast.ThrowStatement:
+		r.expr(n.Value, s)
+	case *ast.LabeledStatement:
+		r.statement(n.Body, s, false)
+
+	case *ast.IfStatement:
+		r.expr(n.Condition, s)
+		r.block(n.Consequence, s)
+		r.block(n.Alternative, s)
+	case *ast.WhileStatement:
+		r.expr(n.Condition, s)
+		r.block(n.Body, s)
+	case *ast.DoWhileStatement:
+		r.block(n.Body, s)
+		r.expr(n.Condition, s)
+	case *ast.ForStatement:
+		loop := r.open(s)
+		if n.Init != nil {
+			r.statement(n.Init, loop, false)
+		}
+		r.expr(n.Condition, loop)
+		r.expr(n.Update, loop)
+		r.block(n.Body, loop)
+	case *ast.ForOfStatement:
+		r.expr(n.Iterable, s)
+		loop := r.open(s)
+		loop.declare(n.VarName, bindLoop)
+		r.block(n.Body, loop)
+	case *ast.ForInStatement:
+		r.expr(n.Object, s)
+		loop := r.open(s)
+		loop.declare(n.VarName, bindLoop)
+		r.block(n.Body, loop)
+	case *ast.TryCatchStatement:
+		r.block(n.TryBlock, s)
+		if n.CatchBlock != nil {
+			catch := r.open(s)
+			if n.CatchParam != nil {
+				catch.declare(n.CatchParam, bindCatch)
+			}
+			r.block(n.CatchBlock, catch)
+		}
+		r.block(n.FinallyBlock, s)
+	case *ast.SwitchStatement:
+		r.expr(n.Expr, s)
+		for _, c := range n.Cases {
+			r.expr(c.Value, s)
+			r.block(c.Body, s)
+		}
+		r.block(n.Default, s)
+
+	case *ast.ClassDeclaration:
+		b := s.declare(n.Name, bindClass)
+		b.exported = b.exported || exported
+		for _, value := range n.StaticProperties {
+			r.expr(value, s)
+		}
+		for _, m := range n.Methods {
+			r.function(m.Parameters, m.RestParameter, m.Body, s)
+		}
+		for _, accessors := range []map[string]*ast.FunctionLiteral{n.Getters, n.Setters} {
+			for _, fn := range accessors {
+				r.function(fn.Parameters, fn.RestParameter, fn.Body, s)
+			}
+		}
+	case *ast.ImportStatement:
+		if n.Alias != nil {
+			s.declare(n.Alias, bindImport)
+		} else {
+			r.importAll(n, s)
+		}
+	case *ast.ExportStatement:
+		r.statement(n.Statement, s, true)
+	}
+}
+
+func declarationKind(isConstant, isGlobal bool) bindingKind {
+	switch {
+	case isConstant:
+		return bindConstant
+	case isGlobal:
+		return bindGlobal
+	}
+	return bindVariable
+}
+
+// functionName is the name a kaj statement declares, if any
+func functionName(expr ast.Expression) *ast.Identifier {
+	switch fn := expr.(type) {
+	case *ast.FunctionLiteral:
+		return fn.Name
+	case *ast.AsyncFunctionLiteral:
+		return fn.Name
+	}
+	return nil
+}
+
+// importAll declares the names exported by a module imported without
+// hisabe. A module that cannot be read makes the scope open.
+func (r *resolver) importAll(is *ast.ImportStatement, s *scope) {
+	content, err := os.ReadFile(packages.Resolve(r.dir, is.Path.Value))
+	if err != nil || strings.HasSuffix(is.Path.Value, ".json") {
+		s.open = true
+		return
+	}
+	p := parser.New(lexer.New(string(content)))
+	program := p.ParseProgram()
+	for _, stmt := range program.Statements {
+		export, ok := stmt.(*ast.ExportStatement)
+		if !ok {
+			continue
+		}
+		var names []*ast.Identifier
+		switch d := export.Statement.(type) {
+		case *ast.VariableDeclaration:
+			names = append(names, d.Name)
+		case *ast.DeclarationList:
+			for _, decl := range d.Declarations {
+				names = append(names, decl.Name)
+			}
+		case *ast.DestructuringDeclaration:
+			names = append(names, d.Names()...)
+		case *ast.ClassDeclaration:
+			names = append(names, d.Name)
+		case *ast.ExpressionStatement:
+			if fn := functionName(d.Expression); fn != nil {
+				names = append(names, fn)
+			}
+		}
+		for _, name := range names {
+			// Declared at the import so that it is never reported as unused
+			// or as shadowing something in another file
+			b := s.declare(&ast.Identifier{Token: is.Token, Value: name.Value}, bindImport)
+			b.reads++
+		}
+	}
+}
+
+// ==================== Expressions ====================
+
+func (r *resolver) function(params []*ast.Parameter, rest *ast.Identifier, body *ast.BlockStatement, s *scope) {
+	fn := r.open(s)
+	for _, p := range params {
+		r.element(p, fn, bindParameter)
+	}
+	if rest != nil {
+		fn.declare(rest, bindParameter)
+	}
+	r.block(body, fn)
+}
+
+// element resolves a parameter or destructuring target and declares the
+// names it binds in order, so that a default sees the names before it
+func (r *resolver) element(el *ast.BindingElement, s *scope, kind bindingKind) []*binding {
+	r.expr(el.Default, s)
+	if el.Pattern == nil {
+		return []*binding{s.declare(el.Name, kind)}
+	}
+	return r.pattern(el.Pattern, s, kind)
+}
+
+// pattern declares the names an array or object pattern binds
+func (r *resolver) pattern(pattern ast.Expression, s *scope, kind bindingKind) []*binding {
+	var bound []*binding
+	var rest *ast.Identifier
+	switch pat := pattern.(type) {
+	case *ast.ArrayPattern:
+		for _, el := range pat.Elements {
+			bound = append(bound, r.element(el, s, kind)...)
+		}
+		rest = pat.Rest
+	case *ast.ObjectPattern:
+		for _, el := range pat.Values {
+			bound = append(bound, r.element(el, s, kind)...)
+		}
+		rest = pat.Rest
+	}
+	if rest != nil {
+		bound = append(bound, s.declare(rest, kind))
+	}
+	return bound
+}
+
+// assignPattern resolves the targets of a destructuring assignment: the
+// names it binds are assigned to, not declared
+func (r *resolver) assignPattern(pattern ast.Expression, n *ast.AssignmentExpression, s *scope) {
+	var elements []*ast.BindingElement
+	var rest *ast.Identifier
+	switch pat := pattern.(type) {
+	case *ast.ArrayPattern:
+		elements, rest = pat.Elements, pat.Rest
+	case *ast.ObjectPattern:
+		elements, rest = pat.Values, pat.Rest
+	}
+	for _, el := range elements {
+		r.expr(el.Default, s)
+		switch {
+		case el.Pattern != nil:
+			r.assignPattern(el.Pattern, n, s)
+		case el.Member != nil:
+			r.expr(el.Member, s)
+		default:
+			r.use(el.Name, s).assign = n
+		}
+	}
+	if rest != nil {
+		r.use(rest, s).assign = n
+	}
+}
+
+func (r *resolver) exprs(exprs []ast.Expression, s *scope) {
+	for _, e := range exprs {
+		r.expr(e, s)
+	}
+}
+
+func (r *resolver) expr(expr ast.Expression, s *scope) {
+	switch n := expr.(type) {
+	case *ast.Identifier:
+		if n != nil {
+			r.use(n, s)
+		}
+	case *ast.BinaryExpression:
+		r.expr(n.Left, s)
+		r.expr(n.Right, s)
+	case *ast.UnaryExpression:
+		r.expr(n.Right, s)
+	case *ast.ConditionalExpression:
+		r.expr(n.Condition, s)
+		r.expr(n.Consequence, s)
+		r.expr(n.Alternative, s)
+	case *ast.AssignmentExpression:
+		switch target := n.Name.(type) {
+		case *ast.Identifier:
+			r.use(target, s).assign = n
+		case *ast.ArrayPattern:
+			// [a, b] = [b, a] assigns to each variable
+			r.assignPattern(target, n, s)
+		default:
+			r.expr(n.Name, s)
+		}
+		r.expr(n.Value, s)
+	case *ast.CallExpression:
+		if ident, ok := n.Function.(*ast.Identifier); ok {
+			r.use(ident, s).call = n
+		} else {
+			r.expr(n.Function, s)
+		}
+		r.exprs(n.Arguments, s)
+	case *ast.MemberExpression:
+		r.expr(n.Object, s)
+		if n.Computed {
+			r.expr(n.Property, s)
+		}
+	case *ast.NewExpression:
+		r.expr(n.Class, s)
+		r.exprs(n.Arguments, s)
+	case *ast.SpreadElement:
+		r.expr(n.Argument, s)
+	case *ast.AwaitExpression:
+		r.expr(n.Expression, s)
+	case *ast.YieldExpression:
+		r.expr(n.Expression, s)
+	case *ast.DeleteExpression:
+		r.expr(n.Target, s)
+	case *ast.TemplateLiteral:
+		r.template(n, s)
+	case *ast.ArrayLiteral:
+		r.exprs(n.Elements, s)
+	case *ast.MapLiteral:
+		for _, key := range n.Keys {
+			// {naam: 1} uses naam as a plain key
+			if _, ok := key.(*ast.Identifier); !ok {
+				r.expr(key, s)
+			}
+			r.expr(n.Pairs[key], s)
+		}
+
+	case *ast.FunctionLiteral:
+		if n.Name != nil {
+			s.declare(n.Name, bindFunction)
+		}
+		r.function(n.Parameters, n.RestParameter, n.Body, s)
+	case *ast.AsyncFunctionLiteral:
+		if n.Name != nil {
+			s.declare(n.Name, bindFunction)
+		}
+		r.function(n.Parameters, n.RestParameter, n.Body, s)
+	}
+}
+
+// template resolves the ${...} parts of a template literal, which the
+// evaluator parses only when the template runs. Names inside are reported
+// at the template's position.
+func (r *resolver) template(t *ast.TemplateLiteral, s *scope) {
+	text := t.Value
+	for i := 0; i+1 < len(text); i++ {
+		if text[i] != '$' || text[i+1] != '{' {
+			continue
+		}
+		depth, j := 1, i+2
+		for ; j < len(text); j++ {
+			if text[j] == '{' {
+				depth++
+			} else if text[j] == '}' {
+				if depth--; depth == 0 {
+					break
+				}
+			}
+		}
+		if depth != 0 {
+			return
+		}
+
+		p := parser.New(lexer.New(text[i+2 : j]))
+		program := p.ParseProgram()
+		if len(p.Diagnostics()) == 0 {
+			ast.Inspect(program, func(n ast.Node) bool {
+				if ident, ok := n.(*ast.Identifier); ok {
+					ident.Token.Line, ident.Token.Column, ident.Token.File = t.Token.Line, t.Token.Column, t.Token.File
+				}
+				return true
+			})
+			r.statements(program.Statements, s)
+		}
+		i = j
+	}
+}
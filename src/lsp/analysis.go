@@ -0,0 +1,300 @@
+package lsp
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
+	"fmt"
+	"sort"
+)
+
+// ==================== Document Symbols ====================
+
+// symbols lists the kaj and sreni declarations of a document; functions
+// declared inside functions and class members become children
+func (d *document) symbols() []DocumentSymbol {
+	return d.statementSymbols(d.program.Statements)
+}
+
+func (d *document) statementSymbols(statements []ast.Statement) []DocumentSymbol {
+	symbols := []DocumentSymbol{}
+	for _, stmt := range statements {
+		if export, ok := stmt.(*ast.ExportStatement); ok {
+			stmt = export.Statement
+		}
+
+		switch s := stmt.(type) {
+		case *ast.ExpressionStatement:
+			if sym, ok := d.functionSymbol(s.Expression, symbolFunction); ok {
+				symbols = append(symbols, sym)
+			}
+		case *ast.VariableDeclaration:
+			// dhoro jog = kaj(a, b) { ... };
+			if sym, ok := d.functionSymbol(s.Value, symbolFunction); ok {
+				sym.Name = s.Name.Value
+				sym.SelectionRange = d.tokenRange(s.Name.Token)
+				symbols = append(symbols, sym)
+			}
+		case *ast.ClassDeclaration:
+			symbols = append(symbols, d.classSymbol(s))
+		}
+	}
+	return symbols
+}
+
+func (d *document) functionSymbol(expr ast.Expression, kind int) (DocumentSymbol, bool) {
+	var start lexer.Token
+	var name *ast.Identifier
+	var params string
+	var body *ast.BlockStatement
+
+	switch fn := expr.(type) {
+	case *ast.FunctionLiteral:
+		start, name, body = fn.Token, fn.Name, fn.Body
+		params = ast.FormatParameters(fn.Parameters, fn.RestParameter)
+	case *ast.AsyncFunctionLiteral:
+		start, name, body = fn.Token, fn.Name, fn.Body
+		params = ast.FormatParameters(fn.Parameters, fn.RestParameter)
+	default:
+		return DocumentSymbol{}, false
+	}
+	if body == nil {
+		return DocumentSymbol{}, false
+	}
+
+	sym := DocumentSymbol{
+		Detail:   "(" + params + ")",
+		Kind:     kind,
+		Range:    d.blockRange(start, body.Token),
+		Children: d.statementSymbols(body.Statements),
+	}
+	if name != nil {
+		sym.Name = name.Value
+		sym.SelectionRange = d.tokenRange(name.Token)
+	} else {
+		sym.SelectionRange = d.tokenRange(start)
+	}
+	return sym, true
+}
+
+func (d *document) classSymbol(cls *ast.ClassDeclaration) DocumentSymbol {
+	sym := DocumentSymbol{
+		Name:           cls.Name.Value,
+		Detail:         "sreni",
+		Kind:           symbolClass,
+		Range:          d.tokenRange(cls.Token),
+		SelectionRange: d.tokenRange(cls.Name.Token),
+	}
+	if i := d.tokenIndex(cls.Name.Token.Line, cls.Name.Token.Column); i >= 0 && i+1 < len(d.tokens) {
+		sym.Range = d.blockRange(cls.Token, d.tokens[i+1])
+	}
+
+	members := []DocumentSymbol{}
+	for _, m := range cls.Methods {
+		kind := symbolMethod
+		if m.Name != nil && m.Name.Value == "shuru" {
+			kind = symbolConstructor
+		}
+		if member, ok := d.functionSymbol(m, kind); ok {
+			members = append(members, member)
+		}
+	}
+	for _, accessors := range []map[string]*ast.FunctionLiteral{cls.Getters, cls.Setters} {
+		for _, fn := range accessors {
+			if member, ok := d.functionSymbol(fn, symbolProperty); ok {
+				members = append(members, member)
+			}
+		}
+	}
+	sort.SliceStable(members, func(i, j int) bool {
+		a, b := members[i].Range.Start, members[j].Range.Start
+		return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+	})
+	sym.Children = members
+	return sym
+}
+
+// ==================== Declarations ====================
+
+// declaration is a name introduced by the program together with the part
+// of the document where it is visible
+type declaration struct {
+	name   *ast.Identifier
+	scope  Range
+	detail string // shown on hover, e.g. "kaj jog(a, b)"
+	kind   int    // completion kind
+}
+
+// declarations collects every variable, function, class, parameter and
+// loop/catch variable declared in the document
+func (d *document) declarations() []declaration {
+	whole := Range{End: Position{Line: len(d.lines) + 1}}
+	var blocks []Range
+	ast.Inspect(d.program, func(n ast.Node) bool {
+		if b, ok := n.(*ast.BlockStatement); ok {
+			blocks = append(blocks, d.blockRange(b.Token, b.Token))
+		}
+		return true
+	})
+
+	// innermost block around a declaration, or the whole file
+	scopeOf := func(tok lexer.Token) Range {
+		pos := d.position(tok.Line, tok.Column)
+		scope := whole
+		for _, b := range blocks {
+			if contains(b, pos) && contains(scope, b.Start) {
+				scope = b
+			}
+		}
+		return scope
+	}
+	bodyScope := func(body *ast.BlockStatement) Range {
+		if body == nil {
+			return whole
+		}
+		return d.blockRange(body.Token, body.Token)
+	}
+
+	var decls []declaration
+	methods := make(map[*ast.Identifier]bool) // reached through ei/objects, not by bare name
+	add := func(name *ast.Identifier, scope Range, detail string, kind int) {
+		if name != nil {
+			decls = append(decls, declaration{name: name, scope: scope, detail: detail, kind: kind})
+		}
+	}
+	addParams := func(params []*ast.Parameter, rest *ast.Identifier, body *ast.BlockStatement) {
+		for _, p := range params {
+			for _, name := range p.Names() {
+				add(name, bodyScope(body), "parameter "+name.Value, completionVariable)
+			}
+		}
+		if rest != nil {
+			add(rest, bodyScope(body), "parameter ..."+rest.Value, completionVariable)
+		}
+	}
+
+	ast.Inspect(d.program, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.VariableDeclaration:
+			add(n.Name, scopeOf(n.Token), fmt.Sprintf("%s %s", n.Token.Literal, n.Name.Value), completionVariable)
+			if fn, ok := n.Value.(*ast.FunctionLiteral); ok && fn.Name == nil {
+				decls[len(decls)-1].detail = fmt.Sprintf("kaj %s(%s)", n.Name.Value, ast.FormatParameters(fn.Parameters, fn.RestParameter))
+				decls[len(decls)-1].kind = completionFunction
+			}
+		case *ast.DestructuringDeclaration:
+			for _, name := range n.Names() {
+				add(name, scopeOf(n.Token), n.Token.Literal+" "+name.Value, completionVariable)
+			}
+		case *ast.FunctionLiteral:
+			if n.Name != nil && !methods[n.Name] {
+				add(n.Name, scopeOf(n.Token), fmt.Sprintf("kaj %s(%s)", n.Name.Value, ast.FormatParameters(n.Parameters, n.RestParameter)), completionFunction)
+			}
+			addParams(n.Parameters, n.RestParameter, n.Body)
+		case *ast.AsyncFunctionLiteral:
+			if n.Name != nil {
+				add(n.Name, scopeOf(n.Token), fmt.Sprintf("proyash kaj %s(%s)", n.Name.Value, ast.FormatParameters(n.Parameters, n.RestParameter)), completionFunction)
+			}
+			addParams(n.Parameters, n.RestParameter, n.Body)
+		case *ast.ClassDeclaration:
+			add(n.Name, scopeOf(n.Token), "sreni "+n.Name.Value, completionClass)
+			for _, m := range n.Methods {
+				methods[m.Name] = true
+			}
+			for _, accessors := range []map[string]*ast.FunctionLiteral{n.Getters, n.Setters} {
+				for _, fn := range accessors {
+					methods[fn.Name] = true
+				}
+			}
+		case *ast.ForOfStatement:
+			add(n.VarName, bodyScope(n.Body), "dhoro "+n.VarName.Value, completionVariable)
+		case *ast.ForInStatement:
+			add(n.VarName, bodyScope(n.Body), "dhoro "+n.VarName.Value, completionVariable)
+		case *ast.TryCatchStatement:
+			add(n.CatchParam, bodyScope(n.CatchBlock), "dhoro_bhul "+identName(n.CatchParam), completionVariable)
+		case *ast.ImportStatement:
+			add(n.Alias, whole, fmt.Sprintf("ano %q hisabe %s", n.Path.Value, identName(n.Alias)), completionVariable)
+		}
+		return true
+	})
+
+	return decls
+}
+
+// resolve finds the declaration of name visible at pos: the innermost
+// scope wins, and within a scope the last declaration before pos
+func (d *document) resolve(name string, pos Position) (declaration, bool) {
+	var best declaration
+	found := false
+	for _, decl := range d.declarations() {
+		if decl.name.Value != name || !contains(decl.scope, pos) {
+			continue
+		}
+		if !found || contains(best.scope, decl.scope.Start) && best.scope != decl.scope {
+			best, found = decl, true
+			continue
+		}
+		declPos := d.position(decl.name.Token.Line, decl.name.Token.Column)
+		bestPos := d.position(best.name.Token.Line, best.name.Token.Column)
+		if decl.scope == best.scope && before(declPos, pos) && before(bestPos, declPos) {
+			best = decl
+		}
+	}
+	return best, found
+}
+
+// exported returns the top-level declaration a module exports as name
+func (d *document) exported(name string) (declaration, bool) {
+	for _, stmt := range d.program.Statements {
+		export, ok := stmt.(*ast.ExportStatement)
+		if !ok {
+			continue
+		}
+		if ident := declaredName(export.Statement); ident != nil && ident.Value == name {
+			for _, decl := range d.declarations() {
+				if decl.name == ident {
+					return decl, true
+				}
+			}
+		}
+	}
+	return declaration{}, false
+}
+
+// declaredName is the name bound by a top-level statement, if any
+func declaredName(stmt ast.Statement) *ast.Identifier {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		return s.Name
+	case *ast.ClassDeclaration:
+		return s.Name
+	case *ast.ExpressionStatement:
+		switch fn := s.Expression.(type) {
+		case *ast.FunctionLiteral:
+			return fn.Name
+		case *ast.AsyncFunctionLiteral:
+			return fn.Name
+		}
+	}
+	return nil
+}
+
+// imports lists the ano statements of the document
+func (d *document) imports() []*ast.ImportStatement {
+	var imports []*ast.ImportStatement
+	for _, stmt := range d.program.Statements {
+		if imp, ok := stmt.(*ast.ImportStatement); ok && imp.Path != nil {
+			imports = append(imports, imp)
+		}
+	}
+	return imports
+}
+
+func identName(ident *ast.Identifier) string {
+	if ident == nil {
+		return ""
+	}
+	return ident.Value
+}
+
+func before(a, b Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character <= b.Character)
+}
@@ -0,0 +1,159 @@
+package lsp
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/parser"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// document is an open (or imported) source file with its parse results
+type document struct {
+	uri         string
+	path        string
+	text        string
+	lines       []string
+	tokens      []lexer.Token
+	closing     map[int]lexer.Token // index of a '{' token -> its matching '}'
+	program     *ast.Program
+	diagnostics []parser.Diagnostic
+}
+
+// newDocument lexes and parses text; syntax errors are kept, not fatal
+func newDocument(uri, text string) *document {
+	path := uriToPath(uri)
+	d := &document{
+		uri:     uri,
+		path:    path,
+		text:    text,
+		lines:   strings.Split(text, "\n"),
+		closing: make(map[int]lexer.Token),
+	}
+
+	l := lexer.NewWithFile(text, path)
+	var open []int
+	for tok := l.NextToken(); tok.Type != lexer.EOF; tok = l.NextToken() {
+		switch tok.Type {
+		case lexer.LBRACE:
+			open = append(open, len(d.tokens))
+		case lexer.RBRACE:
+			if len(open) > 0 {
+				d.closing[open[len(open)-1]] = tok
+				open = open[:len(open)-1]
+			}
+		}
+		d.tokens = append(d.tokens, tok)
+	}
+
+	p := parser.New(lexer.NewWithFile(text, path))
+	d.program = p.ParseProgram()
+	d.diagnostics = p.Diagnostics()
+	return d
+}
+
+// ==================== Positions ====================
+
+// position converts a 1-based lexer line/column (counted in runes) to an
+// LSP position (0-based, UTF-16 code units)
+func (d *document) position(line, column int) Position {
+	if line < 1 {
+		return Position{}
+	}
+	pos := Position{Line: line - 1}
+	if line > len(d.lines) {
+		return pos
+	}
+	n := 0
+	for _, r := range d.lines[line-1] {
+		if n++; n >= column {
+			break
+		}
+		pos.Character += utf16Len(r)
+	}
+	return pos
+}
+
+// tokenRange is the range covered by tok
+func (d *document) tokenRange(tok lexer.Token) Range {
+	width := tok.Width()
+	if tok.Type == lexer.STRING {
+		width += 2 // quotes are not part of the literal
+	}
+	return Range{
+		Start: d.position(tok.Line, tok.Column),
+		End:   d.position(tok.Line, tok.Column+width),
+	}
+}
+
+// blockRange spans from start to the '}' closing the block opened at open
+func (d *document) blockRange(start lexer.Token, open lexer.Token) Range {
+	r := d.tokenRange(start)
+	if i := d.tokenIndex(open.Line, open.Column); i >= 0 {
+		if end, ok := d.closing[i]; ok {
+			r.End = d.position(end.Line, end.Column+1)
+		}
+	}
+	return r
+}
+
+// tokenIndex finds the token starting at line/column, or -1
+func (d *document) tokenIndex(line, column int) int {
+	for i, tok := range d.tokens {
+		if tok.Line == line && tok.Column == column {
+			return i
+		}
+	}
+	return -1
+}
+
+// tokenAt returns the index of the token under an LSP position, or -1
+func (d *document) tokenAt(pos Position) int {
+	for i, tok := range d.tokens {
+		r := d.tokenRange(tok)
+		if r.Start.Line == pos.Line && r.Start.Character <= pos.Character && pos.Character <= r.End.Character {
+			// Prefer the identifier when the cursor sits between "(" and a name
+			if pos.Character == r.End.Character && i+1 < len(d.tokens) {
+				next := d.tokenRange(d.tokens[i+1])
+				if next.Start == pos && d.tokens[i+1].Type == lexer.IDENT {
+					return i + 1
+				}
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+func utf16Len(r rune) int {
+	if r >= 0x10000 && utf8.ValidRune(r) {
+		return 2
+	}
+	return 1
+}
+
+func contains(r Range, pos Position) bool {
+	after := pos.Line > r.Start.Line || (pos.Line == r.Start.Line && pos.Character >= r.Start.Character)
+	before := pos.Line < r.End.Line || (pos.Line == r.End.Line && pos.Character <= r.End.Character)
+	return after && before
+}
+
+// ==================== URIs ====================
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+func pathToURI(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String()
+}
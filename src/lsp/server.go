@@ -0,0 +1,365 @@
+package lsp
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/packages"
+	"BanglaCode/src/parser"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Server answers LSP requests for the documents an editor has open
+type Server struct {
+	in       *bufio.Reader
+	out      io.Writer
+	docs     map[string]*document // open documents by URI
+	shutdown bool
+}
+
+// NewServer creates a server reading requests from in and writing to out
+func NewServer(in io.Reader, out io.Writer) *Server {
+	return &Server{
+		in:   bufio.NewReader(in),
+		out:  out,
+		docs: make(map[string]*document),
+	}
+}
+
+// Run serves requests until the client sends exit or closes the stream
+func (s *Server) Run() error {
+	for {
+		msg, err := s.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return fmt.Errorf("exit before shutdown")
+			}
+			return nil
+		}
+		s.handle(msg)
+	}
+}
+
+// ==================== Transport ====================
+
+// read decodes one "Content-Length" framed JSON-RPC message
+func (s *Server) read() (*message, error) {
+	length := -1
+	for {
+		line, err := s.in.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if value, ok := strings.CutPrefix(line, "Content-Length:"); ok {
+			length, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length %q", value)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("missing Content-Length header")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(s.in, body); err != nil {
+		return nil, err
+	}
+	msg := &message{}
+	if err := json.Unmarshal(body, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (s *Server) write(v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func (s *Server) reply(msg *message, result interface{}) {
+	s.write(response{JSONRPC: "2.0", ID: msg.ID, Result: result})
+}
+
+func (s *Server) replyError(msg *message, code int, text string) {
+	s.write(response{JSONRPC: "2.0", ID: msg.ID, Error: &responseError{Code: code, Message: text}})
+}
+
+func (s *Server) notify(method string, params interface{}) {
+	s.write(notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// ==================== Dispatch ====================
+
+func (s *Server) handle(msg *message) {
+	switch msg.Method {
+	case "initialize":
+		s.reply(msg, map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":       1, // full document on every change
+				"completionProvider":     map[string]interface{}{"triggerCharacters": []string{"."}},
+				"hoverProvider":          true,
+				"documentSymbolProvider": true,
+				"definitionProvider":     true,
+			},
+			"serverInfo": map[string]string{"name": "banglacode"},
+		})
+	case "initialized", "$/cancelRequest", "$/setTrace", "workspace/didChangeConfiguration":
+		// notifications we do not need
+	case "shutdown":
+		s.shutdown = true
+		s.reply(msg, nil)
+
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			s.update(params.TextDocument.URI, params.TextDocument.Text)
+		}
+	case "textDocument/didChange":
+		var params didChangeParams
+		if json.Unmarshal(msg.Params, &params) == nil && len(params.ContentChanges) > 0 {
+			s.update(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+		}
+	case "textDocument/didClose":
+		var params didCloseParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			delete(s.docs, params.TextDocument.URI)
+			s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: params.TextDocument.URI, Diagnostics: []Diagnostic{}})
+		}
+
+	case "textDocument/completion":
+		s.withPosition(msg, s.completion)
+	case "textDocument/hover":
+		s.withPosition(msg, s.hover)
+	case "textDocument/definition":
+		s.withPosition(msg, s.definition)
+	case "textDocument/documentSymbol":
+		var params documentSymbolParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			s.replyError(msg, codeInvalidParams, err.Error())
+			return
+		}
+		doc, ok := s.docs[params.TextDocument.URI]
+		if !ok {
+			s.reply(msg, []DocumentSymbol{})
+			return
+		}
+		s.reply(msg, doc.symbols())
+
+	default:
+		// Requests (with an id) must be answered; notifications are ignored
+		if msg.ID != nil {
+			s.replyError(msg, codeMethodNotFound, "method not supported: "+msg.Method)
+		}
+	}
+}
+
+// withPosition decodes a text document position and answers with handler's
+// result, or null for documents that are not open
+func (s *Server) withPosition(msg *message, handler func(*document, Position) interface{}) {
+	var params textDocumentPositionParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		s.replyError(msg, codeInvalidParams, err.Error())
+		return
+	}
+	doc, ok := s.docs[params.TextDocument.URI]
+	if !ok {
+		s.reply(msg, nil)
+		return
+	}
+	s.reply(msg, handler(doc, params.Position))
+}
+
+// update reparses a document and publishes its syntax errors
+func (s *Server) update(uri, text string) {
+	doc := newDocument(uri, text)
+	s.docs[uri] = doc
+
+	diagnostics := []Diagnostic{}
+	for _, d := range doc.diagnostics {
+		severity := severityError
+		if d.Severity == parser.SeverityWarning {
+			severity = severityWarning
+		}
+		message := d.Message
+		if d.Fix != "" {
+			message += " (" + d.Fix + ")"
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Range: Range{
+				Start: doc.position(d.Start.Line, d.Start.Column),
+				End:   doc.position(d.End.Line, d.End.Column),
+			},
+			Severity: severity,
+			Code:     d.Code,
+			Source:   "banglacode",
+			Message:  message,
+		})
+	}
+	s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics})
+}
+
+// ==================== Features ====================
+
+// completion offers keywords, builtins and the names declared in the file
+func (s *Server) completion(doc *document, pos Position) interface{} {
+	items := []CompletionItem{}
+	for _, kw := range lexer.Keywords() {
+		items = append(items, CompletionItem{Label: kw, Kind: completionKeyword, Detail: "keyword"})
+	}
+
+	names := make([]string, 0, len(builtins.Builtins))
+	for name := range builtins.Builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		item := CompletionItem{Label: name, Kind: completionFunction, Detail: "builtin"}
+		if doc, ok := builtins.Docs[name]; ok {
+			item.Detail = doc.Signature
+			item.Documentation = doc.Summary
+		}
+		items = append(items, item)
+	}
+
+	seen := make(map[string]bool)
+	for _, decl := range doc.declarations() {
+		if seen[decl.name.Value] || !contains(decl.scope, pos) {
+			continue
+		}
+		seen[decl.name.Value] = true
+		items = append(items, CompletionItem{Label: decl.name.Value, Kind: decl.kind, Detail: decl.detail})
+	}
+	return items
+}
+
+// hover documents builtins and shows the declaration of user names
+func (s *Server) hover(doc *document, pos Position) interface{} {
+	i := doc.tokenAt(pos)
+	if i < 0 || doc.tokens[i].Type != lexer.IDENT {
+		return nil
+	}
+	tok := doc.tokens[i]
+	r := doc.tokenRange(tok)
+
+	if decl, target, ok := s.lookup(doc, i, pos); ok {
+		text := "```banglacode\n" + decl.detail + "\n```"
+		if target != doc {
+			text += "\n\n" + filepath.Base(target.path)
+		}
+		return Hover{Contents: MarkupContent{Kind: "markdown", Value: text}, Range: &r}
+	}
+
+	if _, ok := builtins.Builtins[tok.Literal]; ok {
+		text := "```banglacode\n" + tok.Literal + "(...)\n```\nbuiltin function"
+		if d, ok := builtins.Docs[tok.Literal]; ok {
+			text = "```banglacode\n" + d.Signature + "\n```\n" + d.Summary
+		}
+		return Hover{Contents: MarkupContent{Kind: "markdown", Value: text}, Range: &r}
+	}
+	return nil
+}
+
+// definition jumps to where the name under the cursor is declared,
+// following ano imports into other modules
+func (s *Server) definition(doc *document, pos Position) interface{} {
+	i := doc.tokenAt(pos)
+	if i < 0 {
+		return nil
+	}
+
+	// On the path of an import: open the module
+	if doc.tokens[i].Type == lexer.STRING {
+		for _, imp := range doc.imports() {
+			if imp.Path.Token.Line == doc.tokens[i].Line && imp.Path.Token.Column == doc.tokens[i].Column {
+				if target := s.module(doc, imp.Path.Value); target != nil {
+					return Location{URI: target.uri}
+				}
+			}
+		}
+		return nil
+	}
+
+	if doc.tokens[i].Type != lexer.IDENT {
+		return nil
+	}
+	decl, target, ok := s.lookup(doc, i, pos)
+	if !ok {
+		return nil
+	}
+	return Location{URI: target.uri, Range: target.tokenRange(decl.name.Token)}
+}
+
+// lookup resolves the identifier at token index i: alias.name through an
+// aliased import, then local declarations, then names imported by ano
+func (s *Server) lookup(doc *document, i int, pos Position) (declaration, *document, bool) {
+	name := doc.tokens[i].Literal
+
+	if i >= 2 && doc.tokens[i-1].Type == lexer.DOT && doc.tokens[i-2].Type == lexer.IDENT {
+		alias := doc.tokens[i-2].Literal
+		for _, imp := range doc.imports() {
+			if imp.Alias != nil && imp.Alias.Value == alias {
+				if target := s.module(doc, imp.Path.Value); target != nil {
+					if decl, ok := target.exported(name); ok {
+						return decl, target, true
+					}
+				}
+			}
+		}
+		return declaration{}, nil, false
+	}
+
+	if decl, ok := doc.resolve(name, pos); ok {
+		return decl, doc, true
+	}
+
+	for _, imp := range doc.imports() {
+		if imp.Alias != nil {
+			continue
+		}
+		if target := s.module(doc, imp.Path.Value); target != nil {
+			if decl, ok := target.exported(name); ok {
+				return decl, target, true
+			}
+		}
+	}
+	return declaration{}, nil, false
+}
+
+// module loads an imported file the way the evaluator resolves it
+// (relative to the importing file), preferring the editor's open copy
+func (s *Server) module(from *document, importPath string) *document {
+	path := packages.Resolve(filepath.Dir(from.path), importPath)
+	if !strings.HasSuffix(path, ".bang") && !strings.HasSuffix(path, ".bangla") && !strings.HasSuffix(path, ".bong") {
+		return nil
+	}
+	uri := pathToURI(path)
+	if doc, ok := s.docs[uri]; ok {
+		return doc
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return newDocument(uri, string(content))
+}
@@ -0,0 +1,151 @@
+// Package migrate implements banglacode migrate: versioned schema
+// migrations written in SQL or BanglaCode, recorded in a schema_migrations
+// table and applied one transaction at a time through the database
+// builtins (db_transaction_shuru_*, db_exec_*, db_commit_*).
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultDir is where migrations live unless --dir says otherwise
+const DefaultDir = "migrations"
+
+// Migration is one schema change. SQL migrations are a pair of
+// <version>_<name>.up.sql / .down.sql files; BanglaCode migrations are a
+// single <version>_<name>.bang file declaring kaj up(tx) and kaj down(tx).
+type Migration struct {
+	Version  string
+	Name     string
+	UpPath   string // SQL only
+	DownPath string // SQL only; empty if the migration cannot be undone
+	Script   string // BanglaCode only
+}
+
+// ID is the file name stem, e.g. 20261018093000_create_users
+func (m Migration) ID() string {
+	return m.Version + "_" + m.Name
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_]+)\.(up\.sql|down\.sql|bang|bangla|bong)$`)
+
+// Discover reads the migrations in dir, ordered by version
+func Discover(dir string) ([]Migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[string]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, name, kind := match[1], match[2], match[3]
+		path := filepath.Join(dir, entry.Name())
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("version %s is used by both %s and %s", version, m.ID(), version+"_"+name)
+		}
+
+		switch kind {
+		case "up.sql":
+			m.UpPath = path
+		case "down.sql":
+			m.DownPath = path
+		default:
+			if m.Script != "" {
+				return nil, fmt.Errorf("migration %s has more than one script", m.ID())
+			}
+			m.Script = path
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		switch {
+		case m.Script != "" && (m.UpPath != "" || m.DownPath != ""):
+			return nil, fmt.Errorf("migration %s has both a script and SQL files", m.ID())
+		case m.Script == "" && m.UpPath == "":
+			return nil, fmt.Errorf("migration %s has no .up.sql file", m.ID())
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return versionLess(migrations[i].Version, migrations[j].Version)
+	})
+	return migrations, nil
+}
+
+// versionLess compares versions numerically, so 2 sorts before 10
+func versionLess(a, b string) bool {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+var migrationName = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Create writes an empty migration stamped with now and returns the files
+// it created: an .up.sql/.down.sql pair, or one .bang file if script is set
+func Create(dir, name string, script bool, now time.Time) ([]string, error) {
+	name = strings.ReplaceAll(strings.TrimSpace(name), " ", "_")
+	if !migrationName.MatchString(name) {
+		return nil, fmt.Errorf("invalid migration name %q (use letters, digits and _)", name)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	stem := filepath.Join(dir, now.UTC().Format("20060102150405")+"_"+name)
+	files := map[string]string{
+		stem + ".up.sql":   "-- " + name + "\n",
+		stem + ".down.sql": "-- Undo " + name + "\n",
+	}
+	if script {
+		files = map[string]string{stem + ".bang": fmt.Sprintf(scriptTemplate, name)}
+	}
+
+	var created []string
+	for path := range files {
+		if _, err := os.Stat(path); err == nil {
+			return nil, fmt.Errorf("%s already exists", path)
+		}
+		created = append(created, path)
+	}
+	sort.Strings(created)
+	for _, path := range created {
+		if err := os.WriteFile(path, []byte(files[path]), 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return created, nil
+}
+
+const scriptTemplate = `// Migration: %s
+// tx is the transaction ID; pass it to db_exec_*/db_proshno_* so the
+// statements commit or roll back together with the migration.
+
+kaj up(tx) {
+}
+
+kaj down(tx) {
+}
+`
@@ -0,0 +1,91 @@
+package migrate
+
+import "strings"
+
+// SplitStatements splits a SQL script on semicolons, ignoring those inside
+// quotes, comments and PostgreSQL dollar-quoted bodies. Empty statements
+// and comment-only statements are dropped.
+func SplitStatements(script string) []string {
+	var statements []string
+	start := 0
+	hasCode := false
+
+	flush := func(end int) {
+		if hasCode {
+			statements = append(statements, strings.TrimSpace(script[start:end]))
+		}
+		start = end + 1
+		hasCode = false
+	}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == ';':
+			flush(i)
+		case c == '-' && strings.HasPrefix(script[i:], "--"):
+			i = skipUntil(script, i+2, "\n") - 1
+		case c == '/' && strings.HasPrefix(script[i:], "/*"):
+			i = skipUntil(script, i+2, "*/") - 1
+		case c == '\'' || c == '"' || c == '`':
+			i = skipQuoted(script, i, c)
+			hasCode = true
+		case c == '$':
+			if tag := dollarTag(script[i:]); tag != "" {
+				i = skipUntil(script, i+len(tag), tag) - 1
+			}
+			hasCode = true
+		case c != ' ' && c != '\t' && c != '\n' && c != '\r':
+			hasCode = true
+		}
+	}
+	flush(len(script))
+	return statements
+}
+
+// skipUntil returns the index just past the next end marker at or after
+// from, or len(s) if there is none
+func skipUntil(s string, from int, end string) int {
+	if from > len(s) {
+		return len(s)
+	}
+	idx := strings.Index(s[from:], end)
+	if idx < 0 {
+		return len(s)
+	}
+	return from + idx + len(end)
+}
+
+// skipQuoted returns the index of the closing quote of the string or
+// identifier opened at s[i]; doubled quotes and backslash escapes stay inside
+func skipQuoted(s string, i int, quote byte) int {
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case quote:
+			if j+1 < len(s) && s[j+1] == quote {
+				j++
+				continue
+			}
+			return j
+		}
+	}
+	return len(s)
+}
+
+// dollarTag returns the $tag$ opening a dollar-quoted string at the start
+// of s, or "" if s does not start one
+func dollarTag(s string) string {
+	for j := 1; j < len(s); j++ {
+		c := s[j]
+		switch {
+		case c == '$':
+			return s[:j+1]
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || j > 1 && c >= '0' && c <= '9':
+		default:
+			return ""
+		}
+	}
+	return ""
+}
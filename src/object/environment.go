@@ -9,6 +9,33 @@ type Environment struct {
 	outer     *Environment    // parent scope
 	global    *Environment    // reference to global (root) environment
 	mu        sync.RWMutex
+
+	// Variables the bytecode VM resolved to slot indices at compile time.
+	// A nil slot is a variable that has not been declared yet.
+	layout *Layout
+	slots  []Object
+}
+
+// Layout names the slots of a scope compiled by the bytecode VM, so that
+// the evaluator can still find its variables by name
+type Layout struct {
+	Names []string
+	index map[string]int
+}
+
+// NewLayout creates the layout of a scope with the given variables
+func NewLayout(names []string) *Layout {
+	index := make(map[string]int, len(names))
+	for i, name := range names {
+		index[name] = i
+	}
+	return &Layout{Names: names, index: index}
+}
+
+// Index returns the slot of a variable
+func (l *Layout) Index(name string) (int, bool) {
+	i, ok := l.index[name]
+	return i, ok
 }
 
 // NewEnvironment creates a new environment
@@ -31,6 +58,57 @@ func NewEnclosedEnvironment(outer *Environment) *Environment {
 	return env
 }
 
+// NewSlotEnvironment creates an enclosed environment whose variables are
+// stored in the slots of layout
+func NewSlotEnvironment(outer *Environment, layout *Layout) *Environment {
+	return &Environment{
+		outer:  outer,
+		global: outer.GetGlobal(),
+		layout: layout,
+		slots:  make([]Object, len(layout.Names)),
+	}
+}
+
+// Layout returns the slot layout of the environment, or nil if it has none
+func (e *Environment) Layout() *Layout {
+	return e.layout
+}
+
+// Slot returns the variable in slot i. Slots are only read and written by
+// the VM frame that owns them, which holds the event loop like all script
+// code, so they are not locked.
+func (e *Environment) Slot(i int) Object {
+	return e.slots[i]
+}
+
+// SetSlot assigns the variable in slot i
+func (e *Environment) SetSlot(i int, val Object) {
+	e.slots[i] = val
+}
+
+// slot returns the index of a declared slot variable; callers hold e.mu
+func (e *Environment) slot(name string) (int, bool) {
+	if e.layout == nil {
+		return 0, false
+	}
+	i, ok := e.layout.index[name]
+	return i, ok && e.slots[i] != nil
+}
+
+// put stores a variable in its slot or the store; callers hold e.mu
+func (e *Environment) put(name string, val Object) {
+	if e.layout != nil {
+		if i, ok := e.layout.index[name]; ok {
+			e.slots[i] = val
+			return
+		}
+	}
+	if e.store == nil {
+		e.store = make(map[string]Object)
+	}
+	e.store[name] = val
+}
+
 // GetGlobal returns the global (root) environment
 func (e *Environment) GetGlobal() *Environment {
 	if e.global != nil {
@@ -39,10 +117,18 @@ func (e *Environment) GetGlobal() *Environment {
 	return e
 }
 
+// Outer returns the enclosing scope, or nil for the global environment
+func (e *Environment) Outer() *Environment {
+	return e.outer
+}
+
 // Get retrieves a variable from the environment
 func (e *Environment) Get(name string) (Object, bool) {
 	e.mu.RLock()
 	obj, ok := e.store[name]
+	if i, declared := e.slot(name); declared {
+		obj, ok = e.slots[i], true
+	}
 	outer := e.outer
 	e.mu.RUnlock()
 	if ok {
@@ -57,7 +143,7 @@ func (e *Environment) Get(name string) (Object, bool) {
 // Set assigns a variable in the environment
 func (e *Environment) Set(name string, val Object) Object {
 	e.mu.Lock()
-	e.store[name] = val
+	e.put(name, val)
 	e.mu.Unlock()
 	return val
 }
@@ -65,7 +151,10 @@ func (e *Environment) Set(name string, val Object) Object {
 // SetConstant assigns a constant in the environment
 func (e *Environment) SetConstant(name string, val Object) Object {
 	e.mu.Lock()
-	e.store[name] = val
+	e.put(name, val)
+	if e.constants == nil {
+		e.constants = make(map[string]bool)
+	}
 	e.constants[name] = true
 	e.mu.Unlock()
 	return val
@@ -75,7 +164,7 @@ func (e *Environment) SetConstant(name string, val Object) Object {
 func (e *Environment) SetGlobal(name string, val Object) Object {
 	global := e.GetGlobal()
 	global.mu.Lock()
-	global.store[name] = val
+	global.put(name, val)
 	global.mu.Unlock()
 	return val
 }
@@ -99,8 +188,8 @@ func (e *Environment) IsConstant(name string) bool {
 func (e *Environment) Update(name string, val Object) Object {
 	e.mu.Lock()
 	_, ok := e.store[name]
-	if ok {
-		e.store[name] = val
+	if _, declared := e.slot(name); ok || declared {
+		e.put(name, val)
 		e.mu.Unlock()
 		return val
 	}
@@ -110,7 +199,7 @@ func (e *Environment) Update(name string, val Object) Object {
 		return outer.Update(name, val)
 	}
 	e.mu.Lock()
-	e.store[name] = val
+	e.put(name, val)
 	e.mu.Unlock()
 	return val
 }
@@ -123,5 +212,10 @@ func (e *Environment) All() map[string]Object {
 	for k, v := range e.store {
 		out[k] = v
 	}
+	for i, v := range e.slots {
+		if v != nil {
+			out[e.layout.Names[i]] = v
+		}
+	}
 	return out
 }
@@ -2,10 +2,15 @@ package object
 
 import (
 	"BanglaCode/src/ast"
+	"BanglaCode/src/eventloop"
 	"bytes"
+	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"time"
+	"unicode"
 )
 
 // ObjectType represents the type of an object
@@ -45,6 +50,7 @@ const (
 	SET_OBJ             = "SET"
 	ES6MAP_OBJ          = "ES6MAP"
 	GENERATOR_OBJ       = "GENERATOR"
+	ABORT_SIGNAL_OBJ    = "ABORT_SIGNAL"
 )
 
 // Object represents any runtime value
@@ -103,6 +109,7 @@ type StackFrame struct {
 type Error struct {
 	Message   string
 	ErrorType ObjectType // ERROR_OBJ, TYPE_ERROR_OBJ, REFERENCE_ERROR_OBJ, etc.
+	File      string     // source file the error was raised in, if known
 	Line      int
 	Column    int
 	Stack     []StackFrame
@@ -130,6 +137,9 @@ func (e *Error) Inspect() string {
 		errorTypeName = "Error"
 	}
 
+	if e.Line > 0 && e.File != "" {
+		return fmt.Sprintf("%s [%s:%d:%d]: %s", errorTypeName, e.File, e.Line, e.Column, e.Message)
+	}
 	if e.Line > 0 {
 		return fmt.Sprintf("%s [line %d, col %d]: %s", errorTypeName, e.Line, e.Column, e.Message)
 	}
@@ -167,6 +177,71 @@ func (e *Error) GetStack() string {
 	return buf.String()
 }
 
+// Excerpt renders the source line the error points at, with the
+// offending token underlined:
+//
+//	3 | dekho(x);
+//	  |       ^
+func (e *Error) Excerpt(source string) string {
+	return SourceExcerpt(source, e.Line, e.Column, 0)
+}
+
+// SourceExcerpt renders line of source with a caret under column. The
+// underline is width characters wide, or covers the word at column when
+// width is 0. It returns "" when line is outside the source.
+func SourceExcerpt(source string, line, column, width int) string {
+	lines := strings.Split(source, "\n")
+	if line <= 0 || line > len(lines) {
+		return ""
+	}
+
+	text := strings.TrimRight(lines[line-1], "\r")
+	runes := []rune(text) // columns count runes
+	start := column - 1
+	if start < 0 || start > len(runes) {
+		start = 0
+	}
+
+	// Keep tabs in the padding so the caret lines up with the source;
+	// Bengali vowel signs and the hasanta take no space of their own
+	var pad strings.Builder
+	for _, ch := range runes[:start] {
+		switch {
+		case ch == '\t':
+			pad.WriteByte('\t')
+		case !zeroWidth(ch):
+			pad.WriteByte(' ')
+		}
+	}
+
+	if width <= 0 {
+		width = 1
+		for start+width < len(runes) && isWordRune(runes[start]) && isWordRune(runes[start+width]) {
+			width++
+		}
+	}
+	cells := 0
+	for _, ch := range runes[start:min(start+width, len(runes))] {
+		if !zeroWidth(ch) {
+			cells++
+		}
+	}
+
+	gutter := fmt.Sprintf("%4d | ", line)
+	return fmt.Sprintf("%s%s\n%s| %s^%s", gutter, text,
+		strings.Repeat(" ", len(gutter)-2), pad.String(), strings.Repeat("~", max(cells-1, 0)))
+}
+
+func isWordRune(ch rune) bool {
+	return ch == '_' || unicode.IsLetter(ch) || unicode.IsDigit(ch) || unicode.IsMark(ch)
+}
+
+// zeroWidth reports combining characters that a terminal draws on top of
+// the previous character
+func zeroWidth(ch rune) bool {
+	return unicode.In(ch, unicode.Mn, unicode.Me) || ch == '\u200c' || ch == '\u200d'
+}
+
 // NewError creates a generic error
 func NewError(message string) *Error {
 	return &Error{
@@ -219,7 +294,7 @@ func (e *Error) AddStackFrame(function, file string, line, column int) {
 
 // Function represents a user-defined function
 type Function struct {
-	Parameters    []*ast.Identifier
+	Parameters    []*ast.Parameter
 	RestParameter *ast.Identifier // optional rest parameter (...args)
 	Body          *ast.BlockStatement
 	Env           *Environment
@@ -231,13 +306,6 @@ type Function struct {
 func (f *Function) Type() ObjectType { return FUNCTION_OBJ }
 func (f *Function) Inspect() string {
 	var out bytes.Buffer
-	params := []string{}
-	for _, p := range f.Parameters {
-		params = append(params, p.String())
-	}
-	if f.RestParameter != nil {
-		params = append(params, "..."+f.RestParameter.String())
-	}
 	if f.IsGenerator {
 		out.WriteString("kaj*")
 	} else {
@@ -247,7 +315,7 @@ func (f *Function) Inspect() string {
 		out.WriteString(" " + f.Name)
 	}
 	out.WriteString("(")
-	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(ast.FormatParameters(f.Parameters, f.RestParameter))
 	out.WriteString(") {\n")
 	out.WriteString(f.Body.String())
 	out.WriteString("\n}")
@@ -257,9 +325,11 @@ func (f *Function) Inspect() string {
 // BuiltinFunction represents a built-in function
 type BuiltinFunction func(args ...Object) Object
 
-// Builtin wraps a built-in function
+// Builtin wraps a built-in function. Builtins that double as a namespace,
+// such as Promise, keep their static members in Members.
 type Builtin struct {
-	Fn BuiltinFunction
+	Fn      BuiltinFunction
+	Members map[string]Object
 }
 
 func (b *Builtin) Type() ObjectType { return BUILTIN_OBJ }
@@ -283,17 +353,140 @@ func (a *Array) Inspect() string {
 	return out.String()
 }
 
-// Map represents a hash map/object
+// Map represents a hash map/object whose keys enumerate in insertion order
 type Map struct {
-	Pairs map[string]Object
+	Pairs   map[string]Object // lookup only; write through Set and Delete to keep the order
+	order   []string          // keys in insertion order, including deleted ones until compacted
+	pos     map[string]int    // index in order of each live key
+	indexes int               // live integer-like keys, which enumerate first
+}
+
+// NewMap creates an empty map
+func NewMap() *Map {
+	return &Map{Pairs: make(map[string]Object), pos: make(map[string]int)}
+}
+
+// NewMapFrom creates a map holding pairs. A Go map has no order, so the
+// keys are inserted in sorted order to enumerate the same way every run.
+func NewMapFrom(pairs map[string]Object) *Map {
+	keys := make([]string, 0, len(pairs))
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	m := NewMap()
+	for _, k := range keys {
+		m.Set(k, pairs[k])
+	}
+	return m
+}
+
+// Set adds or replaces a pair; a new key is enumerated after the existing ones
+func (m *Map) Set(key string, val Object) {
+	if m.Pairs == nil {
+		m.Pairs = make(map[string]Object)
+	}
+	if m.pos == nil {
+		m.pos = make(map[string]int)
+	}
+	if _, exists := m.pos[key]; !exists {
+		m.pos[key] = len(m.order)
+		m.order = append(m.order, key)
+		if _, ok := ArrayIndex(key); ok {
+			m.indexes++
+		}
+	}
+	m.Pairs[key] = val
+}
+
+// Delete removes a pair
+func (m *Map) Delete(key string) {
+	delete(m.Pairs, key)
+	if _, exists := m.pos[key]; !exists {
+		return
+	}
+	delete(m.pos, key)
+	if _, ok := ArrayIndex(key); ok {
+		m.indexes--
+	}
+	// The key stays in order as a dead entry; compact once they dominate
+	if len(m.order) > 2*len(m.pos)+8 {
+		m.compact()
+	}
+}
+
+// compact drops dead entries from order
+func (m *Map) compact() {
+	live := make([]string, 0, len(m.pos))
+	for i, k := range m.order {
+		if p, exists := m.pos[k]; exists && p == i {
+			m.pos[k] = len(live)
+			live = append(live, k)
+		}
+	}
+	m.order = live
+}
+
+// Len returns the number of pairs whose order is tracked, which is every
+// pair as long as all writes go through Set and Delete
+func (m *Map) Len() int {
+	return len(m.pos)
+}
+
+// Keys returns the keys in JavaScript enumeration order: integer-like keys
+// in ascending order, then the rest in insertion order
+func (m *Map) Keys() []string {
+	keys := make([]string, 0, len(m.pos))
+	var indexes []string
+	for i, k := range m.order {
+		if p, exists := m.pos[k]; !exists || p != i {
+			continue
+		}
+		if m.indexes > 0 {
+			if _, ok := ArrayIndex(k); ok {
+				indexes = append(indexes, k)
+				continue
+			}
+		}
+		keys = append(keys, k)
+	}
+	if len(indexes) == 0 {
+		return keys
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		a, _ := ArrayIndex(indexes[i])
+		b, _ := ArrayIndex(indexes[j])
+		return a < b
+	})
+	return append(indexes, keys...)
+}
+
+// ArrayIndex reports whether key is integer-like (a canonical array index
+// such as "0" or "42", but not "01" or "-1") and returns its value
+func ArrayIndex(key string) (uint32, bool) {
+	if key == "" || len(key) > 10 || (len(key) > 1 && key[0] == '0') {
+		return 0, false
+	}
+	var n uint64
+	for i := 0; i < len(key); i++ {
+		if key[i] < '0' || key[i] > '9' {
+			return 0, false
+		}
+		n = n*10 + uint64(key[i]-'0')
+	}
+	if n >= 1<<32-1 {
+		return 0, false
+	}
+	return uint32(n), true
 }
 
 func (m *Map) Type() ObjectType { return MAP_OBJ }
 func (m *Map) Inspect() string {
 	var out bytes.Buffer
 	pairs := []string{}
-	for key, value := range m.Pairs {
-		pairs = append(pairs, fmt.Sprintf("%s: %s", key, value.Inspect()))
+	for _, key := range m.Keys() {
+		pairs = append(pairs, fmt.Sprintf("%s: %s", key, m.Pairs[key].Inspect()))
 	}
 	out.WriteString("{")
 	out.WriteString(strings.Join(pairs, ", "))
@@ -305,11 +498,16 @@ func (m *Map) Inspect() string {
 type Class struct {
 	Name             string
 	Methods          map[string]*Function
-	Getters          map[string]*Function // getter methods
-	Setters          map[string]*Function // setter methods
-	StaticProperties map[string]Object    // static properties
+	Getters          map[string]*Function    // getter methods
+	Setters          map[string]*Function    // setter methods
+	StaticProperties map[string]Object       // static properties
+	NativeMethods    map[string]NativeMethod // Go-implemented methods, e.g. from db_model
 }
 
+// NativeMethod is an instance method implemented in Go; inst is the
+// receiver (ei)
+type NativeMethod func(inst *Instance, args ...Object) Object
+
 func (c *Class) Type() ObjectType { return CLASS_OBJ }
 func (c *Class) Inspect() string  { return "sreni " + c.Name }
 
@@ -323,14 +521,18 @@ type Instance struct {
 func (i *Instance) Type() ObjectType { return INSTANCE_OBJ }
 func (i *Instance) Inspect() string  { return i.Class.Name + " er udahoron" }
 
-// Break represents a break statement
-type Break struct{}
+// Break represents a break statement; Label is set for thamo label
+type Break struct {
+	Label string
+}
 
 func (b *Break) Type() ObjectType { return BREAK_OBJ }
 func (b *Break) Inspect() string  { return "thamo" }
 
-// Continue represents a continue statement
-type Continue struct{}
+// Continue represents a continue statement; Label is set for chharo label
+type Continue struct {
+	Label string
+}
 
 func (c *Continue) Type() ObjectType { return CONTINUE_OBJ }
 func (c *Continue) Inspect() string  { return "chharo" }
@@ -375,6 +577,9 @@ type Promise struct {
 	ResultChan chan Object // for goroutine communication
 	ErrorChan  chan Object // for error communication
 	Mu         sync.RWMutex
+	callbacks  []func() // run as microtasks once the promise settles
+	external   bool     // settled off the loop, which is kept alive until then
+	handled    bool     // something reacts to the promise, so a rejection is not reported
 }
 
 func (p *Promise) Type() ObjectType { return PROMISE_OBJ }
@@ -397,8 +602,46 @@ func (p *Promise) Inspect() string {
 	}
 }
 
-// CreatePromise creates a new pending promise with channels
+// Settled reports whether the promise has resolved or rejected
+func (p *Promise) Settled() bool {
+	p.Mu.RLock()
+	defer p.Mu.RUnlock()
+	return p.State != PROMISE_PENDING
+}
+
+// OnSettle queues callback as a microtask once the promise settles, or
+// straight away if it already has. The callback handles the promise: if it
+// rejects, the rejection is not reported as unhandled.
+func (p *Promise) OnSettle(callback func()) {
+	p.Mu.Lock()
+	p.handled = true
+	p.Mu.Unlock()
+	p.Observe(callback)
+}
+
+// Observe is OnSettle for bookkeeping, such as clearing a timer, that does
+// not handle a rejection
+func (p *Promise) Observe(callback func()) {
+	p.Mu.Lock()
+	defer p.Mu.Unlock()
+	if p.State == PROMISE_PENDING {
+		p.callbacks = append(p.callbacks, callback)
+		return
+	}
+	eventloop.QueueMicrotask(callback)
+}
+
+// CreatePromise creates a pending promise for work done off the event loop,
+// e.g. by a goroutine doing I/O; the loop stays alive until it settles
 func CreatePromise() *Promise {
+	eventloop.Ref()
+	promise := NewPromise()
+	promise.external = true
+	return promise
+}
+
+// NewPromise creates a pending promise that code on the event loop settles
+func NewPromise() *Promise {
 	return &Promise{
 		State:      PROMISE_PENDING,
 		ResultChan: make(chan Object, 1),
@@ -406,28 +649,308 @@ func CreatePromise() *Promise {
 	}
 }
 
-// ResolvePromise resolves a promise with a value
+// ResolvePromise resolves a promise with a value; settling twice is a no-op
 func ResolvePromise(promise *Promise, value Object) {
-	promise.Mu.Lock()
-	promise.State = PROMISE_RESOLVED
-	promise.Value = value
-	promise.Mu.Unlock()
-	promise.ResultChan <- value
+	promise.settle(PROMISE_RESOLVED, value)
 }
 
-// RejectPromise rejects a promise with an error
+// RejectPromise rejects a promise with an error; settling twice is a no-op
 func RejectPromise(promise *Promise, err Object) {
+	promise.settle(PROMISE_REJECTED, err)
+}
+
+func (p *Promise) settle(state PromiseState, value Object) {
+	p.Mu.Lock()
+	if p.State != PROMISE_PENDING {
+		p.Mu.Unlock()
+		return
+	}
+	p.State = state
+	ch := p.ResultChan
+	if state == PROMISE_RESOLVED {
+		p.Value = value
+	} else {
+		p.Error = value
+		ch = p.ErrorChan
+	}
+	callbacks := p.callbacks
+	p.callbacks = nil
+	unhandled := state == PROMISE_REJECTED && !p.handled
+	p.Mu.Unlock()
+
+	if unhandled {
+		trackRejection(p)
+	}
+	ch <- value
+	for _, callback := range callbacks {
+		eventloop.QueueMicrotask(callback)
+	}
+	if p.external {
+		eventloop.Unref()
+	}
+}
+
+// Result returns what a settled promise holds and whether it was rejected
+func (p *Promise) Result() (Object, bool) {
+	p.Mu.RLock()
+	defer p.Mu.RUnlock()
+	if p.State == PROMISE_REJECTED {
+		return p.Error, true
+	}
+	return p.Value, false
+}
+
+// Rejections that nothing has handled yet, reported at the next microtask
+// checkpoint
+var (
+	rejectionsMu        sync.Mutex
+	pendingRejections   []*Promise
+	onUnhandledRejected func([]*Promise)
+)
+
+// SetUnhandledRejectionHandler sets fn to receive the promises that are
+// still rejected without a handler at the end of a microtask checkpoint,
+// like Node's unhandledRejection. A nil fn ignores them, which is the
+// default for embedders such as tests and the REPL.
+func SetUnhandledRejectionHandler(fn func([]*Promise)) {
+	rejectionsMu.Lock()
+	onUnhandledRejected = fn
+	pendingRejections = nil
+	rejectionsMu.Unlock()
+	eventloop.OnCheckpoint(reportRejections)
+}
+
+func trackRejection(p *Promise) {
+	rejectionsMu.Lock()
+	if onUnhandledRejected != nil {
+		pendingRejections = append(pendingRejections, p)
+	}
+	rejectionsMu.Unlock()
+}
+
+// reportRejections passes the tracked rejections that are still unhandled
+// to the handler
+func reportRejections() {
+	rejectionsMu.Lock()
+	pending, fn := pendingRejections, onUnhandledRejected
+	pendingRejections = nil
+	rejectionsMu.Unlock()
+
+	var unhandled []*Promise
+	for _, p := range pending {
+		p.Mu.RLock()
+		if !p.handled {
+			unhandled = append(unhandled, p)
+		}
+		p.Mu.RUnlock()
+	}
+	if fn != nil && len(unhandled) > 0 {
+		fn(unhandled)
+	}
+}
+
+// ResolveWith resolves promise with value; when value is itself a
+// promise, promise follows it and settles the same way
+func ResolveWith(promise *Promise, value Object) {
+	inner, ok := value.(*Promise)
+	if !ok {
+		ResolvePromise(promise, value)
+		return
+	}
+	if inner == promise {
+		RejectPromise(promise, NewException("TypeError", "a promise cannot resolve to itself"))
+		return
+	}
+	inner.OnSettle(func() { SettleLike(promise, inner) })
+}
+
+// SettleLike settles promise the same way as the settled promise source
+func SettleLike(promise, source *Promise) {
+	if result, rejected := source.Result(); rejected {
+		RejectPromise(promise, result)
+	} else {
+		ResolvePromise(promise, result)
+	}
+}
+
+// NewException creates a catchable exception whose value is an error map
+// {name, message}, as if Error(message) had been thrown
+func NewException(name, message string) *Exception {
+	return &Exception{
+		Message: name + ": " + message,
+		Value:   errorMap(name, message),
+	}
+}
+
+// errorMap builds the {name, message, stack} value of a thrown error
+func errorMap(name, message string) *Map {
+	m := NewMap()
+	m.Set("name", &String{Value: name})
+	m.Set("message", &String{Value: message})
+	m.Set("stack", &String{Value: ""})
+	return m
+}
+
+// Rejection turns a value passed to reject() into what a rejected promise
+// holds: errors and exceptions as they are, anything else as if thrown
+func Rejection(value Object) Object {
+	switch v := value.(type) {
+	case *Error, *Exception:
+		return v
+	case *String:
+		return &Exception{Message: v.Value, Value: v}
+	}
+	return &Exception{Message: value.Inspect(), Value: value}
+}
+
+// RejectionReason is what a rejection handler receives: the thrown value,
+// or the message of an error
+func RejectionReason(err Object) Object {
+	switch e := err.(type) {
+	case *Exception:
+		if e.Value != nil {
+			return e.Value
+		}
+		return &String{Value: e.Message}
+	case *Error:
+		return &String{Value: e.Message}
+	}
+	return err
+}
+
+// AwaitPromise waits for the promise to settle while the event loop keeps
+// running, and returns its value or the error it was rejected with. It
+// returns an *Error if the promise can no longer settle or does not within
+// timeout (when non-zero).
+func AwaitPromise(promise *Promise, timeout time.Duration) Object {
 	promise.Mu.Lock()
-	promise.State = PROMISE_REJECTED
-	promise.Error = err
+	promise.handled = true
 	promise.Mu.Unlock()
-	promise.ErrorChan <- err
+	if !eventloop.Wait(promise.Settled, promise.OnSettle, timeout) {
+		if timeout > 0 {
+			return &Error{Message: fmt.Sprintf("promise did not settle within %s", timeout)}
+		}
+		return &Error{Message: "opekha (await) on a promise that can never settle: nothing is left to run"}
+	}
+	promise.Mu.RLock()
+	defer promise.Mu.RUnlock()
+	if promise.State == PROMISE_REJECTED {
+		return promise.Error
+	}
+	return promise.Value
+}
+
+// AbortSignal tells an operation to give up; AbortController's abort()
+// triggers it. Timers, HTTP requests and database calls accept one as
+// their last argument.
+type AbortSignal struct {
+	Aborted   bool
+	Reason    Object // what was passed to abort(), an AbortError by default
+	listeners map[int]func()
+	nextID    int
+	Mu        sync.Mutex
+}
+
+func (s *AbortSignal) Type() ObjectType { return ABORT_SIGNAL_OBJ }
+func (s *AbortSignal) Inspect() string {
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	if s.Aborted {
+		return "AbortSignal(aborted)"
+	}
+	return "AbortSignal"
+}
+
+// Abort triggers the signal and runs its listeners; aborting twice is a
+// no-op
+func (s *AbortSignal) Abort(reason Object) {
+	s.Mu.Lock()
+	if s.Aborted {
+		s.Mu.Unlock()
+		return
+	}
+	if reason == nil || reason == NULL {
+		reason = NewException("AbortError", "the operation was aborted").Value
+	}
+	s.Aborted = true
+	s.Reason = reason
+	listeners := s.listeners
+	s.listeners = nil
+	s.Mu.Unlock()
+
+	for id := 0; id < s.nextID; id++ {
+		if listener, ok := listeners[id]; ok {
+			listener()
+		}
+	}
+}
+
+// OnAbort calls listener when the signal aborts, or straight away if it
+// already has; the returned function unregisters it
+func (s *AbortSignal) OnAbort(listener func()) (remove func()) {
+	s.Mu.Lock()
+	if s.Aborted {
+		s.Mu.Unlock()
+		listener()
+		return func() {}
+	}
+	if s.listeners == nil {
+		s.listeners = map[int]func(){}
+	}
+	id := s.nextID
+	s.nextID++
+	s.listeners[id] = listener
+	s.Mu.Unlock()
+
+	return func() {
+		s.Mu.Lock()
+		delete(s.listeners, id)
+		s.Mu.Unlock()
+	}
+}
+
+// Context returns a context that is cancelled when the signal aborts, for
+// handing to drivers; a nil signal gives a context that never is. Call
+// stop once the operation has finished.
+func (s *AbortSignal) Context() (ctx context.Context, stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if s == nil {
+		return ctx, cancel
+	}
+	remove := s.OnAbort(cancel)
+	return ctx, func() {
+		remove()
+		cancel()
+	}
+}
+
+// TakeSignal splits an optional trailing AbortSignal off a builtin's
+// arguments
+func TakeSignal(args []Object) ([]Object, *AbortSignal) {
+	if len(args) > 0 {
+		if signal, ok := args[len(args)-1].(*AbortSignal); ok {
+			return args[:len(args)-1], signal
+		}
+	}
+	return args, nil
+}
+
+// RejectOnAbort rejects promise with the signal's reason as soon as it
+// aborts; a nil signal does nothing
+func RejectOnAbort(promise *Promise, signal *AbortSignal) {
+	if signal == nil {
+		return
+	}
+	remove := signal.OnAbort(func() {
+		RejectPromise(promise, Rejection(signal.Reason))
+	})
+	promise.Observe(remove)
 }
 
 // DBConnection represents a database connection
 type DBConnection struct {
 	ID       string            // Unique connection identifier
-	DBType   string            // Database type: "postgres", "mysql", "mongodb", "redis"
+	DBType   string            // Database type: "postgres", "mysql", "sqlite", "mongodb", "redis"
 	Native   interface{}       // Underlying driver connection (*sql.DB, *mongo.Client, *redis.Client)
 	PoolID   string            // Reference to connection pool (if pooled)
 	Metadata map[string]Object // Connection metadata (host, port, database, etc.)
@@ -444,6 +967,7 @@ func (d *DBConnection) Inspect() string {
 // DBResult represents a database query result
 type DBResult struct {
 	Rows         []map[string]Object // Result rows as maps (column name -> value)
+	Columns      []string            // Column names in select order (empty for document stores)
 	RowsAffected int64               // Rows affected by INSERT/UPDATE/DELETE
 	LastInsertID int64               // Last inserted ID (for SQL databases)
 	Error        *Error              // Query error (if any)
@@ -457,6 +981,17 @@ func (d *DBResult) Inspect() string {
 	return fmt.Sprintf("DB_RESULT(rows=%d, affected=%d)", len(d.Rows), d.RowsAffected)
 }
 
+// Row returns row i as a map whose keys enumerate in column order
+func (d *DBResult) Row(i int) *Map {
+	row := NewMap()
+	for _, col := range d.Columns {
+		if val, ok := d.Rows[i][col]; ok {
+			row.Set(col, val)
+		}
+	}
+	return row
+}
+
 // DBPool represents a connection pool
 type DBPool struct {
 	ID          string            // Unique pool identifier
@@ -570,6 +1105,21 @@ type Stream struct {
 	OnEnd         *Function    // End event handler
 	OnError       *Function    // Error event handler
 	Mu            sync.RWMutex // Thread-safe access
+	waiters       []func()
+}
+
+// OnChange queues callback as a microtask the next time data is written to
+// the stream or it ends or closes. The caller must hold Mu.
+func (s *Stream) OnChange(callback func()) {
+	s.waiters = append(s.waiters, callback)
+}
+
+// Notify wakes everything waiting in OnChange. The caller must hold Mu.
+func (s *Stream) Notify() {
+	for _, callback := range s.waiters {
+		eventloop.QueueMicrotask(callback)
+	}
+	s.waiters = nil
 }
 
 func (s *Stream) Type() ObjectType { return STREAM_OBJ }
@@ -662,18 +1212,59 @@ func (m *ES6Map) Inspect() string {
 	return out.String()
 }
 
-// Generator represents a generator object
+// Generator represents a generator object. Its body runs on a goroutine
+// of its own that hands control back and forth with the caller: the
+// caller sends on Resume and waits on Yield, the body does the opposite,
+// so only one of them runs at a time.
 type Generator struct {
 	Function *Function    // The generator function
 	Env      *Environment // Execution environment
 	State    string       // "suspended", "executing", "completed"
 	Value    Object       // Last yielded/returned value
-	Index    int          // Current execution position (statement index)
 	Done     bool         // Whether generator is exhausted
+
+	Resume chan GeneratorSignal // caller to body; nil until the body starts
+	Yield  chan GeneratorSignal // body to caller
+
+	// An async generator (proyash kaj*) answers next(), return() and
+	// throw() with promises and runs those requests one after another:
+	// Pending settles once the most recent request has finished
+	Async   bool
+	Pending *Promise
+}
+
+// GeneratorMode says what a GeneratorSignal carries
+type GeneratorMode int
+
+const (
+	GeneratorNext   GeneratorMode = iota // next(v) sent in; a yielded value out
+	GeneratorThrow                       // throw(e) sent in; an uncaught error out
+	GeneratorReturn                      // return(v) sent in; the body's result out
+)
+
+// GeneratorSignal passes control, and a value, across a generator's
+// suspension point
+type GeneratorSignal struct {
+	Mode  GeneratorMode
+	Value Object
+}
+
+// IteratorResult builds the {value, done} map that next() returns
+func IteratorResult(value Object, done bool) *Map {
+	if value == nil {
+		value = NULL
+	}
+	m := NewMap()
+	m.Set("value", value)
+	m.Set("done", NativeBoolToBooleanObject(done))
+	return m
 }
 
 func (g *Generator) Type() ObjectType { return GENERATOR_OBJ }
 func (g *Generator) Inspect() string {
+	if g.Async {
+		return fmt.Sprintf("AsyncGenerator(state=%s, done=%t)", g.State, g.Done)
+	}
 	return fmt.Sprintf("Generator(state=%s, done=%t)", g.State, g.Done)
 }
 
@@ -0,0 +1,317 @@
+package packages
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// installedFile records which archive each directory of bangla_modules/
+// was extracted from, so install can skip packages that are up to date
+const installedFile = ".installed.json"
+
+// Project is a directory with a banglacode.json manifest
+type Project struct {
+	Dir      string
+	Manifest *Manifest
+	// RegistryLocation overrides the manifest's registry (--registry or
+	// BANGLACODE_REGISTRY)
+	RegistryLocation string
+	Out              io.Writer // progress messages; nil for none
+
+	registry Registry
+}
+
+// OpenProject loads the manifest in dir
+func OpenProject(dir string) (*Project, error) {
+	m, err := LoadManifest(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	return &Project{Dir: dir, Manifest: m, RegistryLocation: os.Getenv("BANGLACODE_REGISTRY")}, nil
+}
+
+// InitProject creates a manifest named after dir if there is none yet
+func InitProject(dir string) (*Project, error) {
+	path := filepath.Join(dir, ManifestFile)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.ToLower(filepath.Base(abs))
+		if !ValidName(name) {
+			name = "amar-app"
+		}
+		m := &Manifest{Name: name, Version: "1.0.0", Main: DefaultMain}
+		if err := m.Save(path); err != nil {
+			return nil, err
+		}
+	}
+	return OpenProject(dir)
+}
+
+func (p *Project) logf(format string, args ...any) {
+	if p.Out != nil {
+		fmt.Fprintf(p.Out, format+"\n", args...)
+	}
+}
+
+// Registry opens the project's registry on first use
+func (p *Project) Registry() (Registry, error) {
+	if p.registry == nil {
+		location := p.RegistryLocation
+		if location == "" {
+			location = p.Manifest.Registry
+		}
+		reg, err := OpenRegistry(location, p.Dir)
+		if err != nil {
+			return nil, err
+		}
+		p.registry = reg
+	}
+	return p.registry, nil
+}
+
+// ==================== Commands ====================
+
+// Install makes bangla_modules/ match the manifest. Versions pinned in
+// banglacode.lock are kept while they still satisfy the manifest; other
+// packages get the newest matching version. Every archive is checked
+// against its recorded SHA-256 before it is extracted.
+func (p *Project) Install() error {
+	lockPath := filepath.Join(p.Dir, LockFile)
+	lock, err := LoadLockfile(lockPath)
+	if err != nil {
+		return err
+	}
+	resolved, err := p.resolve(lock)
+	if err != nil {
+		return err
+	}
+
+	modules := filepath.Join(p.Dir, ModulesDir)
+	installed := readInstalled(modules)
+	for _, name := range resolved.Names() {
+		pkg := resolved.Packages[name]
+		dest := filepath.Join(modules, name)
+		if installed[name] == pkg.Integrity && isFile(filepath.Join(dest, ManifestFile)) {
+			continue
+		}
+		if err := p.fetch(name, pkg, dest); err != nil {
+			return err
+		}
+		installed[name] = pkg.Integrity
+		p.logf("+ %s@%s", name, pkg.Version)
+	}
+
+	// Remove packages nothing depends on any more
+	for name := range installed {
+		if _, ok := resolved.Packages[name]; !ok {
+			if err := os.RemoveAll(filepath.Join(modules, name)); err != nil {
+				return err
+			}
+			delete(installed, name)
+			p.logf("- %s", name)
+		}
+	}
+
+	state := filepath.Join(modules, installedFile)
+	if len(installed) > 0 {
+		err = writeJSON(state, installed)
+	} else {
+		err = os.Remove(state)
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return resolved.Save(lockPath)
+}
+
+// Add adds dependencies given as "name" or "name@constraint" to the
+// manifest and installs them. Without a constraint the newest version is
+// required with ^.
+func (p *Project) Add(specs []string) error {
+	for _, spec := range specs {
+		name, req, _ := strings.Cut(spec, "@")
+		reg, err := p.Registry()
+		if err != nil {
+			return err
+		}
+		idx, err := reg.Index(name)
+		if err != nil {
+			return err
+		}
+		c, err := ParseConstraint(req)
+		if err != nil {
+			return err
+		}
+		v, _, ok := idx.Best(c)
+		if !ok {
+			return noMatch(name, c, idx)
+		}
+		if req == "" {
+			req = "^" + v.String()
+		}
+		if p.Manifest.Dependencies == nil {
+			p.Manifest.Dependencies = map[string]string{}
+		}
+		p.Manifest.Dependencies[name] = req
+	}
+	if err := p.Manifest.Save(filepath.Join(p.Dir, ManifestFile)); err != nil {
+		return err
+	}
+	return p.Install()
+}
+
+// Remove drops dependencies from the manifest and uninstalls whatever is
+// no longer needed
+func (p *Project) Remove(names []string) error {
+	for _, name := range names {
+		if _, ok := p.Manifest.Dependencies[name]; !ok {
+			return fmt.Errorf("%s is not a dependency of %s", name, p.Manifest.Name)
+		}
+		delete(p.Manifest.Dependencies, name)
+	}
+	if err := p.Manifest.Save(filepath.Join(p.Dir, ManifestFile)); err != nil {
+		return err
+	}
+	return p.Install()
+}
+
+// ==================== Resolution ====================
+
+type requirement struct {
+	name, by   string
+	constraint Constraint
+}
+
+// resolve picks one version of every package the manifest needs, directly
+// or through other packages. bangla_modules/ is flat, so two packages that
+// need incompatible versions of a third are an error.
+func (p *Project) resolve(lock *Lockfile) (*Lockfile, error) {
+	result := &Lockfile{LockfileVersion: 1, Packages: map[string]LockedPackage{}}
+	requiredBy := map[string][]requirement{}
+
+	var queue []requirement
+	enqueue := func(deps map[string]string, by string) error {
+		names := make([]string, 0, len(deps))
+		for name := range deps {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			c, err := ParseConstraint(deps[name])
+			if err != nil {
+				return fmt.Errorf("%s: dependency %q: %v", by, name, err)
+			}
+			queue = append(queue, requirement{name: name, by: by, constraint: c})
+		}
+		return nil
+	}
+	if err := enqueue(p.Manifest.Dependencies, ManifestFile); err != nil {
+		return nil, err
+	}
+
+	for len(queue) > 0 {
+		req := queue[0]
+		queue = queue[1:]
+		requiredBy[req.name] = append(requiredBy[req.name], req)
+
+		if picked, ok := result.Packages[req.name]; ok {
+			v, _ := ParseVersion(picked.Version)
+			if !req.constraint.Allows(v) {
+				return nil, conflict(req.name, requiredBy[req.name])
+			}
+			continue
+		}
+
+		pkg, err := p.pick(req, lock)
+		if err != nil {
+			return nil, err
+		}
+		result.Packages[req.name] = pkg
+		if err := enqueue(pkg.Dependencies, req.name+"@"+pkg.Version); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// pick chooses the version for a requirement: the locked one if it still
+// fits, otherwise the newest matching version in the registry
+func (p *Project) pick(req requirement, lock *Lockfile) (LockedPackage, error) {
+	if locked, ok := lock.Packages[req.name]; ok {
+		if v, err := ParseVersion(locked.Version); err == nil && req.constraint.Allows(v) {
+			return locked, nil
+		}
+	}
+
+	reg, err := p.Registry()
+	if err != nil {
+		return LockedPackage{}, err
+	}
+	idx, err := reg.Index(req.name)
+	if err != nil {
+		return LockedPackage{}, fmt.Errorf("%v (required by %s)", err, req.by)
+	}
+	v, entry, ok := idx.Best(req.constraint)
+	if !ok {
+		return LockedPackage{}, noMatch(req.name, req.constraint, idx)
+	}
+	return LockedPackage{
+		Version:      v.String(),
+		Resolved:     entry.File,
+		Integrity:    "sha256-" + entry.SHA256,
+		Dependencies: entry.Dependencies,
+	}, nil
+}
+
+func noMatch(name string, c Constraint, idx *PackageIndex) error {
+	var available []string
+	for _, v := range sortedVersions(idx) {
+		available = append(available, v.String())
+	}
+	if len(available) == 0 {
+		return fmt.Errorf("%s has no published versions", name)
+	}
+	return fmt.Errorf("no version of %s matches %s (available: %s)", name, c, strings.Join(available, ", "))
+}
+
+func conflict(name string, reqs []requirement) error {
+	parts := make([]string, len(reqs))
+	for i, r := range reqs {
+		parts[i] = fmt.Sprintf("%s needs %s", r.by, r.constraint)
+	}
+	return fmt.Errorf("conflicting versions of %s: %s", name, strings.Join(parts, ", "))
+}
+
+// ==================== Fetching ====================
+
+// fetch downloads a package archive, checks its hash and extracts it
+func (p *Project) fetch(name string, pkg LockedPackage, dest string) error {
+	reg, err := p.Registry()
+	if err != nil {
+		return err
+	}
+	archive, err := reg.Archive(name, pkg.Resolved)
+	if err != nil {
+		return fmt.Errorf("%s@%s: %v", name, pkg.Version, err)
+	}
+	if got := "sha256-" + digest(archive); got != pkg.Integrity {
+		return fmt.Errorf("%s@%s: integrity check failed: expected %s, got %s", name, pkg.Version, pkg.Integrity, got)
+	}
+	return Unpack(archive, dest)
+}
+
+func readInstalled(modules string) map[string]string {
+	installed := map[string]string{}
+	if data, err := os.ReadFile(filepath.Join(modules, installedFile)); err == nil {
+		json.Unmarshal(data, &installed)
+	}
+	return installed
+}
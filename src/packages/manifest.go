@@ -0,0 +1,112 @@
+package packages
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Manifest is banglacode.json:
+//
+//	{
+//	  "name": "amar-app",
+//	  "version": "1.0.0",
+//	  "main": "main.bang",
+//	  "registry": "https://packages.example.com",
+//	  "dependencies": {"strutil": "^1.2.0"}
+//	}
+type Manifest struct {
+	Name         string            `json:"name"`
+	Version      string            `json:"version,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	Main         string            `json:"main,omitempty"`
+	Registry     string            `json:"registry,omitempty"`
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+}
+
+// LoadManifest reads a banglacode.json file
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	for name, req := range m.Dependencies {
+		if _, err := ParseConstraint(req); err != nil {
+			return nil, fmt.Errorf("%s: dependency %q: %v", path, name, err)
+		}
+	}
+	return m, nil
+}
+
+// Save writes the manifest as indented JSON
+func (m *Manifest) Save(path string) error {
+	return writeJSON(path, m)
+}
+
+// Lockfile is banglacode.lock. It pins every installed package, direct or
+// not, to one version and the SHA-256 of its archive.
+type Lockfile struct {
+	LockfileVersion int                      `json:"lockfileVersion"`
+	Packages        map[string]LockedPackage `json:"packages"`
+}
+
+// LockedPackage is one entry of the lockfile
+type LockedPackage struct {
+	Version      string            `json:"version"`
+	Resolved     string            `json:"resolved"`  // archive file name in the registry
+	Integrity    string            `json:"integrity"` // "sha256-" + hex digest of the archive
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+}
+
+// LoadLockfile reads banglacode.lock; a missing file is an empty lockfile
+func LoadLockfile(path string) (*Lockfile, error) {
+	lock := &Lockfile{LockfileVersion: 1, Packages: map[string]LockedPackage{}}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if lock.Packages == nil {
+		lock.Packages = map[string]LockedPackage{}
+	}
+	return lock, nil
+}
+
+// Save writes the lockfile as indented JSON
+func (l *Lockfile) Save(path string) error {
+	return writeJSON(path, l)
+}
+
+// Names lists the locked packages in sorted order
+func (l *Lockfile) Names() []string {
+	names := make([]string, 0, len(l.Packages))
+	for name := range l.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writeJSON writes v with two-space indentation (map keys come out sorted,
+// so the files diff cleanly)
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
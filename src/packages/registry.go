@@ -0,0 +1,245 @@
+package packages
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// A registry is a tree of static files, so any directory or plain HTTP
+// file server can host one:
+//
+//	<registry>/<name>/index.json             versions of package <name>
+//	<registry>/<name>/<name>-<version>.tgz   package archives (tar.gz)
+//
+// index.json lists each version with its archive, the archive's SHA-256
+// and the version's dependencies:
+//
+//	{"name": "strutil", "versions": {"1.2.0": {"file": "strutil-1.2.0.tgz",
+//	  "sha256": "9f86d0...", "dependencies": {"ganit": "^1.0.0"}}}}
+
+// PackageIndex is a package's index.json
+type PackageIndex struct {
+	Name     string                  `json:"name"`
+	Versions map[string]VersionEntry `json:"versions"`
+}
+
+// VersionEntry describes one published version
+type VersionEntry struct {
+	File         string            `json:"file"`
+	SHA256       string            `json:"sha256"`
+	Dependencies map[string]string `json:"dependencies,omitempty"`
+}
+
+// Best returns the highest version that satisfies c
+func (idx *PackageIndex) Best(c Constraint) (Version, VersionEntry, bool) {
+	var best Version
+	var entry VersionEntry
+	found := false
+	for raw, e := range idx.Versions {
+		v, err := ParseVersion(raw)
+		if err != nil || !c.Allows(v) {
+			continue
+		}
+		if !found || v.Compare(best) > 0 {
+			best, entry, found = v, e, true
+		}
+	}
+	return best, entry, found
+}
+
+// Registry serves package indexes and archives
+type Registry interface {
+	Index(name string) (*PackageIndex, error)
+	Archive(name, file string) ([]byte, error)
+	String() string
+}
+
+// ErrNotFound is returned for packages the registry does not have
+var ErrNotFound = errors.New("package not found")
+
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// ValidName reports whether name can be used as a package name: lowercase
+// letters, digits, '_', '-' and '.', so it is safe as a path and URL part
+func ValidName(name string) bool {
+	return validName.MatchString(name) && !strings.Contains(name, "..")
+}
+
+// OpenRegistry returns the registry at location: an http(s):// URL, or a
+// directory (relative paths are relative to baseDir)
+func OpenRegistry(location, baseDir string) (Registry, error) {
+	switch {
+	case location == "":
+		return nil, errors.New("no registry configured: set \"registry\" in " + ManifestFile + ", BANGLACODE_REGISTRY or --registry")
+	case strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://"):
+		return &httpRegistry{base: strings.TrimSuffix(location, "/"), client: &http.Client{Timeout: 60 * time.Second}}, nil
+	}
+	dir := strings.TrimPrefix(location, "file://")
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(baseDir, dir)
+	}
+	return &dirRegistry{dir: dir}, nil
+}
+
+// ==================== Directory registry ====================
+
+type dirRegistry struct {
+	dir string
+}
+
+func (r *dirRegistry) String() string { return r.dir }
+
+func (r *dirRegistry) Index(name string) (*PackageIndex, error) {
+	if !ValidName(name) {
+		return nil, fmt.Errorf("invalid package name %q", name)
+	}
+	data, err := os.ReadFile(filepath.Join(r.dir, name, "index.json"))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseIndex(name, data)
+}
+
+func (r *dirRegistry) Archive(name, file string) ([]byte, error) {
+	if !ValidName(name) || file != filepath.Base(file) {
+		return nil, fmt.Errorf("invalid archive %s/%s", name, file)
+	}
+	return os.ReadFile(filepath.Join(r.dir, name, file))
+}
+
+// ==================== HTTP registry ====================
+
+type httpRegistry struct {
+	base   string
+	client *http.Client
+}
+
+func (r *httpRegistry) String() string { return r.base }
+
+func (r *httpRegistry) get(path string) ([]byte, error) {
+	resp, err := r.client.Get(r.base + "/" + path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s/%s: %s", r.base, path, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (r *httpRegistry) Index(name string) (*PackageIndex, error) {
+	if !ValidName(name) {
+		return nil, fmt.Errorf("invalid package name %q", name)
+	}
+	data, err := r.get(name + "/index.json")
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("%s: %w", name, ErrNotFound)
+		}
+		return nil, err
+	}
+	return parseIndex(name, data)
+}
+
+func (r *httpRegistry) Archive(name, file string) ([]byte, error) {
+	if !ValidName(name) || file != filepath.Base(file) {
+		return nil, fmt.Errorf("invalid archive %s/%s", name, file)
+	}
+	return r.get(name + "/" + file)
+}
+
+func parseIndex(name string, data []byte) (*PackageIndex, error) {
+	idx := &PackageIndex{}
+	if err := json.Unmarshal(data, idx); err != nil {
+		return nil, fmt.Errorf("%s/index.json: %v", name, err)
+	}
+	if idx.Versions == nil {
+		idx.Versions = map[string]VersionEntry{}
+	}
+	return idx, nil
+}
+
+// ==================== Publishing ====================
+
+// Publish packs the package in dir and adds it to the directory registry
+// at registryDir; publishing the same version twice is an error. It
+// returns the new index entry.
+func Publish(dir, registryDir string) (VersionEntry, error) {
+	m, err := LoadManifest(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return VersionEntry{}, err
+	}
+	if !ValidName(m.Name) {
+		return VersionEntry{}, fmt.Errorf("invalid package name %q", m.Name)
+	}
+	if _, err := ParseVersion(m.Version); err != nil {
+		return VersionEntry{}, err
+	}
+
+	reg := &dirRegistry{dir: registryDir}
+	idx, err := reg.Index(m.Name)
+	if errors.Is(err, ErrNotFound) {
+		idx, err = &PackageIndex{Name: m.Name, Versions: map[string]VersionEntry{}}, nil
+	}
+	if err != nil {
+		return VersionEntry{}, err
+	}
+	if _, ok := idx.Versions[m.Version]; ok {
+		return VersionEntry{}, fmt.Errorf("%s@%s is already published", m.Name, m.Version)
+	}
+
+	archive, err := Pack(dir)
+	if err != nil {
+		return VersionEntry{}, err
+	}
+	entry := VersionEntry{
+		File:         fmt.Sprintf("%s-%s.tgz", m.Name, m.Version),
+		SHA256:       digest(archive),
+		Dependencies: m.Dependencies,
+	}
+	pkgDir := filepath.Join(registryDir, m.Name)
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		return VersionEntry{}, err
+	}
+	if err := os.WriteFile(filepath.Join(pkgDir, entry.File), archive, 0644); err != nil {
+		return VersionEntry{}, err
+	}
+	idx.Versions[m.Version] = entry
+	return entry, writeJSON(filepath.Join(pkgDir, "index.json"), idx)
+}
+
+// digest is the hex SHA-256 of data
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// sortedVersions lists the versions of an index from oldest to newest
+func sortedVersions(idx *PackageIndex) []Version {
+	var versions []Version
+	for raw := range idx.Versions {
+		if v, err := ParseVersion(raw); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Compare(versions[j]) < 0 })
+	return versions
+}
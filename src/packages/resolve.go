@@ -0,0 +1,70 @@
+// Package packages implements the BanglaCode package manager: the
+// banglacode.json manifest, the banglacode.lock lockfile, registries that
+// serve package archives, installing into bangla_modules/ and resolving
+// "ano" paths to installed packages.
+package packages
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ManifestFile describes a project or package
+	ManifestFile = "banglacode.json"
+	// LockFile records the exact versions and hashes that were installed
+	LockFile = "banglacode.lock"
+	// ModulesDir holds installed packages, next to the manifest
+	ModulesDir = "bangla_modules"
+	// DefaultMain is the entry file of a package whose manifest names none
+	DefaultMain = "main.bang"
+)
+
+// Resolve turns the path of an "ano" statement into a file path. Paths
+// are relative to fromDir, the importing file's directory, as they always
+// were. When no such file exists and the path is not explicitly relative
+// ("./x", "../x"), the bangla_modules/ directories of fromDir and its
+// parents are searched: ano "name" loads the main file of package name and
+// ano "name/file.bang" a file inside it.
+func Resolve(fromDir, importPath string) string {
+	if filepath.IsAbs(importPath) {
+		return importPath
+	}
+	local := filepath.Join(fromDir, importPath)
+	if isFile(local) || strings.HasPrefix(importPath, "./") || strings.HasPrefix(importPath, "../") {
+		return local
+	}
+
+	dir, err := filepath.Abs(fromDir)
+	if err != nil {
+		return local
+	}
+	for {
+		candidate := filepath.Join(dir, ModulesDir, importPath)
+		if isFile(candidate) {
+			return candidate
+		}
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return filepath.Join(candidate, mainFile(candidate))
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return local
+		}
+		dir = parent
+	}
+}
+
+// mainFile is the entry file of the package in dir
+func mainFile(dir string) string {
+	if m, err := LoadManifest(filepath.Join(dir, ManifestFile)); err == nil && m.Main != "" {
+		return m.Main
+	}
+	return DefaultMain
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
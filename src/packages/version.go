@@ -0,0 +1,133 @@
+package packages
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version MAJOR.MINOR.PATCH[-prerelease]
+type Version struct {
+	Major, Minor, Patch int
+	Pre                 string
+}
+
+// ParseVersion parses "1.2.3" or "1.2.3-beta.1"
+func ParseVersion(s string) (Version, error) {
+	var v Version
+	core, pre, _ := strings.Cut(strings.TrimPrefix(strings.TrimSpace(s), "v"), "-")
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("invalid version %q: want MAJOR.MINOR.PATCH", s)
+	}
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, fmt.Errorf("invalid version %q", s)
+		}
+		nums[i] = n
+	}
+	return Version{nums[0], nums[1], nums[2], pre}, nil
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1; a prerelease sorts before its release
+func (v Version) Compare(o Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d != 0 {
+			if d < 0 {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case v.Pre == o.Pre:
+		return 0
+	case v.Pre == "":
+		return 1
+	case o.Pre == "":
+		return -1
+	case v.Pre < o.Pre:
+		return -1
+	}
+	return 1
+}
+
+// Constraint is a version requirement from a manifest:
+//
+//	"1.2.3"    exactly 1.2.3
+//	"^1.2.3"   >=1.2.3 and <2.0.0 (<0.3.0 for ^0.2.x)
+//	"~1.2.3"   >=1.2.3 and <1.3.0
+//	">=1.2.3"  1.2.3 or later
+//	"*", ""    any version
+type Constraint struct {
+	raw      string
+	min, max *Version // max is exclusive; nil means unbounded
+	exact    bool
+}
+
+// ParseConstraint parses a version requirement
+func ParseConstraint(s string) (Constraint, error) {
+	s = strings.TrimSpace(s)
+	c := Constraint{raw: s}
+	if s == "" || s == "*" || s == "latest" {
+		return c, nil
+	}
+
+	op := ""
+	for _, prefix := range []string{">=", "^", "~"} {
+		if strings.HasPrefix(s, prefix) {
+			op = prefix
+			break
+		}
+	}
+	v, err := ParseVersion(strings.TrimPrefix(s, op))
+	if err != nil {
+		return c, err
+	}
+	c.min = &v
+	switch op {
+	case "":
+		c.exact = true
+	case "^":
+		upper := Version{Major: v.Major + 1}
+		if v.Major == 0 {
+			upper = Version{Minor: v.Minor + 1}
+		}
+		c.max = &upper
+	case "~":
+		c.max = &Version{Major: v.Major, Minor: v.Minor + 1}
+	}
+	return c, nil
+}
+
+func (c Constraint) String() string {
+	if c.raw == "" {
+		return "*"
+	}
+	return c.raw
+}
+
+// Allows reports whether v satisfies the constraint. Prereleases only
+// match a constraint that names them exactly.
+func (c Constraint) Allows(v Version) bool {
+	if c.exact {
+		return v.Compare(*c.min) == 0
+	}
+	if v.Pre != "" {
+		return false
+	}
+	if c.min != nil && v.Compare(*c.min) < 0 {
+		return false
+	}
+	return c.max == nil || v.Compare(*c.max) < 0
+}
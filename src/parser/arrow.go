@@ -5,25 +5,21 @@ import (
 	"BanglaCode/src/lexer"
 )
 
-// parseArrowFunctionExpression parses: x => expr  OR  x => { ... }
+// parseArrowFunctionExpression parses: x => expr  OR  (x, y = 1) => { ... }
 func (p *Parser) parseArrowFunctionExpression(left ast.Expression) ast.Expression {
-	params := []*ast.Identifier{}
+	fn := &ast.FunctionLiteral{Token: p.curToken} // ARROW token
 
 	switch l := left.(type) {
 	case *ast.Identifier:
-		params = append(params, l)
+		fn.Parameters = []*ast.Parameter{{Name: l}}
 	case *ast.ArrowParamList:
-		params = append(params, l.Params...)
+		fn.Parameters = l.Params
+		fn.RestParameter = l.Rest
 	default:
-		p.errors = append(p.errors, "invalid arrow function parameters")
+		p.addError(CodeInvalidArrowParams, p.curToken, "", "invalid arrow function parameters")
 		return nil
 	}
 
-	fn := &ast.FunctionLiteral{
-		Token:      p.curToken, // ARROW token
-		Parameters: params,
-	}
-
 	p.nextToken()
 
 	if p.curTokenIs(lexer.LBRACE) {
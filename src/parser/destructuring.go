@@ -5,34 +5,16 @@ import (
 	"BanglaCode/src/lexer"
 )
 
-func (p *Parser) parseArrayDestructuringDeclaration(token lexer.Token, isConstant, isGlobal bool) *ast.ArrayDestructuringDeclaration {
-	stmt := &ast.ArrayDestructuringDeclaration{
+// parseDestructuringDeclaration parses dhoro [a, b] = expr; or
+// dhoro {x, y} = expr; from the '[' or '{'
+func (p *Parser) parseDestructuringDeclaration(token lexer.Token, isConstant, isGlobal bool) *ast.DestructuringDeclaration {
+	stmt := &ast.DestructuringDeclaration{
 		Token:      token,
 		IsConstant: isConstant,
 		IsGlobal:   isGlobal,
-		Names:      []*ast.Identifier{},
 	}
 
-	// current token is '['
-	for {
-		p.nextToken()
-		if p.curTokenIs(lexer.RBRACKET) {
-			break
-		}
-		if !p.curTokenIs(lexer.IDENT) {
-			p.errors = append(p.errors, "array destructuring expects identifiers")
-			return nil
-		}
-		stmt.Names = append(stmt.Names, &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal})
-		if p.peekTokenIs(lexer.COMMA) {
-			p.nextToken()
-			continue
-		}
-		if p.peekTokenIs(lexer.RBRACKET) {
-			p.nextToken()
-			break
-		}
-		p.errors = append(p.errors, "array destructuring expects ',' or ']'")
+	if stmt.Pattern = p.parsePattern(); stmt.Pattern == nil {
 		return nil
 	}
 
@@ -48,77 +30,203 @@ func (p *Parser) parseArrayDestructuringDeclaration(token lexer.Token, isConstan
 	return stmt
 }
 
-func (p *Parser) parseObjectDestructuringDeclaration(token lexer.Token, isConstant, isGlobal bool) *ast.ObjectDestructuringDeclaration {
-	stmt := &ast.ObjectDestructuringDeclaration{
-		Token:      token,
-		IsConstant: isConstant,
-		IsGlobal:   isGlobal,
-		Keys:       []string{},
-		Names:      []*ast.Identifier{},
+// parseAssignmentPattern reads ahead from '[' for a pattern followed by =,
+// as in [a, [b, c], ...rest] = expr. Without one, the parser is rewound
+// and nothing is consumed: the brackets hold an array literal.
+func (p *Parser) parseAssignmentPattern() ast.Expression {
+	lexerState, cur, peek := *p.l, p.curToken, p.peekToken
+	diagnostics, recovering, depth := len(p.diagnostics), p.recovering, p.depth
+
+	assigning := p.assigning
+	p.assigning = true
+	pattern := p.parseArrayPattern()
+	p.assigning = assigning
+	if pattern != nil && p.recovering == recovering && p.peekTokenIs(lexer.ASSIGN) {
+		return pattern
 	}
 
-	if !p.parseObjectDestructuringBindings(stmt) {
+	*p.l, p.curToken, p.peekToken = lexerState, cur, peek
+	p.diagnostics, p.recovering, p.depth = p.diagnostics[:diagnostics], recovering, depth
+	return nil
+}
+
+// parseBindingElement parses a name or a nested pattern, optionally
+// followed by = default. Declarations, parameter lists and the elements of
+// patterns all use it, so they accept the same patterns; the pattern of an
+// assignment may also bind members.
+func (p *Parser) parseBindingElement() *ast.BindingElement {
+	el := &ast.BindingElement{}
+
+	switch p.curToken.Type {
+	case lexer.IDENT:
+		if p.assigning && (p.peekTokenIs(lexer.DOT) || p.peekTokenIs(lexer.LBRACKET)) {
+			if el.Member = p.parseMemberTarget(); el.Member == nil {
+				return nil
+			}
+			break
+		}
+		el.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	case lexer.LBRACKET, lexer.LBRACE:
+		if el.Pattern = p.parsePattern(); el.Pattern == nil {
+			return nil
+		}
+	default:
+		p.addError(CodeUnexpectedToken, p.curToken, "", "expected a name or a destructuring pattern, got %s", p.curToken.Type)
 		return nil
 	}
 
-	if !p.expectPeek(lexer.ASSIGN) {
+	return p.parseDefault(el)
+}
+
+// parseMemberTarget parses obj.key or obj[i] bound by an assignment pattern
+func (p *Parser) parseMemberTarget() *ast.MemberExpression {
+	p.assigning = false
+	target := p.parseExpression(ASSIGN)
+	p.assigning = true
+
+	member, ok := target.(*ast.MemberExpression)
+	if !ok || member.Optional {
+		if target != nil {
+			p.addError(CodeInvalidDestructure, ast.TokenOf(target), "", "array destructuring assignment expects variables, members or patterns")
+		}
 		return nil
 	}
+	return member
+}
+
+// startsBinding reports whether the current token can start a binding
+// element
+func (p *Parser) startsBinding() bool {
+	return p.curTokenIs(lexer.IDENT) || p.curTokenIs(lexer.LBRACKET) || p.curTokenIs(lexer.LBRACE)
+}
+
+// parseDefault parses the optional = default after a binding target
+func (p *Parser) parseDefault(el *ast.BindingElement) *ast.BindingElement {
+	if !p.peekTokenIs(lexer.ASSIGN) {
+		return el
+	}
 	p.nextToken()
-	stmt.Source = p.parseExpression(LOWEST)
-	if p.peekTokenIs(lexer.SEMICOLON) {
-		p.nextToken()
+	p.nextToken()
+	assigning := p.assigning
+	p.assigning = false
+	el.Default = p.parseExpression(LOWEST)
+	p.assigning = assigning
+	if el.Default == nil {
+		return nil
 	}
+	return el
+}
 
-	return stmt
+// parsePattern parses an array or object pattern from the '[' or '{'
+// through the closing bracket; nil reports an error
+func (p *Parser) parsePattern() ast.Expression {
+	if p.curTokenIs(lexer.LBRACKET) {
+		return p.parseArrayPattern()
+	}
+	return p.parseObjectPattern()
 }
 
-func (p *Parser) parseObjectDestructuringBindings(stmt *ast.ObjectDestructuringDeclaration) bool {
-	// current token is '{'
+// parseArrayPattern parses [a, b = 1, [c], {d}, ...rest]
+func (p *Parser) parseArrayPattern() ast.Expression {
+	pattern := &ast.ArrayPattern{Token: p.curToken}
 	for {
 		p.nextToken()
-		if p.curTokenIs(lexer.RBRACE) {
-			return true
+		if p.curTokenIs(lexer.RBRACKET) {
+			return pattern
+		}
+		if p.curTokenIs(lexer.DOTDOTDOT) {
+			if pattern.Rest = p.parsePatternRest(lexer.RBRACKET, "array"); pattern.Rest == nil {
+				return nil
+			}
+			return pattern
 		}
 
-		key, name, ok := p.parseObjectDestructuringPair()
-		if !ok {
-			return false
+		if !p.startsBinding() {
+			p.addError(CodeInvalidDestructure, p.curToken, "", "array destructuring expects names or patterns")
+			return nil
+		}
+		el := p.parseBindingElement()
+		if el == nil {
+			return nil
 		}
-		stmt.Keys = append(stmt.Keys, key)
-		stmt.Names = append(stmt.Names, name)
+		pattern.Elements = append(pattern.Elements, el)
 
 		if p.peekTokenIs(lexer.COMMA) {
 			p.nextToken()
 			continue
 		}
-		if p.peekTokenIs(lexer.RBRACE) {
+		if p.peekTokenIs(lexer.RBRACKET) {
 			p.nextToken()
-			return true
+			return pattern
 		}
-		p.errors = append(p.errors, "object destructuring expects ',' or '}'")
-		return false
+		p.addError(CodeInvalidDestructure, p.curToken, "insert ']'", "array destructuring expects ',' or ']'")
+		return nil
 	}
 }
 
-func (p *Parser) parseObjectDestructuringPair() (string, *ast.Identifier, bool) {
-	if !p.curTokenIs(lexer.IDENT) {
-		p.errors = append(p.errors, "object destructuring expects identifier keys")
-		return "", nil, false
-	}
+// parseObjectPattern parses {x, y: alias, z = 1, w: {v}, ...rest}
+func (p *Parser) parseObjectPattern() ast.Expression {
+	pattern := &ast.ObjectPattern{Token: p.curToken}
+	for {
+		p.nextToken()
+		if p.curTokenIs(lexer.RBRACE) {
+			return pattern
+		}
+		if p.curTokenIs(lexer.DOTDOTDOT) {
+			if pattern.Rest = p.parsePatternRest(lexer.RBRACE, "object"); pattern.Rest == nil {
+				return nil
+			}
+			return pattern
+		}
+
+		if !p.curTokenIs(lexer.IDENT) {
+			p.addError(CodeInvalidDestructure, p.curToken, "", "object destructuring expects identifier keys")
+			return nil
+		}
+		key := p.curToken.Literal
 
-	key := p.curToken.Literal
-	name := key
-	token := p.curToken
+		var el *ast.BindingElement
+		if p.peekTokenIs(lexer.COLON) {
+			p.nextToken()
+			p.nextToken()
+			if !p.startsBinding() {
+				p.addError(CodeInvalidDestructure, p.curToken, "", "object destructuring expects a name or pattern after ':'")
+				return nil
+			}
+			el = p.parseBindingElement()
+		} else {
+			el = p.parseDefault(&ast.BindingElement{Name: &ast.Identifier{Token: p.curToken, Value: key}})
+		}
+		if el == nil {
+			return nil
+		}
+		pattern.Keys = append(pattern.Keys, key)
+		pattern.Values = append(pattern.Values, el)
 
-	if p.peekTokenIs(lexer.COLON) {
-		p.nextToken()
-		if !p.expectPeek(lexer.IDENT) {
-			return "", nil, false
+		if p.peekTokenIs(lexer.COMMA) {
+			p.nextToken()
+			continue
 		}
-		name = p.curToken.Literal
-		token = p.curToken
+		if p.peekTokenIs(lexer.RBRACE) {
+			p.nextToken()
+			return pattern
+		}
+		p.addError(CodeInvalidDestructure, p.curToken, "insert '}'", "object destructuring expects ',' or '}'")
+		return nil
 	}
+}
 
-	return key, &ast.Identifier{Token: token, Value: name}, true
+// parsePatternRest parses ...name, which must be the last element, through
+// the closing bracket
+func (p *Parser) parsePatternRest(closing lexer.TokenType, kind string) *ast.Identifier {
+	if !p.expectPeek(lexer.IDENT) {
+		return nil
+	}
+	rest := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	if !p.peekTokenIs(closing) {
+		p.addError(CodeInvalidDestructure, p.peekToken, "", "the rest element must come last in %s destructuring", kind)
+		return nil
+	}
+	p.nextToken()
+	return rest
 }
@@ -0,0 +1,173 @@
+package parser
+
+import (
+	"BanglaCode/src/lexer"
+	"fmt"
+)
+
+// Severity tells how serious a diagnostic is
+type Severity int
+
+const (
+	// SeverityError marks a syntax error; the program cannot run
+	SeverityError Severity = iota + 1
+	// SeverityWarning marks suspicious code that still parses
+	SeverityWarning
+)
+
+// String returns the lowercase name used in CLI output and JSON
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return "unknown"
+}
+
+// MarshalText lets Severity appear as "error"/"warning" in JSON
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Diagnostic codes reported by the parser
+const (
+	CodeUnexpectedToken    = "E001" // expected one token, found another
+	CodeNoExpression       = "E002" // token cannot start an expression
+	CodeInvalidNumber      = "E003" // malformed number literal
+	CodeInvalidArrowParams = "E004" // left side of => is not a parameter list
+	CodeInvalidDestructure = "E005" // malformed destructuring pattern
+	CodeInvalidSetter      = "E006" // setter without exactly one parameter
+	CodeInvalidGrouping    = "E007" // (a, b) used outside an arrow function
+	CodeInvalidForAwait    = "E008" // ghuriye opekha without (name of iterable)
+	CodeUndefinedLabel     = "E009" // thamo/chharo names a label that does not enclose it
+	CodeAmbiguousPower     = "E010" // unary operator directly on the left of **
+)
+
+// Position is a 1-based line and column in the source
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Diagnostic is one structured problem found while parsing (somossa)
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+	File     string   `json:"file,omitempty"`
+	Start    Position `json:"start"`
+	End      Position `json:"end"` // exclusive
+	Fix      string   `json:"fix,omitempty"`
+}
+
+// String formats the diagnostic as "file:line:col: error[E001]: message"
+func (d Diagnostic) String() string {
+	loc := fmt.Sprintf("%d:%d", d.Start.Line, d.Start.Column)
+	if d.File != "" {
+		loc = d.File + ":" + loc
+	}
+	return fmt.Sprintf("%s: %s[%s]: %s", loc, d.Severity, d.Code, d.Message)
+}
+
+// Diagnostics returns every problem found by ParseProgram, in source order
+func (p *Parser) Diagnostics() []Diagnostic {
+	return p.diagnostics
+}
+
+// Errors returns the parsing errors as plain messages
+func (p *Parser) Errors() []string {
+	errors := []string{}
+	for _, d := range p.diagnostics {
+		if d.Severity != SeverityError {
+			continue
+		}
+		errors = append(errors, fmt.Sprintf("%s at line %d, column %d", d.Message, d.Start.Line, d.Start.Column))
+	}
+	return errors
+}
+
+// addError records a syntax error spanning tok. Only the first error of a
+// statement is kept; the rest are usually knock-on effects of it and are
+// dropped until the parser resynchronizes.
+func (p *Parser) addError(code string, tok lexer.Token, fix string, format string, a ...interface{}) {
+	if p.recovering {
+		return
+	}
+	p.recovering = true
+
+	width := tok.Width()
+	if width == 0 {
+		width = 1
+	}
+	p.diagnostics = append(p.diagnostics, Diagnostic{
+		Severity: SeverityError,
+		Code:     code,
+		Message:  fmt.Sprintf(format, a...),
+		File:     tok.File,
+		Start:    Position{Line: tok.Line, Column: tok.Column},
+		End:      Position{Line: tok.Line, Column: tok.Column + width},
+		Fix:      fix,
+	})
+}
+
+// statementStarts are the keywords that always begin a new statement
+var statementStarts = map[lexer.TokenType]bool{
+	lexer.DHORO:     true,
+	lexer.STHIR:     true,
+	lexer.BISHWO:    true,
+	lexer.JODI:      true,
+	lexer.JOTOKKHON: true,
+	lexer.GHURIYE:   true,
+	lexer.DO:        true,
+	lexer.FERAO:     true,
+	lexer.SRENI:     true,
+	lexer.KAJ:       true,
+	lexer.THAMO:     true,
+	lexer.CHHARO:    true,
+	lexer.ANO:       true,
+	lexer.PATHAO:    true,
+	lexer.CHESTA:    true,
+	lexer.FELO:      true,
+	lexer.BIKOLPO:   true,
+}
+
+// blockContinuations are the keywords that may follow a statement's block
+var blockContinuations = map[lexer.TokenType]bool{
+	lexer.NAHOLE:     true,
+	lexer.DHORO_BHUL: true,
+	lexer.SHESH:      true,
+}
+
+// insideExpression are the tokens that show a '}' closed an object pattern
+// or literal rather than a statement's block
+var insideExpression = map[lexer.TokenType]bool{
+	lexer.ASSIGN: true,
+	lexer.COMMA:  true,
+	lexer.RPAREN: true,
+}
+
+// synchronize skips the rest of a broken statement that started at brace
+// depth depth (panic-mode recovery). It first leaves any blocks the statement
+// opened, then stops on the statement's ';' or final '}', or just before the
+// next statement keyword or the '}' closing the enclosing block, so the
+// caller's nextToken lands on the next statement.
+func (p *Parser) synchronize(depth int) {
+	p.recovering = false
+
+	for !p.curTokenIs(lexer.EOF) && !p.peekTokenIs(lexer.EOF) && p.depth >= depth {
+		if p.depth == depth {
+			if p.curTokenIs(lexer.SEMICOLON) || p.peekTokenIs(lexer.RBRACE) || statementStarts[p.peekToken.Type] {
+				return
+			}
+			// A closed block ends the statement unless an else/catch/finally
+			// follows, or the braces were an object pattern or a map inside
+			// an expression
+			if p.curTokenIs(lexer.RBRACE) && !blockContinuations[p.peekToken.Type] && !insideExpression[p.peekToken.Type] {
+				return
+			}
+		}
+		p.nextToken()
+	}
+}
@@ -3,7 +3,6 @@ package parser
 import (
 	"BanglaCode/src/ast"
 	"BanglaCode/src/lexer"
-	"fmt"
 	"strconv"
 )
 
@@ -32,9 +31,7 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 
 // noPrefixParseFnError reports an error for missing prefix parse function
 func (p *Parser) noPrefixParseFnError(t lexer.TokenType) {
-	msg := fmt.Sprintf("no prefix parse function for %s found at line %d, column %d",
-		t, p.curToken.Line, p.curToken.Column)
-	p.errors = append(p.errors, msg)
+	p.addError(CodeNoExpression, p.curToken, "", "no prefix parse function for %s found", t)
 }
 
 // ==================== Prefix Expressions ====================
@@ -50,8 +47,7 @@ func (p *Parser) parseNumberLiteral() ast.Expression {
 
 	value, err := strconv.ParseFloat(p.curToken.Literal, 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as number", p.curToken.Literal)
-		p.errors = append(p.errors, msg)
+		p.addError(CodeInvalidNumber, p.curToken, "", "could not parse %q as number", p.curToken.Literal)
 		return nil
 	}
 
@@ -93,58 +89,75 @@ func (p *Parser) parseUnaryExpression() ast.Expression {
 	return expression
 }
 
-// parseGroupedExpression parses (expression)
+// parseGroupedExpression parses (expression), or the parameter list of
+// an arrow function when => follows the closing parenthesis
 func (p *Parser) parseGroupedExpression() ast.Expression {
+	if params := p.parseArrowParams(); params != nil {
+		return params
+	}
 	if p.peekTokenIs(lexer.RPAREN) {
-		return p.parseEmptyArrowParams()
+		p.nextToken()
+		return nil
 	}
 	p.nextToken()
 	first := p.parseExpression(LOWEST)
 	if first == nil {
 		return nil
 	}
-	if ident, ok := first.(*ast.Identifier); ok {
-		return p.parseGroupedIdentifierOrArrow(first, ident)
+	if _, ok := first.(*ast.Identifier); ok {
+		return p.parseGroupedIdentifier(first)
 	}
 	if !p.expectPeek(lexer.RPAREN) {
 		return nil
 	}
+	p.grouped = first
 	return first
 }
 
-func (p *Parser) parseEmptyArrowParams() ast.Expression {
-	lp := p.curToken
-	p.nextToken()
-	if p.peekTokenIs(lexer.ARROW) {
-		return &ast.ArrowParamList{Token: lp, Params: []*ast.Identifier{}}
+// parseArrowParams reads ahead from '(' for a parameter list followed by
+// =>. Without one, the parser is rewound and nothing is consumed: (a = 1)
+// and ([x, y]) are ordinary expressions until the arrow says otherwise.
+func (p *Parser) parseArrowParams() *ast.ArrowParamList {
+	lexerState, cur, peek := *p.l, p.curToken, p.peekToken
+	diagnostics, recovering, depth := len(p.diagnostics), p.recovering, p.depth
+
+	params, rest := p.parseFunctionParametersWithRest()
+	if params != nil && p.recovering == recovering && p.peekTokenIs(lexer.ARROW) {
+		return &ast.ArrowParamList{Token: cur, Params: params, Rest: rest}
 	}
+
+	*p.l, p.curToken, p.peekToken = lexerState, cur, peek
+	p.diagnostics, p.recovering, p.depth = p.diagnostics[:diagnostics], recovering, depth
 	return nil
 }
 
-func (p *Parser) parseGroupedIdentifierOrArrow(first ast.Expression, ident *ast.Identifier) ast.Expression {
-	params := []*ast.Identifier{ident}
+// parseGroupedIdentifier parses the rest of (name) and reports (a, b)
+// that is not followed by => (arrow parameters are read by parseArrowParams)
+func (p *Parser) parseGroupedIdentifier(first ast.Expression) ast.Expression {
+	names := 1
 	for p.peekTokenIs(lexer.COMMA) {
 		p.nextToken()
 		if !p.expectPeek(lexer.IDENT) {
 			return nil
 		}
-		params = append(params, &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal})
+		names++
 	}
 	if !p.expectPeek(lexer.RPAREN) {
 		return nil
 	}
-	if p.peekTokenIs(lexer.ARROW) {
-		return &ast.ArrowParamList{Token: p.curToken, Params: params}
-	}
-	if len(params) == 1 {
+	if names == 1 {
 		return first
 	}
-	p.errors = append(p.errors, "grouped identifier list is only valid for arrow functions")
+	p.addError(CodeInvalidGrouping, p.curToken, "add '=> ...' to make this an arrow function", "grouped identifier list is only valid for arrow functions")
 	return nil
 }
 
-// parseArrayLiteral parses [elements]
+// parseArrayLiteral parses [elements], or the pattern of a destructuring
+// assignment when = follows the closing bracket
 func (p *Parser) parseArrayLiteral() ast.Expression {
+	if pattern := p.parseAssignmentPattern(); pattern != nil {
+		return pattern
+	}
 	array := &ast.ArrayLiteral{Token: p.curToken}
 	array.Elements = p.parseExpressionList(lexer.RBRACKET)
 	return array
@@ -166,6 +179,9 @@ func (p *Parser) parseMapLiteral() ast.Expression {
 		p.nextToken()
 		value := p.parseExpression(LOWEST)
 
+		if _, exists := mapLit.Pairs[key]; !exists {
+			mapLit.Keys = append(mapLit.Keys, key)
+		}
 		mapLit.Pairs[key] = value
 
 		if !p.peekTokenIs(lexer.RBRACE) && !p.expectPeek(lexer.COMMA) {
@@ -216,7 +232,8 @@ func (p *Parser) parseFunctionLiteral() ast.Expression {
 	return lit
 }
 
-// parseAsyncFunctionLiteral parses proyash kaj name(params) { body }
+// parseAsyncFunctionLiteral parses proyash kaj name(params) { body } and
+// the async generator proyash kaj* name(params) { body }
 func (p *Parser) parseAsyncFunctionLiteral() ast.Expression {
 	token := p.curToken // PROYASH token
 
@@ -227,6 +244,11 @@ func (p *Parser) parseAsyncFunctionLiteral() ast.Expression {
 
 	lit := &ast.AsyncFunctionLiteral{Token: token}
 
+	if p.peekTokenIs(lexer.ASTERISK) {
+		p.nextToken()
+		lit.IsGenerator = true
+	}
+
 	// Check if function has a name
 	if p.peekTokenIs(lexer.IDENT) {
 		p.nextToken()
@@ -260,55 +282,68 @@ func (p *Parser) parseAwaitExpression() ast.Expression {
 	return exp
 }
 
-// parseYieldExpression parses utpadan expression (generator yield)
+// parseYieldExpression parses utpadan expression (generator yield) and
+// utpadan* iterable (yield delegation)
 func (p *Parser) parseYieldExpression() ast.Expression {
 	exp := &ast.YieldExpression{Token: p.curToken}
 
-	// yield can be used alone or with a value
-	if !p.peekTokenIs(lexer.SEMICOLON) && !p.peekTokenIs(lexer.RBRACE) {
+	if p.peekTokenIs(lexer.ASTERISK) {
 		p.nextToken()
-		exp.Expression = p.parseExpression(PREFIX)
+		exp.Delegate = true
+	}
+
+	// yield can be used alone or with a value; like an assignment, the
+	// value extends as far right as possible: utpadan a + b yields a + b
+	switch p.peekToken.Type {
+	case lexer.SEMICOLON, lexer.RBRACE, lexer.RPAREN, lexer.RBRACKET, lexer.COMMA, lexer.COLON:
+		if exp.Delegate {
+			p.addError(CodeNoExpression, p.peekToken, "", "utpadan* needs an iterable to delegate to")
+			return nil
+		}
+	default:
+		p.nextToken()
+		exp.Expression = p.parseExpression(LOWEST)
 	}
 
 	return exp
 }
 
 // parseFunctionParameters parses function parameter list (legacy, kept for compatibility)
-func (p *Parser) parseFunctionParameters() []*ast.Identifier {
+func (p *Parser) parseFunctionParameters() []*ast.Parameter {
 	params, _ := p.parseFunctionParametersWithRest()
 	return params
 }
 
 // parseFunctionParametersWithRest parses function parameters including rest parameter
-func (p *Parser) parseFunctionParametersWithRest() ([]*ast.Identifier, *ast.Identifier) {
-	identifiers := []*ast.Identifier{}
+func (p *Parser) parseFunctionParametersWithRest() ([]*ast.Parameter, *ast.Identifier) {
+	params := []*ast.Parameter{}
 
 	if p.peekTokenIs(lexer.RPAREN) {
 		p.nextToken()
-		return identifiers, nil
+		return params, nil
 	}
-	p.nextToken()
-	if p.curTokenIs(lexer.DOTDOTDOT) {
-		return p.parseRestOnlyParameters(identifiers)
-	}
-	ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
-	identifiers = append(identifiers, ident)
-	for p.peekTokenIs(lexer.COMMA) {
-		p.nextToken()
+	for {
 		p.nextToken()
 		if p.curTokenIs(lexer.DOTDOTDOT) {
-			return p.parseRestOnlyParameters(identifiers)
+			return p.parseRestOnlyParameters(params)
+		}
+		param := p.parseBindingElement()
+		if param == nil {
+			return nil, nil
+		}
+		params = append(params, param)
+		if !p.peekTokenIs(lexer.COMMA) {
+			break
 		}
-		ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
-		identifiers = append(identifiers, ident)
+		p.nextToken()
 	}
 	if !p.expectPeek(lexer.RPAREN) {
 		return nil, nil
 	}
-	return identifiers, nil
+	return params, nil
 }
 
-func (p *Parser) parseRestOnlyParameters(identifiers []*ast.Identifier) ([]*ast.Identifier, *ast.Identifier) {
+func (p *Parser) parseRestOnlyParameters(params []*ast.Parameter) ([]*ast.Parameter, *ast.Identifier) {
 	if !p.expectPeek(lexer.IDENT) {
 		return nil, nil
 	}
@@ -316,7 +351,7 @@ func (p *Parser) parseRestOnlyParameters(identifiers []*ast.Identifier) ([]*ast.
 	if !p.expectPeek(lexer.RPAREN) {
 		return nil, nil
 	}
-	return identifiers, restParam
+	return params, restParam
 }
 
 // parseNewExpression parses notun ClassName(args)
@@ -345,6 +380,16 @@ func (p *Parser) parseBinaryExpression(left ast.Expression) ast.Expression {
 	}
 
 	precedence := p.curPrecedence()
+	if p.curTokenIs(lexer.POWER) {
+		// -2 ** 2 could mean (-2) ** 2 or -(2 ** 2), so like JavaScript
+		// it must be written with parentheses
+		if unary, ok := left.(*ast.UnaryExpression); ok && left != p.grouped {
+			fix := "add parentheses: (" + unary.Operator + "a) ** b or " + unary.Operator + "(a ** b)"
+			p.addError(CodeAmbiguousPower, p.curToken, fix, "unary '%s' cannot appear directly before '**'", unary.Operator)
+			return nil
+		}
+		precedence-- // 2 ** 3 ** 2 is 2 ** (3 ** 2)
+	}
 	p.nextToken()
 	expression.Right = p.parseExpression(precedence)
 
@@ -359,6 +404,17 @@ func (p *Parser) parseAssignmentExpression(left ast.Expression) ast.Expression {
 		Operator: p.curToken.Literal,
 	}
 
+	// [a, b] = [b, a] is read as an *ast.ArrayPattern by parseArrayLiteral;
+	// an array literal here is not a valid assignment target
+	if target, ok := left.(*ast.ArrayLiteral); ok {
+		if expression.Operator != "=" {
+			p.addError(CodeInvalidDestructure, p.curToken, "use '='", "array destructuring assignment needs '=', got '%s'", expression.Operator)
+			return nil
+		}
+		p.addError(CodeInvalidDestructure, target.Token, "", "array destructuring assignment expects variables, members or patterns")
+		return nil
+	}
+
 	precedence := p.curPrecedence()
 	p.nextToken()
 	expression.Value = p.parseExpression(precedence)
@@ -389,7 +445,7 @@ func (p *Parser) parseMemberExpression(left ast.Expression) ast.Expression {
 		}
 	} else {
 		exp.Computed = false
-		if !p.expectPeek(lexer.IDENT) {
+		if !p.expectPropertyName() {
 			return nil
 		}
 		exp.Property = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
@@ -398,8 +454,59 @@ func (p *Parser) parseMemberExpression(left ast.Expression) ast.Expression {
 	return exp
 }
 
+// parseConditionalExpression parses cond ? a : b. The alternative may be
+// another conditional, so a ? b : c ? d : e groups to the right.
+func (p *Parser) parseConditionalExpression(condition ast.Expression) ast.Expression {
+	exp := &ast.ConditionalExpression{Token: p.curToken, Condition: condition}
+
+	p.nextToken()
+	exp.Consequence = p.parseExpression(LOWEST)
+	if !p.expectPeek(lexer.COLON) {
+		return nil
+	}
+	p.nextToken()
+	exp.Alternative = p.parseExpression(ASSIGN)
+
+	return exp
+}
+
+// parseOptionalChain parses obj?.prop, obj?.[index] and fn?.(args)
+func (p *Parser) parseOptionalChain(left ast.Expression) ast.Expression {
+	tok := p.curToken
+	switch {
+	case p.peekTokenIs(lexer.LPAREN):
+		p.nextToken()
+		return &ast.CallExpression{Token: tok, Function: left, Optional: true,
+			Arguments: p.parseExpressionList(lexer.RPAREN)}
+	case p.peekTokenIs(lexer.LBRACKET):
+		p.nextToken()
+		exp := &ast.MemberExpression{Token: tok, Object: left, Computed: true, Optional: true}
+		p.nextToken()
+		exp.Property = p.parseExpression(LOWEST)
+		if !p.expectPeek(lexer.RBRACKET) {
+			return nil
+		}
+		return exp
+	}
+	if !p.expectPropertyName() {
+		return nil
+	}
+	return &ast.MemberExpression{Token: tok, Object: left, Optional: true,
+		Property: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}}
+}
+
 // ==================== Helpers ====================
 
+// expectPropertyName advances to the name after a dot. Keywords are plain
+// names there, as in q.delete() or opts.in.
+func (p *Parser) expectPropertyName() bool {
+	if !p.peekTokenIs(lexer.IDENT) && lexer.LookupIdent(p.peekToken.Literal) == p.peekToken.Type {
+		p.nextToken()
+		return true
+	}
+	return p.expectPeek(lexer.IDENT)
+}
+
 // parseExpressionList parses a comma-separated list of expressions
 func (p *Parser) parseExpressionList(end lexer.TokenType) []ast.Expression {
 	list := []ast.Expression{}
@@ -435,103 +542,18 @@ func (p *Parser) parseSpreadElement() ast.Expression {
 	return spread
 }
 
-// containsYield recursively checks if a block statement contains yield expressions
+// containsYield reports whether a function body uses utpadan at any
+// depth; nested functions do not count, they are generators of their own
 func (p *Parser) containsYield(block *ast.BlockStatement) bool {
-	if block == nil {
-		return false
-	}
-
-	for _, stmt := range block.Statements {
-		if p.statementContainsYield(stmt) {
-			return true
-		}
-	}
-
-	return false
-}
-
-// statementContainsYield checks if a statement contains yield
-func (p *Parser) statementContainsYield(stmt ast.Statement) bool {
-	switch s := stmt.(type) {
-	case *ast.ExpressionStatement:
-		return p.expressionContainsYield(s.Expression)
-	case *ast.ReturnStatement:
-		if s.ReturnValue != nil {
-			return p.expressionContainsYield(s.ReturnValue)
+	found := false
+	ast.Inspect(block, func(node ast.Node) bool {
+		switch node.(type) {
+		case *ast.YieldExpression:
+			found = true
+		case *ast.FunctionLiteral, *ast.AsyncFunctionLiteral:
+			return false
 		}
-	case *ast.VariableDeclaration:
-		if s.Value != nil {
-			return p.expressionContainsYield(s.Value)
-		}
-	case *ast.IfStatement:
-		if p.expressionContainsYield(s.Condition) {
-			return true
-		}
-		if p.containsYield(s.Consequence) {
-			return true
-		}
-		if s.Alternative != nil && p.containsYield(s.Alternative) {
-			return true
-		}
-	case *ast.WhileStatement:
-		if p.expressionContainsYield(s.Condition) {
-			return true
-		}
-		if p.containsYield(s.Body) {
-			return true
-		}
-	case *ast.ForStatement:
-		if s.Init != nil && p.statementContainsYield(s.Init) {
-			return true
-		}
-		if s.Condition != nil && p.expressionContainsYield(s.Condition) {
-			return true
-		}
-		if s.Update != nil && p.expressionContainsYield(s.Update) {
-			return true
-		}
-		if p.containsYield(s.Body) {
-			return true
-		}
-	}
-	return false
-}
-
-// expressionContainsYield checks if an expression contains yield
-func (p *Parser) expressionContainsYield(expr ast.Expression) bool {
-	if expr == nil {
-		return false
-	}
-
-	switch e := expr.(type) {
-	case *ast.YieldExpression:
-		return true
-	case *ast.CallExpression:
-		if p.expressionContainsYield(e.Function) {
-			return true
-		}
-		for _, arg := range e.Arguments {
-			if p.expressionContainsYield(arg) {
-				return true
-			}
-		}
-	case *ast.BinaryExpression:
-		return p.expressionContainsYield(e.Left) || p.expressionContainsYield(e.Right)
-	case *ast.UnaryExpression:
-		return p.expressionContainsYield(e.Right)
-	case *ast.ArrayLiteral:
-		for _, elem := range e.Elements {
-			if p.expressionContainsYield(elem) {
-				return true
-			}
-		}
-	case *ast.MapLiteral:
-		for key, val := range e.Pairs {
-			if p.expressionContainsYield(key) || p.expressionContainsYield(val) {
-				return true
-			}
-		}
-	}
-
-	return false
+		return !found
+	})
+	return found
 }
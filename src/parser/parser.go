@@ -15,8 +15,13 @@ type (
 
 // Parser represents the BanglaCode parser
 type Parser struct {
-	l      *lexer.Lexer
-	errors []string
+	l           *lexer.Lexer
+	diagnostics []Diagnostic
+	recovering  bool           // an error was reported; skip until the next statement
+	depth       int            // number of '{' opened up to curToken
+	labels      []string       // labeled statements enclosing curToken
+	grouped     ast.Expression // expression closed by the last ')', to tell (-a) ** b from -a ** b
+	assigning   bool           // reading the pattern of a destructuring assignment, which may bind members
 
 	curToken  lexer.Token
 	peekToken lexer.Token
@@ -27,7 +32,7 @@ type Parser struct {
 
 // New creates a new parser from a lexer
 func New(l *lexer.Lexer) *Parser {
-	p := &Parser{l: l, errors: []string{}}
+	p := &Parser{l: l}
 	p.prefixParseFns = make(map[lexer.TokenType]prefixParseFn)
 	p.infixParseFns = make(map[lexer.TokenType]infixParseFn)
 	p.registerPrefixParsers()
@@ -48,6 +53,7 @@ func (p *Parser) registerPrefixParsers() {
 	p.registerPrefix(lexer.BANG, p.parseUnaryExpression)
 	p.registerPrefix(lexer.NA, p.parseUnaryExpression)
 	p.registerPrefix(lexer.MINUS, p.parseUnaryExpression)
+	p.registerPrefix(lexer.TILDE, p.parseUnaryExpression)
 	p.registerPrefix(lexer.LPAREN, p.parseGroupedExpression)
 	p.registerPrefix(lexer.LBRACKET, p.parseArrayLiteral)
 	p.registerPrefix(lexer.LBRACE, p.parseMapLiteral)
@@ -66,6 +72,13 @@ func (p *Parser) registerInfixParsers() {
 	p.registerInfix(lexer.ASTERISK, p.parseBinaryExpression)
 	p.registerInfix(lexer.SLASH, p.parseBinaryExpression)
 	p.registerInfix(lexer.PERCENT, p.parseBinaryExpression)
+	p.registerInfix(lexer.POWER, p.parseBinaryExpression)
+	p.registerInfix(lexer.AMPERSAND, p.parseBinaryExpression)
+	p.registerInfix(lexer.PIPE, p.parseBinaryExpression)
+	p.registerInfix(lexer.CARET, p.parseBinaryExpression)
+	p.registerInfix(lexer.SHL, p.parseBinaryExpression)
+	p.registerInfix(lexer.SHR, p.parseBinaryExpression)
+	p.registerInfix(lexer.USHR, p.parseBinaryExpression)
 	p.registerInfix(lexer.EQ, p.parseBinaryExpression)
 	p.registerInfix(lexer.NOT_EQ, p.parseBinaryExpression)
 	p.registerInfix(lexer.LT, p.parseBinaryExpression)
@@ -81,28 +94,48 @@ func (p *Parser) registerInfixParsers() {
 	p.registerInfix(lexer.MINUS_ASSIGN, p.parseAssignmentExpression)
 	p.registerInfix(lexer.ASTERISK_ASSIGN, p.parseAssignmentExpression)
 	p.registerInfix(lexer.SLASH_ASSIGN, p.parseAssignmentExpression)
+	p.registerInfix(lexer.PERCENT_ASSIGN, p.parseAssignmentExpression)
+	p.registerInfix(lexer.POWER_ASSIGN, p.parseAssignmentExpression)
+	p.registerInfix(lexer.AND_BIT_ASSIGN, p.parseAssignmentExpression)
+	p.registerInfix(lexer.OR_BIT_ASSIGN, p.parseAssignmentExpression)
+	p.registerInfix(lexer.XOR_ASSIGN, p.parseAssignmentExpression)
+	p.registerInfix(lexer.SHL_ASSIGN, p.parseAssignmentExpression)
+	p.registerInfix(lexer.SHR_ASSIGN, p.parseAssignmentExpression)
+	p.registerInfix(lexer.USHR_ASSIGN, p.parseAssignmentExpression)
+	p.registerInfix(lexer.NULLISH_ASSIGN, p.parseAssignmentExpression)
+	p.registerInfix(lexer.OR_ASSIGN, p.parseAssignmentExpression)
+	p.registerInfix(lexer.AND_ASSIGN, p.parseAssignmentExpression)
+	p.registerInfix(lexer.NULLISH, p.parseBinaryExpression)
+	p.registerInfix(lexer.QUESTION, p.parseConditionalExpression)
+	p.registerInfix(lexer.OPTIONAL, p.parseOptionalChain)
 	p.registerInfix(lexer.LPAREN, p.parseCallExpression)
 	p.registerInfix(lexer.LBRACKET, p.parseMemberExpression)
 	p.registerInfix(lexer.DOT, p.parseMemberExpression)
 	p.registerInfix(lexer.ARROW, p.parseArrowFunctionExpression)
 }
 
-// Errors returns the list of parsing errors
-func (p *Parser) Errors() []string {
-	return p.errors
-}
-
 // peekError adds an error for unexpected peek token
 func (p *Parser) peekError(t lexer.TokenType) {
-	msg := fmt.Sprintf("expected next token to be %s, got %s at line %d, column %d",
-		t, p.peekToken.Type, p.peekToken.Line, p.peekToken.Column)
-	p.errors = append(p.errors, msg)
+	fix := ""
+	switch t {
+	case lexer.SEMICOLON, lexer.RPAREN, lexer.RBRACKET, lexer.RBRACE, lexer.LPAREN, lexer.LBRACE, lexer.COLON:
+		fix = fmt.Sprintf("insert '%s'", t)
+	}
+	p.addError(CodeUnexpectedToken, p.peekToken, fix,
+		"expected next token to be %s, got %s", t, p.peekToken.Type)
 }
 
 // nextToken advances to the next token
 func (p *Parser) nextToken() {
 	p.curToken = p.peekToken
 	p.peekToken = p.l.NextToken()
+
+	switch p.curToken.Type {
+	case lexer.LBRACE:
+		p.depth++
+	case lexer.RBRACE:
+		p.depth--
+	}
 }
 
 // curTokenIs checks if current token is of given type
@@ -141,8 +174,11 @@ func (p *Parser) ParseProgram() *ast.Program {
 	program.Statements = []ast.Statement{}
 
 	for !p.curTokenIs(lexer.EOF) {
+		depth := p.depth
 		stmt := p.parseStatement()
-		if stmt != nil {
+		if p.recovering {
+			p.synchronize(depth)
+		} else if stmt != nil {
 			program.Statements = append(program.Statements, stmt)
 		}
 		p.nextToken()
@@ -6,18 +6,25 @@ import "BanglaCode/src/lexer"
 const (
 	_ int = iota
 	LOWEST
-	ASSIGN      // =, +=, -=, *=, /=
+	ASSIGN      // =, +=, -=, *=, /=, %=, **=, &=, |=, ^=, <<=, >>=, >>>=, ??=, ||=, &&=
 	ARROWP      // =>
+	TERNARY     // cond ? a : b
+	NULLISH     // ??
 	OR          // ba (||)
 	AND         // ebong (&&)
+	BITOR       // |
+	BITXOR      // ^
+	BITAND      // &
 	INOP        // in, instanceof
 	EQUALS      // ==, !=
 	LESSGREATER // <, >, <=, >=
+	SHIFT       // <<, >>, >>>
 	SUM         // +, -
 	PRODUCT     // *, /, %
-	PREFIX      // -x, !x, na x
+	POWER       // ** (right-associative)
+	PREFIX      // -x, !x, na x, ~x
 	CALL        // function(x)
-	INDEX       // array[index], obj.prop
+	INDEX       // array[index], obj.prop, obj?.prop
 )
 
 // precedences maps token types to their precedence levels
@@ -27,9 +34,25 @@ var precedences = map[lexer.TokenType]int{
 	lexer.MINUS_ASSIGN:    ASSIGN,
 	lexer.ASTERISK_ASSIGN: ASSIGN,
 	lexer.SLASH_ASSIGN:    ASSIGN,
+	lexer.PERCENT_ASSIGN:  ASSIGN,
+	lexer.POWER_ASSIGN:    ASSIGN,
+	lexer.AND_BIT_ASSIGN:  ASSIGN,
+	lexer.OR_BIT_ASSIGN:   ASSIGN,
+	lexer.XOR_ASSIGN:      ASSIGN,
+	lexer.SHL_ASSIGN:      ASSIGN,
+	lexer.SHR_ASSIGN:      ASSIGN,
+	lexer.USHR_ASSIGN:     ASSIGN,
+	lexer.NULLISH_ASSIGN:  ASSIGN,
+	lexer.OR_ASSIGN:       ASSIGN,
+	lexer.AND_ASSIGN:      ASSIGN,
 	lexer.ARROW:           ARROWP,
+	lexer.QUESTION:        TERNARY,
+	lexer.NULLISH:         NULLISH,
 	lexer.BA:              OR,
 	lexer.EBONG:           AND,
+	lexer.PIPE:            BITOR,
+	lexer.CARET:           BITXOR,
+	lexer.AMPERSAND:       BITAND,
 	lexer.IN:              INOP,
 	lexer.INSTANCEOF:      INOP,
 	lexer.EQ:              EQUALS,
@@ -38,14 +61,19 @@ var precedences = map[lexer.TokenType]int{
 	lexer.GT:              LESSGREATER,
 	lexer.LTE:             LESSGREATER,
 	lexer.GTE:             LESSGREATER,
+	lexer.SHL:             SHIFT,
+	lexer.SHR:             SHIFT,
+	lexer.USHR:            SHIFT,
 	lexer.PLUS:            SUM,
 	lexer.MINUS:           SUM,
 	lexer.ASTERISK:        PRODUCT,
 	lexer.SLASH:           PRODUCT,
 	lexer.PERCENT:         PRODUCT,
+	lexer.POWER:           POWER,
 	lexer.LPAREN:          CALL,
 	lexer.LBRACKET:        INDEX,
 	lexer.DOT:             INDEX,
+	lexer.OPTIONAL:        INDEX,
 }
 
 // peekPrecedence returns the precedence of the next token
@@ -63,3 +91,13 @@ func (p *Parser) curPrecedence() int {
 	}
 	return LOWEST
 }
+
+// Precedence returns the binding power of an infix operator token, or
+// LOWEST for tokens that are not operators (used by the formatter to
+// decide where parentheses are needed)
+func Precedence(t lexer.TokenType) int {
+	if p, ok := precedences[t]; ok {
+		return p
+	}
+	return LOWEST
+}
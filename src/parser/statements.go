@@ -3,6 +3,7 @@ package parser
 import (
 	"BanglaCode/src/ast"
 	"BanglaCode/src/lexer"
+	"slices"
 )
 
 // parseStatement determines which statement to parse based on the current token
@@ -40,6 +41,11 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseThrowStatement()
 	case lexer.BIKOLPO:
 		return p.parseSwitchStatement()
+	case lexer.IDENT:
+		if p.peekTokenIs(lexer.COLON) {
+			return p.parseLabeledStatement()
+		}
+		return p.parseExpressionStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -49,14 +55,9 @@ func (p *Parser) parseStatement() ast.Statement {
 func (p *Parser) parseVariableDeclaration(isConstant bool, isGlobal bool) ast.Statement {
 	declToken := p.curToken
 
-	if p.peekTokenIs(lexer.LBRACKET) {
-		p.nextToken()
-		return p.parseArrayDestructuringDeclaration(declToken, isConstant, isGlobal)
-	}
-
-	if p.peekTokenIs(lexer.LBRACE) {
+	if p.peekTokenIs(lexer.LBRACKET) || p.peekTokenIs(lexer.LBRACE) {
 		p.nextToken()
-		return p.parseObjectDestructuringDeclaration(declToken, isConstant, isGlobal)
+		return p.parseDestructuringDeclaration(declToken, isConstant, isGlobal)
 	}
 
 	stmt := &ast.VariableDeclaration{
@@ -79,6 +80,10 @@ func (p *Parser) parseVariableDeclaration(isConstant bool, isGlobal bool) ast.St
 
 	stmt.Value = p.parseExpression(LOWEST)
 
+	if p.peekTokenIs(lexer.COMMA) {
+		return p.parseDeclarationList(declToken, stmt)
+	}
+
 	if p.peekTokenIs(lexer.SEMICOLON) {
 		p.nextToken()
 	}
@@ -86,6 +91,37 @@ func (p *Parser) parseVariableDeclaration(isConstant bool, isGlobal bool) ast.St
 	return stmt
 }
 
+// parseDeclarationList parses the rest of "dhoro a = 1, b = 2" after the
+// first declaration; every name shares the keyword of the first
+func (p *Parser) parseDeclarationList(declToken lexer.Token, first *ast.VariableDeclaration) ast.Statement {
+	list := &ast.DeclarationList{Token: declToken, Declarations: []*ast.VariableDeclaration{first}}
+
+	for p.peekTokenIs(lexer.COMMA) {
+		p.nextToken()
+		if !p.expectPeek(lexer.IDENT) {
+			return nil
+		}
+		decl := &ast.VariableDeclaration{
+			Token:      declToken,
+			Name:       &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal},
+			IsConstant: first.IsConstant,
+			IsGlobal:   first.IsGlobal,
+		}
+		if !p.expectPeek(lexer.ASSIGN) {
+			return nil
+		}
+		p.nextToken()
+		decl.Value = p.parseExpression(LOWEST)
+		list.Declarations = append(list.Declarations, decl)
+	}
+
+	if p.peekTokenIs(lexer.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return list
+}
+
 // parseIfStatement parses "jodi (condition) { } nahole { }"
 func (p *Parser) parseIfStatement() *ast.IfStatement {
 	stmt := &ast.IfStatement{Token: p.curToken}
@@ -161,13 +197,32 @@ func (p *Parser) parseWhileStatement() *ast.WhileStatement {
 func (p *Parser) parseForStatement() ast.Statement {
 	forToken := p.curToken
 
+	// ghuriye opekha (x of src) awaits each value
+	awaits := p.peekTokenIs(lexer.OPEKHA)
+	if awaits {
+		p.nextToken()
+	}
+
 	if !p.expectPeek(lexer.LPAREN) {
 		return nil
 	}
 
 	p.nextToken()
 
-	if iterStmt := p.parseForInOrForOf(forToken); iterStmt != nil {
+	iterStmt := p.parseForInOrForOf(forToken)
+	if awaits {
+		if forOf, ok := iterStmt.(*ast.ForOfStatement); ok {
+			forOf.Await = true
+			return forOf
+		}
+		// Parse the rest of the loop so that it is reported only once
+		if iterStmt == nil {
+			p.parseClassicForStatement(forToken)
+		}
+		p.addError(CodeInvalidForAwait, forToken, "", "ghuriye opekha needs the form (name of iterable)")
+		return nil
+	}
+	if iterStmt != nil {
 		return iterStmt
 	}
 
@@ -290,6 +345,9 @@ func (p *Parser) parseClassDeclaration() *ast.ClassDeclaration {
 		}
 		p.nextToken()
 	}
+	if p.curTokenIs(lexer.RBRACE) {
+		stmt.Rbrace = p.curToken
+	}
 
 	return stmt
 }
@@ -318,7 +376,7 @@ func (p *Parser) parseGetter() *ast.FunctionLiteral {
 	}
 
 	lit.Body = p.parseBlockStatement()
-	lit.Parameters = []*ast.Identifier{}
+	lit.Parameters = []*ast.Parameter{}
 
 	return lit
 }
@@ -341,7 +399,7 @@ func (p *Parser) parseSetter() *ast.FunctionLiteral {
 	lit.Parameters = p.parseFunctionParameters()
 
 	if len(lit.Parameters) != 1 {
-		p.errors = append(p.errors, "setter must have exactly one parameter")
+		p.addError(CodeInvalidSetter, lit.Name.Token, "give the setter exactly one parameter", "setter must have exactly one parameter")
 		return nil
 	}
 
@@ -399,24 +457,58 @@ func (p *Parser) parseConstructor() *ast.FunctionLiteral {
 	return lit
 }
 
-// parseBreakStatement parses "thamo"
+// parseBreakStatement parses "thamo" or "thamo label"
 func (p *Parser) parseBreakStatement() *ast.BreakStatement {
-	stmt := &ast.BreakStatement{Token: p.curToken}
+	stmt := &ast.BreakStatement{Token: p.curToken, Label: p.parseJumpLabel()}
 	if p.peekTokenIs(lexer.SEMICOLON) {
 		p.nextToken()
 	}
 	return stmt
 }
 
-// parseContinueStatement parses "chharo"
+// parseContinueStatement parses "chharo" or "chharo label"
 func (p *Parser) parseContinueStatement() *ast.ContinueStatement {
-	stmt := &ast.ContinueStatement{Token: p.curToken}
+	stmt := &ast.ContinueStatement{Token: p.curToken, Label: p.parseJumpLabel()}
 	if p.peekTokenIs(lexer.SEMICOLON) {
 		p.nextToken()
 	}
 	return stmt
 }
 
+// parseJumpLabel parses the optional label after thamo/chharo. It must be
+// on the same line, so a name on the next line starts a new statement.
+func (p *Parser) parseJumpLabel() *ast.Identifier {
+	if !p.peekTokenIs(lexer.IDENT) || p.peekToken.Line != p.curToken.Line {
+		return nil
+	}
+	keyword := p.curToken.Literal
+	p.nextToken()
+	label := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	if !slices.Contains(p.labels, label.Value) {
+		p.addError(CodeUndefinedLabel, p.curToken, "", "%s: label '%s' is not defined", keyword, label.Value)
+	}
+	return label
+}
+
+// parseLabeledStatement parses "label: statement"; thamo label and
+// chharo label inside the statement refer to it
+func (p *Parser) parseLabeledStatement() *ast.LabeledStatement {
+	stmt := &ast.LabeledStatement{
+		Token: p.curToken,
+		Label: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal},
+	}
+	p.nextToken() // ':'
+	p.nextToken()
+
+	p.labels = append(p.labels, stmt.Label.Value)
+	stmt.Body = p.parseStatement()
+	p.labels = p.labels[:len(p.labels)-1]
+	if stmt.Body == nil {
+		return nil
+	}
+	return stmt
+}
+
 // parseBlockStatement parses "{ statements }"
 func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	block := &ast.BlockStatement{Token: p.curToken}
@@ -425,12 +517,18 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	p.nextToken()
 
 	for !p.curTokenIs(lexer.RBRACE) && !p.curTokenIs(lexer.EOF) {
+		depth := p.depth
 		stmt := p.parseStatement()
-		if stmt != nil {
+		if p.recovering {
+			p.synchronize(depth)
+		} else if stmt != nil {
 			block.Statements = append(block.Statements, stmt)
 		}
 		p.nextToken()
 	}
+	if p.curTokenIs(lexer.RBRACE) {
+		block.Rbrace = p.curToken
+	}
 
 	return block
 }
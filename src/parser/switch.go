@@ -30,6 +30,9 @@ func (p *Parser) parseSwitchStatement() *ast.SwitchStatement {
 		}
 		return nil
 	}
+	if p.curTokenIs(lexer.RBRACE) {
+		stmt.Rbrace = p.curToken
+	}
 	return stmt
 }
 
@@ -3,6 +3,7 @@ package repl
 import (
 	"BanglaCode/src/evaluator"
 	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/eventloop"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
 	"BanglaCode/src/parser"
@@ -217,11 +218,14 @@ func Start(in io.Reader, out io.Writer) {
 		p := parser.New(l)
 
 		program := p.ParseProgram()
-		if len(p.Errors()) != 0 {
-			printParserErrors(out, p.Errors())
+		if len(p.Diagnostics()) != 0 {
+			printParserErrors(out, p.Diagnostics(), input)
 			continue
 		}
 
+		// Timers and promises left behind keep running in the background
+		// between lines
+		eventloop.Lock()
 		evaluated := evaluator.Eval(program, env)
 		if evaluated != nil {
 			if evaluated.Type() != object.NULL_OBJ && evaluated.Type() != object.ERROR_OBJ {
@@ -233,6 +237,7 @@ func Start(in io.Reader, out io.Writer) {
 				io.WriteString(out, Reset+"\n")
 			}
 		}
+		eventloop.Unlock()
 	}
 }
 
@@ -266,14 +271,20 @@ func readMultiLine(scanner *bufio.Scanner, initial string) string {
 	return builder.String()
 }
 
-func printParserErrors(out io.Writer, errors []string) {
+func printParserErrors(out io.Writer, diagnostics []parser.Diagnostic, input string) {
 	io.WriteString(out, Red)
 	io.WriteString(out, "╔════════════════════════════════════════════╗\n")
 	io.WriteString(out, "║  Bhul! Parser Errors                       ║\n")
 	io.WriteString(out, "╚════════════════════════════════════════════╝\n")
 	io.WriteString(out, "\033[0;31m") // Regular Red
-	for _, msg := range errors {
-		io.WriteString(out, "  ▸ "+msg+"\n")
+	for _, d := range diagnostics {
+		io.WriteString(out, "  ▸ "+d.String()+"\n")
+		if excerpt := object.SourceExcerpt(input, d.Start.Line, d.Start.Column, d.End.Column-d.Start.Column); excerpt != "" {
+			io.WriteString(out, excerpt+"\n")
+		}
+		if d.Fix != "" {
+			io.WriteString(out, "    sahajjo: "+d.Fix+"\n")
+		}
 	}
 	io.WriteString(out, Reset)
 }
@@ -0,0 +1,159 @@
+package testrunner
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ==================== Human-readable output ====================
+
+func paint(color bool, code, text string) string {
+	if !color {
+		return text
+	}
+	return "\033[" + code + "m" + text + "\033[0m"
+}
+
+func printFile(out io.Writer, file string, results []Result, color bool) {
+	fmt.Fprintln(out, paint(color, "1", file))
+	for _, res := range results {
+		if res.Name == "" {
+			fmt.Fprintf(out, "  %s could not load file\n", paint(color, "31", "✗"))
+			printMessage(out, res.Message, color)
+			continue
+		}
+		switch res.Status {
+		case Passed:
+			fmt.Fprintf(out, "  %s %s %s\n", paint(color, "32", "✓"), res.FullName(), paint(color, "2", "("+formatDuration(res.Duration)+")"))
+		default:
+			fmt.Fprintf(out, "  %s %s\n", paint(color, "31", "✗"), res.FullName())
+			printMessage(out, res.Message, color)
+		}
+	}
+}
+
+func printMessage(out io.Writer, message string, color bool) {
+	for _, line := range strings.Split(message, "\n") {
+		fmt.Fprintln(out, "      "+paint(color, "31", line))
+	}
+}
+
+func printSummary(out io.Writer, report *Report, color bool) {
+	passed, failed, errored := report.Count(Passed), report.Count(Failed), report.Count(Errored)
+	parts := []string{paint(color, "32", fmt.Sprintf("%d passed", passed))}
+	if failed > 0 {
+		parts = append(parts, paint(color, "31", fmt.Sprintf("%d failed", failed)))
+	}
+	if errored > 0 {
+		parts = append(parts, paint(color, "31", fmt.Sprintf("%d error(s)", errored)))
+	}
+	fmt.Fprintf(out, "\n%s (%d tests, %s)\n", strings.Join(parts, ", "), len(report.Results), formatDuration(report.Duration))
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Millisecond {
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	}
+	return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000)
+}
+
+// ==================== JUnit XML ====================
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML: one testsuite per file, one
+// testcase per test (classname is the file plus its describe groups)
+func WriteJUnit(w io.Writer, report *Report) error {
+	doc := junitTestSuites{Time: seconds(report.Duration)}
+	index := make(map[string]int)
+	for _, res := range report.Results {
+		i, ok := index[res.File]
+		if !ok {
+			i = len(doc.Suites)
+			index[res.File] = i
+			doc.Suites = append(doc.Suites, junitTestSuite{Name: res.File})
+		}
+		suite := &doc.Suites[i]
+
+		name := res.Name
+		if name == "" {
+			name = "(load)"
+		}
+		tc := junitTestCase{
+			Name:      name,
+			ClassName: strings.Join(append([]string{res.File}, res.Groups...), "."),
+			Time:      seconds(res.Duration),
+		}
+		firstLine, _, _ := strings.Cut(res.Message, "\n")
+		switch res.Status {
+		case Failed:
+			tc.Failure = &junitProblem{Message: firstLine, Type: "AssertionError", Text: res.Message}
+			suite.Failures++
+			doc.Failures++
+		case Errored:
+			tc.Error = &junitProblem{Message: firstLine, Type: "Error", Text: res.Message}
+			suite.Errors++
+			doc.Errors++
+		}
+		suite.Tests++
+		doc.Tests++
+		suite.Cases = append(suite.Cases, tc)
+	}
+	for i := range doc.Suites {
+		var total time.Duration
+		for _, res := range report.Results {
+			if res.File == doc.Suites[i].Name {
+				total += res.Duration
+			}
+		}
+		doc.Suites[i].Time = seconds(total)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,297 @@
+// Package testrunner implements banglacode test: it discovers *_test.bang
+// files, collects the tests they declare with porikkha (and group with
+// describe), runs them one by one and reports the results as text and as
+// JUnit XML.
+package testrunner
+
+import (
+	"BanglaCode/src/evaluator"
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/eventloop"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/object"
+	"BanglaCode/src/packages"
+	"BanglaCode/src/parser"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Status is the outcome of one test
+type Status string
+
+const (
+	Passed  Status = "pass"
+	Failed  Status = "fail"  // an assertion failed
+	Errored Status = "error" // the test threw something other than an AssertionError
+)
+
+// Result is the outcome of one porikkha (or of a file that could not be
+// loaded, in which case Name is empty)
+type Result struct {
+	File     string
+	Groups   []string // enclosing describe names, outermost first
+	Name     string
+	Status   Status
+	Message  string
+	Duration time.Duration
+}
+
+// FullName joins the describe groups and the test name with " > "
+func (r Result) FullName() string {
+	return strings.Join(append(append([]string{}, r.Groups...), r.Name), " > ")
+}
+
+// Report collects the results of a run
+type Report struct {
+	Results  []Result
+	Duration time.Duration
+}
+
+// Count returns how many results have the given status
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// OK reports whether every test passed
+func (r *Report) OK() bool {
+	return r.Count(Failed) == 0 && r.Count(Errored) == 0
+}
+
+// Options control a run
+type Options struct {
+	Filter  *regexp.Regexp // only run tests whose full name matches
+	Timeout time.Duration  // per test, for async tests (default 5s)
+	Out     io.Writer      // human-readable output; nil for none
+	Color   bool
+}
+
+// test is a porikkha collected while loading a file
+type test struct {
+	groups []string
+	name   string
+	fn     object.Object
+	env    *object.Environment
+}
+
+// Discover expands paths into the *_test.bang files they contain; plain
+// files are taken as given. Directories are walked recursively, skipping
+// hidden directories and installed packages.
+func Discover(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				name := d.Name()
+				if p != path && (strings.HasPrefix(name, ".") || name == packages.ModulesDir || name == "node_modules") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if IsTestFile(p) {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// IsTestFile reports whether path names a test file (name_test.bang,
+// .bangla or .bong)
+func IsTestFile(path string) bool {
+	for _, ext := range []string{".bang", ".bangla", ".bong"} {
+		if strings.HasSuffix(path, "_test"+ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// Run loads and runs every test in files
+func Run(files []string, opts Options) *Report {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	start := time.Now()
+	report := &Report{}
+	for _, file := range files {
+		results := runFile(file, opts)
+		report.Results = append(report.Results, results...)
+		if opts.Out != nil {
+			printFile(opts.Out, file, results, opts.Color)
+		}
+	}
+	report.Duration = time.Since(start)
+	if opts.Out != nil {
+		printSummary(opts.Out, report, opts.Color)
+	}
+	return report
+}
+
+// runFile evaluates one test file to collect its tests, then runs them
+func runFile(file string, opts Options) []Result {
+	fileError := func(message string) []Result {
+		return []Result{{File: file, Status: Errored, Message: message}}
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fileError(err.Error())
+	}
+	p := parser.New(lexer.NewWithFile(string(content), file))
+	program := p.ParseProgram()
+	if len(p.Diagnostics()) != 0 {
+		lines := make([]string, len(p.Diagnostics()))
+		for i, d := range p.Diagnostics() {
+			lines[i] = d.String()
+		}
+		return fileError(strings.Join(lines, "\n"))
+	}
+
+	// The file and its tests run on the event loop; whatever they leave
+	// scheduled runs in the background afterwards
+	eventloop.Lock()
+	defer eventloop.Unlock()
+
+	absPath, _ := filepath.Abs(file)
+	evaluator.SetCurrentDir(filepath.Dir(absPath))
+	evaluator.ResetModules()
+
+	env := object.NewEnvironment()
+	builtins.InitializeEnvironmentWithConstants(env)
+	var tests []test
+	var groups []string
+	collecting := true
+
+	// porikkha (পরীক্ষা - test) registers a test
+	env.Set("porikkha", &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return &object.Error{Message: fmt.Sprintf("wrong number of arguments. got=%d, want=2", len(args))}
+			}
+			name, ok := args[0].(*object.String)
+			if !ok {
+				return &object.Error{Message: fmt.Sprintf("porikkha name must be STRING, got %s", args[0].Type())}
+			}
+			if !collecting {
+				return &object.Error{Message: "porikkha cannot be declared inside a running test"}
+			}
+			tests = append(tests, test{groups: append([]string{}, groups...), name: name.Value, fn: args[1], env: env})
+			return object.NULL
+		},
+	})
+
+	// describe groups the tests declared inside fn under a name
+	env.Set("describe", &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 2 {
+				return &object.Error{Message: fmt.Sprintf("wrong number of arguments. got=%d, want=2", len(args))}
+			}
+			name, ok := args[0].(*object.String)
+			if !ok {
+				return &object.Error{Message: fmt.Sprintf("describe name must be STRING, got %s", args[0].Type())}
+			}
+			groups = append(groups, name.Value)
+			defer func() { groups = groups[:len(groups)-1] }()
+			if result := evaluator.ApplyFunction(args[1], nil, env, lexer.Token{}, nil); isFailure(result) {
+				return result
+			}
+			return object.NULL
+		},
+	})
+
+	if result := evaluator.Eval(program, env); isFailure(result) {
+		return fileError(failureMessage(result))
+	}
+	collecting = false
+
+	var results []Result
+	for _, t := range tests {
+		res := Result{File: file, Groups: t.groups, Name: t.name}
+		if opts.Filter != nil && !opts.Filter.MatchString(res.FullName()) {
+			continue
+		}
+		started := time.Now()
+		res.Status, res.Message = runTest(t, opts.Timeout)
+		res.Duration = time.Since(started)
+		results = append(results, res)
+	}
+	return results
+}
+
+// runTest calls a test function; async tests are awaited
+func runTest(t test, timeout time.Duration) (Status, string) {
+	result := evaluator.ApplyFunction(t.fn, nil, t.env, lexer.Token{}, nil)
+	if promise, ok := result.(*object.Promise); ok {
+		started := time.Now()
+		result = object.AwaitPromise(promise, timeout)
+		switch {
+		case !promise.Settled() && time.Since(started) >= timeout:
+			return Errored, fmt.Sprintf("test timed out after %s", timeout)
+		case !promise.Settled():
+			return Errored, failureMessage(result)
+		case promise.State == object.PROMISE_REJECTED && !isFailure(result):
+			result = &object.Exception{Message: result.Inspect(), Value: result}
+		}
+	}
+
+	switch {
+	case builtins.IsAssertionFailure(result):
+		return Failed, failureMessage(result)
+	case isFailure(result):
+		return Errored, failureMessage(result)
+	}
+	return Passed, ""
+}
+
+func isFailure(obj object.Object) bool {
+	if obj == nil {
+		return false
+	}
+	return obj.Type() == object.ERROR_OBJ || obj.Type() == object.EXCEPTION_OBJ
+}
+
+func failureMessage(obj object.Object) string {
+	switch v := obj.(type) {
+	case *object.Exception:
+		if errorMap, ok := v.Value.(*object.Map); ok {
+			name, _ := errorMap.Pairs["name"].(*object.String)
+			message, _ := errorMap.Pairs["message"].(*object.String)
+			if name != nil && message != nil {
+				return name.Value + ": " + message.Value
+			}
+		}
+		return v.Message
+	case *object.Error:
+		return v.Inspect()
+	}
+	return obj.Inspect()
+}
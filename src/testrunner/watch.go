@@ -0,0 +1,75 @@
+package testrunner
+
+import (
+	"BanglaCode/src/packages"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Watch calls run once, then again every time a BanglaCode source file
+// below paths is added, removed or modified. It polls every interval and
+// returns when stop is closed.
+func Watch(paths []string, interval time.Duration, stop <-chan struct{}, run func()) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	last := snapshot(paths)
+	run()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			current := snapshot(paths)
+			if !sameSnapshot(last, current) {
+				last = current
+				run()
+			}
+		}
+	}
+}
+
+// snapshot records the modification time of every source file below paths
+// (imported modules as well as tests, so editing code reruns its tests)
+func snapshot(paths []string) map[string]time.Time {
+	files := make(map[string]time.Time)
+	for _, root := range paths {
+		filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				name := d.Name()
+				if p != root && (strings.HasPrefix(name, ".") || name == packages.ModulesDir || name == "node_modules") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			switch filepath.Ext(p) {
+			case ".bang", ".bangla", ".bong", ".json":
+				if info, err := os.Stat(p); err == nil {
+					files[p] = info.ModTime()
+				}
+			}
+			return nil
+		})
+	}
+	return files
+}
+
+func sameSnapshot(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, t := range a {
+		if !b[path].Equal(t) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,645 @@
+// Package vm implements the stack-based virtual machine for BanglaCode.
+// It runs bytecode produced by src/compiler, sharing object values,
+// environments and builtins with the tree-walking evaluator.
+package vm
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/compiler"
+	"BanglaCode/src/evaluator"
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/object"
+	"fmt"
+	"sort"
+)
+
+const (
+	// initialStackSize is the starting capacity of the value stack
+	initialStackSize = 256
+	// maxFrames bounds recursion depth for compiled function calls
+	maxFrames = 100000
+)
+
+// frame is one activation of a program or function body
+type frame struct {
+	code    *compiler.Bytecode
+	ip      int
+	env     *object.Environment
+	base    int  // stack pointer when the frame was entered
+	program bool // top-level program frame
+
+	fn   *object.Function // function being run, for stack traces
+	call lexer.Token      // call site of fn
+}
+
+// VM executes compiled BanglaCode
+type VM struct {
+	stack  []object.Object
+	sp     int
+	frames []*frame
+	env    *object.Environment // program environment
+	result object.Object       // value of the last top-level statement
+
+	// compiled function literals by body, for calls to the functions
+	// created from them
+	functions map[*ast.BlockStatement]*compiler.Function
+}
+
+// New creates a VM that runs programs in env
+func New(env *object.Environment) *VM {
+	return &VM{
+		stack:     make([]object.Object, initialStackSize),
+		env:       env,
+		functions: make(map[*ast.BlockStatement]*compiler.Function),
+	}
+}
+
+// Run compiles and executes a program in env, returning the same value
+// evaluator.Eval would return for it
+func Run(program *ast.Program, env *object.Environment) object.Object {
+	return New(env).Run(program)
+}
+
+// Run compiles and executes a program
+func (vm *VM) Run(program *ast.Program) object.Object {
+	code, err := compiler.Compile(program)
+	if err != nil {
+		return &object.Error{Message: err.Error()}
+	}
+
+	vm.register(code)
+	vm.sp = 0
+	vm.result = nil
+	vm.frames = append(vm.frames[:0], &frame{code: code, env: vm.env, program: true})
+	return vm.run()
+}
+
+// run is the fetch-decode-execute loop
+func (vm *VM) run() object.Object {
+	for {
+		f := vm.frames[len(vm.frames)-1]
+		ins := f.code.Instructions
+
+		if f.ip >= len(ins) {
+			if f.program {
+				return vm.result
+			}
+			var completion object.Object
+			if vm.sp > f.base {
+				completion = vm.stack[vm.sp-1]
+			}
+			if out, done := vm.leave(completion); done {
+				return out
+			}
+			continue
+		}
+
+		op := compiler.Opcode(ins[f.ip])
+		f.ip++
+
+		var signal object.Object
+
+		switch op {
+		case compiler.OpConstant:
+			idx := vm.readOperand(f)
+			vm.push(f.code.Constants[idx])
+
+		case compiler.OpNull:
+			vm.push(object.NULL)
+
+		case compiler.OpNil:
+			vm.push(nil)
+
+		case compiler.OpPop:
+			vm.sp--
+
+		case compiler.OpEndStmt:
+			val := vm.pop()
+			if isControlSignal(val) {
+				signal = val
+			}
+
+		case compiler.OpLoopSignal:
+			exit := vm.readOperand(f)
+			cont := vm.readOperand(f)
+			val := vm.pop()
+			switch {
+			case val == object.BREAK:
+				f.ip = exit
+			case val == object.CONTINUE:
+				f.ip = cont
+			case isControlSignal(val):
+				signal = val
+			}
+
+		case compiler.OpResult:
+			val := vm.pop()
+			if rv, ok := val.(*object.ReturnValue); ok {
+				return rv.Value
+			}
+			vm.result = val
+
+		case compiler.OpJump:
+			f.ip = vm.readOperand(f)
+
+		case compiler.OpJumpIfFalse:
+			target := vm.readOperand(f)
+			if !evaluator.IsTruthy(vm.pop()) {
+				f.ip = target
+			}
+
+		case compiler.OpJumpIfNotNull:
+			target := vm.readOperand(f)
+			if vm.stack[vm.sp-1] != object.NULL {
+				f.ip = target
+			} else {
+				vm.pop()
+			}
+
+		case compiler.OpGetName:
+			ident := f.code.Nodes[vm.readOperand(f)].(*ast.Identifier)
+			val := lookupName(ident, f.env)
+			if isError(val) {
+				signal = val
+				break
+			}
+			vm.push(val)
+
+		case compiler.OpDefine:
+			val := vm.stack[vm.sp-1]
+			switch node := f.code.Nodes[vm.readOperand(f)].(type) {
+			case *ast.VariableDeclaration:
+				switch {
+				case node.IsConstant:
+					f.env.SetConstant(node.Name.Value, val)
+				case node.IsGlobal:
+					f.env.SetGlobal(node.Name.Value, val)
+				default:
+					f.env.Set(node.Name.Value, val)
+				}
+			case *ast.Identifier:
+				f.env.Set(node.Value, val)
+			}
+
+		case compiler.OpGetLocal:
+			slot := vm.readOperand(f)
+			ident := f.code.Nodes[vm.readOperand(f)].(*ast.Identifier)
+			val := loadSlot(f.env, slot, ident)
+			if isError(val) {
+				signal = val
+				break
+			}
+			vm.push(val)
+
+		case compiler.OpGetFree:
+			env := outer(f.env, vm.readOperand(f))
+			slot := vm.readOperand(f)
+			ident := f.code.Nodes[vm.readOperand(f)].(*ast.Identifier)
+			val := loadSlot(env, slot, ident)
+			if isError(val) {
+				signal = val
+				break
+			}
+			vm.push(val)
+
+		case compiler.OpDefineLocal:
+			f.env.SetSlot(vm.readOperand(f), vm.stack[vm.sp-1])
+
+		case compiler.OpSetLocal:
+			slot := vm.readOperand(f)
+			assign := f.code.Nodes[vm.readOperand(f)].(*ast.AssignmentExpression)
+			val := storeSlot(f.env, slot, assign, vm.pop())
+			if isError(val) {
+				signal = val
+				break
+			}
+			vm.push(val)
+
+		case compiler.OpSetFree:
+			env := outer(f.env, vm.readOperand(f))
+			slot := vm.readOperand(f)
+			assign := f.code.Nodes[vm.readOperand(f)].(*ast.AssignmentExpression)
+			val := storeSlot(env, slot, assign, vm.pop())
+			if isError(val) {
+				signal = val
+				break
+			}
+			vm.push(val)
+
+		case compiler.OpClosure:
+			fn := f.code.Functions[vm.readOperand(f)]
+			vm.push(&object.Function{
+				Parameters:    fn.Parameters,
+				RestParameter: fn.RestParameter,
+				Body:          fn.Body,
+				Env:           f.env,
+				Name:          fn.Name,
+				IsAsync:       fn.IsAsync,
+				IsGenerator:   fn.IsGenerator,
+			})
+
+		case compiler.OpGetMember:
+			me := f.code.Nodes[vm.readOperand(f)].(*ast.MemberExpression)
+			val := evaluator.LocateError(evaluator.AccessMember(vm.pop(), me, f.env), me.Token)
+			if isError(val) {
+				signal = val
+				break
+			}
+			vm.push(val)
+
+		case compiler.OpIndex:
+			me := f.code.Nodes[vm.readOperand(f)].(*ast.MemberExpression)
+			key := vm.pop()
+			val := evaluator.LocateError(evaluator.AccessIndex(vm.pop(), key, me, f.env), me.Token)
+			if isError(val) {
+				signal = val
+				break
+			}
+			vm.push(val)
+
+		case compiler.OpSetMember:
+			assign := f.code.Nodes[vm.readOperand(f)].(*ast.AssignmentExpression)
+			val := vm.pop()
+			obj := vm.pop()
+			val = evaluator.LocateError(evaluator.AssignMember(obj, assign.Name.(*ast.MemberExpression), assign.Operator, val, f.env), assign.Token)
+			if isError(val) {
+				signal = val
+				break
+			}
+			vm.push(val)
+
+		case compiler.OpSetIndex:
+			assign := f.code.Nodes[vm.readOperand(f)].(*ast.AssignmentExpression)
+			key := vm.pop()
+			val := vm.pop()
+			obj := vm.pop()
+			val = evaluator.LocateError(evaluator.AssignIndex(obj, key, assign.Name.(*ast.MemberExpression), assign.Operator, val, f.env), assign.Token)
+			if isError(val) {
+				signal = val
+				break
+			}
+			vm.push(val)
+
+		case compiler.OpMap:
+			literal := f.code.Nodes[vm.readOperand(f)].(*ast.MapLiteral)
+			n := len(literal.Keys)
+			m := object.NewMap()
+			for i, key := range literal.Keys {
+				m.Set(mapKey(key), vm.stack[vm.sp-n+i])
+			}
+			vm.sp -= n
+			vm.push(m)
+
+		case compiler.OpAssign:
+			assign := f.code.Nodes[vm.readOperand(f)].(*ast.AssignmentExpression)
+			val := assignName(assign, vm.pop(), f.env)
+			if isError(val) {
+				signal = val
+				break
+			}
+			vm.push(val)
+
+		case compiler.OpUnary:
+			unary := f.code.Nodes[vm.readOperand(f)].(*ast.UnaryExpression)
+			val := evaluator.LocateError(evaluator.UnaryOp(unary.Operator, vm.pop()), unary.Token)
+			if isError(val) {
+				signal = val
+				break
+			}
+			vm.push(val)
+
+		case compiler.OpBinary:
+			binary := f.code.Nodes[vm.readOperand(f)].(*ast.BinaryExpression)
+			right := vm.pop()
+			left := vm.pop()
+			val := evaluator.LocateError(binaryOp(binary.Operator, left, right), binary.Token)
+			if isError(val) {
+				signal = val
+				break
+			}
+			vm.push(val)
+
+		case compiler.OpArray:
+			n := vm.readOperand(f)
+			var elements []object.Object
+			if n > 0 {
+				elements = make([]object.Object, n)
+				copy(elements, vm.stack[vm.sp-n:vm.sp])
+			}
+			vm.sp -= n
+			vm.push(&object.Array{Elements: elements})
+
+		case compiler.OpCall:
+			call := f.code.Nodes[vm.readOperand(f)].(*ast.CallExpression)
+			argc := vm.readOperand(f)
+			signal = vm.call(f, call, argc)
+
+		case compiler.OpReturn:
+			val := vm.pop()
+			if f.program {
+				return val
+			}
+			if out, done := vm.leave(val); done {
+				return out
+			}
+
+		case compiler.OpEnterScope:
+			f.env = object.NewSlotEnvironment(f.env, f.code.Scopes[vm.readOperand(f)])
+
+		case compiler.OpLeaveScope:
+			f.env = f.env.Outer()
+
+		case compiler.OpEval:
+			node := f.code.Nodes[vm.readOperand(f)]
+			val := evaluator.Eval(node, f.env)
+			if isError(val) {
+				signal = val
+				break
+			}
+			vm.push(val)
+
+		default:
+			return &object.Error{Message: fmt.Sprintf("unknown opcode %d", op)}
+		}
+
+		if signal != nil {
+			if out, done := vm.unwind(signal); done {
+				return out
+			}
+		}
+	}
+}
+
+// call invokes the callee below argc arguments on the stack. Functions
+// compiled by this VM run as a new frame; everything else goes through the
+// evaluator. A non-nil result is an error that must unwind the frame.
+func (vm *VM) call(f *frame, call *ast.CallExpression, argc int) object.Object {
+	args := make([]object.Object, argc)
+	copy(args, vm.stack[vm.sp-argc:vm.sp])
+	callee := vm.stack[vm.sp-argc-1]
+	vm.sp -= argc + 1
+
+	if fn, ok := callee.(*object.Function); ok {
+		if compiled := vm.compiled(fn); compiled != nil {
+			return vm.callCompiled(fn, compiled, args, call)
+		}
+	}
+
+	val := evaluator.LocateError(evaluator.ApplyFunction(callee, args, f.env, call.Token, call.Function), call.Token)
+	if isError(val) {
+		return val
+	}
+	vm.push(val)
+	return nil
+}
+
+// callCompiled enters a frame for a function compiled by this VM, with its
+// parameters bound to the slots of a new environment
+func (vm *VM) callCompiled(fn *object.Function, compiled *compiler.Function, args []object.Object, call *ast.CallExpression) object.Object {
+	if errObj := evaluator.CheckArguments(fn, len(args), call.Token); errObj != nil {
+		return errObj
+	}
+	if len(vm.frames) >= maxFrames {
+		return errorAt(call.Token, "maximum call stack size exceeded")
+	}
+
+	env := object.NewSlotEnvironment(fn.Env, compiled.Layout)
+	if compiled.SimpleParameters {
+		for i := range fn.Parameters {
+			if i < len(args) {
+				env.SetSlot(i, args[i])
+			} else {
+				env.SetSlot(i, object.NULL)
+			}
+		}
+		if fn.RestParameter != nil {
+			rest := []object.Object{}
+			if len(args) > len(fn.Parameters) {
+				rest = args[len(fn.Parameters):]
+			}
+			env.SetSlot(len(fn.Parameters), &object.Array{Elements: rest})
+		}
+	} else if failed := evaluator.BindParameters(env, fn, args); failed != nil {
+		return evaluator.LocateError(failed, call.Token)
+	}
+
+	vm.frames = append(vm.frames, &frame{
+		code: compiled.Code,
+		env:  env,
+		base: vm.sp,
+		fn:   fn,
+		call: call.Token,
+	})
+	return nil
+}
+
+// compiled returns the compiled body of a function this VM can run: one
+// created by OpClosure in the scope its slots were resolved against.
+// Functions the evaluator created, even from the same literal, use
+// environments without those slots and are run by the evaluator.
+func (vm *VM) compiled(fn *object.Function) *compiler.Function {
+	compiled, ok := vm.functions[fn.Body]
+	if !ok || compiled.Code == nil || fn.Env.Layout() != compiled.Outer {
+		return nil
+	}
+	return compiled
+}
+
+// register records the function literals of code, and those nested in
+// them, for calls
+func (vm *VM) register(code *compiler.Bytecode) {
+	for _, fn := range code.Functions {
+		vm.functions[fn.Body] = fn
+		if fn.Code != nil {
+			vm.register(fn.Code)
+		}
+	}
+}
+
+// leave finishes the current function frame with val and hands the result
+// to the caller, like unwrapReturnValue at the end of a call
+func (vm *VM) leave(val object.Object) (object.Object, bool) {
+	f := vm.frames[len(vm.frames)-1]
+	if rv, ok := val.(*object.ReturnValue); ok {
+		val = rv.Value
+	}
+	vm.sp = f.base
+	vm.frames = vm.frames[:len(vm.frames)-1]
+
+	if errObj, ok := val.(*object.Error); ok {
+		evaluator.RecordCallFrame(errObj, f.fn, f.call)
+		return vm.unwind(evaluator.LocateError(errObj, f.call))
+	}
+	vm.push(val)
+	return nil, false
+}
+
+// unwind stops the current statement with a control-flow result or error.
+// Function frames return it to their caller; the program frame records it
+// and moves on to the next top-level statement, like evalProgram.
+func (vm *VM) unwind(val object.Object) (object.Object, bool) {
+	f := vm.frames[len(vm.frames)-1]
+	if !f.program {
+		return vm.leave(val)
+	}
+
+	switch v := val.(type) {
+	case *object.Error:
+		return v, true
+	case *object.ReturnValue:
+		return v.Value, true
+	}
+
+	vm.result = val
+	vm.sp = f.base
+	f.env = vm.env
+	next := sort.SearchInts(f.code.Statements, f.ip)
+	if next < len(f.code.Statements) {
+		f.ip = f.code.Statements[next]
+	} else {
+		f.ip = len(f.code.Instructions)
+	}
+	return nil, false
+}
+
+func (vm *VM) readOperand(f *frame) int {
+	v := int(compiler.ReadUint16(f.code.Instructions[f.ip:]))
+	f.ip += 2
+	return v
+}
+
+func (vm *VM) push(obj object.Object) {
+	if vm.sp >= len(vm.stack) {
+		vm.stack = append(vm.stack, make([]object.Object, len(vm.stack))...)
+	}
+	vm.stack[vm.sp] = obj
+	vm.sp++
+}
+
+func (vm *VM) pop() object.Object {
+	vm.sp--
+	return vm.stack[vm.sp]
+}
+
+// lookupName resolves a variable, then a builtin
+func lookupName(ident *ast.Identifier, env *object.Environment) object.Object {
+	if val, ok := env.Get(ident.Value); ok {
+		return val
+	}
+	if builtin, ok := builtins.Builtins[ident.Value]; ok {
+		return builtin
+	}
+	return errorAt(ident.Token, "variable '%s' is not defined", ident.Value)
+}
+
+// outer returns the environment depth scopes out from env
+func outer(env *object.Environment, depth int) *object.Environment {
+	for ; depth > 0; depth-- {
+		env = env.Outer()
+	}
+	return env
+}
+
+// loadSlot reads a slot variable. Until its declaration has run, the name
+// still refers to a variable outside the scope, as it does in the
+// evaluator.
+func loadSlot(env *object.Environment, slot int, ident *ast.Identifier) object.Object {
+	if val := env.Slot(slot); val != nil {
+		return val
+	}
+	return lookupName(ident, env.Outer())
+}
+
+// storeSlot performs =, +=, -=, *= and /= on a slot variable
+func storeSlot(env *object.Environment, slot int, assign *ast.AssignmentExpression, val object.Object) object.Object {
+	current := env.Slot(slot)
+	if current == nil {
+		return assignName(assign, val, env.Outer())
+	}
+	if assign.Operator != "=" {
+		val = evaluator.BinaryOp(evaluator.CompoundOperator(assign.Operator), current, val)
+		if isError(val) {
+			return evaluator.LocateError(val, assign.Token)
+		}
+	}
+	env.SetSlot(slot, val)
+	return val
+}
+
+// mapKey returns the key of a map literal entry the compiler accepted
+func mapKey(key ast.Expression) string {
+	if ident, ok := key.(*ast.Identifier); ok {
+		return ident.Value
+	}
+	return key.(*ast.StringLiteral).Value
+}
+
+// assignName performs =, +=, -=, *= and /= on a variable
+func assignName(assign *ast.AssignmentExpression, val object.Object, env *object.Environment) object.Object {
+	name := assign.Name.(*ast.Identifier).Value
+
+	if env.IsConstant(name) {
+		return errorAt(assign.Token, "'%s' ekti sthir (constant), eitake bodlano jabe na", name)
+	}
+
+	if assign.Operator != "=" {
+		current, ok := env.Get(name)
+		if !ok {
+			return errorAt(assign.Token, "variable '%s' is not defined", name)
+		}
+		val = evaluator.BinaryOp(evaluator.CompoundOperator(assign.Operator), current, val)
+		if isError(val) {
+			return evaluator.LocateError(val, assign.Token)
+		}
+	}
+
+	env.Update(name, val)
+	return val
+}
+
+// binaryOp is evaluator.BinaryOp with a fast path for common number operators
+func binaryOp(operator string, left, right object.Object) object.Object {
+	l, lok := left.(*object.Number)
+	r, rok := right.(*object.Number)
+	if lok && rok {
+		switch operator {
+		case "+":
+			return &object.Number{Value: l.Value + r.Value}
+		case "-":
+			return &object.Number{Value: l.Value - r.Value}
+		case "*":
+			return &object.Number{Value: l.Value * r.Value}
+		case "<":
+			return object.NativeBoolToBooleanObject(l.Value < r.Value)
+		case ">":
+			return object.NativeBoolToBooleanObject(l.Value > r.Value)
+		case "<=":
+			return object.NativeBoolToBooleanObject(l.Value <= r.Value)
+		case ">=":
+			return object.NativeBoolToBooleanObject(l.Value >= r.Value)
+		}
+	}
+	return evaluator.BinaryOp(operator, left, right)
+}
+
+// errorAt creates an error at the position of tok
+func errorAt(tok lexer.Token, format string, a ...interface{}) *object.Error {
+	return &object.Error{Message: fmt.Sprintf(format, a...), File: tok.File, Line: tok.Line, Column: tok.Column}
+}
+
+func isError(obj object.Object) bool {
+	return obj != nil && obj.Type() == object.ERROR_OBJ
+}
+
+// isControlSignal reports whether a statement value stops the enclosing block
+func isControlSignal(obj object.Object) bool {
+	if obj == nil {
+		return false
+	}
+	switch obj.Type() {
+	case object.RETURN_OBJ, object.EXCEPTION_OBJ, object.BREAK_OBJ, object.CONTINUE_OBJ:
+		return true
+	}
+	return false
+}
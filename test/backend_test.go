@@ -0,0 +1,24 @@
+package test
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/evaluator"
+	"BanglaCode/src/eventloop"
+	"BanglaCode/src/object"
+	"BanglaCode/src/vm"
+	"os"
+)
+
+// evalProgram runs a parsed program on the backend selected by the
+// BANGLACODE_BACKEND environment variable: "vm" for the bytecode VM,
+// anything else for the tree-walking evaluator.
+//
+//	BANGLACODE_BACKEND=vm go test ./test/...
+func evalProgram(program *ast.Program, env *object.Environment) object.Object {
+	eventloop.Lock()
+	defer eventloop.Unlock()
+	if os.Getenv("BANGLACODE_BACKEND") == "vm" {
+		return vm.Run(program, env)
+	}
+	return evaluator.Eval(program, env)
+}
@@ -1,7 +1,6 @@
 package test
 
 import (
-	"BanglaCode/src/evaluator"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
 	"BanglaCode/src/parser"
@@ -14,7 +13,7 @@ func testEvalBuiltin(input string) object.Object {
 	p := parser.New(l)
 	program := p.ParseProgram()
 	env := object.NewEnvironment()
-	return evaluator.Eval(program, env)
+	return evalProgram(program, env)
 }
 
 // dorghyo (length) tests
@@ -649,9 +648,32 @@ func TestBuiltinJsonBanao(t *testing.T) {
 		t.Fatalf("object is not String. got=%T", evaluated)
 	}
 
-	// JSON order may vary, so just check it contains expected parts
-	if len(str.Value) < 10 {
-		t.Errorf("JSON string too short. got=%q", str.Value)
+	if str.Value != `{"name":"Ankan","age":25}` {
+		t.Errorf("wrong JSON. got=%q", str.Value)
+	}
+}
+
+func TestBuiltinJsonKeyOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`json_banao(json_poro('{"zeta": 1, "alpha": {"y": [1, {"q": 2, "p": 3}], "x": 2}}'))`,
+			`{"zeta":1,"alpha":{"y":[1,{"q":2,"p":3}],"x":2}}`},
+		{`json_banao({b: 1, "10": 2, a: 3, "2": 4})`, `{"2":4,"10":2,"b":1,"a":3}`},
+		{`dhoro o = {b: 1, a: 2}; o["c"] = 3; o["b"] = 4; json_banao(o)`, `{"b":4,"a":2,"c":3}`},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEvalBuiltin(tt.input)
+		str, ok := evaluated.(*object.String)
+		if !ok {
+			t.Errorf("object is not String. got=%T (%+v)", evaluated, evaluated)
+			continue
+		}
+		if str.Value != tt.expected {
+			t.Errorf("wrong JSON for %q. expected=%s, got=%s", tt.input, tt.expected, str.Value)
+		}
 	}
 }
 
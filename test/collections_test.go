@@ -3,7 +3,6 @@ package test
 import (
 	"testing"
 
-	"BanglaCode/src/evaluator"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
 	"BanglaCode/src/parser"
@@ -504,5 +503,5 @@ func evalInput(input string) object.Object {
 	p := parser.New(l)
 	program := p.ParseProgram()
 	env := object.NewEnvironment()
-	return evaluator.Eval(program, env)
+	return evalProgram(program, env)
 }
@@ -3,7 +3,6 @@ package test
 import (
 	"testing"
 
-	"BanglaCode/src/evaluator"
 	"BanglaCode/src/evaluator/builtins"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
@@ -16,7 +15,7 @@ func evalCryptoInput(input string) object.Object {
 	program := p.ParseProgram()
 	env := object.NewEnvironment()
 	builtins.InitializeEnvironmentWithConstants(env)
-	return evaluator.Eval(program, env)
+	return evalProgram(program, env)
 }
 
 func TestAESEncryptionDecryption(t *testing.T) {
@@ -0,0 +1,141 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins/database/cursor"
+	"BanglaCode/src/evaluator/builtins/database/sqlite"
+	"BanglaCode/src/object"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSQLiteCursor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`dhoro names = [];
+		  ghuriye (row of db_cursor_sqlite(db, "SELECT name FROM users ORDER BY id")) { dhokao(names, row.name); }
+		  lipi(names)`, "[Rahim, Karim, Jamil]"},
+		{`dhoro ages = [];
+		  ghuriye (row of db_cursor(db, "SELECT age FROM users WHERE age > ? ORDER BY age", [26])) { dhokao(ages, row.age); }
+		  lipi(ages)`, "[30, 41]"},
+		{`dhoro c = db_cursor_sqlite(db, "SELECT id, name FROM users ORDER BY id LIMIT 1");
+		  lipi(c.next()) + " " + lipi(c.next()) + " " + lipi(c.next().done)`,
+			"{value: {id: 1, name: Rahim}, done: false} {value: khali, done: true} true"},
+		{`dhoro [first, second] = db_cursor_sqlite(db, "SELECT id FROM users ORDER BY id");
+		  lipi(first.id) + lipi(second.id)`, "12"},
+		{`dhoro s = stream_readable_srishti();
+		  dhoro n = db_cursor_sqlite(db, "SELECT id, name FROM users WHERE id < 3 ORDER BY id").pipe(s);
+		  lipi(n) + " " + stream_poro(s)`, "2 {\"id\":1,\"name\":\"Rahim\"}\n{\"id\":2,\"name\":\"Karim\"}\n"},
+		{`dhoro s = stream_readable_srishti();
+		  db_cursor_sqlite(db, "SELECT id FROM users WHERE id = 0").pipe(s);
+		  dhoro chunks = 0;
+		  ghuriye opekha (chunk of s) { chunks = chunks + 1; }
+		  lipi(chunks)`, "0"},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(sqliteUsers+tt.input), tt.expected)
+	}
+
+	failures := []struct {
+		input    string
+		expected string
+	}{
+		{`db_cursor_sqlite(db, "SELECT * FROM missing")`, "db_cursor_sqlite: SQL logic error: no such table: missing"},
+		{`db_cursor_sqlite(db, "SELECT 1", "x")`, "third argument must be ARRAY"},
+		{`db_cursor(db_jukto_sqlite({}))`, "db_cursor: wrong number of arguments"},
+		{`db_cursor_sqlite(db, "SELECT 1").pipe(stream_writable_srishti(), 1)`, "pipe: wrong number of arguments"},
+	}
+	for _, tt := range failures {
+		errObj, ok := testEval(sqliteUsers + tt.input).(*object.Error)
+		if !ok || !strings.Contains(errObj.Message, tt.expected) {
+			t.Errorf("input %q: expected error containing %q, got %v", tt.input, tt.expected, errObj)
+		}
+	}
+}
+
+// TestSQLiteCursorReleasesConnection checks that the rows behind a cursor
+// go back to the pool however the loop over them ends
+func TestSQLiteCursorReleasesConnection(t *testing.T) {
+	tests := []string{
+		`ghuriye (row of db_cursor_sqlite(db, "SELECT * FROM users")) { thamo; }`,
+		`kaj first() { ghuriye (row of db_cursor_sqlite(db, "SELECT * FROM users")) { ferao row; } }
+		 first();`,
+		`chesta { ghuriye (row of db_cursor_sqlite(db, "SELECT * FROM users")) { felo "stop"; } } dhoro_bhul(e) {}`,
+		`ghuriye (row of db_cursor_sqlite(db, "SELECT * FROM users")) {}`,
+		`dhoro c = db_cursor_sqlite(db, "SELECT * FROM users"); c.next(); c.close();`,
+	}
+
+	for _, input := range tests {
+		conn, ok := testEval(sqliteUsers + input + "\ndb").(*object.DBConnection)
+		if !ok {
+			t.Fatalf("input %q did not return the connection", input)
+		}
+		db, err := sqlite.Handle(conn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if inUse := db.Stats().InUse; inUse != 0 {
+			t.Errorf("input %q left %d connection(s) in use", input, inUse)
+		}
+		sqlite.Close(conn)
+	}
+}
+
+// fakeSource yields its rows and then fails with err, if set
+type fakeSource struct {
+	rows   []*object.Map
+	err    error
+	closed int
+}
+
+func (s *fakeSource) Next() (*object.Map, error) {
+	if len(s.rows) == 0 {
+		return nil, s.err
+	}
+	row := s.rows[0]
+	s.rows = s.rows[1:]
+	return row, nil
+}
+
+func (s *fakeSource) Close() error {
+	s.closed++
+	return nil
+}
+
+func TestCursorClosesOnError(t *testing.T) {
+	row := object.NewMap()
+	row.Set("id", &object.Number{Value: 1})
+
+	source := &fakeSource{rows: []*object.Map{row}, err: errors.New("connection reset")}
+	c := cursor.New("db_cursor_test", source)
+	next := c.Pairs["next"].(*object.Builtin)
+
+	if result := next.Fn().(*object.Map); result.Pairs["done"] != object.FALSE {
+		t.Fatalf("expected a row, got %s", result.Inspect())
+	}
+	errObj, ok := next.Fn().(*object.Error)
+	if !ok || errObj.Message != "db_cursor_test: connection reset" {
+		t.Fatalf("expected the source error, got %v", errObj)
+	}
+	if result := next.Fn().(*object.Map); result.Pairs["done"] != object.TRUE {
+		t.Errorf("expected a failed cursor to be done, got %s", result.Inspect())
+	}
+	c.Pairs["close"].(*object.Builtin).Fn()
+	if source.closed != 1 {
+		t.Errorf("expected the source to be closed once, got %d", source.closed)
+	}
+
+	// A stream that refuses writes closes the cursor too
+	source = &fakeSource{rows: []*object.Map{row, row}}
+	c = cursor.New("db_cursor_test", source)
+	stream := &object.Stream{StreamType: "writable", IsClosed: true}
+	if _, ok := c.Pairs["pipe"].(*object.Builtin).Fn(stream).(*object.Error); !ok {
+		t.Error("expected pipe into a closed stream to fail")
+	}
+	if source.closed != 1 || len(source.rows) != 1 {
+		t.Errorf("expected pipe to stop and close the cursor, got closed=%d rows left=%d", source.closed, len(source.rows))
+	}
+}
@@ -0,0 +1,178 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins/database"
+	"BanglaCode/src/evaluator/builtins/database/sqlite"
+	"BanglaCode/src/object"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSQLitePool(t *testing.T, name string, config database.PoolConfig) *database.ConnectionPool {
+	t.Helper()
+	pool, err := database.NewConnectionPoolWithConfig("sqlite", map[string]interface{}{"shared": name}, config)
+	if err != nil {
+		t.Fatalf("NewConnectionPoolWithConfig failed: %v", err)
+	}
+	t.Cleanup(func() { pool.Close() })
+	return pool
+}
+
+// killConnection closes a pooled connection's database handle behind the
+// pool's back, like a server dropping the connection
+func killConnection(t *testing.T, conn *object.DBConnection) {
+	t.Helper()
+	db, err := sqlite.Handle(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+}
+
+func TestConnectionPoolHealthCheck(t *testing.T) {
+	config := database.DefaultPoolConfig()
+	config.MaxConns, config.MinConns = 3, 1
+	config.HealthCheckInterval = 50 * time.Millisecond
+	pool := newSQLitePool(t, "pool-health", config)
+
+	// A freshly returned connection is lent again without a ping, so even a
+	// dead one comes straight back
+	conn, _ := pool.Get()
+	killConnection(t, conn)
+	pool.Return(conn)
+	if again, _ := pool.Get(); again != conn {
+		t.Fatal("expected the freshly returned connection to be lent without a ping")
+	}
+	pool.Return(conn)
+	if stats := pool.Stats(); stats["unhealthy_conns"] != int64(0) {
+		t.Errorf("expected no ping on a fresh borrow: %v", stats)
+	}
+
+	// Once it has been idle for HealthCheckInterval it is pinged when
+	// borrowed, and replaced because it is dead
+	time.Sleep(config.HealthCheckInterval)
+	for i := 0; i < 2; i++ {
+		c, err := pool.Get()
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if c == conn {
+			t.Fatal("expected the dead connection to be evicted")
+		}
+		defer pool.Return(c)
+	}
+	if stats := pool.Stats(); stats["unhealthy_conns"] != int64(1) || stats["active_conns"] != int64(2) {
+		t.Errorf("unexpected stats after eviction: %v", stats)
+	}
+
+	// The periodic check evicts dead idle connections and reconnects up to MinConns
+	config.MinConns = 2
+	pool2 := newSQLitePool(t, "pool-health-2", config)
+	a, _ := pool2.Get()
+	b, _ := pool2.Get()
+	killConnection(t, a)
+	killConnection(t, b)
+	pool2.Return(a)
+	pool2.Return(b)
+	pool2.HealthCheck()
+
+	stats := pool2.Stats()
+	if stats["unhealthy_conns"] != int64(2) || stats["total_conns"] != int64(2) || stats["idle_conns"] != 2 || stats["created_conns"] != int64(4) {
+		t.Errorf("unexpected stats after health check: %v", stats)
+	}
+}
+
+func TestConnectionPoolLifetimeAndWaitTimeout(t *testing.T) {
+	config := database.DefaultPoolConfig()
+	config.MaxConns, config.MinConns = 1, 1
+	config.MaxLifetime = 100 * time.Millisecond
+	config.WaitTimeout = 30 * time.Millisecond
+	pool := newSQLitePool(t, "pool-lifetime", config)
+
+	first, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	// The only connection is in use, so the next Get times out
+	start := time.Now()
+	if _, err := pool.Get(); err == nil || !strings.Contains(err.Error(), "exhausted") {
+		t.Fatalf("expected a wait timeout, got %v", err)
+	}
+	if waited := time.Since(start); waited < config.WaitTimeout {
+		t.Errorf("expected Get to wait %s, waited %s", config.WaitTimeout, waited)
+	}
+
+	// A waiting Get gets the connection as soon as it comes back
+	got := make(chan *object.DBConnection)
+	go func() {
+		conn, _ := pool.Get()
+		got <- conn
+	}()
+	time.Sleep(5 * time.Millisecond)
+	pool.Return(first)
+	second := <-got
+	if second != first {
+		t.Fatalf("expected the waiter to get the returned connection")
+	}
+
+	// Past MaxLifetime the connection is replaced
+	time.Sleep(config.MaxLifetime)
+	pool.Return(second)
+	third, err := pool.Get()
+	if err != nil || third == first {
+		t.Fatalf("expected a fresh connection, got %v, %v", third, err)
+	}
+
+	stats := pool.Stats()
+	if stats["wait_count"] != int64(2) || stats["wait_timeouts"] != int64(1) || stats["expired_conns"] != int64(1) ||
+		stats["created_conns"] != int64(2) || stats["closed_conns"] != int64(1) {
+		t.Errorf("unexpected stats: %v", stats)
+	}
+	if ms := stats["wait_duration_ms"].(float64); ms < 30 {
+		t.Errorf("expected at least 30ms of waiting, got %v", ms)
+	}
+}
+
+func TestPoolBuiltins(t *testing.T) {
+	pool := `dhoro pool = db_pool_banao("sqlite", {"shared": "pool-builtins"}, 2, {"min_conns": 1, "wait_timeout": 20});
+	`
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`dhoro conn = db_pool_nao(pool);
+		  db_exec(conn, "CREATE TABLE IF NOT EXISTS t (x)");
+		  dhoro inUse = lipi(pool);
+		  db_pool_ferot(pool, conn);
+		  dhoro out = inUse + " " + lipi(pool);`, "DB_POOL(sqlite, active=1/2) DB_POOL(sqlite, active=0/2)"},
+		{`dhoro a = db_pool_nao(pool);
+		  dhoro b = db_pool_nao(pool);
+		  dhoro s = db_pool_tothyo(pool);
+		  dhoro out = lipi([s.total_conns, s.active_conns, s.idle_conns, s.created_conns, s.wait_count]);`, "[2, 2, 0, 2, 0]"},
+		{`dhoro out = lipi(db_pool_stats(pool).min_conns) + " " + lipi(db_pool_porikkha(pool).total_conns);`, "1 1"},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(pool+tt.input+"\ndb_pool_bondho(pool);\nout"), tt.expected)
+	}
+
+	failures := []struct {
+		input    string
+		expected string
+	}{
+		{`db_pool_bondho(pool); db_pool_stats(pool)`, "not found"},
+		{`dhoro a = db_pool_nao(pool); db_pool_nao(pool); db_pool_nao(pool)`, "exhausted: no connection available after 20ms"},
+		{`dhoro a = db_pool_nao(pool); db_pool_ferot(pool, a); db_pool_ferot(pool, a)`, "is not borrowed from pool"},
+		{`db_pool_ferot(pool, db_jukto_sqlite({}))`, "connection belongs to different pool"},
+		{`db_pool_banao("oracle", {})`, "unsupported database type 'oracle'"},
+		{`db_pool_nao("pool")`, "argument must be DB_POOL"},
+	}
+	for _, tt := range failures {
+		errObj, ok := testEval(pool + tt.input).(*object.Error)
+		if !ok || !strings.Contains(errObj.Message, tt.expected) {
+			t.Errorf("input %q: expected error containing %q, got %v", tt.input, tt.expected, errObj)
+		}
+	}
+}
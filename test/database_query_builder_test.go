@@ -0,0 +1,128 @@
+package test
+
+import (
+	"BanglaCode/src/object"
+	"strings"
+	"testing"
+)
+
+func TestQueryBuilderSQL(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`db_table("postgres", "users").select("id", "name").where("age", ">", 18).orWhere("name", "IN", ["a", "b"]).orderBy("id", "desc").limit(10).offset(5).toSql()`,
+			`{sql: SELECT "id", "name" FROM "users" WHERE "age" > $1 OR "name" IN ($2, $3) ORDER BY "id" DESC LIMIT 10 OFFSET 5, params: [18, a, b]}`},
+		{`db_table("mysql", "users").where({"active": sotti, "deleted_at": khali}).offset(20).toSql()`,
+			"{sql: SELECT * FROM `users` WHERE `active` = ? AND `deleted_at` IS NULL LIMIT 18446744073709551615 OFFSET 20, params: [true]}"},
+		{`db_table("sqlite", "posts").select("posts.title", "users.name AS author").join("users", "users.id", "posts.user_id").leftJoin("tags", "tags.post_id", "=", "posts.id").toSql()`,
+			`{sql: SELECT "posts"."title", "users"."name" AS "author" FROM "posts" JOIN "users" ON "users"."id" = "posts"."user_id" LEFT JOIN "tags" ON "tags"."post_id" = "posts"."id", params: []}`},
+		{`db_table("postgres", "users").insert([{"name": "Rahim", "age": 30}, {"name": "Karim", "age": 25}]).returning("id").toSql()`,
+			`{sql: INSERT INTO "users" ("name", "age") VALUES ($1, $2), ($3, $4) RETURNING "id", params: [Rahim, 30, Karim, 25]}`},
+		{`db_table("mysql", "users").where("id", 7).update({"name": "x", "age": 1}).toSql()`,
+			"{sql: UPDATE `users` SET `name` = ?, `age` = ? WHERE `id` = ?, params: [x, 1, 7]}"},
+		{`db_table("sqlite", "users").where("id", "IN", []).delete().toSql()`,
+			`{sql: DELETE FROM "users" WHERE 1 = 0, params: []}`},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(`lipi(`+tt.input+`)`), tt.expected)
+	}
+
+	// Chains never modify the query they start from
+	testStringObject(t, testEval(`dhoro base = db_table("postgres", "users"); base.where("id", 1); lipi(base.toSql())`),
+		`{sql: SELECT * FROM "users", params: []}`)
+}
+
+func TestQueryBuilderRun(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`lipi(users.where("age", ">", 26).orderBy("age", "desc").get())`,
+			"[{id: 3, name: Jamil, age: 41}, {id: 1, name: Rahim, age: 30}]"},
+		{`lipi(users.select("name").where({"age": 25}).first())`, "{name: Karim}"},
+		{`lipi(users.where("age", ">", 100).first())`, "khali"},
+		{`lipi(users.count()) + " " + lipi(users.where("name", "LIKE", "%im").count())`, "3 2"},
+		{`dhoro r = users.insert({"name": "Nasir", "age": 19}).run(); lipi(r["last_insert_id"]) + " " + lipi(users.count())`, "4 4"},
+		{`lipi(users.insert({"name": "Nasir", "age": 19}).returning("id", "name").get())`, "[{id: 4, name: Nasir}]"},
+		{`lipi(users.where("age", "<", 40).update({"age": 0}).run()["rows_affected"]) + " " + lipi(users.where("age", 0).count())`, "2 2"},
+		{`users.where("name", "Karim").delete().run(); lipi(users.select("name").orderBy("id").get())`,
+			"[{name: Rahim}, {name: Jamil}]"},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(sqliteUsers+`dhoro users = db_table(db, "users");`+tt.input), tt.expected)
+	}
+}
+
+const sqliteUserModel = `
+sreni User {
+	sthir table = "users";
+	shuru(name, age) { ei.name = name; ei.age = age; }
+	kaj porichoy() { ferao ei.name + " (" + lipi(ei.age) + ")"; }
+}
+db_model(User, db);
+`
+
+func TestModel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`User.khojo(2).porichoy()`, "Karim (25)"},
+		{`lipi(User.khojo(99))`, "khali"},
+		{`dhoro all = User.khojo(); lipi(dorghyo(all)) + " " + all[2].name`, "3 Jamil"},
+		{`User.khojo({"age": 30})[0].porichoy()`, "Rahim (30)"},
+		{`User.query().where("age", ">", 26).orderBy("age").first().porichoy()`, "Rahim (30)"},
+		{`dhoro u = notun User("Nasir", 19); u.save(); lipi(u.id) + " " + User.khojo(u.id).porichoy()`, "4 Nasir (19)"},
+		{`dhoro u = User.khojo(1); u.age = 31; u.save(); lipi(User.khojo(1).age) + " " + lipi(dorghyo(User.khojo()))`, "31 3"},
+		{`dhoro u = User.khojo(1); lipi(u.delete()) + " " + lipi(u.delete()) + " " + lipi(User.khojo(1))`, "true false khali"},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(sqliteUsers+sqliteUserModel+tt.input), tt.expected)
+	}
+
+	// A set primary key with no matching row is inserted as given
+	testStringObject(t, testEval(sqliteUsers+`
+	sreni Account {
+		sthir table = "users";
+		sthir columns = ["name"];
+	}
+	db_model(Account, db);
+	dhoro a = notun Account();
+	a.id = 10; a.name = "Solo"; a.nickname = "ignored";
+	a.save();
+	lipi(db_query_sqlite(db, "SELECT * FROM users WHERE id = 10")["rows"])`), "[{id: 10, name: Solo, age: khali}]")
+}
+
+func TestQueryBuilderErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`db_table("mongodb", "users")`, "mongodb does not support SQL queries"},
+		{`db_table("postgres", "users; DROP TABLE users")`, "invalid identifier"},
+		{`db_table("postgres", "users").where("age", "=>", 1)`, "where: "},
+		{`db_table("postgres", "users").where("id", "IN", 5)`, "where: "},
+		{`db_table("mysql", "users").delete().returning("id")`, "returning: "},
+		{`db_table("sqlite", "users").insert([{"a": 1}, {"b": 2}])`, "row 1 is missing column 'a'"},
+		{`db_table("sqlite", "users").limit(-1)`, "limit: "},
+		{`db_table("sqlite", "users").get()`, "query was built for a dialect only"},
+		{`db_table(db, "users").insert({"name": "x"}).count()`, "count needs a select query"},
+		{`sreni T {} db_model(T, db)`, "must declare 'sthir table"},
+		{`sreni T { sthir table = "users"; } dhoro t = notun T(); db_model(T, db); t.delete()`, "instance has no id"},
+	}
+
+	for _, tt := range tests {
+		errObj, ok := testEval(sqliteUsers + tt.input).(*object.Error)
+		if !ok {
+			t.Errorf("expected error for %q", tt.input)
+			continue
+		}
+		if !strings.Contains(errObj.Message, tt.expected) {
+			t.Errorf("wrong error for %q. expected to contain %q, got %q", tt.input, tt.expected, errObj.Message)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins/database"
+	"BanglaCode/src/object"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sqliteUsers = `
+dhoro db = db_jukto_sqlite({"database": ":memory:"});
+db_exec_sqlite(db, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, age INTEGER)");
+db_bulk_insert_sqlite(db, "users", ["name", "age"], [["Rahim", 30], ["Karim", 25], ["Jamil", 41]]);
+`
+
+func TestSQLiteQueries(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`lipi(db_query_sqlite(db, "SELECT id, name, age FROM users ORDER BY id")["rows"])`,
+			"[{id: 1, name: Rahim, age: 30}, {id: 2, name: Karim, age: 25}, {id: 3, name: Jamil, age: 41}]"},
+		{`lipi(db_proshno_sqlite(db, "SELECT name FROM users WHERE age > ? ORDER BY age LIMIT ?", [26, 1])["rows"])`,
+			"[{name: Rahim}]"},
+		{`dhoro r = db_proshno_sqlite(db, "INSERT INTO users (name, age) VALUES (?, ?)", ["Nasir", khali]);
+		  lipi(r["last_insert_id"]) + " " + lipi(r["rows_affected"])`, "4 1"},
+		{`lipi(db_exec_sqlite(db, "UPDATE users SET age = age + 1 WHERE age < 40")["rows_affected"])`, "2"},
+		{`lipi(db_proshno_sqlite(db, "DELETE FROM users WHERE name = ? RETURNING id", ["Karim"])["rows"])`, "[{id: 2}]"},
+		{`lipi(db_query(db_jukto("sqlite", {}), "SELECT 1 + 1 AS n")["rows"])`, "[{n: 2}]"},
+		{`lipi((opekha db_query_async_sqlite(db, "SELECT count(*) AS n FROM users"))["rows"])`, "[{n: 3}]"},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(sqliteUsers+tt.input), tt.expected)
+	}
+}
+
+func TestSQLiteTransactions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`dhoro tx = db_transaction_shuru_sqlite(db);
+		  db_exec_sqlite(tx, "DELETE FROM users");
+		  dhoro inside = dorghyo(db_query_sqlite(tx, "SELECT * FROM users")["rows"]);
+		  db_rollback_sqlite(tx);
+		  lipi(inside) + " " + lipi(dorghyo(db_query_sqlite(db, "SELECT * FROM users")["rows"]))`, "0 3"},
+		{`dhoro tx = db_transaction_shuru_sqlite(db);
+		  db_proshno_sqlite(tx, "INSERT INTO users (name, age) VALUES (?, ?)", ["Nasir", 19]);
+		  db_commit_sqlite(tx);
+		  lipi(db_query_sqlite(db, "SELECT count(*) AS n FROM users")["rows"][0]["n"])`, "4"},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(sqliteUsers+tt.input), tt.expected)
+	}
+
+	errObj, ok := testEval(sqliteUsers + `dhoro tx = db_transaction_shuru_sqlite(db); db_commit_sqlite(tx); db_exec_sqlite(tx, "DELETE FROM users")`).(*object.Error)
+	if !ok || !strings.Contains(errObj.Message, "not found") {
+		t.Errorf("expected finished transaction error, got %v", errObj)
+	}
+}
+
+func TestSQLiteDatabases(t *testing.T) {
+	// Each :memory: connection is private; "shared" names one across connections
+	testStringObject(t, testEval(`
+	dhoro a = db_jukto_sqlite({});
+	db_exec_sqlite(a, "CREATE TABLE t (x)");
+	dhoro b = db_jukto_sqlite({});
+	dhoro c = db_jukto_sqlite({"shared": "sqlite-test"});
+	db_exec_sqlite(c, "CREATE TABLE s (x)");
+	dhoro d = db_jukto_sqlite({"shared": "sqlite-test"});
+	lipi(dorghyo(db_query_sqlite(b, "SELECT name FROM sqlite_master")["rows"])) + " " +
+		lipi(db_query_sqlite(d, "SELECT name FROM sqlite_master")["rows"])
+	`), "0 [{name: s}]")
+
+	// Database files persist across connections
+	path := filepath.Join(t.TempDir(), "app.db")
+	testEval(`dhoro db = db_jukto_sqlite({"database": "` + path + `"});
+	db_exec_sqlite(db, "CREATE TABLE t (x)");
+	db_exec_sqlite(db, "INSERT INTO t VALUES ('saved')");
+	db_bandho(db);`)
+	testStringObject(t, testEval(`lipi(db_query_sqlite(db_jukto_sqlite({"database": "`+path+`"}), "SELECT x FROM t")["rows"])`), "[{x: saved}]")
+}
+
+func TestSQLiteErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`db_query_sqlite(db, "SELECT * FROM missing")`, "no such table: missing"},
+		{`db_proshno_sqlite(db, "INSERT INTO users (name) VALUES (?)", [khali])`, "NOT NULL constraint failed"},
+		{`db_bulk_insert_sqlite(db, "users", ["name", "age"], [["Solo"]])`, "row 0 has 1 columns, expected 2"},
+		{`db_query_sqlite(5, "SELECT 1")`, "first argument must be DB_CONNECTION or STRING (transaction ID), got NUMBER"},
+		{`db_jukto_sqlite(5)`, "db_jukto_sqlite: argument must be a map, got NUMBER"},
+		{`db_bandho(db); db_query_sqlite(db, "SELECT 1")`, "database is closed"},
+	}
+
+	for _, tt := range tests {
+		errObj, ok := testEval(sqliteUsers + tt.input).(*object.Error)
+		if !ok {
+			t.Errorf("expected error for %q", tt.input)
+			continue
+		}
+		if !strings.Contains(errObj.Message, tt.expected) {
+			t.Errorf("wrong error for %q. expected to contain %q, got %q", tt.input, tt.expected, errObj.Message)
+		}
+	}
+}
+
+func TestSQLiteConnectionPool(t *testing.T) {
+	pool, err := database.NewConnectionPool("sqlite", map[string]interface{}{"shared": "pool-test"}, 3)
+	if err != nil {
+		t.Fatalf("NewConnectionPool failed: %v", err)
+	}
+
+	conn, err := pool.Get()
+	if err != nil {
+		t.Fatalf("pool.Get failed: %v", err)
+	}
+	if conn.DBType != "sqlite" || conn.PoolID == "" || conn.Native == nil {
+		t.Fatalf("expected a live pooled sqlite connection, got %+v", conn)
+	}
+
+	stats := pool.Stats()
+	if stats["active_conns"] != int64(1) || stats["idle_conns"] != 1 {
+		t.Errorf("unexpected stats while borrowed: %v", stats)
+	}
+
+	if err := pool.Return(conn); err != nil {
+		t.Errorf("pool.Return failed: %v", err)
+	}
+	if err := pool.Close(); err != nil {
+		t.Errorf("pool.Close failed: %v", err)
+	}
+}
@@ -3,7 +3,6 @@ package test
 import (
 	"testing"
 
-	"BanglaCode/src/evaluator"
 	"BanglaCode/src/evaluator/builtins"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
@@ -16,7 +15,7 @@ func evalErrorInput(input string) object.Object {
 	program := p.ParseProgram()
 	env := object.NewEnvironment()
 	builtins.InitializeEnvironmentWithConstants(env)
-	return evaluator.Eval(program, env)
+	return evalProgram(program, env)
 }
 
 // Test Error() constructor
@@ -556,3 +555,14 @@ func TestIsErrorWithNonErrors(t *testing.T) {
 		}
 	}
 }
+
+// Error objects list their keys in the order they were created
+func TestErrorObjectKeyOrder(t *testing.T) {
+	for _, name := range []string{"Error", "TypeError", "ReferenceError", "RangeError", "SyntaxError"} {
+		result := evalErrorInput(`lipi(` + name + `("boom"))`)
+		expected := "{message: boom, name: " + name + ", stack: }"
+		if str, ok := result.(*object.String); !ok || str.Value != expected {
+			t.Errorf("%s: expected %q, got %s", name, expected, result.Inspect())
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package test
 
 import (
-	"BanglaCode/src/evaluator"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
 	"BanglaCode/src/parser"
@@ -629,6 +628,24 @@ func TestNullValue(t *testing.T) {
 	testNullObject(t, evaluated)
 }
 
+func TestBengaliScriptProgram(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"ধরো ক = ১০; ক + ৫;", 15},
+		{"কাজ যোগ(ক, খ) { ফেরাও ক + খ; } যোগ(১.৫, ২);", 3.5},
+		{"ধরো ফল = ০; যদি (সত্যি এবং না মিথ্যা) { ফল = ১; } নাহলে { ফল = ২; } ফল;", 1},
+		{"ধরো মোট = ০; ঘুরিয়ে (ধরো i = ০; i < ৪; i += ১) { মোট += i; } মোট;", 6},
+	}
+
+	for _, tt := range tests {
+		testNumberObject(t, testEval(tt.input), tt.expected)
+	}
+	// Banglish and Bengali spellings of a keyword are interchangeable
+	testNumberObject(t, testEval("dhoro x = ৭; ধরো y = x * 2; y;"), 14)
+}
+
 // Helper functions
 
 func testEval(input string) object.Object {
@@ -637,7 +654,7 @@ func testEval(input string) object.Object {
 	program := p.ParseProgram()
 	env := object.NewEnvironment()
 
-	return evaluator.Eval(program, env)
+	return evalProgram(program, env)
 }
 
 func testNumberObject(t *testing.T, obj object.Object, expected float64) bool {
@@ -0,0 +1,90 @@
+package test
+
+import (
+	"BanglaCode/src/object"
+	"testing"
+)
+
+func TestEventLoopOrdering(t *testing.T) {
+	input := `
+	dhoro log = [];
+	setTimeout(kaj() { dhokao(log, "timeout 20"); }, 20);
+	setTimeout(kaj() { dhokao(log, "timeout 0"); }, 0);
+	setTimeout(kaj() { dhokao(log, "timeout 0 again"); }, 0);
+
+	proyash kaj one() { ferao 1; }
+	proyash kaj first() {
+		dhokao(log, "async start");
+		opekha one();
+		dhokao(log, "after await");
+	}
+	first();
+	dhokao(log, "sync end");
+
+	opekha ghumaao(40);
+	joro(log, ", ")
+	`
+
+	expected := "async start, sync end, after await, timeout 0, timeout 0 again, timeout 20"
+	for i := 0; i < 5; i++ {
+		result := testEval(input)
+		str, ok := result.(*object.String)
+		if !ok {
+			t.Fatalf("Expected string, got %s", result.Inspect())
+		}
+		if str.Value != expected {
+			t.Fatalf("run %d: expected %q, got %q", i, expected, str.Value)
+		}
+	}
+}
+
+func TestEventLoopIsSingleThreaded(t *testing.T) {
+	// A blocking sleep holds the loop, so the timer cannot fire during it
+	input := `
+	dhoro x = 0;
+	setTimeout(kaj() { x = 5; }, 0);
+	ghum(20);
+	dhoro during = x;
+	opekha ghumaao(1);
+	[during, x]
+	`
+	result := testEval(input)
+	arr, ok := result.(*object.Array)
+	if !ok || len(arr.Elements) != 2 {
+		t.Fatalf("Expected array of 2, got %s", result.Inspect())
+	}
+	testNumberObject(t, arr.Elements[0], 0)
+	testNumberObject(t, arr.Elements[1], 5)
+
+	// Many async functions updating shared state never interleave
+	input = `
+	dhoro count = 0;
+	proyash kaj bump() {
+		opekha ghumaao(1);
+		dhoro c = count;
+		count = c + 1;
+	}
+	dhoro kaj_gulo = [];
+	ghuriye (dhoro i = 0; i < 50; i = i + 1) { dhokao(kaj_gulo, bump()); }
+	opekha sob_proyash(kaj_gulo);
+	count
+	`
+	testNumberObject(t, testEval(input), 50)
+}
+
+func TestEventLoopAwaitWithoutWork(t *testing.T) {
+	input := `
+	dhoro s = stream_readable_srishti();
+	proyash kaj read() {
+		ghuriye opekha (chunk of s) { dekho(chunk); }
+	}
+	opekha read();
+	`
+	errObj, ok := testEval(input).(*object.Error)
+	if !ok {
+		t.Fatalf("Expected error for a promise that can never settle")
+	}
+	if errObj.Message != "opekha (await) on a promise that can never settle: nothing is left to run" {
+		t.Errorf("Unexpected error message: %s", errObj.Message)
+	}
+}
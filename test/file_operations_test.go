@@ -5,7 +5,6 @@ import (
 	"testing"
 	"time"
 
-	"BanglaCode/src/evaluator"
 	"BanglaCode/src/evaluator/builtins"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
@@ -18,7 +17,7 @@ func evalFileInput(input string) object.Object {
 	program := p.ParseProgram()
 	env := object.NewEnvironment()
 	builtins.InitializeEnvironmentWithConstants(env)
-	return evaluator.Eval(program, env)
+	return evalProgram(program, env)
 }
 
 // Test file append
@@ -0,0 +1,197 @@
+package test
+
+import (
+	"BanglaCode/src/format"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/parser"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func formatString(t *testing.T, src string) string {
+	t.Helper()
+	out, err := format.Source([]byte(src), "test.bang")
+	if err != nil {
+		t.Fatalf("format failed: %v\nsource:\n%s", err, src)
+	}
+	return string(out)
+}
+
+func TestFormatCanonicalLayout(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"dhoro   x=1+2*3", "dhoro x = 1 + 2 * 3;\n"},
+		{"dhoro x = (1 + 2) * 3;", "dhoro x = (1 + 2) * 3;\n"},
+		{"dhoro x = ((a));", "dhoro x = a;\n"},
+		{"dhoro y = na (a ebong b);", "dhoro y = na (a ebong b);\n"},
+		{"kaj jog(a,b){ferao a+b;}", "kaj jog(a, b) {\n    ferao a + b;\n}\n"},
+		{"jodi(x>1){dekho('boro');}nahole jodi(x==1){dekho(\"ek\");}nahole{}",
+			"jodi (x > 1) {\n    dekho(\"boro\");\n} nahole jodi (x == 1) {\n    dekho(\"ek\");\n} nahole {}\n"},
+		{"ghuriye(dhoro i=0;i<3;i=i+1){chharo;}", "ghuriye (dhoro i = 0; i < 3; i = i + 1) {\n    chharo;\n}\n"},
+		{"kaj* g(){dhoro x=utpadan 1;utpadan*  h();}", "kaj* g() {\n    dhoro x = utpadan 1;\n    utpadan* h();\n}\n"},
+		{"proyash kaj* g(){ghuriye opekha(x of src()){utpadan x;}}", "proyash kaj* g() {\n    ghuriye opekha (x of src()) {\n        utpadan x;\n    }\n}\n"},
+		{"dhoro f = (a) => a * 2;", "dhoro f = (a) => a * 2;\n"},
+		{"dhoro f = x => {ferao x;};", "dhoro f = (x) => {\n    ferao x;\n};\n"},
+		{"dhoro m = {a:1,b:[1,2]};", "dhoro m = {a: 1, b: [1, 2]};\n"},
+		{"dhoro m = {\n  a: 1,\n  b: 2,\n};", "dhoro m = {\n    a: 1,\n    b: 2\n};\n"},
+		{`dhoro s = 'bolo "hi"';`, "dhoro s = 'bolo \"hi\"';\n"},
+		{"sreni A{sthir n=1; shuru(x){ei.x=x;} kaj get(){ferao ei.x;}}",
+			"sreni A {\n    sthir n = 1;\n    shuru(x) {\n        ei.x = x;\n    }\n    kaj get() {\n        ferao ei.x;\n    }\n}\n"},
+		{"bikolpo(x){khetre 1{thamo;} manchito{dekho(x);}}",
+			"bikolpo (x) {\n    khetre 1 {\n        thamo;\n    }\n    manchito {\n        dekho(x);\n    }\n}\n"},
+		{"dhoro a=1;\n\n\n\ndhoro b=2;", "dhoro a = 1;\n\ndhoro b = 2;\n"},
+		{"dhoro v=a?b:c?d:e;", "dhoro v = a ? b : c ? d : e;\n"},
+		{"dhoro v=(a?b:c)?d:e;", "dhoro v = (a ? b : c) ? d : e;\n"},
+		{"dhoro v=u?.a?.[0]?.(1)??(x ba y);", "dhoro v = u?.a?.[0]?.(1) ?? x ba y;\n"},
+		{"x??=1;y||=2;z&&=3;", "x ??= 1;\ny ||= 2;\nz &&= 3;\n"},
+		{"dhoro a=(2**3)**2+2**(3**2);", "dhoro a = (2 ** 3) ** 2 + 2 ** 3 ** 2;\n"},
+		{"dhoro p=(-2)**2-(-(2**2));", "dhoro p = (-2) ** 2 - -(2 ** 2);\n"},
+		{"dhoro b=(a|b)&~c<<1;", "dhoro b = (a | b) & ~c << 1;\n"},
+		{"dhoro c=a&&b||c;", "dhoro c = a && b || c;\n"},
+		{"x>>>=1;", "x >>>= 1;\n"},
+		{"kaj f(a,b=a*2,{naam,boyosh:age},[x,y]=[1,2],...rest){}", "kaj f(a, b = a * 2, {naam, boyosh: age}, [x, y] = [1, 2], ...rest) {}\n"},
+		{"dhoro g=({x},n=1)=>x*n;", "dhoro g = ({x}, n = 1) => x * n;\n"},
+		{"dhoro {a:{b=1+2},c:[d,...e],...f}=x;", "dhoro {a: {b = 1 + 2}, c: [d, ...e], ...f} = x;\n"},
+		{"kaj h([a,{b:c}=y],{d=[1]}){}", "kaj h([a, {b: c} = y], {d = [1]}) {}\n"},
+		{"[a,[b,o.c=1],...d]=[d,a];", "[a, [b, o.c = 1], ...d] = [d, a];\n"},
+	}
+
+	for _, tt := range tests {
+		if got := formatString(t, tt.input); got != tt.expected {
+			t.Errorf("format(%q):\ngot:\n%s\nwant:\n%s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatKeepsComments(t *testing.T) {
+	input := `// shuru
+dhoro x = 1;   // trailing
+
+kaj f() {
+    // bhitore
+    ferao x;
+    // shesh e
+}
+// file er shesh
+`
+	expected := `// shuru
+dhoro x = 1; // trailing
+
+kaj f() {
+    // bhitore
+    ferao x;
+    // shesh e
+}
+// file er shesh
+`
+	if got := formatString(t, input); got != expected {
+		t.Errorf("got:\n%s\nwant:\n%s", got, expected)
+	}
+}
+
+func TestFormatKeepsBengaliScript(t *testing.T) {
+	input := "ধরো মোট=০;\nঘুরিয়ে(ধরো i=১;i<=১০;i+=১){যদি(i%২==০){মোট+=i;}নাহলে{চালিয়ে_যাও(i);}}\ndekho(মোট, না সত্যি);"
+	expected := `ধরো মোট = ০;
+ঘুরিয়ে (ধরো i = ১; i <= ১০; i += ১) {
+    যদি (i % ২ == ০) {
+        মোট += i;
+    } নাহলে {
+        চালিয়ে_যাও(i);
+    }
+}
+dekho(মোট, না সত্যি);
+`
+	got := formatString(t, input)
+	if got != expected {
+		t.Errorf("got:\n%s\nwant:\n%s", got, expected)
+	}
+	if again := formatString(t, got); again != got {
+		t.Errorf("not idempotent:\n%s", again)
+	}
+
+	// Banglish stays Banglish
+	if got := formatString(t, "dhoro x=৫;"); got != "dhoro x = ৫;\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestFormatSyntaxError(t *testing.T) {
+	if _, err := format.Source([]byte("dhoro = ;"), "bad.bang"); err == nil {
+		t.Fatal("expected a syntax error")
+	}
+}
+
+// Formatting must not change what a program does
+func TestFormatPreservesBehaviour(t *testing.T) {
+	input := `kaj f(a,b){ferao (a-b)*2 - (a - (b - 1));}
+dhoro m = {x:1,y:[2,3]};
+dhoro g = (n) => n % 4;
+f(10, 4) + m.y[1] + g(7) - -(m.x + 1);`
+
+	before := testEval(input)
+	after := testEval(formatString(t, input))
+	if before.Inspect() != after.Inspect() {
+		t.Fatalf("result changed: %s -> %s", before.Inspect(), after.Inspect())
+	}
+}
+
+// Nested unary operators survive fmt -> parse -> fmt: the output parses to
+// the same AST, formats to itself and never glues two minus signs together
+func TestFormatRoundTripNestedUnary(t *testing.T) {
+	inputs := []string{
+		"dhoro a = -(-x);",
+		"dhoro a = -(-(-x));",
+		"dhoro a = - -x;",
+		"dhoro a = -(~x) + ~(~x);",
+		"dhoro a = !(!x) ebong na (na x);",
+		"dhoro a = b - (-(-c));",
+		"dhoro a = -(-(2 ** 2));",
+	}
+	for _, input := range inputs {
+		once := formatString(t, input)
+		if strings.Contains(once, "--") {
+			t.Errorf("%q formatted as %q", input, once)
+		}
+		if twice := formatString(t, once); twice != once {
+			t.Errorf("%q: not idempotent: %q -> %q", input, once, twice)
+		}
+		if before, after := parseProgramString(t, input), parseProgramString(t, once); before != after {
+			t.Errorf("%q: AST changed: %s -> %s", input, before, after)
+		}
+	}
+}
+
+func parseProgramString(t *testing.T, src string) string {
+	t.Helper()
+	p := parser.New(lexer.New(src))
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+	return program.String()
+}
+
+// Formatting formatted code returns it unchanged
+func TestFormatIdempotentOnExamples(t *testing.T) {
+	files, _ := filepath.Glob("../examples/*.bang")
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		once, err := format.Source(src, file)
+		if err != nil {
+			continue // examples that do not parse are covered by the parser tests
+		}
+		twice, err := format.Source(once, file)
+		if err != nil {
+			t.Errorf("%s: formatted output does not parse: %v", file, err)
+			continue
+		}
+		if string(once) != string(twice) {
+			t.Errorf("%s: formatting is not idempotent", file)
+		}
+	}
+}
@@ -1,7 +1,13 @@
 package test
 
 import (
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
+	"BanglaCode/src/parser"
+	"fmt"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -221,6 +227,324 @@ router.ana("/test", kaj(req, res) {}, "extra");
 	}
 }
 
+// Test route patterns with named, optional and wildcard segments
+func TestRouterPathParameters(t *testing.T) {
+	handler := testEval(`kaj(req, res) { ferao req; }`).(*object.Function)
+
+	router := builtins.NewRouter("")
+	router.AddRoute("GET", "/users/:id", handler)
+	router.AddRoute("GET", "/users/:id/posts/:postId", handler)
+	router.AddRoute("GET", "/users/me", handler)
+	router.AddRoute("GET", "/archive/:year/:month?", handler)
+	router.AddRoute("GET", "/static/*", handler)
+	router.AddRoute("GET", "/files/*path", handler)
+
+	tests := []struct {
+		path   string
+		found  bool
+		params map[string]string
+	}{
+		{"/users/42", true, map[string]string{"id": "42"}},
+		{"/users/42/", true, map[string]string{"id": "42"}},
+		{"/users/me", true, map[string]string{}},
+		{"/users/7/posts/99", true, map[string]string{"id": "7", "postId": "99"}},
+		{"/archive/2024", true, map[string]string{"year": "2024"}},
+		{"/archive/2024/05", true, map[string]string{"year": "2024", "month": "05"}},
+		{"/static/css/site.css", true, map[string]string{"*": "css/site.css"}},
+		{"/files/a/b/c.txt", true, map[string]string{"path": "a/b/c.txt"}},
+		{"/users", false, nil},
+		{"/users/1/comments", false, nil},
+	}
+
+	for _, tt := range tests {
+		_, params, ok := router.Match("GET", tt.path)
+		if ok != tt.found {
+			t.Errorf("Match(%q) found=%v, want %v", tt.path, ok, tt.found)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if len(params) != len(tt.params) {
+			t.Errorf("Match(%q) params=%v, want %v", tt.path, params, tt.params)
+			continue
+		}
+		for k, v := range tt.params {
+			if params[k] != v {
+				t.Errorf("Match(%q) params[%q]=%q, want %q", tt.path, k, params[k], v)
+			}
+		}
+	}
+}
+
+// Test optional segments belong to the route that declared them, not to
+// every route sharing the parameter segment
+func TestRouterOptionalParamsPerRoute(t *testing.T) {
+	handler := testEval(`kaj(req, res) { ferao req; }`).(*object.Function)
+
+	router := builtins.NewRouter("")
+	router.AddRoute("GET", "/a/:y?/b", handler)
+	router.AddRoute("GET", "/a/:x/c", handler)
+	router.AddRoute("GET", "/p/:y?", handler)
+	router.AddRoute("GET", "/p/:x", handler) // replaces /p/:y?
+	router.AddRoute("GET", "/q/:x", handler)
+	router.AddRoute("GET", "/q/:y?", handler) // replaces /q/:x
+
+	tests := []struct {
+		path   string
+		found  bool
+		params map[string]string
+	}{
+		{"/a/b", true, map[string]string{}},
+		{"/a/1/b", true, map[string]string{"y": "1"}},
+		{"/a/1/c", true, map[string]string{"x": "1"}},
+		{"/a/c", false, nil},
+		{"/p/5", true, map[string]string{"x": "5"}},
+		{"/p", false, nil},
+		{"/q", true, map[string]string{}},
+		{"/q/5", true, map[string]string{"y": "5"}},
+	}
+
+	for _, tt := range tests {
+		_, params, ok := router.Match("GET", tt.path)
+		if ok != tt.found {
+			t.Errorf("Match(%q) found=%v, want %v", tt.path, ok, tt.found)
+			continue
+		}
+		if ok && fmt.Sprint(params) != fmt.Sprint(tt.params) {
+			t.Errorf("Match(%q) params=%v, want %v", tt.path, params, tt.params)
+		}
+	}
+}
+
+// Test req.params is passed to the handler
+func TestRouterParamsInRequest(t *testing.T) {
+	handler := testEval(`kaj(req, res) { uttor(res, "user " + req.params.id); }`).(*object.Function)
+
+	router := builtins.NewRouter("")
+	router.AddRoute("GET", "/users/:id", handler)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/users/42", nil))
+
+	if rec.Body.String() != "user 42" {
+		t.Errorf("expected body %q, got %q", "user 42", rec.Body.String())
+	}
+}
+
+// Test mounting a sub-router keeps its parameterized routes
+func TestRouterMountWithParams(t *testing.T) {
+	handler := testEval(`kaj(req, res) { uttor(res, req.params.id); }`).(*object.Function)
+
+	sub := builtins.NewRouter("")
+	sub.AddRoute("GET", "/:id", handler)
+
+	router := builtins.NewRouter("")
+	router.MountSubRouter("/api/users", sub)
+
+	_, params, ok := router.Match("GET", "/api/users/5")
+	if !ok {
+		t.Fatal("expected mounted route to match")
+	}
+	if params["id"] != "5" {
+		t.Errorf("expected id=5, got %q", params["id"])
+	}
+}
+
+// serveRouter runs a script that builds a router named app and sends one request to it
+func serveRouter(t *testing.T, script, method, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	env := object.NewEnvironment()
+	l := lexer.New(script)
+	p := parser.New(l)
+	program := p.ParseProgram()
+	if len(p.Errors()) > 0 {
+		t.Fatalf("parser errors: %v", p.Errors())
+	}
+	if result := evalProgram(program, env); isError(result) {
+		t.Fatalf("script error: %s", result.Inspect())
+	}
+
+	app, ok := env.Get("app")
+	if !ok {
+		t.Fatal("script did not define app")
+	}
+	router, ok := builtins.LookupRouter(app)
+	if !ok {
+		t.Fatal("app is not a router")
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+// Test global middleware runs before handlers and can mutate req
+func TestRouterGlobalMiddleware(t *testing.T) {
+	rec := serveRouter(t, `
+dhoro app = router_banao();
+app.bebohar(kaj(req, res, next) {
+    req.user = "ankan";
+    res.headers["X-Powered-By"] = "BanglaCode";
+    next();
+});
+app.ana("/me", kaj(req, res) {
+    uttor(res, req.user);
+});
+`, "GET", "/me")
+
+	if rec.Body.String() != "ankan" {
+		t.Errorf("expected body %q, got %q", "ankan", rec.Body.String())
+	}
+	if rec.Header().Get("X-Powered-By") != "BanglaCode" {
+		t.Errorf("expected X-Powered-By header, got %q", rec.Header().Get("X-Powered-By"))
+	}
+}
+
+// Test middleware can short-circuit the chain
+func TestRouterMiddlewareShortCircuit(t *testing.T) {
+	script := `
+dhoro app = router_banao();
+app.bebohar("/admin", kaj(req, res, next) {
+    jodi (req.headers["Authorization"] == khali) {
+        uttor(res, "unauthorized", 401);
+        ferao;
+    }
+    next();
+});
+app.ana("/admin/stats", kaj(req, res) { uttor(res, "stats"); });
+app.ana("/public", kaj(req, res) { uttor(res, "public"); });
+`
+	rec := serveRouter(t, script, "GET", "/admin/stats")
+	if rec.Code != 401 || rec.Body.String() != "unauthorized" {
+		t.Errorf("expected 401 unauthorized, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = serveRouter(t, script, "GET", "/public")
+	if rec.Code != 200 || rec.Body.String() != "public" {
+		t.Errorf("expected 200 public, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+// Test per-route middleware runs in order before the handler
+func TestRouterPerRouteMiddleware(t *testing.T) {
+	rec := serveRouter(t, `
+dhoro app = router_banao();
+dhoro first = kaj(req, res, next) { req.trace = "a"; next(); };
+dhoro second = kaj(req, res, next) { req.trace = req.trace + "b"; next(); };
+app.ana("/trace", first, second, kaj(req, res) {
+    uttor(res, req.trace + "c");
+});
+`, "GET", "/trace")
+
+	if rec.Body.String() != "abc" {
+		t.Errorf("expected body %q, got %q", "abc", rec.Body.String())
+	}
+}
+
+// Test middleware on a mounted sub-router only applies below the mount path
+func TestRouterMountedMiddleware(t *testing.T) {
+	script := `
+dhoro api = router_banao();
+api.bebohar(kaj(req, res, next) { req.scope = "api"; next(); });
+api.ana("/users/:id", kaj(req, res) { uttor(res, req.scope + ":" + req.params.id); });
+
+dhoro app = router_banao();
+app.ana("/health", kaj(req, res) { uttor(res, "scope=" + dhoron(req.scope)); });
+app.bebohar("/api", api);
+`
+	rec := serveRouter(t, script, "GET", "/api/users/3")
+	if rec.Body.String() != "api:3" {
+		t.Errorf("expected body %q, got %q", "api:3", rec.Body.String())
+	}
+
+	rec = serveRouter(t, script, "GET", "/health")
+	if rec.Body.String() != "scope=NULL" {
+		t.Errorf("expected body %q, got %q", "scope=NULL", rec.Body.String())
+	}
+}
+
+// Test a mounted sub-router is consulted per request, so routes and
+// middleware added after mounting it still apply
+func TestRouterMountThenAddRoute(t *testing.T) {
+	script := `
+dhoro api = router_banao();
+dhoro app = router_banao();
+app.bebohar("/api", api);
+
+api.bebohar(kaj(req, res, next) { req.scope = "api"; next(); });
+api.ana("/users/:id", kaj(req, res) { uttor(res, req.scope + ":" + req.params.id); });
+`
+	rec := serveRouter(t, script, "GET", "/api/users/3")
+	if rec.Code != 200 || rec.Body.String() != "api:3" {
+		t.Errorf("expected 200 api:3, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = serveRouter(t, script, "GET", "/api/missing")
+	if rec.Code != 404 {
+		t.Errorf("expected 404 below the mount path, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	evaluated := testEval(`dhoro a = router_banao(); dhoro b = router_banao(); b.bebohar("/a", a); a.bebohar("/b", b);`)
+	if !isError(evaluated) || !strings.Contains(evaluated.Inspect(), "cannot mount a router inside itself") {
+		t.Errorf("expected an error mounting a router inside itself, got %s", evaluated.Inspect())
+	}
+}
+
+// Test middleware runs before the route lookup, so requests no route
+// matches pass through it before the 404
+func TestRouterMiddlewareWithoutRoute(t *testing.T) {
+	script := `
+dhoro app = router_banao();
+app.bebohar(kaj(req, res, next) {
+    res.headers["X-Request-Path"] = req.path;
+    jodi (req.method == "OPTIONS") {
+        uttor(res, "", 204);
+        ferao;
+    }
+    next();
+});
+app.ana("/users", kaj(req, res) { uttor(res, "users"); });
+`
+	rec := serveRouter(t, script, "GET", "/missing")
+	if rec.Code != 404 || rec.Body.String() != "404 page not found\n" {
+		t.Errorf("expected 404, got %d %q", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("X-Request-Path") != "/missing" {
+		t.Errorf("expected middleware to run before the 404, got headers %v", rec.Header())
+	}
+
+	rec = serveRouter(t, script, "OPTIONS", "/users")
+	if rec.Code != 204 {
+		t.Errorf("expected middleware to answer the preflight with 204, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = serveRouter(t, script, "GET", "/users")
+	if rec.Code != 200 || rec.Body.String() != "users" {
+		t.Errorf("expected 200 users, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+// Test several servers in one script, each with its own handler, answering
+// anun calls made by the same script
+func TestServerChaluRequestsFromScript(t *testing.T) {
+	result := testEval(`
+server_chalu(18731, kaj(req, res) { uttor(res, "one " + req.path); });
+server_chalu(18732, kaj(req, res, next) { req.n = "two"; next(); }, kaj(req, res) { uttor(res, req.n); });
+
+dhoro app = router_banao();
+app.ana("/", kaj(req, res) { uttor(res, "router"); });
+server_chalu(18733, app);
+
+dhoro a = anun("http://127.0.0.1:18731/x");
+dhoro b = anun("http://127.0.0.1:18732/");
+dhoro c = anun("http://127.0.0.1:18733/");
+dhoro d = anun("http://127.0.0.1:18733/missing");
+a.body + "|" + b.body + "|" + c.body + "|" + lipi(d.status)
+`)
+	testStringObject(t, result, "one /x|two|router|404")
+}
+
 func isError(result object.Object) bool {
 	if result == nil {
 		return false
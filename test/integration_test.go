@@ -1,7 +1,6 @@
 package test
 
 import (
-	"BanglaCode/src/evaluator"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
 	"BanglaCode/src/parser"
@@ -13,7 +12,7 @@ func runProgram(input string) object.Object {
 	p := parser.New(l)
 	program := p.ParseProgram()
 	env := object.NewEnvironment()
-	return evaluator.Eval(program, env)
+	return evalProgram(program, env)
 }
 
 // Test a complete factorial program
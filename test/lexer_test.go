@@ -101,6 +101,61 @@ func TestNextToken_Operators(t *testing.T) {
 	}
 }
 
+func TestNextToken_BitwiseOperators(t *testing.T) {
+	input := `& | ^ ~ << >> >>> ** %= **= &= |= ^= <<= >>= >>>= && || a>>>b`
+
+	tests := []struct {
+		expectedType    lexer.TokenType
+		expectedLiteral string
+		expectedColumn  int
+	}{
+		{lexer.AMPERSAND, "&", 1},
+		{lexer.PIPE, "|", 3},
+		{lexer.CARET, "^", 5},
+		{lexer.TILDE, "~", 7},
+		{lexer.SHL, "<<", 9},
+		{lexer.SHR, ">>", 12},
+		{lexer.USHR, ">>>", 15},
+		{lexer.POWER, "**", 19},
+		{lexer.PERCENT_ASSIGN, "%=", 22},
+		{lexer.POWER_ASSIGN, "**=", 25},
+		{lexer.AND_BIT_ASSIGN, "&=", 29},
+		{lexer.OR_BIT_ASSIGN, "|=", 32},
+		{lexer.XOR_ASSIGN, "^=", 35},
+		{lexer.SHL_ASSIGN, "<<=", 38},
+		{lexer.SHR_ASSIGN, ">>=", 42},
+		{lexer.USHR_ASSIGN, ">>>=", 46},
+		// && and || are ebong and ba, spelled the JavaScript way
+		{lexer.EBONG, "ebong", 51},
+		{lexer.BA, "ba", 54},
+		{lexer.IDENT, "a", 57},
+		{lexer.USHR, ">>>", 58},
+		{lexer.IDENT, "b", 61},
+		{lexer.EOF, "", 62},
+	}
+
+	l := lexer.New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+
+		if tok.Column != tt.expectedColumn {
+			t.Fatalf("tests[%d] - column wrong. expected=%d, got=%d",
+				i, tt.expectedColumn, tok.Column)
+		}
+	}
+}
+
 func TestNextToken_Delimiters(t *testing.T) {
 	input := `, ; : . ( ) { } [ ]`
 
@@ -636,6 +691,57 @@ func TestNextToken_LogicalOperators(t *testing.T) {
 	}
 }
 
+func TestNextToken_ConditionalOperators(t *testing.T) {
+	input := `a ? b : c ?? d?.e?.[0] f?.() x ??= 1 ||= 2 &&= 3`
+
+	tests := []struct {
+		expectedType    lexer.TokenType
+		expectedLiteral string
+	}{
+		{lexer.IDENT, "a"},
+		{lexer.QUESTION, "?"},
+		{lexer.IDENT, "b"},
+		{lexer.COLON, ":"},
+		{lexer.IDENT, "c"},
+		{lexer.NULLISH, "??"},
+		{lexer.IDENT, "d"},
+		{lexer.OPTIONAL, "?."},
+		{lexer.IDENT, "e"},
+		{lexer.OPTIONAL, "?."},
+		{lexer.LBRACKET, "["},
+		{lexer.NUMBER, "0"},
+		{lexer.RBRACKET, "]"},
+		{lexer.IDENT, "f"},
+		{lexer.OPTIONAL, "?."},
+		{lexer.LPAREN, "("},
+		{lexer.RPAREN, ")"},
+		{lexer.IDENT, "x"},
+		{lexer.NULLISH_ASSIGN, "??="},
+		{lexer.NUMBER, "1"},
+		{lexer.OR_ASSIGN, "||="},
+		{lexer.NUMBER, "2"},
+		{lexer.AND_ASSIGN, "&&="},
+		{lexer.NUMBER, "3"},
+		{lexer.EOF, ""},
+	}
+
+	l := lexer.New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
 func TestNextToken_BreakContinue(t *testing.T) {
 	input := `thamo; chharo;`
 
@@ -789,6 +895,11 @@ func TestLookupIdent(t *testing.T) {
 		{"khali", lexer.KHALI},
 		{"myVariable", lexer.IDENT},
 		{"foo", lexer.IDENT},
+		{"ধরো", lexer.DHORO},
+		{"ফেরাও", lexer.FERAO},
+		{"প্র\u09af\u09bcাস", lexer.PROYASH}, // য় as য + nukta
+		{"প্র\u09dfাস", lexer.PROYASH},       // য় as one code point
+		{"নাম", lexer.IDENT},
 	}
 
 	for _, tt := range tests {
@@ -798,3 +909,39 @@ func TestLookupIdent(t *testing.T) {
 		}
 	}
 }
+
+func TestBengaliScript(t *testing.T) {
+	input := "ধরো নাম = ৪২.৫;\nজোগফল_২ = নাম;"
+
+	tests := []struct {
+		expectedType    lexer.TokenType
+		expectedLiteral string
+		expectedRaw     string
+		expectedLine    int
+		expectedColumn  int
+	}{
+		{lexer.DHORO, "dhoro", "ধরো", 1, 1},
+		{lexer.IDENT, "নাম", "", 1, 5},
+		{lexer.ASSIGN, "=", "", 1, 9},
+		{lexer.NUMBER, "42.5", "৪২.৫", 1, 11},
+		{lexer.SEMICOLON, ";", "", 1, 15},
+		{lexer.IDENT, "জোগফল_২", "", 2, 1},
+		{lexer.ASSIGN, "=", "", 2, 9},
+		{lexer.IDENT, "নাম", "", 2, 11},
+		{lexer.SEMICOLON, ";", "", 2, 14},
+		{lexer.EOF, "", "", 2, 15},
+	}
+
+	l := lexer.New(input)
+	for i, tt := range tests {
+		tok := l.NextToken()
+		if tok.Type != tt.expectedType || tok.Literal != tt.expectedLiteral || tok.Raw != tt.expectedRaw {
+			t.Fatalf("tests[%d] - got %s %q (raw %q), want %s %q (raw %q)",
+				i, tok.Type, tok.Literal, tok.Raw, tt.expectedType, tt.expectedLiteral, tt.expectedRaw)
+		}
+		if tok.Line != tt.expectedLine || tok.Column != tt.expectedColumn {
+			t.Errorf("tests[%d] %q - wrong position. got=%d:%d, want=%d:%d",
+				i, tok.Literal, tok.Line, tok.Column, tt.expectedLine, tt.expectedColumn)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins"
+	"BanglaCode/src/lint"
+	"BanglaCode/src/object"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// lintCodes returns "line:rule" for every problem found in input
+func lintCodes(input string, cfg *lint.Config) []string {
+	var codes []string
+	for _, d := range lint.Source([]byte(input), "test.bang", cfg) {
+		codes = append(codes, fmt.Sprintf("%d:%s", d.Start.Line, d.Code))
+	}
+	return codes
+}
+
+func TestLintRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"unused variable", "dhoro a = 1;\ndhoro b = 2;\ndekho(b);", []string{"1:unused-variable"}},
+		{"assignment is not a use", "dhoro a = 1;\na = 2;", []string{"1:unused-variable"}},
+		{"underscore and exports", "dhoro _a = 1;\npathao dhoro b = 2;", nil},
+		{"template use", "dhoro naam = \"x\";\ndekho(`hi ${naam}`);", nil},
+		{"const reassign", "sthir a = 1;\na = 2;\ndekho(a);", []string{"2:const-reassign"}},
+		{"destructuring assignment", "sthir a = 1;\ndhoro b = [];\n[[a], ...b] = [[2]];\ndekho(a);", []string{"2:unused-variable", "3:const-reassign"}},
+		{"unreachable after ferao", "kaj f() {\n    ferao 1;\n    dekho(2);\n}\nf();", []string{"3:unreachable-code"}},
+		{"unreachable after if/else", "kaj f(x) {\n    jodi (x) { ferao 1; } nahole { felo \"e\"; }\n    dekho(x);\n}\nf(1);", []string{"3:unreachable-code"}},
+		{"unknown function", "dekhoo(1);", []string{"1:unknown-function"}},
+		{"declared later", "f();\nkaj f() {}", nil},
+		{"builtin arity", "dorghyo(1, 2);\nboro(1);\ndorghyo(...[1]);", []string{"1:builtin-arity", "2:builtin-arity"}},
+		{"shadowed builtin is user function", "kaj dorghyo(a, b) { ferao a; }\ndorghyo(1, 2);", []string{"1:shadow"}},
+		{"shadowing", "dhoro x = 1;\nkaj f(x) { ferao x; }\nf(x);", []string{"2:shadow"}},
+		{"outer declared later", "kaj f() {\n    dhoro x = 1;\n    ferao x;\n}\ndhoro x = f();\ndekho(x);", nil},
+		{"nearest earlier outer", "dhoro x = 1;\nkaj f() {\n    kaj g(x) { ferao x; }\n    dhoro x = 2;\n    ferao g(x);\n}\ndekho(f(), x);", []string{"3:shadow", "4:shadow"}},
+		{"nested patterns", "dhoro {a: [b, ...c], d = b, e: {g}} = {};\nkaj f({p: {b} = {}}, [r, ...s]) { ferao b + r; }\ndekho(c, d, f);", []string{"1:unused-variable", "2:shadow"}},
+		{"blocks share scope", "dhoro x = 1;\njodi (sotti) { dhoro x = 2; }\ndekho(x);", nil},
+		{"await outside async", "kaj f() {\n    ferao opekha ghumaao(1);\n}\nproyash kaj g() { opekha ghumaao(1); }\nopekha ghumaao(1);\nf(); g();", []string{"2:await-outside-async"}},
+		{"for await outside async", "kaj f(xs) {\n    ghuriye opekha (x of xs) {}\n}\nproyash kaj g(xs) { ghuriye opekha (x of xs) {} }\nf([]); g([]);", []string{"2:await-outside-async"}},
+		{"this in methods", "sreni A {\n    kaj get() { ferao ei.x; }\n}\ndekho(A);", nil},
+	}
+
+	for _, tt := range tests {
+		got := lintCodes(tt.input, nil)
+		if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestLintSuppressionAndConfig(t *testing.T) {
+	input := `dhoro a = 1; // lint-disable-line unused-variable
+// lint-disable-next-line
+dhoro b = 1;
+dhoro c = 1;
+dhoro lekho = 2;
+dekho(lekho);`
+	if got := lintCodes(input, nil); strings.Join(got, ",") != "4:unused-variable,5:shadow" {
+		t.Errorf("suppression: got %v", got)
+	}
+	if got := lintCodes("// lint-disable shadow\n"+input, nil); strings.Join(got, ",") != "5:unused-variable" {
+		t.Errorf("file suppression: got %v", got)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, lint.ConfigFile)
+	os.WriteFile(path, []byte(`{"rules": {"shadow": "off", "unused-variable": "error"}}`), 0644)
+	cfg, err := lint.FindConfig(dir)
+	if err != nil || cfg == nil {
+		t.Fatalf("FindConfig: %v, %v", cfg, err)
+	}
+	diagnostics := lint.Source([]byte(input), "test.bang", cfg)
+	if len(diagnostics) != 1 || diagnostics[0].Code != "unused-variable" || diagnostics[0].Severity.String() != "error" {
+		t.Errorf("config: got %+v", diagnostics)
+	}
+
+	os.WriteFile(path, []byte(`{"rules": {"no-such-rule": "off"}}`), 0644)
+	if _, err := lint.LoadConfig(path); err == nil {
+		t.Error("expected an error for an unknown rule")
+	}
+}
+
+func TestLintReportsSyntaxErrors(t *testing.T) {
+	diagnostics := lint.Source([]byte("dhoro = ;"), "bad.bang", nil)
+	if len(diagnostics) == 0 || !strings.HasPrefix(diagnostics[0].Code, "E") {
+		t.Fatalf("expected parser diagnostics, got %+v", diagnostics)
+	}
+}
+
+// The arity table must agree with the builtins themselves
+func TestBuiltinAritiesMatchBuiltins(t *testing.T) {
+	for name, arity := range builtins.Arities {
+		fn, ok := builtins.Builtins[name]
+		if !ok {
+			t.Errorf("%s: not a builtin", name)
+			continue
+		}
+		counts := []int{arity.Min - 1}
+		if arity.Max >= 0 {
+			counts = append(counts, arity.Max+1)
+		}
+		for _, n := range counts {
+			if n < 0 {
+				continue
+			}
+			args := make([]object.Object, n)
+			for i := range args {
+				args[i] = &object.Null{}
+			}
+			if _, isErr := fn.Fn(args...).(*object.Error); !isErr {
+				t.Errorf("%s: expected an error with %d argument(s)", name, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package test
+
+import (
+	"BanglaCode/src/lsp"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// lspSession feeds framed requests to a language server and decodes its output
+type lspSession struct {
+	in     bytes.Buffer
+	nextID int
+}
+
+func (s *lspSession) send(method string, params interface{}) int {
+	s.nextID++
+	s.frame(map[string]interface{}{"jsonrpc": "2.0", "id": s.nextID, "method": method, "params": params})
+	return s.nextID
+}
+
+func (s *lspSession) notify(method string, params interface{}) {
+	s.frame(map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params})
+}
+
+func (s *lspSession) frame(v interface{}) {
+	body, _ := json.Marshal(v)
+	fmt.Fprintf(&s.in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+type lspMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+}
+
+// run executes the queued messages and returns the server's replies
+func (s *lspSession) run(t *testing.T) []lspMessage {
+	t.Helper()
+	s.send("shutdown", nil)
+	s.notify("exit", nil)
+
+	var out bytes.Buffer
+	if err := lsp.NewServer(&s.in, &out).Run(); err != nil {
+		t.Fatalf("server error: %v", err)
+	}
+
+	var messages []lspMessage
+	r := bufio.NewReader(&out)
+	for {
+		header, err := r.ReadString('\n')
+		if err == io.EOF {
+			return messages
+		}
+		length, _ := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "Content-Length:")))
+		r.ReadString('\n')
+		body := make([]byte, length)
+		io.ReadFull(r, body)
+
+		var msg lspMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatalf("bad message %s: %v", body, err)
+		}
+		messages = append(messages, msg)
+	}
+}
+
+func resultOf(t *testing.T, messages []lspMessage, id int, v interface{}) {
+	t.Helper()
+	for _, msg := range messages {
+		if msg.ID != nil && *msg.ID == id {
+			if err := json.Unmarshal(msg.Result, v); err != nil {
+				t.Fatalf("cannot decode result %s: %v", msg.Result, err)
+			}
+			return
+		}
+	}
+	t.Fatalf("no response for request %d", id)
+}
+
+func fileURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+func openDoc(s *lspSession, uri, text string) {
+	s.notify("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri, "languageId": "banglacode", "version": 1, "text": text},
+	})
+}
+
+func at(uri string, line, character int) map[string]interface{} {
+	return map[string]interface{}{
+		"textDocument": map[string]string{"uri": uri},
+		"position":     map[string]int{"line": line, "character": character},
+	}
+}
+
+func TestLSPPublishesDiagnostics(t *testing.T) {
+	s := &lspSession{}
+	s.send("initialize", map[string]interface{}{})
+	openDoc(s, "file:///tmp/bhul.bang", "dhoro = 5;\ndekho(\"কখ\" +);\ndhoro ok = 1;")
+	messages := s.run(t)
+
+	var params struct {
+		URI         string           `json:"uri"`
+		Diagnostics []lsp.Diagnostic `json:"diagnostics"`
+	}
+	for _, msg := range messages {
+		if msg.Method == "textDocument/publishDiagnostics" {
+			json.Unmarshal(msg.Params, &params)
+		}
+	}
+
+	if len(params.Diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %+v", params.Diagnostics)
+	}
+	first := params.Diagnostics[0]
+	if first.Range.Start != (lsp.Position{Line: 0, Character: 6}) || first.Code != "E001" {
+		t.Errorf("wrong first diagnostic: %+v", first)
+	}
+	// Columns are UTF-16 offsets: the Bengali letters count once each
+	if second := params.Diagnostics[1]; second.Range.Start != (lsp.Position{Line: 1, Character: 12}) {
+		t.Errorf("wrong second diagnostic: %+v", second)
+	}
+}
+
+func TestLSPCompletionAndHover(t *testing.T) {
+	uri := "file:///tmp/main.bang"
+	s := &lspSession{}
+	openDoc(s, uri, "kaj jog(a, b) {\n    ferao a + b;\n}\ndekho(dorghyo(\"abc\"));\ndekho(jog(1, 2));")
+	completion := s.send("textDocument/completion", at(uri, 1, 10))
+	builtinHover := s.send("textDocument/hover", at(uri, 3, 8))
+	userHover := s.send("textDocument/hover", at(uri, 4, 7))
+	messages := s.run(t)
+
+	var items []lsp.CompletionItem
+	resultOf(t, messages, completion, &items)
+	labels := map[string]lsp.CompletionItem{}
+	for _, item := range items {
+		labels[item.Label] = item
+	}
+	for _, want := range []string{"dhoro", "ferao", "dekho", "dorghyo", "server_chalu", "jog", "a"} {
+		if _, ok := labels[want]; !ok {
+			t.Errorf("completion is missing %q", want)
+		}
+	}
+	if labels["bhag"].Detail != "bhag(str, separator)" {
+		t.Errorf("builtin completion lacks signature: %+v", labels["bhag"])
+	}
+
+	var hover lsp.Hover
+	resultOf(t, messages, builtinHover, &hover)
+	if !strings.Contains(hover.Contents.Value, "dorghyo(value)") {
+		t.Errorf("unexpected builtin hover: %q", hover.Contents.Value)
+	}
+
+	resultOf(t, messages, userHover, &hover)
+	if !strings.Contains(hover.Contents.Value, "kaj jog(a, b)") {
+		t.Errorf("unexpected hover for user function: %q", hover.Contents.Value)
+	}
+}
+
+func TestLSPDocumentSymbols(t *testing.T) {
+	uri := "file:///tmp/shapes.bang"
+	s := &lspSession{}
+	openDoc(s, uri, `kaj elaka(r) {
+    kaj borgo(x) { ferao x * x; }
+    ferao 3.14 * borgo(r);
+}
+sreni Britto {
+    shuru(r) { ei.r = r; }
+    kaj elaka() { ferao 3.14 * ei.r * ei.r; }
+}
+dhoro dhoro_jog = kaj(a, b) { ferao a + b; };`)
+	id := s.send("textDocument/documentSymbol", map[string]interface{}{"textDocument": map[string]string{"uri": uri}})
+	messages := s.run(t)
+
+	var symbols []lsp.DocumentSymbol
+	resultOf(t, messages, id, &symbols)
+
+	if len(symbols) != 3 {
+		t.Fatalf("expected 3 top-level symbols, got %+v", symbols)
+	}
+	if symbols[0].Name != "elaka" || len(symbols[0].Children) != 1 || symbols[0].Children[0].Name != "borgo" {
+		t.Errorf("wrong function symbol: %+v", symbols[0])
+	}
+	if symbols[0].Range.End.Line != 3 {
+		t.Errorf("function range should end at its closing brace, got %+v", symbols[0].Range)
+	}
+	if symbols[1].Name != "Britto" || len(symbols[1].Children) != 2 || symbols[1].Children[0].Name != "shuru" {
+		t.Errorf("wrong class symbol: %+v", symbols[1])
+	}
+	if symbols[2].Name != "dhoro_jog" {
+		t.Errorf("expected function assigned to variable, got %+v", symbols[2])
+	}
+}
+
+func TestLSPGoToDefinition(t *testing.T) {
+	dir := t.TempDir()
+	utils := "pathao kaj jog(a, b) {\n    ferao a + b;\n}\npathao sthir PI = 3.14;\n"
+	if err := os.WriteFile(filepath.Join(dir, "utils.bang"), []byte(utils), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	uri := fileURI(filepath.Join(dir, "main.bang"))
+	main := `ano "utils.bang";
+ano "utils.bang" hisabe u;
+dhoro x = 1;
+kaj f(x) {
+    ferao x + jog(1, 2) + u.PI;
+}`
+
+	s := &lspSession{}
+	openDoc(s, uri, main)
+	param := s.send("textDocument/definition", at(uri, 4, 10))
+	imported := s.send("textDocument/definition", at(uri, 4, 16))
+	aliased := s.send("textDocument/definition", at(uri, 4, 28))
+	path := s.send("textDocument/definition", at(uri, 0, 6))
+	messages := s.run(t)
+
+	var loc lsp.Location
+	resultOf(t, messages, param, &loc)
+	if loc.URI != uri || loc.Range.Start != (lsp.Position{Line: 3, Character: 6}) {
+		t.Errorf("x should resolve to the parameter, got %+v", loc)
+	}
+
+	utilsURI := fileURI(filepath.Join(dir, "utils.bang"))
+	resultOf(t, messages, imported, &loc)
+	if loc.URI != utilsURI || loc.Range.Start != (lsp.Position{Line: 0, Character: 11}) {
+		t.Errorf("jog should resolve into utils.bang, got %+v", loc)
+	}
+
+	resultOf(t, messages, aliased, &loc)
+	if loc.URI != utilsURI || loc.Range.Start != (lsp.Position{Line: 3, Character: 13}) {
+		t.Errorf("u.PI should resolve into utils.bang, got %+v", loc)
+	}
+
+	resultOf(t, messages, path, &loc)
+	if loc.URI != utilsURI {
+		t.Errorf("import path should open the module, got %+v", loc)
+	}
+}
+
+func TestLSPBengaliScriptPositions(t *testing.T) {
+	uri := "file:///tmp/bangla.bang"
+	// Columns count runes; LSP positions count UTF-16 units (the same for
+	// Bengali, which is inside the BMP)
+	text := "ধরো নাম = \"অঙ্কন\";\ndekho(নাম);"
+
+	s := &lspSession{}
+	openDoc(s, uri, text)
+	def := s.send("textDocument/definition", at(uri, 1, 7))
+	messages := s.run(t)
+
+	var loc lsp.Location
+	resultOf(t, messages, def, &loc)
+	want := lsp.Range{Start: lsp.Position{Line: 0, Character: 4}, End: lsp.Position{Line: 0, Character: 7}}
+	if loc.URI != uri || loc.Range != want {
+		t.Errorf("expected নাম at %+v, got %+v", want, loc)
+	}
+}
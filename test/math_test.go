@@ -4,7 +4,6 @@ import (
 	"math"
 	"testing"
 
-	"BanglaCode/src/evaluator"
 	"BanglaCode/src/evaluator/builtins"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
@@ -18,7 +17,7 @@ func evalMathInput(input string) object.Object {
 	program := p.ParseProgram()
 	env := object.NewEnvironment()
 	builtins.InitializeEnvironmentWithConstants(env)
-	return evaluator.Eval(program, env)
+	return evalProgram(program, env)
 }
 
 // Helper to check if float values are approximately equal
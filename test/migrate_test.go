@@ -0,0 +1,284 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator/builtins/database"
+	"BanglaCode/src/migrate"
+	"BanglaCode/src/object"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newMigrator writes files into a fresh migrations directory and opens a
+// migrator on a SQLite database next to it
+func newMigrator(t *testing.T, files map[string]string) (*migrate.Migrator, *object.DBConnection) {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, "migrations")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	conn, err := migrate.Connect("sqlite:" + filepath.Join(root, "app.db"))
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	m, err := migrate.New(conn, dir)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return m, conn
+}
+
+func migrationStates(t *testing.T, m *migrate.Migrator) []string {
+	t.Helper()
+	entries, err := m.Status()
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	var states []string
+	for _, e := range entries {
+		state := "pending"
+		if e.Applied {
+			state = "applied"
+		}
+		states = append(states, e.ID()+" "+state)
+	}
+	return states
+}
+
+// sqliteRows runs a query on conn and returns its rows as text
+func sqliteRows(t *testing.T, conn *object.DBConnection, sql string) string {
+	t.Helper()
+	result := database.Builtins["db_query_sqlite"].Fn(conn, &object.String{Value: sql})
+	resultMap, ok := result.(*object.Map)
+	if !ok {
+		t.Fatalf("query %q failed: %s", sql, result.Inspect())
+	}
+	return resultMap.Pairs["rows"].Inspect()
+}
+
+func tableNames(t *testing.T, conn *object.DBConnection) string {
+	return sqliteRows(t, conn, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'schema_%' ORDER BY name")
+}
+
+var usersMigrations = map[string]string{
+	"1_create_users.up.sql": `-- users; with a comment
+CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL DEFAULT 'x;y');
+CREATE INDEX users_name ON users (name);`,
+	"1_create_users.down.sql": "DROP TABLE users;",
+	"2_seed_users.bang": `kaj up(tx) {
+	db_proshno_sqlite(tx, "INSERT INTO users (name) VALUES (?)", ["Rahim"]);
+}
+kaj down(tx) {
+	db_exec_sqlite(tx, "DELETE FROM users");
+}`,
+	"10_create_posts.up.sql":   "CREATE TABLE posts (id INTEGER PRIMARY KEY);",
+	"10_create_posts.down.sql": "DROP TABLE posts;",
+	"README.md":                "not a migration",
+}
+
+func TestMigrateUpDownStatus(t *testing.T) {
+	m, conn := newMigrator(t, usersMigrations)
+
+	expected := []string{"1_create_users pending", "2_seed_users pending", "10_create_posts pending"}
+	if got := migrationStates(t, m); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("wrong initial status. expected %v, got %v", expected, got)
+	}
+
+	if n, err := m.Up(2); err != nil || n != 2 {
+		t.Fatalf("Up(2) = %d, %v", n, err)
+	}
+	if got := tableNames(t, conn); got != "[{name: users}]" {
+		t.Errorf("expected users table only, got %q", got)
+	}
+	if n, err := m.Up(0); err != nil || n != 1 {
+		t.Fatalf("Up(0) = %d, %v", n, err)
+	}
+	if n, err := m.Up(0); err != nil || n != 0 {
+		t.Fatalf("second Up(0) = %d, %v", n, err)
+	}
+
+	if got := sqliteRows(t, conn, "SELECT name FROM users"); got != "[{name: Rahim}]" {
+		t.Errorf("expected the seeded user, got %s", got)
+	}
+
+	// Down undoes the newest migrations first
+	if n, err := m.Down(2); err != nil || n != 2 {
+		t.Fatalf("Down(2) = %d, %v", n, err)
+	}
+	expected = []string{"1_create_users applied", "2_seed_users pending", "10_create_posts pending"}
+	if got := migrationStates(t, m); !reflect.DeepEqual(got, expected) {
+		t.Errorf("wrong status after Down(2). expected %v, got %v", expected, got)
+	}
+	if got := sqliteRows(t, conn, "SELECT name FROM users"); got != "[]" {
+		t.Errorf("expected the seed to be rolled back, got %s", got)
+	}
+
+	if n, err := m.Down(0); err != nil || n != 1 {
+		t.Fatalf("Down(0) = %d, %v", n, err)
+	}
+	if got := tableNames(t, conn); got != "[]" {
+		t.Errorf("expected no tables after rolling everything back, got %q", got)
+	}
+}
+
+func TestMigrateFailureRollsBack(t *testing.T) {
+	m, conn := newMigrator(t, map[string]string{
+		"1_ok.up.sql":      "CREATE TABLE a (x);",
+		"2_broken.up.sql":  "CREATE TABLE b (x);\nINSERT INTO missing VALUES (1);",
+		"3_after.up.sql":   "CREATE TABLE c (x);",
+		"4_script.bang":    `kaj up(tx) { felo "boom"; }`,
+		"5_no_down.up.sql": "SELECT 1;",
+	})
+
+	n, err := m.Up(0)
+	if err == nil || !strings.Contains(err.Error(), "2_broken: ") || !strings.Contains(err.Error(), "no such table: missing") {
+		t.Fatalf("expected 2_broken to fail, got %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 migration applied before the failure, got %d", n)
+	}
+	// The failed migration's CREATE TABLE was rolled back with it
+	if got := tableNames(t, conn); got != "[{name: a}]" {
+		t.Errorf("expected only table a, got %q", got)
+	}
+
+	os.Remove(filepath.Join(m.Dir, "2_broken.up.sql"))
+	if _, err := m.Up(0); err == nil || !strings.Contains(err.Error(), "4_script: boom") {
+		t.Fatalf("expected script failure, got %v", err)
+	}
+
+	os.Remove(filepath.Join(m.Dir, "4_script.bang"))
+	if _, err := m.Up(0); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if _, err := m.Down(1); err == nil || !strings.Contains(err.Error(), "5_no_down has no .down.sql file") {
+		t.Errorf("expected missing down error, got %v", err)
+	}
+
+	os.Remove(filepath.Join(m.Dir, "5_no_down.up.sql"))
+	entries, _ := m.Status()
+	if last := entries[len(entries)-1]; !last.Missing || last.ID() != "5_no_down" {
+		t.Errorf("expected 5_no_down to be reported missing, got %+v", last)
+	}
+}
+
+func TestMigrateAsyncScript(t *testing.T) {
+	m, conn := newMigrator(t, map[string]string{
+		"1_async.bang": `proyash kaj up(tx) {
+	opekha ghumaao(10);
+	db_exec_sqlite(tx, "CREATE TABLE a (x)");
+}`,
+		"2_async_broken.bang": `proyash kaj up(tx) {
+	db_exec_sqlite(tx, "CREATE TABLE b (x)");
+	opekha ghumaao(10);
+	db_exec_sqlite(tx, "INSERT INTO missing VALUES (1)");
+}`,
+	})
+
+	n, err := m.Up(0)
+	if err == nil || !strings.Contains(err.Error(), "2_async_broken: ") || !strings.Contains(err.Error(), "no such table: missing") {
+		t.Fatalf("expected 2_async_broken to fail, got %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 migration applied before the failure, got %d", n)
+	}
+	// The first script's table was created before its commit, the second's
+	// was rolled back when its promise rejected
+	if got := tableNames(t, conn); got != "[{name: a}]" {
+		t.Errorf("expected only table a, got %q", got)
+	}
+}
+
+func TestMigrateDryRunAndLock(t *testing.T) {
+	m, conn := newMigrator(t, usersMigrations)
+
+	var out strings.Builder
+	m.DryRun, m.Out = true, &out
+	if n, err := m.Up(0); err != nil || n != 3 {
+		t.Fatalf("dry run Up = %d, %v", n, err)
+	}
+	if !strings.Contains(out.String(), "CREATE INDEX users_name ON users (name);") ||
+		!strings.Contains(out.String(), "(runs up() from ") {
+		t.Errorf("dry run output missing statements:\n%s", out.String())
+	}
+	if got := tableNames(t, conn); got != "[]" {
+		t.Errorf("dry run changed the schema: %q", got)
+	}
+	m.DryRun = false
+
+	// Another deploy holds the lock
+	database.Builtins["db_exec_sqlite"].Fn(conn, &object.String{Value: "INSERT INTO schema_migrations_lock VALUES (1, 'deploy-a', '2026-01-01T00:00:00Z')"})
+	if _, err := m.Up(0); err == nil || !strings.Contains(err.Error(), "locked by deploy-a") {
+		t.Fatalf("expected lock error, got %v", err)
+	}
+	if err := m.Unlock(); err != nil {
+		t.Fatalf("Unlock failed: %v", err)
+	}
+	if n, err := m.Up(0); err != nil || n != 3 {
+		t.Fatalf("Up after unlock = %d, %v", n, err)
+	}
+	if got := sqliteRows(t, conn, "SELECT * FROM schema_migrations_lock"); got != "[]" {
+		t.Errorf("expected the lock to be released, got %s", got)
+	}
+}
+
+func TestMigrateCreateAndDiscover(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "migrations")
+	now := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+	files, err := migrate.Create(dir, "add users", false, now)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	expected := []string{filepath.Join(dir, "20261018093000_add_users.down.sql"), filepath.Join(dir, "20261018093000_add_users.up.sql")}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, got %v", expected, files)
+	}
+	if _, err := migrate.Create(dir, "seed", true, now.Add(time.Second)); err != nil {
+		t.Fatalf("Create script failed: %v", err)
+	}
+	if _, err := migrate.Create(dir, "add users", false, now); err == nil {
+		t.Error("expected an error when the files already exist")
+	}
+	if _, err := migrate.Create(dir, "bad-name", false, now); err == nil {
+		t.Error("expected an error for an invalid name")
+	}
+
+	migrations, err := migrate.Discover(dir)
+	if err != nil || len(migrations) != 2 || migrations[1].Script == "" {
+		t.Fatalf("Discover = %+v, %v", migrations, err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "20261018093000_other.up.sql"), nil, 0o644)
+	if _, err := migrate.Discover(dir); err == nil || !strings.Contains(err.Error(), "is used by both") {
+		t.Errorf("expected duplicate version error, got %v", err)
+	}
+}
+
+func TestMigrateSplitStatements(t *testing.T) {
+	script := `-- header; comment
+CREATE TABLE t (s TEXT DEFAULT 'it''s; fine', "odd;name" INT);
+/* block; comment */
+CREATE FUNCTION f() RETURNS int AS $body$ SELECT 1; $body$ LANGUAGE sql;
+INSERT INTO t VALUES ($1);;
+-- trailing comment only`
+
+	expected := []string{
+		"-- header; comment\nCREATE TABLE t (s TEXT DEFAULT 'it''s; fine', \"odd;name\" INT)",
+		"/* block; comment */\nCREATE FUNCTION f() RETURNS int AS $body$ SELECT 1; $body$ LANGUAGE sql",
+		"INSERT INTO t VALUES ($1)",
+	}
+	if got := migrate.SplitStatements(script); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
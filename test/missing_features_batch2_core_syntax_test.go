@@ -1,6 +1,10 @@
 package test
 
-import "testing"
+import (
+	"BanglaCode/src/object"
+	"strings"
+	"testing"
+)
 
 func TestDoWhileLoop(t *testing.T) {
 	input := `
@@ -60,3 +64,456 @@ func TestDeleteArrayIndex(t *testing.T) {
 	`
 	testBooleanObject(t, testEval(input), true)
 }
+
+func TestConditionalExpression(t *testing.T) {
+	testStringObject(t, testEval(`dhoro x = 20; x >= 18 ? "boro" : "choto"`), "boro")
+	testStringObject(t, testEval(`dhoro n = 0; n > 0 ? "pos" : n < 0 ? "neg" : "shunno"`), "shunno")
+	// only the chosen branch is evaluated
+	testNumberObject(t, testEval(`dhoro c = 0; kaj f() { c = c + 1; } sotti ? 1 : f(); c`), 0)
+}
+
+func TestNullishCoalescing(t *testing.T) {
+	testNumberObject(t, testEval(`khali ?? 5`), 5)
+	testNumberObject(t, testEval(`0 ?? 5`), 0)
+	testBooleanObject(t, testEval(`mittha ?? sotti`), false)
+	testNumberObject(t, testEval(`dhoro m = {}; m.port ?? 3000`), 3000)
+	testNumberObject(t, testEval(`dhoro c = 0; kaj f() { c = c + 1; } 1 ?? f(); c`), 0)
+}
+
+func TestOptionalChaining(t *testing.T) {
+	testNullObject(t, testEval(`dhoro u = khali; u?.thikana.shohor`))
+	testNullObject(t, testEval(`dhoro u = {}; u.thikana?.shohor`))
+	testStringObject(t, testEval(`dhoro u = {thikana: {shohor: "Dhaka"}}; u?.thikana?.shohor`), "Dhaka")
+	testNullObject(t, testEval(`dhoro a = khali; a?.[0]`))
+	testNumberObject(t, testEval(`dhoro a = [7]; a?.[0]`), 7)
+	testNullObject(t, testEval(`dhoro f = khali; f?.()`))
+	testNumberObject(t, testEval(`kaj f() { ferao 3; } f?.()`), 3)
+	// the rest of the chain, call arguments included, is skipped
+	testNumberObject(t, testEval(`dhoro c = 0; kaj g() { c = c + 1; } dhoro u = khali; u?.save(g()); c`), 0)
+
+	evaluated := testEval(`dhoro u = {}; u.thikana.shohor`)
+	if _, ok := evaluated.(*object.Error); !ok {
+		t.Errorf("expected error without ?., got %s", evaluated.Inspect())
+	}
+}
+
+func TestLogicalAssignment(t *testing.T) {
+	testNumberObject(t, testEval(`dhoro x = khali; x ??= 5; x`), 5)
+	testNumberObject(t, testEval(`dhoro x = 1; x ??= 5; x`), 1)
+	testNumberObject(t, testEval(`dhoro x = mittha; x ||= 2; x`), 2)
+	testNumberObject(t, testEval(`dhoro x = 0; x ||= 2; x`), 0)
+	testNumberObject(t, testEval(`dhoro x = 1; x &&= 2; x`), 2)
+	testNullObject(t, testEval(`dhoro x = khali; x &&= 2; x`))
+	testNumberObject(t, testEval(`dhoro m = {}; m.port ??= 80; m.port ??= 81; m.port`), 80)
+	testNumberObject(t, testEval(`dhoro a = [1, khali]; a[1] ||= 9; a[1]`), 9)
+	// the value is not evaluated, and a sthir is not touched, when nothing is assigned
+	testNumberObject(t, testEval(`dhoro c = 0; kaj f() { c = c + 1; } dhoro x = 1; x ??= f(); c`), 0)
+	testNumberObject(t, testEval(`sthir k = 1; k ??= 2; k`), 1)
+}
+
+func TestBitwiseOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"5 & 3", 1},
+		{"5 | 3", 7},
+		{"5 ^ 3", 6},
+		{"~5", -6},
+		{"~-1", 0},
+		{"1 << 4", 16},
+		{"1 << 31", -2147483648},
+		{"1 << 32", 1}, // the shift count is taken modulo 32
+		{"-16 >> 2", -4},
+		{"-16 >>> 28", 15},
+		{"-1 >>> 0", 4294967295},
+		{"4294967297 | 0", 1},
+		{"2147483648 | 0", -2147483648},
+		{"-1.9 | 0", -1},
+	}
+
+	for _, tt := range tests {
+		testNumberObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestExponentOperator(t *testing.T) {
+	testNumberObject(t, testEval("2 ** 10"), 1024)
+	testNumberObject(t, testEval("2 ** 3 ** 2"), 512)
+	testNumberObject(t, testEval("2 ** -1"), 0.5)
+}
+
+func TestCompoundAssignmentOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"dhoro x = 7; x %= 4; x", 3},
+		{"dhoro x = 3; x **= 3; x", 27},
+		{"dhoro x = 6; x &= 3; x", 2},
+		{"dhoro x = 6; x |= 3; x", 7},
+		{"dhoro x = 6; x ^= 3; x", 5},
+		{"dhoro x = 1; x <<= 3; x", 8},
+		{"dhoro x = -8; x >>= 1; x", -4},
+		{"dhoro x = -1; x >>>= 28; x", 15},
+		{"dhoro m = {a: 6}; m.a **= 2; m.a", 36},
+		{"dhoro a = [5]; a[0] <<= 1; a[0]", 10},
+	}
+
+	for _, tt := range tests {
+		testNumberObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestSymbolicLogicalOperators(t *testing.T) {
+	testBooleanObject(t, testEval("sotti && mittha"), false)
+	testBooleanObject(t, testEval("mittha || sotti"), true)
+	testBooleanObject(t, testEval("1 < 2 && 2 < 3 || mittha"), true)
+}
+
+func TestDefaultParameters(t *testing.T) {
+	testNumberObject(t, testEval(`kaj f(a, b = 10) { ferao a + b; } f(1)`), 11)
+	testNumberObject(t, testEval(`kaj f(a, b = 10) { ferao a + b; } f(1, 2)`), 3)
+	testNumberObject(t, testEval(`kaj f(a, b = 10) { ferao a + b; } f(1, khali)`), 11)
+	// defaults are evaluated at call time in the callee scope
+	testNumberObject(t, testEval(`kaj f(a, b = a * 2) { ferao b; } f(4)`), 8)
+	testNumberObject(t, testEval(`dhoro c = 0; kaj g() { c = c + 1; ferao c; } kaj f(x = g()) { ferao x; } f(); f(); f(9); c`), 2)
+	testNumberObject(t, testEval(`dhoro f = (a, b = 5) => a * b; f(2)`), 10)
+	testNumberObject(t, testEval(`kaj f(a = 1, ...rest) { ferao a + dorghyo(rest); } f()`), 1)
+	testNumberObject(t, testEval(`sreni Bindu { shuru(x = 3, y = x) { ei.x = x; ei.y = y; } kaj jog(n = 1) { ferao (ei.x + ei.y) * n; } } notun Bindu().jog()`), 6)
+
+	for _, input := range []string{
+		`kaj f(a, b = 1) { ferao a; } f()`,
+		`kaj f(a, b = 1) { ferao a; } f(1, 2, 3)`,
+	} {
+		evaluated := testEval(input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: expected error, got %s", input, evaluated.Inspect())
+			continue
+		}
+		if !strings.Contains(errObj.Message, "expects 1 to 2 argument(s)") {
+			t.Errorf("%s: wrong error message: %s", input, errObj.Message)
+		}
+	}
+}
+
+func TestDestructuringParameters(t *testing.T) {
+	testStringObject(t, testEval(`kaj f({naam, boyosh}) { ferao naam + boyosh; } f({naam: "Rahim", boyosh: "30"})`), "Rahim30")
+	testStringObject(t, testEval(`kaj f({naam: n}) { ferao n; } f({naam: "Karim"})`), "Karim")
+	testNumberObject(t, testEval(`kaj f([a, b]) { ferao a - b; } f([9, 4])`), 5)
+	testNumberObject(t, testEval(`dhoro f = ({x}) => x; f({x: 42})`), 42)
+	testNumberObject(t, testEval(`dhoro f = ([a, b], c) => a + b + c; f([1, 2], 3)`), 6)
+	testNumberObject(t, testEval(`kaj f([a, b] = [7, 8]) { ferao a + b; } f()`), 15)
+	testNullObject(t, testEval(`kaj f({missing}) { ferao missing; } f({})`))
+	// ( ... ) without => is still a grouped expression
+	testNumberObject(t, testEval(`dhoro a = 1; (a = 5); a`), 5)
+	testNumberObject(t, testEval(`([3, 4])[1]`), 4)
+
+	evaluated := testEval(`kaj f({naam}) { ferao naam; } f(5)`)
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("expected error, got %s", evaluated.Inspect())
+	}
+	if errObj.Message != "object destructuring source must be MAP, got NUMBER" {
+		t.Errorf("wrong error message: %s", errObj.Message)
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	// utpadan works inside loops, branches and chesta blocks
+	testStringObject(t, testEval(`
+kaj* gon(n) {
+  ghuriye (dhoro i = 0; i < n; i = i + 1) {
+    jodi (i % 2 == 0) { utpadan "j"; } nahole { chesta { utpadan "b"; } shesh {} }
+  }
+}
+dhoro s = ""; ghuriye (x of gon(4)) { s = s + x; } s`), "jbjb")
+	// next(v) sends a value in as the result of utpadan
+	testNumberObject(t, testEval(`
+kaj* jog() { dhoro mot = 0; jotokkhon (sotti) { mot = mot + (utpadan mot); } }
+dhoro g = jog(); g.next(); g.next(5); g.next(7)["value"]`), 12)
+	// return(v) finishes the generator and runs its shesh blocks
+	testStringObject(t, testEval(`
+dhoro log = "";
+kaj* g() { chesta { utpadan 1; utpadan 2; } shesh { log = log + "shesh"; } }
+dhoro it = g(); it.next();
+dhoro r = it.return("x");
+jodi (r["done"] ebong it.next()["done"]) { log + r["value"]; } nahole { "chole"; }`), "sheshx")
+	// throw(e) raises inside the generator, where chesta can catch it
+	testStringObject(t, testEval(`
+kaj* g() { chesta { utpadan 1; } dhoro_bhul (e) { utpadan "dhora " + e; } }
+dhoro it = g(); it.next(); it.throw("bhul")["value"]`), "dhora bhul")
+	// utpadan* delegates to generators and arrays, and evaluates to the
+	// inner generator's return value
+	testStringObject(t, testEval(`
+kaj* inner() { utpadan "a"; ferao "r"; }
+kaj* outer() { dhoro r = utpadan* inner(); utpadan r; utpadan* ["b", "c"]; }
+dhoro s = ""; ghuriye (x of outer()) { s = s + x; } s`), "arbc")
+	// breaking out of for-of closes the generator
+	testStringObject(t, testEval(`
+dhoro log = "";
+kaj* g() { chesta { utpadan 1; utpadan 2; } shesh { log = log + "bondho"; } }
+ghuriye (x of g()) { thamo; } log`), "bondho")
+
+	evaluated := testEval(`kaj* g() { utpadan 1; } dhoro it = g(); it.next(); it.throw("bhul")`)
+	if _, ok := evaluated.(*object.Exception); !ok {
+		t.Errorf("expected uncaught exception from throw(), got %s", evaluated.Inspect())
+	}
+}
+
+func TestIteratorProtocol(t *testing.T) {
+	classes := `
+sreni Porishor {
+  shuru(lo, hi) { ei.lo = lo; ei.hi = hi; }
+  kaj* iterator() { ghuriye (dhoro i = ei.lo; i < ei.hi; i = i + 1) { utpadan i; } }
+}
+sreni Gona {
+  shuru(n) { ei.n = n; }
+  kaj iterator() {
+    dhoro n = ei.n;
+    ferao { next: kaj() { n = n - 1; ferao { value: n + 1, done: n < 0 }; } };
+  }
+}
+`
+	testNumberObject(t, testEval(classes+`dhoro mot = 0; ghuriye (x of notun Porishor(1, 5)) { mot = mot + x; } mot`), 10)
+	testNumberObject(t, testEval(classes+`dorghyo([...notun Porishor(0, 3), ...notun Gona(2)])`), 5)
+	testNumberObject(t, testEval(classes+`dhoro [a, b] = notun Gona(9); a * 10 + b`), 98)
+	testNumberObject(t, testEval(classes+`kaj jog(...x) { ferao x[0] + x[1]; } jog(...notun Porishor(3, 5))`), 7)
+	testNumberObject(t, testEval(`kaj* shob() { dhoro i = 0; jotokkhon (sotti) { utpadan i; i = i + 1; } } dhoro [a, b, c] = shob(); c`), 2)
+
+	evaluated := testEval(`dhoro [a] = 5;`)
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("expected error, got %s", evaluated.Inspect())
+	}
+	if errObj.Message != "array destructuring source must be ARRAY or iterable, got NUMBER" {
+		t.Errorf("wrong error message: %s", errObj.Message)
+	}
+}
+
+func TestAsyncGenerators(t *testing.T) {
+	gen := `
+proyash kaj* gona(n) {
+  ghuriye (dhoro i = 1; i <= n; i = i + 1) {
+    opekha ghumaao(1);
+    utpadan i;
+  }
+  ferao "sesh";
+}
+`
+	testNumberObject(t, testEval(gen+`dhoro mot = 0; ghuriye opekha (x of gona(4)) { mot = mot + x; } mot`), 10)
+	// next() answers with promises, and requests run in order
+	testStringObject(t, testEval(gen+`
+dhoro g = gona(1);
+dhoro a = g.next(); dhoro b = g.next(); dhoro c = g.next();
+dhoro ra = opekha a; dhoro rb = opekha b; dhoro rc = opekha c;
+jodi (rc["done"]) { "" + ra["value"] + rb["value"]; }`), "1sesh")
+	// utpadan awaits promises, and ghuriye opekha awaits plain values
+	testNumberObject(t, testEval(`
+proyash kaj dui() { ferao 2; }
+proyash kaj* g() { utpadan dui(); }
+dhoro mot = 0;
+ghuriye opekha (x of g()) { mot = mot + x; }
+ghuriye opekha (x of [dui(), 3]) { mot = mot + x; }
+mot`), 7)
+	// delegation and early exit
+	testStringObject(t, testEval(`
+dhoro log = "";
+proyash kaj* bhitor() { chesta { utpadan "a"; utpadan "b"; } shesh { log = log + "!"; } }
+proyash kaj* bahir() { utpadan* ["x"]; utpadan* bhitor(); }
+ghuriye opekha (v of bahir()) { log = log + v; jodi (v == "a") { thamo; } }
+log`), "xa!")
+	// an iterator whose next() returns promises
+	testNumberObject(t, testEval(`
+proyash kaj fol(v, d) { ferao {value: v, done: d}; }
+dhoro n = 0;
+dhoro src = {iterator: kaj() { ferao {next: kaj() { n = n + 1; ferao fol(n, n > 3); }}; }};
+dhoro mot = 0;
+ghuriye opekha (x of src) { mot = mot + x; }
+mot`), 6)
+
+	evaluated := testEval(gen + `dhoro [a] = gona(1);`)
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("expected error, got %s", evaluated.Inspect())
+	}
+	if errObj.Message != "an async generator can only be read with ghuriye opekha" {
+		t.Errorf("wrong error message: %s", errObj.Message)
+	}
+}
+
+func TestCommaDeclarations(t *testing.T) {
+	testNumberObject(t, testEval(`dhoro a = 1, b = a + 1, c = b * 10; a + b + c`), 23)
+	testNumberObject(t, testEval(`
+	dhoro steps = 0;
+	ghuriye (dhoro i = 0, j = 10; i < j; i = i + 1) { j = j - 1; steps = steps + 1; }
+	steps`), 5)
+
+	evaluated := testEval(`sthir x = 1, y = 2; y = 3;`)
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("expected constant error, got %s", evaluated.Inspect())
+	}
+	if !strings.Contains(errObj.Message, "'y' ekti sthir") {
+		t.Errorf("wrong error message: %s", errObj.Message)
+	}
+}
+
+func TestLabeledBreakContinue(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`
+		dhoro log = [];
+		outer: ghuriye (dhoro i = 0; i < 3; i = i + 1) {
+			ghuriye (dhoro j = 0; j < 3; j = j + 1) {
+				jodi (j == 1) { chharo outer; }
+				jodi (i == 2) { thamo outer; }
+				dhokao(log, lipi(i) + lipi(j));
+			}
+		}
+		joro(log, ",")
+		`, "00,10"},
+		{`
+		dhoro n = 0;
+		bahir: jotokkhon (sotti) {
+			do {
+				n = n + 1;
+				jodi (n == 4) { thamo bahir; }
+			} jotokkhon (sotti);
+		}
+		lipi(n)
+		`, "4"},
+		{`
+		dhoro found = "";
+		rows: ghuriye (row of [[1, 2], [3, 4], [5, 6]]) {
+			ghuriye (key in {a: 1}) {
+				jodi (row[1] == 4) { found = lipi(row[0]) + key; thamo rows; }
+				chharo rows;
+			}
+		}
+		found
+		`, "3a"},
+		{`
+		// thamo label leaves a labeled statement that is not a loop
+		dhoro log = "";
+		block: jodi (sotti) { log = log + "a"; thamo block; }
+		log + "b"
+		`, "ab"},
+		{`
+		// an unlabeled thamo still stops only the innermost loop
+		dhoro count = 0;
+		outer: ghuriye (dhoro i = 0; i < 3; i = i + 1) {
+			jotokkhon (sotti) { count = count + 1; thamo; }
+		}
+		lipi(count)
+		`, "3"},
+		{`
+		// leaving a generator loop early through a label closes it
+		dhoro log = "";
+		kaj* gen() { chesta { utpadan 1; utpadan 2; } shesh { log = log + "closed"; } }
+		outer: ghuriye (dhoro i = 0; i < 2; i = i + 1) {
+			ghuriye (x of gen()) { chharo outer; }
+		}
+		log
+		`, "closedclosed"},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestArrayDestructuringAssignment(t *testing.T) {
+	testStringObject(t, testEval(`dhoro a = "x", b = "y"; [a, b] = [b, a]; a + b`), "yx")
+	testStringObject(t, testEval(`
+	dhoro arr = [1, 2, 3];
+	dhoro obj = {naam: ""};
+	[arr[0], arr[2], obj.naam] = [arr[2], arr[0], "Ankan"];
+	joro(arr, ",") + " " + obj.naam
+	`), "3,2,1 Ankan")
+	testStringObject(t, testEval(`
+	dhoro a = 1, b = 2, c = 3;
+	[a, b, c] = [9];
+	lipi(a) + " " + lipi(b) + " " + lipi(c)
+	`), "9 khali khali")
+
+	evaluated := testEval(`sthir a = 1; dhoro b = 2; [a, b] = [b, a];`)
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("expected constant error, got %s", evaluated.Inspect())
+	}
+	if !strings.Contains(errObj.Message, "'a' ekti sthir") {
+		t.Errorf("wrong error message: %s", errObj.Message)
+	}
+
+	testStringObject(t, testEval(`
+	dhoro a = 0, b = 0, c = 0, baki = [];
+	[a, [b, c], ...baki] = [1, [2, 3], 4, 5];
+	lipi([a, b, c, baki])
+	`), "[1, 2, 3, [4, 5]]")
+	testStringObject(t, testEval(`
+	dhoro obj = {naam: ""}, arr = [0, 0], boyosh = 0;
+	[[obj.naam, arr[1]], {boyosh = 30}, obj["desh"] = "BD"] = [["Ankan", 7], {}];
+	lipi([obj.naam, arr, boyosh, obj.desh])
+	`), "[Ankan, [0, 7], 30, BD]")
+
+	evaluated = testEval(`sthir a = 1; dhoro b = 2; [b, [a]] = [3, [4]];`)
+	if errObj, ok := evaluated.(*object.Error); !ok || !strings.Contains(errObj.Message, "'a' ekti sthir") {
+		t.Errorf("expected constant error from a nested target, got %s", evaluated.Inspect())
+	}
+}
+
+// TestDestructuringPatterns runs each pattern in a dhoro declaration, as a
+// function parameter and, for array patterns, in an assignment, which all
+// share one pattern grammar
+func TestDestructuringPatterns(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		source   string
+		result   string
+		expected string
+	}{
+		{`{naam: n}`, `{naam: "Rahim"}`, `n`, "Rahim"},
+		{`{naam, boyosh = 30}`, `{naam: "Rahim"}`, `lipi([naam, boyosh])`, "[Rahim, 30]"},
+		{`{boyosh: age = 18}`, `{boyosh: khali}`, `lipi(age)`, "18"},
+		{`[a, b = a + 1]`, `[1]`, `lipi([a, b])`, "[1, 2]"},
+		{`{thikana: {shohor}}`, `{thikana: {shohor: "Dhaka"}}`, `shohor`, "Dhaka"},
+		{`{gun: [x, y]}`, `{gun: [3, 4]}`, `lipi(x * y)`, "12"},
+		{`[a, [b, c]]`, `[1, [2, 3]]`, `lipi([a, b, c])`, "[1, 2, 3]"},
+		{`[{id}, {id: second}]`, `[{id: 1}, {id: 2}]`, `lipi([id, second])`, "[1, 2]"},
+		{`{pos: {x, y} = {x: 0, y: 0}}`, `{}`, `lipi([x, y])`, "[0, 0]"},
+		{`[first, ...baki]`, `[1, 2, 3]`, `lipi([first, baki])`, "[1, [2, 3]]"},
+		{`[first, ...baki]`, `[1]`, `lipi(dorghyo(baki))`, "0"},
+		{`{a, ...baki}`, `{a: 1, c: 3, b: 2}`, `json_banao(baki)`, `{"c":3,"b":2}`},
+		{`{a: [p, ...q], ...r}`, `{a: [1, 2], z: 26}`, `lipi([p, q, r.z])`, "[1, [2], 26]"},
+	}
+
+	for _, tt := range tests {
+		declaration := "dhoro " + tt.pattern + " = " + tt.source + ";\n" + tt.result
+		testStringObject(t, testEval(declaration), tt.expected)
+
+		parameter := "kaj f(" + tt.pattern + ") { ferao " + tt.result + "; }\nf(" + tt.source + ")"
+		testStringObject(t, testEval(parameter), tt.expected)
+
+		arrow := "dhoro g = (" + tt.pattern + ") => " + tt.result + ";\ng(" + tt.source + ")"
+		testStringObject(t, testEval(arrow), tt.expected)
+
+		if strings.HasPrefix(tt.pattern, "[") {
+			assignment := tt.pattern + " = " + tt.source + ";\n" + tt.result
+			testStringObject(t, testEval(assignment), tt.expected)
+		}
+	}
+
+	// A rest element reads an iterable to the end
+	testStringObject(t, testEval(`kaj* gen() { utpadan 1; utpadan 2; utpadan 3; }
+	dhoro [a, ...baki] = gen();
+	lipi(baki)`), "[2, 3]")
+
+	evaluated := testEval(`dhoro {a: {b}} = {a: 5};`)
+	if errObj, ok := evaluated.(*object.Error); !ok || errObj.Message != "object destructuring source must be MAP, got NUMBER" {
+		t.Errorf("expected a nested source error, got %s", evaluated.Inspect())
+	}
+}
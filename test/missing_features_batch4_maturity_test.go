@@ -24,7 +24,7 @@ func TestTimerBuiltins(t *testing.T) {
 	inputTimeout := `
 	dhoro x = 0;
 	setTimeout(kaj() { x = 5; }, 10);
-	ghum(40);
+	opekha ghumaao(40);
 	x;
 	`
 	testNumberObject(t, testEval(inputTimeout), 5)
@@ -32,11 +32,11 @@ func TestTimerBuiltins(t *testing.T) {
 	inputInterval := `
 	dhoro c = 0;
 	dhoro id = setInterval(kaj() { c = c + 1; }, 5);
-	ghum(35);
+	opekha ghumaao(35);
 	clearInterval(id);
 	dhoro prev = c;
-	ghum(20);
-	c == prev;
+	opekha ghumaao(20);
+	c == prev && c > 0;
 	`
 	testBooleanObject(t, testEval(inputInterval), true)
 }
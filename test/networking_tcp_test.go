@@ -1,8 +1,6 @@
 package test
 
 import (
-	"BanglaCode/src/evaluator"
-	"BanglaCode/src/evaluator/builtins"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
 	"BanglaCode/src/parser"
@@ -19,12 +17,7 @@ func evalTCP(input string) object.Object {
 	program := p.ParseProgram()
 	env := object.NewEnvironment()
 
-	// Set EvalFunc for builtins
-	builtins.EvalFunc = func(fn *object.Function, args []object.Object) object.Object {
-		return evaluator.Eval(fn.Body, object.NewEnclosedEnvironment(fn.Env))
-	}
-
-	return evaluator.Eval(program, env)
+	return evalProgram(program, env)
 }
 
 func TestTCPServerChalu(t *testing.T) {
@@ -304,6 +297,47 @@ func TestTCPLekho(t *testing.T) {
 	}
 }
 
+func TestTCPConnectionForAwait(t *testing.T) {
+	listener, err := net.Listen("tcp", ":8091")
+	if err != nil {
+		t.Fatalf("failed to start test server: %v", err)
+	}
+	defer listener.Close()
+
+	// Send two chunks, then hang up
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		conn.Write([]byte("ek "))
+		time.Sleep(50 * time.Millisecond)
+		conn.Write([]byte("dui"))
+		conn.Close()
+	}()
+
+	input := `
+		proyash kaj shobShuno() {
+			dhoro conn = opekha tcp_jukto("localhost", 8091);
+			dhoro shob = "";
+			ghuriye opekha (chunk of conn) {
+				shob = shob + chunk;
+			}
+			ferao shob;
+		}
+		opekha shobShuno();
+	`
+
+	result := evalTCP(input)
+	str, ok := result.(*object.String)
+	if !ok {
+		t.Fatalf("expected string, got %s", result.Inspect())
+	}
+	if str.Value != "ek dui" {
+		t.Errorf("expected %q, got %q", "ek dui", str.Value)
+	}
+}
+
 // Helper function to check if string contains substring
 func tcpContains(s, substr string) bool {
 	return len(s) >= len(substr) && (fmt.Sprintf("%s", s) != "" &&
@@ -1,8 +1,6 @@
 package test
 
 import (
-	"BanglaCode/src/evaluator"
-	"BanglaCode/src/evaluator/builtins"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
 	"BanglaCode/src/parser"
@@ -18,12 +16,7 @@ func evalUDP(input string) object.Object {
 	program := p.ParseProgram()
 	env := object.NewEnvironment()
 
-	// Set EvalFunc for builtins
-	builtins.EvalFunc = func(fn *object.Function, args []object.Object) object.Object {
-		return evaluator.Eval(fn.Body, object.NewEnclosedEnvironment(fn.Env))
-	}
-
-	return evaluator.Eval(program, env)
+	return evalProgram(program, env)
 }
 
 func TestUDPServerChalu(t *testing.T) {
@@ -1,8 +1,6 @@
 package test
 
 import (
-	"BanglaCode/src/evaluator"
-	"BanglaCode/src/evaluator/builtins"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/object"
 	"BanglaCode/src/parser"
@@ -22,12 +20,7 @@ func evalWS(input string) object.Object {
 	program := p.ParseProgram()
 	env := object.NewEnvironment()
 
-	// Set EvalFunc for builtins
-	builtins.EvalFunc = func(fn *object.Function, args []object.Object) object.Object {
-		return evaluator.Eval(fn.Body, object.NewEnclosedEnvironment(fn.Env))
-	}
-
-	return evaluator.Eval(program, env)
+	return evalProgram(program, env)
 }
 
 func TestWebSocketServerChalu(t *testing.T) {
@@ -372,3 +372,32 @@ func TestObjectMethodsIntegration(t *testing.T) {
 		}
 	}
 }
+
+// ==================== Key Order Tests ====================
+
+func TestObjectKeyOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`joro(chabi({z: 1, a: 2, m: 3}), ",")`, "z,a,m"},
+		{`joro(chabi({b: 1, "10": 2, a: 3, "2": 4, "-1": 5}), ",")`, "2,10,b,a,-1"},
+		{`joro(maan({z: 1, a: 2, m: 3}), ",")`, "1,2,3"},
+		{`dhoro o = {a: 1, b: 2, c: 3}; delete o.a; o.a = 4; joro(chabi(o), ",")`, "b,c,a"},
+		{`dhoro o = {x: 1}; mishra(o, {c: 2, b: 3}); joro(chabi(o), ",")`, "x,c,b"},
+		{`dhoro s = []; ghuriye (k in {q: 1, p: 2, "3": 3}) { dhokao(s, k); } joro(s, ",")`, "3,q,p"},
+		{`lipi({z: 1, a: {d: 1, c: 2}})`, "{z: 1, a: {d: 1, c: 2}}"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		str, ok := evaluated.(*object.String)
+		if !ok {
+			t.Errorf("expected String for %q, got %T (%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if str.Value != tt.expected {
+			t.Errorf("wrong order for %q. expected=%s, got=%s", tt.input, tt.expected, str.Value)
+		}
+	}
+}
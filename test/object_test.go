@@ -2,6 +2,14 @@ package test
 
 import (
 	"BanglaCode/src/object"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -137,23 +145,115 @@ func TestEmptyArrayObject(t *testing.T) {
 }
 
 func TestMapObject(t *testing.T) {
-	m := &object.Map{
-		Pairs: map[string]object.Object{
-			"name": &object.String{Value: "Ankan"},
-		},
-	}
+	m := object.NewMap()
+	m.Set("name", &object.String{Value: "Ankan"})
 
 	if m.Type() != object.MAP_OBJ {
 		t.Errorf("m.Type() wrong. got=%s", m.Type())
 	}
 
-	// Note: Map iteration order is not guaranteed, so we check presence
 	inspect := m.Inspect()
 	if inspect != "{name: Ankan}" {
 		t.Errorf("m.Inspect() wrong. got=%s", inspect)
 	}
 }
 
+func TestMapKeyOrder(t *testing.T) {
+	m := object.NewMap()
+	for _, k := range []string{"z", "a", "10", "m", "2", "01"} {
+		m.Set(k, &object.Number{Value: 1})
+	}
+	m.Delete("a")
+	m.Set("a", &object.Number{Value: 2})
+	m.Set("z", &object.Number{Value: 3}) // overwriting keeps the original slot
+
+	expected := []string{"2", "10", "z", "m", "01", "a"}
+	if keys := m.Keys(); !slices.Equal(keys, expected) {
+		t.Errorf("m.Keys() wrong. expected=%v, got=%v", expected, keys)
+	}
+	if inspect := m.Inspect(); inspect != "{2: 1, 10: 1, z: 3, m: 1, 01: 1, a: 2}" {
+		t.Errorf("m.Inspect() wrong. got=%s", inspect)
+	}
+
+	// A Go map has no order, so NewMapFrom inserts its keys sorted
+	from := object.NewMapFrom(map[string]object.Object{
+		"b": object.TRUE, "a": object.TRUE, "c": object.TRUE,
+	})
+	if keys := from.Keys(); !slices.Equal(keys, []string{"a", "b", "c"}) {
+		t.Errorf("from.Keys() wrong. got=%v", keys)
+	}
+
+	// Deleting most keys compacts the order without losing it
+	big := object.NewMap()
+	for i := 0; i < 100; i++ {
+		big.Set(fmt.Sprintf("k%d", i), object.TRUE)
+	}
+	for i := 0; i < 100; i++ {
+		if i%10 != 0 {
+			big.Delete(fmt.Sprintf("k%d", i))
+		}
+	}
+	big.Set("k1", object.TRUE)
+	expected = []string{"k0", "k10", "k20", "k30", "k40", "k50", "k60", "k70", "k80", "k90", "k1"}
+	if keys := big.Keys(); !slices.Equal(keys, expected) || big.Len() != len(big.Pairs) {
+		t.Errorf("big.Keys() wrong. got=%v", keys)
+	}
+}
+
+// TestMapWritesGoThroughSet checks that nothing in the tree writes to
+// Map.Pairs directly, which would leave the key out of the enumeration order
+func TestMapWritesGoThroughSet(t *testing.T) {
+	fset := token.NewFileSet()
+	err := filepath.WalkDir("../src", func(path string, d fs.DirEntry, err error) error {
+		// object.go implements Map; collections/map.go writes ES6Map.Pairs
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") ||
+			strings.HasSuffix(path, "object/object.go") || strings.HasSuffix(path, "collections/map.go") {
+			return err
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil || !importsObject(file) {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			var target ast.Expr
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					if index, ok := lhs.(*ast.IndexExpr); ok {
+						target = index.X
+					}
+				}
+			case *ast.CallExpr:
+				if fn, ok := n.Fun.(*ast.Ident); ok && fn.Name == "delete" && len(n.Args) == 2 {
+					target = n.Args[0]
+				}
+			case *ast.CompositeLit:
+				if sel, ok := n.Type.(*ast.SelectorExpr); ok && sel.Sel.Name == "Map" {
+					if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "object" && len(n.Elts) > 0 {
+						t.Errorf("%s: object.Map literal; use object.NewMap and Set", fset.Position(n.Pos()))
+					}
+				}
+			}
+			if sel, ok := target.(*ast.SelectorExpr); ok && sel.Sel.Name == "Pairs" {
+				t.Errorf("%s: write to Pairs; use Set or Delete", fset.Position(n.Pos()))
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+func importsObject(file *ast.File) bool {
+	for _, imp := range file.Imports {
+		if imp.Path.Value == `"BanglaCode/src/object"` {
+			return true
+		}
+	}
+	return false
+}
+
 func TestClassObject(t *testing.T) {
 	class := &object.Class{
 		Name:    "Person",
@@ -0,0 +1,206 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator"
+	"BanglaCode/src/packages"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTree writes files (relative path -> content) below dir
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// publish writes a package with the given manifest and files to a temp dir
+// and publishes it to registry
+func publish(t *testing.T, registry, manifest string, files map[string]string) {
+	t.Helper()
+	dir := t.TempDir()
+	files[packages.ManifestFile] = manifest
+	writeTree(t, dir, files)
+	if _, err := packages.Publish(dir, registry); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+}
+
+// testRegistry publishes ganit 1.0.0 and 1.1.0 and strutil 1.0.0, which
+// depends on ganit ^1.0.0
+func testRegistry(t *testing.T) string {
+	registry := t.TempDir()
+	publish(t, registry, `{"name": "ganit", "version": "1.0.0"}`, map[string]string{
+		"main.bang": `pathao kaj jog(a, b) { ferao a + b; }`,
+	})
+	publish(t, registry, `{"name": "ganit", "version": "1.1.0"}`, map[string]string{
+		"main.bang": `pathao kaj jog(a, b) { ferao a + b + 0; }`,
+	})
+	publish(t, registry, `{"name": "strutil", "version": "1.0.0", "main": "lib.bang", "dependencies": {"ganit": "^1.0.0"}}`, map[string]string{
+		"lib.bang": `ano "ganit";
+pathao kaj doubleLen(s) { ferao jog(dorghyo(s), dorghyo(s)); }`,
+	})
+	return registry
+}
+
+func newProject(t *testing.T, registry string) *packages.Project {
+	t.Helper()
+	dir := t.TempDir()
+	writeTree(t, dir, map[string]string{
+		packages.ManifestFile: `{"name": "app", "version": "1.0.0", "registry": "` + filepath.ToSlash(registry) + `"}`,
+	})
+	project, err := packages.OpenProject(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	project.RegistryLocation = ""
+	return project
+}
+
+func TestPackageInstallAndImport(t *testing.T) {
+	registry := testRegistry(t)
+	project := newProject(t, registry)
+
+	if err := project.Add([]string{"strutil"}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if got := project.Manifest.Dependencies["strutil"]; got != "^1.0.0" {
+		t.Errorf("expected strutil ^1.0.0 in manifest, got %q", got)
+	}
+
+	lock, err := packages.LoadLockfile(filepath.Join(project.Dir, packages.LockFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(lock.Names(), ","); got != "ganit,strutil" {
+		t.Fatalf("expected ganit and strutil locked, got %s", got)
+	}
+	if v := lock.Packages["ganit"].Version; v != "1.1.0" {
+		t.Errorf("expected newest ganit 1.1.0, got %s", v)
+	}
+	if !strings.HasPrefix(lock.Packages["ganit"].Integrity, "sha256-") {
+		t.Errorf("expected sha256 integrity, got %q", lock.Packages["ganit"].Integrity)
+	}
+
+	evaluator.SetCurrentDir(project.Dir)
+	defer evaluator.SetCurrentDir(".")
+	evaluator.ResetModules()
+	defer evaluator.ResetModules()
+	testNumberObject(t, testEval(`ano "strutil"; doubleLen("abc");`), 6)
+
+	// A newer release does not replace the locked version
+	publish(t, registry, `{"name": "ganit", "version": "1.2.0"}`, map[string]string{
+		"main.bang": `pathao kaj jog(a, b) { ferao 0; }`,
+	})
+	if err := project.Install(); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	lock, _ = packages.LoadLockfile(filepath.Join(project.Dir, packages.LockFile))
+	if v := lock.Packages["ganit"].Version; v != "1.1.0" {
+		t.Errorf("expected locked ganit 1.1.0 to be kept, got %s", v)
+	}
+
+	// Removing strutil also removes ganit, which only it needed
+	if err := project.Remove([]string{"strutil"}); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	for _, name := range []string{"strutil", "ganit"} {
+		if _, err := os.Stat(filepath.Join(project.Dir, packages.ModulesDir, name)); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be uninstalled", name)
+		}
+	}
+	lock, _ = packages.LoadLockfile(filepath.Join(project.Dir, packages.LockFile))
+	if len(lock.Packages) != 0 {
+		t.Errorf("expected empty lockfile, got %v", lock.Names())
+	}
+}
+
+func TestPackageIntegrityCheck(t *testing.T) {
+	registry := testRegistry(t)
+	project := newProject(t, registry)
+	if err := project.Add([]string{"ganit@1.0.0"}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	// Tamper with the archive and reinstall from scratch
+	if err := os.RemoveAll(filepath.Join(project.Dir, packages.ModulesDir)); err != nil {
+		t.Fatal(err)
+	}
+	archive := filepath.Join(registry, "ganit", "ganit-1.0.0.tgz")
+	if err := os.WriteFile(archive, []byte("not the published archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err := project.Install()
+	if err == nil || !strings.Contains(err.Error(), "integrity check failed") {
+		t.Fatalf("expected integrity error, got %v", err)
+	}
+}
+
+func TestPackageHTTPRegistry(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir(testRegistry(t))))
+	defer server.Close()
+
+	project := newProject(t, "")
+	project.RegistryLocation = server.URL
+	if err := project.Add([]string{"strutil@~1.0.0"}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	lib := filepath.Join(project.Dir, packages.ModulesDir, "strutil", "lib.bang")
+	if got := packages.Resolve(project.Dir, "strutil"); got != lib {
+		t.Errorf("expected strutil to resolve to %s, got %s", lib, got)
+	}
+
+	err := project.Add([]string{"ganit@^2.0.0"})
+	if err == nil || !strings.Contains(err.Error(), "available: 1.0.0, 1.1.0") {
+		t.Errorf("expected no-match error listing versions, got %v", err)
+	}
+	err = project.Add([]string{"nei"})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestVersionConstraints(t *testing.T) {
+	tests := []struct {
+		constraint, version string
+		expected            bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3", "1.2.4", false},
+		{"^1.2.3", "1.9.0", true},
+		{"^1.2.3", "2.0.0", false},
+		{"^1.2.3", "1.2.2", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{">=1.2.3", "4.0.0", true},
+		{"*", "0.0.1", true},
+		{"*", "1.0.0-beta", false},
+		{"1.0.0-beta", "1.0.0-beta", true},
+	}
+	for _, tt := range tests {
+		c, err := packages.ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.constraint, err)
+		}
+		v, err := packages.ParseVersion(tt.version)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.version, err)
+		}
+		if got := c.Allows(v); got != tt.expected {
+			t.Errorf("%s allows %s: got=%t, want=%t", tt.constraint, tt.version, got, tt.expected)
+		}
+	}
+}
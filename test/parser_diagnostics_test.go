@@ -0,0 +1,205 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/object"
+	"BanglaCode/src/parser"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func parseDiagnostics(input string) ([]parser.Diagnostic, int) {
+	p := parser.New(lexer.NewWithFile(input, "bad.bang"))
+	program := p.ParseProgram()
+	return p.Diagnostics(), len(program.Statements)
+}
+
+func TestParserReportsAllErrors(t *testing.T) {
+	input := `dhoro = 5;
+dhoro y = 2;
+dekho(y +);
+jodi x > 1 { dekho(1); }
+dhoro ok = 1;
+dekho(ok`
+
+	diagnostics, statements := parseDiagnostics(input)
+
+	expected := []struct {
+		code string
+		line int
+		col  int
+	}{
+		{parser.CodeUnexpectedToken, 1, 7},
+		{parser.CodeNoExpression, 3, 10},
+		{parser.CodeUnexpectedToken, 4, 6},
+		{parser.CodeUnexpectedToken, 6, 9},
+	}
+
+	if len(diagnostics) != len(expected) {
+		t.Fatalf("wrong number of diagnostics. want=%d, got=%d (%v)", len(expected), len(diagnostics), diagnostics)
+	}
+	for i, want := range expected {
+		d := diagnostics[i]
+		if d.Code != want.code || d.Start.Line != want.line || d.Start.Column != want.col {
+			t.Errorf("diagnostic %d wrong. want %s at %d:%d, got %s", i, want.code, want.line, want.col, d)
+		}
+		if d.Severity != parser.SeverityError || d.File != "bad.bang" {
+			t.Errorf("diagnostic %d: want error in bad.bang, got %s", i, d)
+		}
+	}
+
+	// dhoro y and dhoro ok still parse
+	if statements != 2 {
+		t.Errorf("expected 2 good statements to survive recovery, got %d", statements)
+	}
+}
+
+func TestParserRecoversInsideBlocks(t *testing.T) {
+	input := `kaj f() {
+	dhoro = 1;
+	dhoro b = 2;
+	ferao b;
+}
+sreni A {
+	set x() { }
+	kaj y() { ferao 1; }
+}
+dhoro [a, 1] = [1];
+dhoro c = f();`
+
+	diagnostics, statements := parseDiagnostics(input)
+
+	codes := []string{}
+	for _, d := range diagnostics {
+		codes = append(codes, d.Code)
+	}
+	want := []string{parser.CodeUnexpectedToken, parser.CodeInvalidSetter, parser.CodeInvalidDestructure}
+	if strings.Join(codes, ",") != strings.Join(want, ",") {
+		t.Fatalf("wrong diagnostics. want=%v, got=%v", want, diagnostics)
+	}
+
+	// kaj f (its body recovered) and dhoro c
+	if statements != 2 {
+		t.Errorf("expected 2 statements, got %d", statements)
+	}
+}
+
+func TestDiagnosticDetails(t *testing.T) {
+	diagnostics, _ := parseDiagnostics("dhoro a = (1 + 2;")
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", diagnostics)
+	}
+
+	d := diagnostics[0]
+	if d.Start != (parser.Position{Line: 1, Column: 17}) || d.End != (parser.Position{Line: 1, Column: 18}) {
+		t.Errorf("wrong span: %+v - %+v", d.Start, d.End)
+	}
+	if d.Fix != "insert ')'" {
+		t.Errorf("wrong fix: %q", d.Fix)
+	}
+	if got := d.String(); got != "bad.bang:1:17: error[E001]: expected next token to be ), got ;" {
+		t.Errorf("wrong string: %q", got)
+	}
+
+	p := parser.New(lexer.New("dhoro a = (1 + 2;"))
+	p.ParseProgram()
+	errors := p.Errors()
+	if len(errors) != 1 || errors[0] != "expected next token to be ), got ; at line 1, column 17" {
+		t.Errorf("Errors() kept old format wrong: %q", errors)
+	}
+}
+
+func TestModuleParseErrorsListsAll(t *testing.T) {
+	dir := t.TempDir()
+	module := "dhoro = 1;\ndhoro b = ;\n"
+	if err := os.WriteFile(filepath.Join(dir, "bhanga.bang"), []byte(module), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	evaluator.SetCurrentDir(dir)
+	defer evaluator.SetCurrentDir(".")
+
+	p := parser.New(lexer.New(`ano "bhanga.bang";`))
+	result := evalProgram(p.ParseProgram(), object.NewEnvironment())
+
+	errObj, ok := result.(*object.Error)
+	if !ok {
+		t.Fatalf("expected error, got %T", result)
+	}
+	if !strings.Contains(errObj.Message, "bhanga.bang:1:7") || !strings.Contains(errObj.Message, "bhanga.bang:2:11") {
+		t.Errorf("expected both module errors in message, got %q", errObj.Message)
+	}
+}
+
+func TestForAwaitNeedsForOf(t *testing.T) {
+	diagnostics, _ := parseDiagnostics("ghuriye opekha (dhoro i = 0; i < 3; i = i + 1) {}\ndhoro ok = 1;")
+	if len(diagnostics) != 1 || diagnostics[0].Code != parser.CodeInvalidForAwait {
+		t.Fatalf("expected one %s diagnostic, got %v", parser.CodeInvalidForAwait, diagnostics)
+	}
+	if diagnostics[0].Message != "ghuriye opekha needs the form (name of iterable)" {
+		t.Errorf("wrong message: %q", diagnostics[0].Message)
+	}
+}
+
+func TestJumpToUndefinedLabel(t *testing.T) {
+	diagnostics, _ := parseDiagnostics("outer: jotokkhon (sotti) { thamo outer; }\njotokkhon (sotti) { chharo outer; }")
+	if len(diagnostics) != 1 || diagnostics[0].Code != parser.CodeUndefinedLabel {
+		t.Fatalf("expected one %s diagnostic, got %v", parser.CodeUndefinedLabel, diagnostics)
+	}
+	if diagnostics[0].Message != "chharo: label 'outer' is not defined" {
+		t.Errorf("wrong message: %q", diagnostics[0].Message)
+	}
+
+	for _, input := range []string{"[a, 1] = [b, a];", "[a, [b, f()]] = x;", "[a, ...b.c] = x;", "[a?.b] = x;", "[a, b] += x;"} {
+		diagnostics, _ = parseDiagnostics("dhoro [a, b] = [1, 2];\n" + input)
+		if len(diagnostics) != 1 || diagnostics[0].Code != parser.CodeInvalidDestructure {
+			t.Fatalf("%s: expected one %s diagnostic, got %v", input, parser.CodeInvalidDestructure, diagnostics)
+		}
+	}
+}
+
+func TestUnaryBeforePowerIsAmbiguous(t *testing.T) {
+	for _, input := range []string{"dhoro x = -2 ** 2;", "dhoro y = !a ** 2;", "dhoro z = 1 + ~a ** 2;", "dhoro w = -(2) ** 2;"} {
+		diagnostics, _ := parseDiagnostics(input)
+		if len(diagnostics) != 1 || diagnostics[0].Code != parser.CodeAmbiguousPower {
+			t.Fatalf("%s: expected one %s diagnostic, got %v", input, parser.CodeAmbiguousPower, diagnostics)
+		}
+	}
+
+	diagnostics, _ := parseDiagnostics("dhoro x = -2 ** 2;")
+	if d := diagnostics[0]; d.Message != "unary '-' cannot appear directly before '**'" || d.Start != (parser.Position{Line: 1, Column: 14}) {
+		t.Errorf("wrong diagnostic: %+v", d)
+	}
+
+	for _, input := range []string{"dhoro x = (-2) ** 2;", "dhoro y = -(2 ** 2);", "dhoro z = 2 ** -2;"} {
+		if diagnostics, _ := parseDiagnostics(input); len(diagnostics) != 0 {
+			t.Errorf("%s: unexpected diagnostics %v", input, diagnostics)
+		}
+	}
+}
+
+func TestMalformedDestructuringPatterns(t *testing.T) {
+	tests := []struct {
+		input   string
+		message string
+	}{
+		{"dhoro [...a, b] = x;", "the rest element must come last in array destructuring"},
+		{"dhoro {...r, a} = x;", "the rest element must come last in object destructuring"},
+		{"kaj f({...r, a}) {}", "the rest element must come last in object destructuring"},
+		{"dhoro {a: 1} = x;", "object destructuring expects a name or pattern after ':'"},
+		{"kaj f([a, [1]]) {}", "array destructuring expects names or patterns"},
+	}
+
+	for _, tt := range tests {
+		diagnostics, _ := parseDiagnostics(tt.input)
+		if len(diagnostics) != 1 || diagnostics[0].Code != parser.CodeInvalidDestructure {
+			t.Fatalf("%s: expected one %s diagnostic, got %v", tt.input, parser.CodeInvalidDestructure, diagnostics)
+		}
+		if diagnostics[0].Message != tt.message {
+			t.Errorf("%s: wrong message: %q", tt.input, diagnostics[0].Message)
+		}
+	}
+}
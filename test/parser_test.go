@@ -4,6 +4,7 @@ import (
 	"BanglaCode/src/ast"
 	"BanglaCode/src/lexer"
 	"BanglaCode/src/parser"
+	"strings"
 	"testing"
 )
 
@@ -347,6 +348,26 @@ func TestOperatorPrecedence(t *testing.T) {
 		{"a * b / c;", "((a * b) / c)"},
 		{"(a + b) * c;", "((a + b) * c)"},
 		{"1 + (2 + 3) + 4;", "((1 + (2 + 3)) + 4)"},
+		{"a ? b : c ? d : e;", "(a ? b : (c ? d : e))"},
+		{"a ba b ? c + 1 : d;", "((a ba b) ? (c + 1) : d)"},
+		{"a ?? b ba c;", "(a ?? (b ba c))"},
+		{"a ?? b ?? c;", "((a ?? b) ?? c)"},
+		{"x = a ? b : c;", "x = (a ? b : c)"},
+		{"a?.b.c;", "a?.b.c"},
+		{"a?.[0]?.(1);", "a?.[0]?.(1)"},
+		{"x ??= a ?? b;", "x ??= (a ?? b)"},
+		{"2 ** 3 ** 2;", "(2 ** (3 ** 2))"},
+		{"2 * 3 ** 2;", "(2 * (3 ** 2))"},
+		{"(-2) ** 2;", "((-2) ** 2)"},
+		{"-(2 ** 2);", "(-(2 ** 2))"},
+		{"2 ** -2;", "(2 ** (-2))"},
+		{"a | b ^ c & d;", "(a | (b ^ (c & d)))"},
+		{"a & b == c;", "(a & (b == c))"},
+		{"1 + 2 << 3 < 4;", "(((1 + 2) << 3) < 4)"},
+		{"~a >>> 1;", "((~a) >>> 1)"},
+		{"a && b || c ebong d;", "((a ebong b) ba (c ebong d))"},
+		{"a | b ebong c;", "((a | b) ebong c)"},
+		{"x **= 2 ** 3;", "x **= (2 ** 3)"},
 	}
 
 	for _, tt := range tests {
@@ -542,8 +563,8 @@ func TestFunctionLiteral(t *testing.T) {
 			len(function.Parameters))
 	}
 
-	testLiteralExpression(t, function.Parameters[0], "a")
-	testLiteralExpression(t, function.Parameters[1], "b")
+	testLiteralExpression(t, function.Parameters[0].Name, "a")
+	testLiteralExpression(t, function.Parameters[1].Name, "b")
 
 	if len(function.Body.Statements) != 1 {
 		t.Fatalf("function.Body.Statements has not 1 statement. got=%d\n",
@@ -584,6 +605,71 @@ func TestNamedFunctionLiteral(t *testing.T) {
 	}
 }
 
+func TestFunctionParameterDefaultsAndPatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"kaj f(a, b = 10) {}", "a, b = 10"},
+		{"kaj f({naam, boyosh: age}, [x, y] = [1, 2], ...rest) {}", "{naam, boyosh: age}, [x, y] = [1, 2], ...rest"},
+		{"(a, b = a * 2) => a", "a, b = (a * 2)"},
+		{"({x}) => x", "{x}"},
+		{"([a, b], ...rest) => a", "[a, b], ...rest"},
+		{"kaj f({a: {b = 1}, ...r}, [c, [d], {e: f} = {}, ...g]) {}", "{a: {b = 1}, ...r}, [c, [d], {e: f} = {}, ...g]"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := parser.New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		function, ok := stmt.Expression.(*ast.FunctionLiteral)
+		if !ok {
+			t.Fatalf("%q: not *ast.FunctionLiteral. got=%T", tt.input, stmt.Expression)
+		}
+		if got := ast.FormatParameters(function.Parameters, function.RestParameter); got != tt.expected {
+			t.Errorf("%q: parameters wrong. want=%q, got=%q", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestDestructuringDeclarationPatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		names    string
+	}{
+		{"dhoro [a, b] = x;", "dhoro [a, b] = x;", "a,b"},
+		{"sthir {naam: n, boyosh = 1} = x;", "sthir {naam: n, boyosh = 1} = x;", "n,boyosh"},
+		{"dhoro {a: {b}, c: [d, ...e], ...f} = x;", "dhoro {a: {b}, c: [d, ...e], ...f} = x;", "b,d,e,f"},
+		{"bishwo [[a], {b} = {}, ...c] = x;", "bishwo [[a], {b} = {}, ...c] = x;", "a,b,c"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := parser.New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		decl, ok := program.Statements[0].(*ast.DestructuringDeclaration)
+		if !ok {
+			t.Fatalf("%q: not *ast.DestructuringDeclaration. got=%T", tt.input, program.Statements[0])
+		}
+		if decl.String() != tt.expected {
+			t.Errorf("%q: wrong String(). got=%q", tt.input, decl.String())
+		}
+		names := []string{}
+		for _, name := range decl.Names() {
+			names = append(names, name.Value)
+		}
+		if strings.Join(names, ",") != tt.names {
+			t.Errorf("%q: wrong names. want=%s, got=%v", tt.input, tt.names, names)
+		}
+	}
+}
+
 func TestCallExpression(t *testing.T) {
 	input := "add(1, 2 * 3, 4 + 5);"
 
@@ -673,6 +759,8 @@ func TestMemberExpression(t *testing.T) {
 	}{
 		{"myArray[0];", "myArray", "0", true},
 		{"obj.name;", "obj", "name", false},
+		{"query.delete;", "query", "delete", false}, // keywords are valid property names
+		{"range.in;", "range", "in", false},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,258 @@
+package test
+
+import (
+	"BanglaCode/src/eventloop"
+	"BanglaCode/src/object"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPromiseConstructor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`opekha notun Promise((resolve, reject) => { setTimeout(() => resolve("done"), 5); })`, "done"},
+		{`opekha notun Promise((resolve) => { resolve("first"); resolve("second"); })`, "first"},
+		{`opekha notun Promise((resolve) => { resolve(Promise.resolve("adopted")); })`, "adopted"},
+		{`
+		dhoro result = "";
+		chesta {
+			opekha notun Promise((resolve, reject) => { reject("nope"); resolve("late"); });
+		} dhoro_bhul(e) {
+			result = e;
+		}
+		result
+		`, "nope"},
+		{`
+		dhoro result = "";
+		chesta {
+			opekha notun Promise(kaj(resolve, reject) { felo "thrown"; });
+		} dhoro_bhul(e) {
+			result = e;
+		}
+		result
+		`, "thrown"},
+		{`
+		// Wrapping a callback-style API
+		kaj porePorbe(ms, callback) { setTimeout(() => callback(khali, ms * 2), ms); }
+		kaj porePorbeAsync(ms) {
+			ferao notun Promise((resolve, reject) => {
+				porePorbe(ms, (err, value) => { jodi (err != khali) { reject(err); } nahole { resolve(value); } });
+			});
+		}
+		dhoro v = opekha porePorbeAsync(5);
+		"got " + lipi(v)
+		`, "got 10"},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(tt.input), tt.expected)
+	}
+
+	errObj, ok := testEval(`notun Promise(5)`).(*object.Error)
+	if !ok || errObj.Message != "argument to `Promise` must be FUNCTION executor, got NUMBER" {
+		t.Errorf("Expected executor type error, got %v", errObj)
+	}
+}
+
+func TestPromiseChaining(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`opekha Promise.resolve(2).then((x) => x * 3).then((x) => "value " + lipi(x))`, "value 6"},
+		{`opekha Promise.resolve(1).then((x) => ghumaao(5).then(() => "waited"))`, "waited"},
+		{`opekha Promise.reject("bad").then((x) => "skipped").catch((e) => "caught " + e)`, "caught bad"},
+		{`opekha Promise.resolve(1).then(kaj() { felo "inside"; }).catch((e) => e)`, "inside"},
+		{`opekha Promise.reject("r").then(khali, (e) => "handled " + e)`, "handled r"},
+		{`
+		dhoro log = [];
+		dhoro v = opekha Promise.resolve("kept").finally(() => dhokao(log, "finally"));
+		v + " " + joro(log, ",")
+		`, "kept finally"},
+		{`
+		dhoro result = "";
+		chesta {
+			opekha Promise.reject("original").finally(() => "ignored");
+		} dhoro_bhul(e) {
+			result = e;
+		}
+		result
+		`, "original"},
+		{`
+		// Handlers run as microtasks, after the current code
+		dhoro log = [];
+		Promise.resolve(1).then(() => dhokao(log, "then"));
+		dhokao(log, "sync");
+		opekha ghumaao(1);
+		joro(log, ",")
+		`, "sync,then"},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestPromiseCombinators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`opekha Promise.race([ghumaao(30).then(() => "slow"), ghumaao(1).then(() => "fast")])`, "fast"},
+		{`opekha prothom_proyash([Promise.reject("first"), ghumaao(5)]).catch((e) => e)`, "first"},
+		{`
+		dhoro results = opekha Promise.allSettled([Promise.resolve(1), Promise.reject("x")]);
+		results[0].status + ":" + lipi(results[0].value) + " " + results[1].status + ":" + results[1].reason
+		`, "fulfilled:1 rejected:x"},
+		{`json_banao(opekha sob_nishpotti([Promise.resolve(1), Promise.reject("x")]))`,
+			`[{"status":"fulfilled","value":1},{"status":"rejected","reason":"x"}]`},
+		{`lipi(dorghyo(opekha sob_nishpotti([])))`, "0"},
+		{`opekha Promise.any([Promise.reject("a"), ghumaao(5).then(() => "b")])`, "b"},
+		{`
+		dhoro result = "";
+		chesta {
+			opekha jekono_proyash([Promise.reject("a"), Promise.reject("b")]);
+		} dhoro_bhul(e) {
+			result = e.name + " " + joro(e.errors, ",");
+		}
+		result
+		`, "AggregateError a,b"},
+		{`opekha Promise.all([Promise.resolve("a"), ghumaao(1).then(() => "b")]).then((v) => joro(v, ""))`, "ab"},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestPromiseTimeout(t *testing.T) {
+	input := `
+	dhoro result = "";
+	chesta {
+		opekha somoy_shima(ghumaao(200), 10);
+	} dhoro_bhul(e) {
+		result = e.name + ": " + e.message;
+	}
+	result
+	`
+	testStringObject(t, testEval(input), "TimeoutError: promise did not settle within 10ms")
+
+	testStringObject(t, testEval(`opekha Promise.timeout(ghumaao(1).then(() => "in time"), 100)`), "in time")
+}
+
+func TestAbortController(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`
+		dhoro c = notun AbortController();
+		dhoro before = c.signal.aborted;
+		c.abort();
+		lipi(before) + " " + lipi(c.signal.aborted) + " " + c.signal.reason.name
+		`, "false true AbortError"},
+		{`
+		dhoro c = notun AbortController();
+		dhoro fired = mittha;
+		setTimeout(() => { fired = sotti; }, 5, c.signal);
+		c.abort();
+		opekha ghumaao(20);
+		lipi(fired)
+		`, "false"},
+		{`
+		dhoro c = notun AbortController();
+		setTimeout(() => c.abort("stop"), 5);
+		dhoro result = "";
+		chesta {
+			opekha ghumaao(5000, c.signal);
+		} dhoro_bhul(e) {
+			result = e;
+		}
+		result
+		`, "stop"},
+		{`
+		dhoro c = notun AbortController();
+		dhoro count = 0;
+		setInterval(() => { count = count + 1; jodi (count == 3) { c.abort(); } }, 1, c.signal);
+		opekha ghumaao(30);
+		lipi(count)
+		`, "3"},
+		{`
+		dhoro c = notun AbortController();
+		c.abort();
+		dhoro result = "";
+		chesta {
+			opekha anun_async("http://127.0.0.1:1/", c.signal);
+		} dhoro_bhul(e) {
+			result = e.name;
+		}
+		result
+		`, "AbortError"},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+// runReportingRejections runs input to completion and returns the reasons
+// of the promises reported as rejected without a handler
+func runReportingRejections(t *testing.T, input string) []string {
+	t.Helper()
+	var mu sync.Mutex
+	var reasons []string
+	object.SetUnhandledRejectionHandler(func(rejected []*object.Promise) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, p := range rejected {
+			reason, _ := p.Result()
+			reasons = append(reasons, object.RejectionReason(reason).Inspect())
+		}
+	})
+	defer object.SetUnhandledRejectionHandler(nil)
+
+	testEval(input)
+	eventloop.Lock()
+	// Bounded, so that timers and servers other tests left behind don't
+	// keep it running
+	eventloop.RunUntil(func() bool { return false }, 50*time.Millisecond)
+	eventloop.Unlock()
+
+	mu.Lock()
+	defer mu.Unlock()
+	return reasons
+}
+
+func TestUnhandledRejections(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`Promise.reject("nope");`, "nope"},
+		{`proyash kaj f() { felo "bad"; }
+		  f();`, "bad"},
+		{`Promise.reject("a").then((x) => x);`, "a"},
+		{`dhoro p = Promise.reject("late");
+		  setTimeout(() => p.catch(() => 0), 5);`, "late"},
+		{`Promise.reject("x").catch(() => 0);`, ""},
+		{`proyash kaj f() { felo "bad"; }
+		  chesta { opekha f(); } dhoro_bhul(e) {}`, ""},
+		{`dhoro p = Promise.reject("awaited");
+		  chesta { opekha p; } dhoro_bhul(e) {}`, ""},
+		{`opekha sob_nishpotti([Promise.reject("settled")]);`, ""},
+		{`dhoro c = notun AbortController();
+		  ghumaao(1000, c.signal).catch(() => 0);
+		  c.abort();`, ""},
+		{`Promise.reject("x").finally(() => 0).catch(() => 0);`, ""},
+	}
+
+	for _, tt := range tests {
+		if got := strings.Join(runReportingRejections(t, tt.input), ","); got != tt.expected {
+			t.Errorf("input %q: expected unhandled %q, got %q", tt.input, tt.expected, got)
+		}
+	}
+}
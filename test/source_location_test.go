@@ -0,0 +1,134 @@
+package test
+
+import (
+	"BanglaCode/src/evaluator"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/object"
+	"BanglaCode/src/parser"
+	"BanglaCode/src/vm"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// evalFile parses input as if it came from file and runs it on both backends
+func evalFile(t *testing.T, input, file string) []*object.Error {
+	t.Helper()
+
+	backends := []func(*parser.Parser) object.Object{
+		func(p *parser.Parser) object.Object { return evaluator.Eval(p.ParseProgram(), object.NewEnvironment()) },
+		func(p *parser.Parser) object.Object { return vm.Run(p.ParseProgram(), object.NewEnvironment()) },
+	}
+
+	var errs []*object.Error
+	for _, run := range backends {
+		result := run(parser.New(lexer.NewWithFile(input, file)))
+		errObj, ok := result.(*object.Error)
+		if !ok {
+			t.Fatalf("expected error, got %T (%v)", result, result)
+		}
+		errs = append(errs, errObj)
+	}
+	return errs
+}
+
+func TestTokensCarryFile(t *testing.T) {
+	l := lexer.NewWithFile("dhoro x = 5;", "main.bang")
+	for tok := l.NextToken(); tok.Type != lexer.EOF; tok = l.NextToken() {
+		if tok.File != "main.bang" {
+			t.Fatalf("token %q has file %q, want main.bang", tok.Literal, tok.File)
+		}
+	}
+}
+
+func TestErrorCarriesFile(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"dhoro a = 1;\ndekho(a + nai);", "Error [app.bang:2:11]: variable 'nai' is not defined"},
+		{"dhoro a = 5 / 0;", "Error [app.bang:1:13]: division by zero"},
+		{"dorghyo(1, 2, 3);", "[app.bang:1:8]"},
+		{"sthir PI = 3;\nPI = 4;", "Error [app.bang:2:4]"},
+	}
+
+	for _, tt := range tests {
+		for _, errObj := range evalFile(t, tt.input, "app.bang") {
+			if !strings.Contains(errObj.Inspect(), tt.expected) {
+				t.Errorf("input %q: got %q, want it to contain %q", tt.input, errObj.Inspect(), tt.expected)
+			}
+		}
+	}
+}
+
+func TestErrorStackFrames(t *testing.T) {
+	input := `kaj bhitor(x) {
+	ferao x / 0;
+}
+kaj bahir(x) {
+	ferao bhitor(x);
+}
+bahir(1);`
+
+	for _, errObj := range evalFile(t, input, "stack.bang") {
+		expected := []object.StackFrame{
+			{Function: "bhitor", File: "stack.bang", Line: 2, Column: 10},
+			{Function: "bahir", File: "stack.bang", Line: 5, Column: 14},
+			{Function: "", File: "stack.bang", Line: 7, Column: 6},
+		}
+		if len(errObj.Stack) != len(expected) {
+			t.Fatalf("wrong number of frames. want=%d, got=%d (%+v)", len(expected), len(errObj.Stack), errObj.Stack)
+		}
+		for i, frame := range expected {
+			if errObj.Stack[i] != frame {
+				t.Errorf("frame %d wrong. want=%+v, got=%+v", i, frame, errObj.Stack[i])
+			}
+		}
+		if !strings.Contains(errObj.GetStack(), "at bahir (stack.bang:5:14)") {
+			t.Errorf("stack trace missing caller frame:\n%s", errObj.GetStack())
+		}
+	}
+}
+
+func TestErrorFromImportedModule(t *testing.T) {
+	dir := t.TempDir()
+	module := `pathao kaj bhag(a, b) {
+	ferao a / b;
+}`
+	if err := os.WriteFile(filepath.Join(dir, "ganit.bang"), []byte(module), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	evaluator.SetCurrentDir(dir)
+	defer evaluator.SetCurrentDir(".")
+
+	input := `ano "ganit.bang";
+bhag(1, 0);`
+
+	for _, errObj := range evalFile(t, input, "main.bang") {
+		if !strings.HasSuffix(errObj.File, "ganit.bang") || errObj.Line != 2 {
+			t.Errorf("expected error in ganit.bang line 2, got %s:%d", errObj.File, errObj.Line)
+		}
+		if len(errObj.Stack) != 2 {
+			t.Fatalf("expected 2 stack frames, got %+v", errObj.Stack)
+		}
+		if errObj.Stack[1].File != "main.bang" || errObj.Stack[1].Line != 2 {
+			t.Errorf("expected call site in main.bang line 2, got %+v", errObj.Stack[1])
+		}
+	}
+}
+
+func TestErrorExcerpt(t *testing.T) {
+	source := "dhoro a = 1;\n\tdekho(a + nai);\n"
+	errObj := &object.Error{Message: "variable 'nai' is not defined", File: "x.bang", Line: 2, Column: 12}
+
+	expected := "   2 | \tdekho(a + nai);\n     | \t          ^~~"
+	if got := errObj.Excerpt(source); got != expected {
+		t.Errorf("wrong excerpt.\nwant:\n%s\ngot:\n%s", expected, got)
+	}
+
+	if got := (&object.Error{Message: "no position"}).Excerpt(source); got != "" {
+		t.Errorf("expected no excerpt for error without position, got %q", got)
+	}
+}
@@ -334,3 +334,39 @@ func TestStreamRealWorldExample(t *testing.T) {
 		t.Errorf("Expected stream to be closed")
 	}
 }
+
+// TestStreamForAwait tests reading a readable stream with ghuriye opekha
+func TestStreamForAwait(t *testing.T) {
+	input := `
+	dhoro stream = stream_readable_srishti();
+
+	proyash kaj feed() {
+		opekha ghumaao(5);
+		stream_lekho(stream, "ek ");
+		opekha ghumaao(5);
+		stream_lekho(stream, "dui");
+		stream_shesh(stream);
+	}
+	feed();
+
+	dhoro shob = "";
+	ghuriye opekha (chunk of stream) {
+		shob = shob + chunk;
+	}
+	shob
+	`
+
+	result := testEval(input)
+	str, ok := result.(*object.String)
+	if !ok {
+		t.Fatalf("Expected string, got %s", result.Inspect())
+	}
+	if str.Value != "ek dui" {
+		t.Errorf("Expected 'ek dui', got %q", str.Value)
+	}
+
+	result = testEval(`dhoro s = stream_readable_srishti(); stream_shesh(s); stream_lekho(s, "x")`)
+	if errObj, ok := result.(*object.Error); !ok || errObj.Message != "Cannot write to ended stream" {
+		t.Errorf("Expected ended stream error, got %s", result.Inspect())
+	}
+}
@@ -0,0 +1,183 @@
+package test
+
+import (
+	"BanglaCode/src/object"
+	"BanglaCode/src/testrunner"
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// writeTestProject creates files (name -> source) in a temporary directory
+func writeTestProject(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestAssertionBuiltins(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr string // substring of the AssertionError message, "" for success
+	}{
+		{`nishchit(sotti);`, ""},
+		{`nishchit(0 > 1, "gonona");`, "gonona: expected a truthy value, got false"},
+		{`nishchit_soman([1, {a: 2}], [1, {a: 2}]);`, ""},
+		{`nishchit_soman(1, 2);`, "expected: 2"},
+		{`nishchit_soman({a: [1, 2]}, {a: [1, 3]});`, ".a[1]: expected 3, got 2"},
+		{`nishchit_soman({a: 1}, {b: 1});`, ".a: unexpected 1"},
+		{`nishchit_osoman(1, 2);`, ""},
+		{`nishchit_osoman([1], [1]);`, "expected values to differ"},
+		{`nishchit_felbe(kaj() { felo Error("boom"); }, "boom");`, ""},
+		{`nishchit_felbe(kaj() { ferao 1; });`, "expected function to throw"},
+		{`nishchit_felbe(kaj() { felo "x"; }, "y");`, `expected error containing "y"`},
+	}
+
+	for _, tt := range tests {
+		result := testEval(tt.input)
+		if tt.wantErr == "" {
+			if exc, ok := result.(*object.Exception); ok {
+				t.Errorf("%s: unexpected failure %s", tt.input, exc.Message)
+			}
+			continue
+		}
+		exc, ok := result.(*object.Exception)
+		if !ok {
+			t.Errorf("%s: expected AssertionError, got %T (%+v)", tt.input, result, result)
+			continue
+		}
+		if !strings.HasPrefix(exc.Message, "AssertionError: ") || !strings.Contains(exc.Message, tt.wantErr) {
+			t.Errorf("%s: message = %q, want it to contain %q", tt.input, exc.Message, tt.wantErr)
+		}
+	}
+}
+
+func TestAssertionErrorsAreCatchable(t *testing.T) {
+	result := testEval(`
+		dhoro naam = "";
+		chesta { nishchit_soman(1, 2); } dhoro_bhul (e) { naam = e.name; }
+		naam;
+	`)
+	if str, ok := result.(*object.String); !ok || str.Value != "AssertionError" {
+		t.Fatalf("expected caught AssertionError, got %+v", result)
+	}
+}
+
+func TestTestRunnerReportsResults(t *testing.T) {
+	dir := writeTestProject(t, map[string]string{
+		"math.bang": `pathao kaj jog(a, b) { ferao a + b; }`,
+		"math_test.bang": `
+			ano "math.bang";
+			describe("jog", kaj() {
+				porikkha("duiti sonkha", kaj() { nishchit_soman(jog(1, 2), 3); });
+				porikkha("bhul uttor", kaj() { nishchit_soman(jog(1, 2), 4); });
+			});
+			porikkha("async", proyash kaj() {
+				dhoro v = opekha proyash kaj() { ferao 5; }();
+				nishchit_soman(v, 5);
+			});
+			porikkha("async rejects", kaj() {
+				nishchit_felbe(proyash kaj() { felo "na"; }(), "na");
+			});
+			porikkha("throws", kaj() { felo "oops"; });
+		`,
+		"nested/broken_test.bang": `porikkha("x", kaj() {`,
+		"helper.bang":             `porikkha("not a test file", kaj() {});`,
+	})
+
+	files, err := testrunner.Discover([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 test files, got %v", files)
+	}
+
+	var out bytes.Buffer
+	report := testrunner.Run(files, testrunner.Options{Out: &out})
+	if report.OK() {
+		t.Fatal("expected failing report")
+	}
+
+	status := make(map[string]testrunner.Status)
+	for _, res := range report.Results {
+		status[res.FullName()] = res.Status
+	}
+	want := map[string]testrunner.Status{
+		"jog > duiti sonkha": testrunner.Passed,
+		"jog > bhul uttor":   testrunner.Failed,
+		"async":              testrunner.Passed,
+		"async rejects":      testrunner.Passed,
+		"throws":             testrunner.Errored,
+		"":                   testrunner.Errored, // broken_test.bang does not parse
+	}
+	for name, st := range want {
+		if status[name] != st {
+			t.Errorf("%q: status = %q, want %q", name, status[name], st)
+		}
+	}
+
+	text := out.String()
+	for _, s := range []string{"✓ jog > duiti sonkha", "✗ jog > bhul uttor", "expected: 4", "actual:   3", "could not load file", "3 passed, 1 failed, 2 error(s)"} {
+		if !strings.Contains(text, s) {
+			t.Errorf("output missing %q:\n%s", s, text)
+		}
+	}
+}
+
+func TestTestRunnerFilterAndJUnit(t *testing.T) {
+	dir := writeTestProject(t, map[string]string{
+		"a_test.bang": `
+			describe("dol", kaj() {
+				porikkha("pass", kaj() { nishchit(sotti); });
+				porikkha("fail", kaj() { nishchit(mittha); });
+			});
+			porikkha("other", kaj() { nishchit(sotti); });
+		`,
+	})
+	files, _ := testrunner.Discover([]string{dir})
+
+	report := testrunner.Run(files, testrunner.Options{Filter: regexp.MustCompile(`^dol > `)})
+	if len(report.Results) != 2 {
+		t.Fatalf("filter should keep 2 tests, got %d", len(report.Results))
+	}
+
+	var buf bytes.Buffer
+	if err := testrunner.WriteJUnit(&buf, report); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Cases []struct {
+				Name      string    `xml:"name,attr"`
+				ClassName string    `xml:"classname,attr"`
+				Failure   *struct{} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JUnit XML: %v\n%s", err, buf.String())
+	}
+	if doc.Tests != 2 || doc.Failures != 1 || len(doc.Suites) != 1 {
+		t.Fatalf("unexpected totals: %+v", doc)
+	}
+	c := doc.Suites[0].Cases[1]
+	if c.Name != "fail" || c.Failure == nil || !strings.HasSuffix(c.ClassName, "a_test.bang.dol") {
+		t.Errorf("unexpected testcase: %+v", c)
+	}
+}
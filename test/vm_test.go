@@ -0,0 +1,381 @@
+package test
+
+import (
+	"BanglaCode/src/ast"
+	"BanglaCode/src/compiler"
+	"BanglaCode/src/evaluator"
+	"BanglaCode/src/eventloop"
+	"BanglaCode/src/lexer"
+	"BanglaCode/src/object"
+	"BanglaCode/src/parser"
+	"BanglaCode/src/vm"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// runBoth evaluates input with the tree-walking evaluator and the VM
+func runBoth(input string) (object.Object, object.Object) {
+	parse := func() *parser.Parser { return parser.New(lexer.New(input)) }
+	eventloop.Lock()
+	defer eventloop.Unlock()
+
+	p := parse()
+	treeResult := evaluator.Eval(p.ParseProgram(), object.NewEnvironment())
+
+	p = parse()
+	vmResult := vm.Run(p.ParseProgram(), object.NewEnvironment())
+
+	return treeResult, vmResult
+}
+
+func inspect(obj object.Object) string {
+	if obj == nil {
+		return "<nil>"
+	}
+	return string(obj.Type()) + ":" + obj.Inspect()
+}
+
+func TestVMMatchesEvaluator(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"arithmetic", "(5 + 10 * 2 + 15 / 3) * 2 + -10"},
+		{"string concat", `"Bangla" + "Code" + 1`},
+		{"comparison", "1 < 2 == sotti"},
+		{"logical", "sotti ebong na mittha"},
+		{"variables", "dhoro a = 5; dhoro b = a * 2; b + a"},
+		{"compound assignment", "dhoro x = 10; x += 5; x -= 3; x *= 2; x /= 4; x"},
+		{"if else", "dhoro x = 3; jodi (x > 5) { 1 } nahole { 2 }"},
+		{"if without else", "jodi (mittha) { 1 }"},
+		{"empty block", "jodi (sotti) { }"},
+		{"while", "dhoro i = 0; dhoro s = 0; jotokkhon (i < 10) { s = s + i; i = i + 1; } s"},
+		{"for", "dhoro s = 0; ghuriye (dhoro i = 0; i < 5; i = i + 1) { s = s + i; } s"},
+		{"for scope", "dhoro i = 100; ghuriye (dhoro i = 0; i < 3; i = i + 1) { } i"},
+		{"break continue", `
+			dhoro s = 0;
+			ghuriye (dhoro i = 0; i < 10; i = i + 1) {
+				jodi (i == 2) { chharo; }
+				jodi (i == 6) { thamo; }
+				s = s + i;
+			}
+			s`},
+		{"nested loops", `
+			dhoro count = 0;
+			ghuriye (dhoro i = 0; i < 4; i = i + 1) {
+				dhoro j = 0;
+				jotokkhon (sotti) {
+					j = j + 1;
+					jodi (j > i) { thamo; }
+					count = count + 1;
+				}
+			}
+			count`},
+		{"do while", "dhoro i = 0; do { i = i + 1; } jotokkhon (i < 5); i"},
+		{"comma declarations", "dhoro a = 1, b = a + 1; ghuriye (dhoro i = 0, j = 3; i < j; i = i + 1) { b = b + i; } b"},
+		{"swap", "dhoro a = 1, b = 2; [a, b] = [b, a]; a * 10 + b"},
+		{"nested assignment", "dhoro a = 1, b = 2, c = []; [a, [b], ...c] = [b, [a], 3, 4]; a * 10 + b + dorghyo(c)"},
+		{"labeled loops", `
+			dhoro count = 0;
+			outer: ghuriye (dhoro i = 0; i < 5; i = i + 1) {
+				jotokkhon (sotti) {
+					jodi (i == 3) { thamo outer; }
+					count = count + 1;
+					chharo outer;
+				}
+			}
+			count`},
+		{"recursion", "kaj fib(n) { jodi (n < 2) { ferao n; } ferao fib(n - 1) + fib(n - 2); } fib(15)"},
+		{"return from loop", `
+			kaj find(arr, target) {
+				ghuriye (dhoro i = 0; i < dorghyo(arr); i = i + 1) {
+					jodi (arr[i] == target) { ferao i; }
+				}
+				ferao -1;
+			}
+			find([4, 8, 15, 16], 15)`},
+		{"closures", `
+			kaj counter() {
+				dhoro n = 0;
+				ferao kaj() { n = n + 1; ferao n; };
+			}
+			dhoro c = counter();
+			c(); c(); c()`},
+		{"implicit completion value", "kaj f() { 42 } f()"},
+		{"empty function", "kaj f() { } f()"},
+		{"top-level return", "ferao 7; 99"},
+		{"arrays and builtins", "dhoro a = [1, 2, 3]; dhokao(a, 4); dorghyo(a)"},
+		{"spread call", "kaj add(a, b, c) { ferao a + b + c; } add(...[1, 2, 3])"},
+		{"rest params", "kaj f(a, ...rest) { ferao dorghyo(rest); } f(1, 2, 3)"},
+		{"classes", `
+			sreni Bindu {
+				shuru(x) { ei.x = x; }
+				kaj dvigun() { ferao ei.x * 2; }
+			}
+			dhoro b = notun Bindu(21);
+			b.dvigun()`},
+		{"try catch in loop", `
+			dhoro s = 0;
+			ghuriye (dhoro i = 0; i < 5; i = i + 1) {
+				chesta {
+					jodi (i == 3) { felo "boom"; }
+					s = s + i;
+				} dhoro_bhul (e) {
+					s = s + 100;
+				}
+			}
+			s`},
+		{"break from try inside loop", `
+			dhoro i = 0;
+			jotokkhon (sotti) {
+				i = i + 1;
+				chesta { jodi (i == 4) { thamo; } } dhoro_bhul (e) { }
+			}
+			i`},
+		{"return from try", `
+			kaj f() {
+				ghuriye (dhoro i = 0; i < 10; i = i + 1) {
+					chesta { jodi (i == 3) { ferao i * 10; } } dhoro_bhul (e) { }
+				}
+				ferao -1;
+			}
+			f()`},
+		{"uncaught throw in function", `
+			kaj f() { felo "oops"; ferao 1; }
+			f()`},
+		{"top-level throw continues", `felo "first"; 5`},
+		{"undefined variable", "dhoro a = 1; a + b"},
+		{"constant reassignment", "sthir PI = 3; PI = 4"},
+		{"wrong argument count", "kaj f(a) { ferao a; } f(1, 2)"},
+		{"error inside loop", "ghuriye (dhoro i = 0; i < 3; i = i + 1) { i + khali_nai; }"},
+		{"division by zero", "kaj f(x) { ferao 10 / x; } f(0)"},
+		{"global from function", "kaj f() { bishwo g = 5; } f(); g"},
+		{"map literal", `dhoro m = {naam: "Rahim", boyosh: 30}; m.naam`},
+		{"template literal", "dhoro n = 3; `n = ${n * 2}`"},
+		{"switch", `
+			dhoro x = 2;
+			dhoro r = "";
+			bikolpo (x) {
+				khetre 1 { r = "one"; thamo; }
+				khetre 2 { r = "two"; thamo; }
+				manchito { r = "other"; }
+			}
+			r`},
+		{"for of", "dhoro s = 0; ghuriye (x of [1, 2, 3]) { s = s + x; } s"},
+		{"ternary", `dhoro n = 0; [n > 0 ? "pos" : n < 0 ? "neg" : "shunno", sotti ? 1 : 2]`},
+		{"nullish", "dhoro c = 0; kaj f() { c = c + 1; ferao 2; } [khali ?? f(), 0 ?? f(), c]"},
+		{"optional chain", "dhoro u = khali; dhoro m = {a: {b: 1}}; [u?.a.b, m?.a?.b, u?.f(1), m.x?.y]"},
+		{"logical assignment", "dhoro x = khali; x ??= 1; x ||= 2; x &&= x + 1; x"},
+		{"bitwise", "[5 & 3, 5 | 3, 5 ^ 3, ~5, 1 << 31, -16 >> 2, -1 >>> 0, 2 ** 3 ** 2, sotti && mittha || sotti]"},
+		{"default parameters", "kaj f(a, b = a * 2, {naam} = {naam: \"Rahim\"}) { ferao [a, b, naam]; } [f(1), f(1, 5), f(1, khali, {naam: \"Karim\"})]"},
+		{"destructuring parameters", "dhoro g = ([x, y], {z}) => x + y + z; g([1, 2], {z: 3})"},
+		{"nested destructuring", "dhoro {a: [b, ...c], d = 4, ...e} = {a: [1, 2, 3], f: 5}; kaj h({p: {q} = {q: 6}}) { ferao q; } [b, c, d, e, h({})]"},
+		{"generator delegation", "kaj* a() { utpadan 1; ferao 2; } kaj* b() { dhoro r = utpadan* a(); utpadan r; } [...b()]"},
+		{"generator send", "kaj* g() { dhoro x = utpadan 1; utpadan x * 2; } dhoro it = g(); it.next(); it.next(21)[\"value\"]"},
+		{"bitwise assignment", "dhoro x = 7; x %= 4; x **= 3; x &= 12; x |= 3; x ^= 1; x <<= 2; x >>= 1; x >>>= 1; x"},
+		{"closure counter", "kaj counter() { dhoro n = 0; ferao kaj() { n += 1; ferao n; }; } dhoro c = counter(); c(); c(); dhoro d = counter(); [c(), d()]"},
+		{"nested closures", "kaj a(x) { ferao kaj(y) { ferao kaj(z) { x = x + 1; ferao x + y + z; }; }; } dhoro f = a(1)(10); [f(100), f(100)]"},
+		{"local before declaration", "dhoro x = 1; kaj f() { dhoro y = x; dhoro x = 2; ferao [y, x]; }; [f(), x]"},
+		{"assign before declaration", "dhoro x = 1; kaj f() { x = 5; dhoro x = 2; x = 3; ferao x; }; [f(), x]"},
+		{"assign undeclared from function", "kaj f() { fresh = 7; }; f(); fresh"},
+		{"loop closures share the loop scope", "dhoro fs = []; ghuriye (dhoro i = 0; i < 3; i = i + 1) { dhokao(fs, kaj() { ferao i; }); } [fs[0](), fs[2]()]"},
+		{"named function expression", "dhoro f = kaj fact(n) { jodi (n < 2) { ferao 1; } ferao n * fact(n - 1); }; [f(5), fact(3)]"},
+		{"inner recursion", "kaj outer(n) { kaj inner(k) { jodi (k == 0) { ferao 0; } ferao k + inner(k - 1); } ferao inner(n); }; outer(10)"},
+		{"rest parameter", "kaj f(a, ...r) { ferao [a, r, dorghyo(r)]; }; [f(1), f(1, 2, 3)]"},
+		{"local constant", "kaj f() { sthir k = 1; k = 2; ferao k; } f()"},
+		{"member access and assignment", `dhoro m = {a: 1, "b": [1, 2]}; m.a += 5; m.b[1] = m.a; m["c"] = m.b[0] + m.b[1]; [m.a, m.b, m.c, m.z, [1, 2][5]]`},
+		{"methods in maps", "dhoro calc = {base: 10, add: kaj(x) { ferao calc.base + x; }}; calc.add(5)"},
+		{"callbacks run by builtins", "kaj f(xs) { dhoro total = 0; dhoro k = 3; dhoro ys = manchitro(xs, kaj(x) { total = total + x; ferao x * k; }); ferao [ys, total]; }; f([1, 2])"},
+		{"fallback code sees slots", "kaj f() { dhoro a = 1; chesta { dhoro b = a + 1; a = 10; } dhoro_bhul (e) { } ferao [a, b, `${a}-${b}`]; }; f()"},
+		{"member errors", "kaj f(x) { ferao x.y.z; } f({})"},
+		{"shadowed parameter", "dhoro a = 1; kaj f(a) { a += 1; ferao a; }; [f(5), a]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			treeResult, vmResult := runBoth(tt.input)
+			if inspect(treeResult) != inspect(vmResult) {
+				t.Errorf("backends disagree:\nevaluator: %s\nvm:        %s", inspect(treeResult), inspect(vmResult))
+			}
+		})
+	}
+}
+
+func TestVMErrorPosition(t *testing.T) {
+	input := "dhoro a = 1;\ndhoro b = a + nai;"
+	_, result := runBoth(input)
+
+	errObj, ok := result.(*object.Error)
+	if !ok {
+		t.Fatalf("expected error, got %s", inspect(result))
+	}
+	if !strings.Contains(errObj.Message, "'nai' is not defined") {
+		t.Errorf("unexpected message: %s", errObj.Message)
+	}
+	if errObj.Line != 2 {
+		t.Errorf("expected error on line 2, got %d", errObj.Line)
+	}
+}
+
+func TestVMDeepRecursion(t *testing.T) {
+	input := "kaj sum(n) { jodi (n == 0) { ferao 0; } ferao n + sum(n - 1); } sum(5000)"
+	_, result := runBoth(input)
+	testNumberObject(t, result, 12502500)
+}
+
+func TestCompilerInstructions(t *testing.T) {
+	p := parser.New(lexer.New("dhoro x = 1 + 2; jotokkhon (x < 10) { x = x + 1; }"))
+	code, err := compiler.Compile(p.ParseProgram())
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+
+	expected := []string{
+		"OpConstant 0",
+		"OpConstant 1",
+		"OpBinary 0",
+		"OpDefine 1",
+		"OpResult",
+		"OpGetName 2",
+		"OpConstant 2",
+		"OpBinary 3",
+		"OpJumpIfFalse",
+		"OpGetName 4",
+		"OpConstant 3",
+		"OpBinary 5",
+		"OpAssign 6",
+		"OpLoopSignal",
+		"OpJump",
+		"OpNull",
+		"OpResult",
+	}
+
+	lines := strings.Split(strings.TrimSpace(code.Instructions.String()), "\n")
+	if len(lines) != len(expected) {
+		t.Fatalf("wrong instruction count. want=%d, got=%d\n%s", len(expected), len(lines), code.Instructions)
+	}
+	for i, want := range expected {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("instruction %d wrong. want=%q, got=%q", i, want, lines[i])
+		}
+	}
+	if len(code.Statements) != 2 {
+		t.Errorf("expected 2 top-level statements, got %d", len(code.Statements))
+	}
+}
+
+func TestCompilerResolvesSlots(t *testing.T) {
+	input := `
+		kaj counter(start) {
+			dhoro n = start;
+			dhoro state = {calls: 0, log: []};
+			ferao kaj(step) {
+				n += step;
+				state.calls = state.calls + 1;
+				state.log[0] = n;
+				ferao [n, state["calls"]];
+			};
+		}
+		ghuriye (dhoro i = 0; i < 2; i = i + 1) { counter(i)(1); }`
+	code, err := compiler.Compile(parser.New(lexer.New(input)).ParseProgram())
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	if fallbacks := compiler.AllFallbacks(code); len(fallbacks) != 0 {
+		t.Errorf("expected no fallbacks, got %d: %v", len(fallbacks), fallbacks)
+	}
+
+	counter := code.Functions[0]
+	if counter.Layout.Names[0] != "start" || !counter.SimpleParameters {
+		t.Errorf("expected the parameter in slot 0, got %v", counter.Layout.Names)
+	}
+	inner := counter.Code.Functions[0]
+	if inner.Outer != counter.Layout {
+		t.Errorf("expected the closure to be resolved against counter's slots")
+	}
+
+	// Top-level variables such as counter stay in the program environment
+	tests := []struct {
+		code     *compiler.Bytecode
+		expected []string
+		byName   bool
+	}{
+		{code, []string{"OpClosure 0", "OpDefine 0", "OpEnterScope 0", "OpGetLocal 0", "OpDefineLocal 0", "OpGetName"}, true},
+		{counter.Code, []string{"OpGetLocal 0", "OpDefineLocal 1", "OpMap", "OpClosure 0", "OpReturn"}, false},
+		{inner.Code, []string{"OpGetLocal 0", "OpSetFree 1 1", "OpGetFree 1 2", "OpGetMember", "OpSetMember", "OpSetIndex", "OpIndex"}, false},
+	}
+	for _, tt := range tests {
+		instructions := tt.code.Instructions.String()
+		for _, want := range tt.expected {
+			if !strings.Contains(instructions, want) {
+				t.Errorf("expected %q in\n%s", want, instructions)
+			}
+		}
+		if !tt.byName && strings.Contains(instructions, "OpGetName") {
+			t.Errorf("expected every name to be resolved to a slot in\n%s", instructions)
+		}
+	}
+}
+
+func TestCompilerFallsBackToEvaluator(t *testing.T) {
+	input := "sreni A { }\nkaj f(x) { chesta { felo x; } dhoro_bhul (e) { } ferao x?.y; }"
+	code, err := compiler.Compile(parser.New(lexer.New(input)).ParseProgram())
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	if !strings.Contains(code.Instructions.String(), "OpEval 0") {
+		t.Errorf("expected class declaration to compile to OpEval, got\n%s", code.Instructions)
+	}
+
+	var fallbacks []string
+	for _, node := range compiler.AllFallbacks(code) {
+		fallbacks = append(fallbacks, fmt.Sprintf("%T", node))
+	}
+	expected := "*ast.ClassDeclaration *ast.TryCatchStatement *ast.MemberExpression"
+	if got := strings.Join(fallbacks, " "); got != expected {
+		t.Errorf("expected fallbacks %q, got %q", expected, got)
+	}
+}
+
+// BenchmarkBackends compares the evaluator and the VM:
+//
+//	go test ./test/ -run '^$' -bench Backends
+func BenchmarkBackends(b *testing.B) {
+	programs := []struct {
+		name  string
+		input string
+	}{
+		{"fib", "kaj fib(n) { jodi (n < 2) { ferao n; } ferao fib(n - 1) + fib(n - 2); } fib(20)"},
+		{"loop", `kaj sum(n) {
+			dhoro s = 0;
+			ghuriye (dhoro i = 0; i < n; i = i + 1) { jodi (i % 3 == 0) { s += i; } nahole { s -= 1; } }
+			ferao s;
+		} sum(100000)`},
+		{"closures", `kaj counter() { dhoro n = 0; ferao kaj() { n = n + 1; ferao n; }; }
+		dhoro c = counter();
+		ghuriye (dhoro i = 0; i < 20000; i = i + 1) { c(); }`},
+		{"members", `dhoro p = {x: 0, y: 0, path: [0, 0, 0, 0]};
+		ghuriye (dhoro i = 0; i < 20000; i = i + 1) { p.x = p.x + 1; p.path[i % 4] = p.y; p["y"] += p.x; }`},
+	}
+	backends := []struct {
+		name string
+		run  func(*ast.Program, *object.Environment) object.Object
+	}{
+		{"evaluator", func(program *ast.Program, env *object.Environment) object.Object { return evaluator.Eval(program, env) }},
+		{"vm", vm.Run},
+	}
+
+	for _, program := range programs {
+		parsed := parser.New(lexer.New(program.input)).ParseProgram()
+		for _, backend := range backends {
+			b.Run(program.name+"/"+backend.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if result := backend.run(parsed, object.NewEnvironment()); isError(result) {
+						b.Fatal(result.Inspect())
+					}
+				}
+			})
+		}
+	}
+}